@@ -2,40 +2,138 @@ package main
 
 import (
 	"agent/internal/agent"
+	"agent/internal/autostart"
 	"agent/internal/config"
+	"agent/internal/i18n"
 	"agent/internal/logging"
+	"agent/internal/loopback"
 	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"go.uber.org/zap"
+)
+
+const (
+	EVENT_CONNECTION_STATE = "connection:state" // 프론트엔드로 보내는 연결 상태 변경 이벤트
+	EVENT_ENCODING_CHANGED = "capture:encoding" // 프론트엔드로 보내는 인코딩 변경 이벤트
+	EVENT_LOG_ENTRY        = "log:entry"        // 프론트엔드 로그 콘솔로 보내는 새 로그 (logging.Entry)
+	EVENT_PREVIEW_FRAME    = "capture:preview"  // 프론트엔드 미리보기로 보내는 송신 화면 축소본 (agent.UIPreview)
+	EVENT_REPORTED         = "event:reported"   // 프론트엔드 보고 이벤트 목록으로 보내는 새 보고 이벤트 (agent.EventRecord)
+	LOG_FEED_BUFFER        = 256                // 로그 콘솔 전달 대기 수 (넘치면 버림, GetRecentLogs 로 보충)
+	EVENT_FEED_BUFFER      = 256                // 보고 이벤트 전달 대기 수 (넘치면 버림, GetRecentEvents 로 보충)
 )
 
 // App struct
 type App struct {
 	ctx context.Context // 애플리케이션 컨텍스트
 
-	agent *agent.Agent
+	agent    *agent.Agent
+	loopback *loopback.Server // 루프백 모드 시 프로세스 내 모의 서버
+	cfg      *config.Config
+	logger   *zap.SugaredLogger // nil 이면 로그 생략
+
+	tray       *trayMenu   // 트레이 메뉴 (TrayEnabled 가 아니면 nil)
+	trayActive atomic.Bool // 트레이 아이콘 표시 중 (창 닫기를 숨김으로 처리)
+	quitting   atomic.Bool // 트레이 종료 메뉴로 종료 중
+	indicator  captureIndicator
+	notifier   *desktopNotifier // 데스크톱 알림 (Notifications 가 아니면 nil)
 }
 
-// NewApp 함수는 App 구조체의 새 인스턴스를 생성합니다.
-func NewApp() *App {
-	return &App{}
+// NewApp 함수는 App 구조체의 새 인스턴스를 생성합니다. cfg 는 창 옵션(StartMinimized 등)을 정하려고 Wails 실행 전에 읽은 설정입니다.
+func NewApp(cfg *config.Config) *App {
+	return &App{cfg: cfg}
 }
 
-// startup 함수는 애플리케이션 시작 시 호출되어 gRPC 연결 및 스트림을 설정합니다.
+// startup 함수는 애플리케이션 시작 시 호출되어 에이전트를 생성하고 백그라운드 연결을 시작합니다.
 func (a *App) startup(ctx context.Context) { // 단일 책임: 앱 초기화
 	baseCtx, cancel := context.WithCancel(ctx)
 	a.ctx = baseCtx
-	cfg := config.Load()
+	cfg := a.cfg
 	logger, err := logging.NewLogger()
 	if err != nil { // 실패해도 진행
 		logger = nil
 	}
+	if cfg.LoopbackMode { // 외부 수집 서버 대신 프로세스 내 모의 서버로 접속
+		srv, err := loopback.Start(cfg.DataDir, logger)
+		if err != nil && logger != nil {
+			logger.Errorf("루프백 서버 시작 실패: %v", err)
+		} else if err == nil {
+			a.loopback = srv
+			cfg.ServerAddr = srv.Addr()
+		}
+	}
 	ag := agent.New(a.ctx, cancel, cfg, logger)
-	a.agent = ag
-	ag.Init()
+	a.agent, a.logger = ag, logger
+	a.notifier = newDesktopNotifier(a)
+	ag.SetConnectionListener(func(st agent.ConnectionStatus) { // 연결 준비 상태를 프론트엔드에 전달
+		runtime.EventsEmit(a.ctx, EVENT_CONNECTION_STATE, st)
+		a.notifier.connection(st)
+	})
+	ag.SetCommandListener(a.notifier.command)
+	ag.SetPreviewListener(func(p agent.UIPreview) { // 미리보기를 켠 동안 UIPreviewMs 간격
+		runtime.EventsEmit(a.ctx, EVENT_PREVIEW_FRAME, p)
+	})
+	ag.SetCaptureListener(a.setCaptureIndicator)
+	events := make(chan agent.EventRecord, EVENT_FEED_BUFFER)
+	ag.SetEventListener(func(e agent.EventRecord) { // 이벤트를 낸 고루틴을 막지 않도록 차면 버림
+		select {
+		case events <- e:
+		default:
+		}
+	})
+	ag.Init() // 즉시 반환, 연결은 백그라운드에서 진행
+	go a.runLogFeed()
+	go a.runEventFeed(events)
+	a.startTray()
+}
+
+// runLogFeed 함수는 새 로그를 log:entry 이벤트로 프론트엔드에 전달합니다. 로그를 남긴 고루틴을 막지 않도록
+// 대기열이 차면 버리며, 콘솔은 마지막으로 받은 순번 이후를 GetRecentLogs 로 채웁니다.
+func (a *App) runLogFeed() { // 단일 책임: 로그 콘솔 전달
+	feed := make(chan logging.Entry, LOG_FEED_BUFFER)
+	cancel := logging.Subscribe(func(e logging.Entry) {
+		select {
+		case feed <- e:
+		default:
+		}
+	})
+	defer cancel()
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case e := <-feed:
+			runtime.EventsEmit(a.ctx, EVENT_LOG_ENTRY, e)
+		}
+	}
+}
+
+// runEventFeed 함수는 보고 이벤트를 event:reported 이벤트로 프론트엔드에 전달합니다.
+// 목록은 마지막으로 받은 순번 이후를 GetRecentEvents 로 채웁니다.
+func (a *App) runEventFeed(events <-chan agent.EventRecord) { // 단일 책임: 보고 이벤트 전달
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case e := <-events:
+			runtime.EventsEmit(a.ctx, EVENT_REPORTED, e)
+		}
+	}
 }
 
 // shutdown 함수는 애플리케이션 종료 시 호출되어 자원을 정리합니다.
 func (a *App) shutdown(ctx context.Context) {
-	a.agent.Close()
+	a.stopTray()
+	a.indicator.stop()
+	a.notifier.stop()
+	if a.agent != nil {
+		a.agent.Close()
+	}
+	if a.loopback != nil {
+		a.loopback.Stop()
+	}
 }
 
 // StartCapture 함수는 화면 캡처를 시작합니다.
@@ -54,12 +152,12 @@ func (a *App) StopCapture() {
 	a.agent.StopCapture()
 }
 
-// ListMonitors 함수는 사용 가능한 모니터 목록 문자열 배열을 반환합니다.
-func (a *App) ListMonitors() []string { // 단일 책임: 모니터 목록 노출
+// ListMonitors 함수는 사용 가능한 모니터 목록을 썸네일과 함께 반환합니다 (모니터 선택 화면용).
+func (a *App) ListMonitors() []agent.MonitorInfo { // 단일 책임: 모니터 목록 노출
 	if a.agent == nil {
 		return nil
 	}
-	return a.agent.ListMonitors()
+	return a.agent.Monitors(true)
 }
 
 // SelectMonitor 함수는 단일 모드로 전환 후 특정 모니터를 선택합니다.
@@ -67,7 +165,11 @@ func (a *App) SelectMonitor(index int) bool { // 단일 책임: 모니터 선택
 	if a.agent == nil {
 		return false
 	}
-	return a.agent.SelectSingleMonitor(index)
+	if !a.agent.SelectSingleMonitor(index) {
+		return false
+	}
+	a.agent.PersistSettings(config.MonitorSettings)
+	return true
 }
 
 // SetCombinedMode 함수는 combined 모드로 전환합니다.
@@ -76,4 +178,437 @@ func (a *App) SetCombinedMode() { // 단일 책임: combined 모드 전환 노
 		return
 	}
 	a.agent.SetCombinedMode()
+	a.agent.PersistSettings(config.MonitorSettings)
+}
+
+// SetCombinedLayout 함수는 combined 모드 배치(horizontal | vertical | grid | physical)를 바꿔 combined 모드로 전환합니다.
+func (a *App) SetCombinedLayout(layout string) error { // 단일 책임: combined 배치 변경 노출
+	if a.agent == nil {
+		return nil
+	}
+	if err := a.agent.SetCombinedLayout(layout); err != nil {
+		return err
+	}
+	a.agent.PersistSettings(config.MonitorSettings)
+	return nil
+}
+
+// SetAllMonitorsMode 함수는 모든 모니터를 모니터별 프레임으로 동시에 캡처하는 all 모드로 전환합니다.
+func (a *App) SetAllMonitorsMode() { // 단일 책임: all 모드 전환 노출
+	if a.agent == nil {
+		return
+	}
+	a.agent.SetAllMonitorsMode()
+	a.agent.PersistSettings(config.MonitorSettings)
+}
+
+// ListWindows 함수는 window 모드로 캡처할 수 있는 창 목록을 반환합니다.
+func (a *App) ListWindows() ([]agent.WindowInfo, error) { // 단일 책임: 창 목록 노출
+	if a.agent == nil {
+		return nil, nil
+	}
+	return a.agent.ListWindows()
+}
+
+// SelectWindow 함수는 window 모드로 전환해 제목/프로세스 이름이 일치하는 창을 캡처합니다.
+func (a *App) SelectWindow(title, process string) error { // 단일 책임: 창 선택 노출
+	if a.agent == nil {
+		return nil
+	}
+	if err := a.agent.SelectWindow(title, process); err != nil {
+		return err
+	}
+	a.agent.PersistSettings(config.MonitorSettings)
+	return nil
+}
+
+// SetEncoding 함수는 캡처를 멈추지 않고 인코딩(png | jpeg | delta | tiles | webp | h264, 빈 값이면 유지)과 품질(0 이면 유지)을 바꿉니다.
+func (a *App) SetEncoding(encoding string, quality int) error { // 단일 책임: 인코딩 변경 노출
+	if a.agent == nil {
+		return nil
+	}
+	if err := a.agent.SetEncoding(encoding, quality); err != nil {
+		return err
+	}
+	a.agent.PersistSettings(config.EncodingSettings)
+	runtime.EventsEmit(a.ctx, EVENT_ENCODING_CHANGED, encoding, quality)
+	return nil
+}
+
+// CollectDiagnostics 함수는 진단 번들을 생성해 서버로 업로드하고 저장 경로를 반환합니다.
+func (a *App) CollectDiagnostics() (string, error) { // 단일 책임: 진단 번들 생성 노출
+	if a.agent == nil {
+		return "", nil
+	}
+	return a.agent.CollectDiagnostics("", true)
+}
+
+// CaptureNow 함수는 스트리밍 설정과 무관하게 원본 해상도 무손실 PNG 한 장을 즉시 캡처합니다.
+func (a *App) CaptureNow() (agent.Screenshot, error) { // 단일 책임: 즉시 캡처 노출
+	if a.agent == nil {
+		return agent.Screenshot{}, nil
+	}
+	return a.agent.CaptureNow()
+}
+
+// StartRecording 함수는 지정한 분 동안 로컬 녹화를 시작하고 녹화 ID 를 반환합니다.
+func (a *App) StartRecording(minutes int) (string, error) { // 단일 책임: 녹화 시작 노출
+	if a.agent == nil {
+		return "", nil
+	}
+	return a.agent.StartRecording(minutes)
+}
+
+// StopRecording 함수는 진행 중인 녹화를 종료하고 업로드합니다.
+func (a *App) StopRecording() (string, error) { // 단일 책임: 녹화 종료 노출
+	if a.agent == nil {
+		return "", nil
+	}
+	return a.agent.StopRecording()
+}
+
+// UploadRecording 함수는 저장된 녹화 파일을 업로드하고 업로드한 파일 수를 반환합니다 (id 가 비어 있으면 업로드되지 않은 전체).
+func (a *App) UploadRecording(id string) (int, error) { // 단일 책임: 녹화 업로드 노출
+	if a.agent == nil {
+		return 0, nil
+	}
+	return a.agent.UploadRecording(id)
+}
+
+// GetLoopbackStatus 함수는 루프백 모드에서 수신된 프레임/이벤트 요약을 반환합니다 (비활성 시 nil).
+func (a *App) GetLoopbackStatus() *loopback.Status { // 단일 책임: 루프백 수신 요약 노출
+	if a.loopback == nil {
+		return nil
+	}
+	st := a.loopback.Status()
+	return &st
+}
+
+// GetConnectionStatus 함수는 서버 연결 준비 상태를 반환합니다.
+func (a *App) GetConnectionStatus() agent.ConnectionStatus { // 단일 책임: 연결 상태 노출
+	if a.agent == nil {
+		return agent.ConnectionStatus{State: agent.CONN_STATE_IDLE}
+	}
+	return a.agent.ConnectionStatus()
+}
+
+// GetPrivacyMasks 함수는 인코딩 전에 가리는 화면 영역 목록을 반환합니다.
+func (a *App) GetPrivacyMasks() []agent.PrivacyMask { // 단일 책임: 가림 영역 노출
+	if a.agent == nil {
+		return []agent.PrivacyMask{}
+	}
+	return a.agent.PrivacyMasks()
+}
+
+// SetPrivacyMasks 함수는 가림 영역과 방식(black | blur, 빈 값이면 유지)을 바꿉니다.
+func (a *App) SetPrivacyMasks(masks []agent.PrivacyMask, mode string) error { // 단일 책임: 가림 영역 변경 노출
+	if a.agent == nil {
+		return nil
+	}
+	if err := a.agent.SetPrivacyMasks(masks, mode); err != nil {
+		return err
+	}
+	a.agent.PersistSettings(config.PrivacySettings)
+	return nil
+}
+
+// GetBlockedApps 함수는 캡처 금지 앱 규칙(프로세스 이름 또는 "title:" 접두 창 제목) 목록을 반환합니다.
+func (a *App) GetBlockedApps() []string { // 단일 책임: 금지 목록 노출
+	if a.agent == nil {
+		return []string{}
+	}
+	return a.agent.BlockedApps()
+}
+
+// SetBlockedApps 함수는 캡처 금지 앱 규칙과 처리 방식(blank | pause, 빈 값이면 유지)을 바꿉니다.
+func (a *App) SetBlockedApps(rules []string, action string) error { // 단일 책임: 금지 목록 변경 노출
+	if a.agent == nil {
+		return nil
+	}
+	if err := a.agent.SetBlockedApps(rules, action); err != nil {
+		return err
+	}
+	a.agent.PersistSettings(config.BlockedAppSettings)
+	return nil
+}
+
+// GetCaptureStats 함수는 캡처/인코딩/송신/드롭 누적 계수와 평균 인코딩 시간, 평균 프레임 크기, 실효 FPS 를 반환합니다.
+func (a *App) GetCaptureStats() agent.CaptureStats { // 단일 책임: 캡처 계측 노출
+	if a.agent == nil {
+		return agent.CaptureStats{}
+	}
+	return a.agent.CaptureStats()
+}
+
+// GetFrameQueueStats 함수는 캡처와 송신 사이 프레임 큐의 대기 수, 버린 프레임 수, 대기 시간을 반환합니다.
+func (a *App) GetFrameQueueStats() agent.FrameQueueStats { // 단일 책임: 송신 큐 계측 노출
+	if a.agent == nil {
+		return agent.FrameQueueStats{}
+	}
+	return a.agent.FrameQueueStats()
+}
+
+// GetQualityStats 함수는 대역폭 상한 기반 전송 품질 단계를 반환합니다.
+func (a *App) GetQualityStats() agent.QualityStats { // 단일 책임: 품질 단계 노출
+	if a.agent == nil {
+		return agent.QualityStats{}
+	}
+	return a.agent.QualityStats()
+}
+
+// GetClipboardConsent 함수는 클립보드 감시 사용자 동의 여부를 반환합니다.
+func (a *App) GetClipboardConsent() bool { // 단일 책임: 클립보드 동의 노출
+	if a.agent == nil {
+		return false
+	}
+	return a.agent.ClipboardConsent()
+}
+
+// GetConfigProblems 함수는 잘못 지정해 대체 값으로 실행 중인 설정 항목 목록을 반환합니다 (설정 화면 경고 표시용).
+func (a *App) GetConfigProblems() []config.Problem { // 단일 책임: 설정 문제 노출
+	if a.agent == nil {
+		return []config.Problem{}
+	}
+	return a.agent.ConfigProblems()
+}
+
+// GetRecentLogs 함수는 순번이 after 보다 큰 최근 로그를 오래된 순으로 최대 limit 개 반환합니다 (limit 0 이면 보관분 전체).
+func (a *App) GetRecentLogs(limit int, after uint64) []logging.Entry { // 단일 책임: 최근 로그 노출
+	return logging.RecentEntries(limit, after)
+}
+
+// GetRecentEvents 함수는 순번이 after 보다 큰 최근 보고 이벤트를 최대 limit 개 반환합니다 (서버로 보낸 내용 확인용).
+func (a *App) GetRecentEvents(limit int, after uint64) []agent.EventRecord { // 단일 책임: 최근 보고 이벤트 노출
+	if a.agent == nil {
+		return nil
+	}
+	return a.agent.RecentEvents(limit, after)
+}
+
+// GetBandwidth 함수는 서버로 보낸 바이트를 스트림 묶음별 최근 1분/1시간/24시간과 누적으로 반환합니다.
+func (a *App) GetBandwidth() agent.BandwidthStats { // 단일 책임: 송신량 노출
+	if a.agent == nil {
+		return agent.BandwidthStats{}
+	}
+	return a.agent.Bandwidth()
+}
+
+// SetLogLevel 함수는 재시작 없이 로그 수준을 바꿉니다 (debug | info | warn | error, 문제 해결용).
+func (a *App) SetLogLevel(name string) error { // 단일 책임: 로그 수준 변경 노출
+	if a.agent == nil {
+		return logging.SetLevel(name)
+	}
+	return a.agent.SetLogLevel(name)
+}
+
+// GetLogLevel 함수는 현재 로그 수준을 반환합니다.
+func (a *App) GetLogLevel() string { // 단일 책임: 로그 수준 조회 노출
+	return logging.Level()
+}
+
+// ExportConfig 함수는 현재 유효 설정을 JSON 으로 반환합니다 (지원 담당자가 사용자 설정을 재현할 때 사용).
+func (a *App) ExportConfig() (string, error) { // 단일 책임: 설정 내보내기 노출
+	if a.agent == nil {
+		return "", nil
+	}
+	return a.agent.ExportConfig()
+}
+
+// PauseCapture 함수는 캡처를 minutes 분 동안 멈추고, 캡처 중이었으면 끝날 때 자동으로 다시 시작합니다.
+func (a *App) PauseCapture(minutes int) error { // 단일 책임: 캡처 일시 중지 노출
+	if a.agent == nil {
+		return nil
+	}
+	return a.agent.PauseCapture(time.Duration(minutes) * time.Minute)
+}
+
+// ResumeCapture 함수는 일시 중지를 바로 끝내고, 일시 중지 전에 캡처 중이었으면 다시 시작합니다.
+func (a *App) ResumeCapture() error { // 단일 책임: 캡처 재개 노출
+	if a.agent == nil {
+		return nil
+	}
+	return a.agent.ResumeCapture()
+}
+
+// GetPauseRemaining 함수는 자동 재개까지 남은 시간(초)을 반환합니다 (일시 중지 중이 아니면 0).
+func (a *App) GetPauseRemaining() int { // 단일 책임: 남은 일시 중지 시간 노출
+	if a.agent == nil {
+		return 0
+	}
+	return int(a.agent.PauseRemaining().Round(time.Second) / time.Second)
+}
+
+// GetIdentity 함수는 에이전트 ID, 장치 이름, ID 출처를 반환합니다.
+func (a *App) GetIdentity() agent.Identity { // 단일 책임: 식별 정보 노출
+	if a.agent == nil {
+		return agent.Identity{}
+	}
+	return a.agent.Identity()
+}
+
+// CopyAgentID 함수는 에이전트 ID 를 클립보드에 복사합니다 (관리자에게 장치를 알려 줄 때).
+func (a *App) CopyAgentID() error { // 단일 책임: 에이전트 ID 복사
+	if a.agent == nil {
+		return nil
+	}
+	return runtime.ClipboardSetText(a.ctx, a.agent.Identity().AgentID)
+}
+
+// RegenerateAgentID 함수는 새 에이전트 ID 를 발급해 저장하고 반환합니다 (재시작 후 적용).
+func (a *App) RegenerateAgentID() (string, error) { // 단일 책임: 에이전트 ID 재발급 노출
+	if a.agent == nil {
+		return "", nil
+	}
+	return a.agent.RegenerateAgentID()
+}
+
+// SetDeviceName 함수는 서버에 보일 장치 이름을 바꿉니다 (빈 값이면 호스트명).
+func (a *App) SetDeviceName(name string) error { // 단일 책임: 장치 이름 변경 노출
+	if a.agent == nil {
+		return nil
+	}
+	return a.agent.SetDeviceName(name)
+}
+
+// GetLocale 함수는 UI 문구에 쓰는 현재 로캘(ko | en)을 반환합니다.
+func (a *App) GetLocale() string { // 단일 책임: 현재 로캘 노출
+	return i18n.Locale()
+}
+
+// GetMessages 함수는 현재 로캘의 문구 카탈로그를 반환합니다 (key → 문구, 프론트엔드 번역용).
+func (a *App) GetMessages() map[string]string { // 단일 책임: 문구 카탈로그 노출
+	return i18n.Messages()
+}
+
+// SetLocale 함수는 표시 언어를 바꿔 저장합니다 (빈 값이면 OS 로캘). 바꾼 뒤 GetMessages 로 문구를 다시 받습니다.
+func (a *App) SetLocale(tag string) error { // 단일 책임: 표시 언어 변경 노출
+	if a.agent == nil {
+		return nil
+	}
+	if err := a.agent.SetLocale(tag); err != nil {
+		return err
+	}
+	a.refreshTray()
+	return nil
+}
+
+// GetConsent 함수는 캡처 동의 필요 여부와 기록된 동의를 반환합니다.
+func (a *App) GetConsent() agent.ConsentStatus { // 단일 책임: 동의 상태 노출
+	if a.agent == nil {
+		return agent.ConsentStatus{}
+	}
+	return a.agent.Consent()
+}
+
+// AcceptConsent 함수는 현재 사용자의 캡처 동의를 기록합니다 (캡처는 따로 시작).
+func (a *App) AcceptConsent() (agent.ConsentStatus, error) { // 단일 책임: 동의 수락 노출
+	if a.agent == nil {
+		return agent.ConsentStatus{}, nil
+	}
+	return a.agent.AcceptConsent()
+}
+
+// RevokeConsent 함수는 캡처 동의를 철회합니다. 동의가 필요한 설정이면 진행 중인 캡처도 멈춥니다.
+func (a *App) RevokeConsent() error { // 단일 책임: 동의 철회 노출
+	if a.agent == nil {
+		return nil
+	}
+	return a.agent.RevokeConsent()
+}
+
+// GetSettings 함수는 설정 화면에 보일 캡처 설정을 반환합니다.
+func (a *App) GetSettings() agent.CaptureSettings { // 단일 책임: 캡처 설정 조회 노출
+	if a.agent == nil {
+		return agent.CaptureSettings{}
+	}
+	return a.agent.GetSettings()
+}
+
+// UpdateSettings 함수는 설정 화면의 캡처 설정을 검증해 저장/적용합니다. 검증 실패 항목은 결과의 Problems 로 돌려줍니다.
+func (a *App) UpdateSettings(s agent.CaptureSettings) (agent.SettingsUpdate, error) { // 단일 책임: 캡처 설정 변경 노출
+	if a.agent == nil {
+		return agent.SettingsUpdate{}, nil
+	}
+	return a.agent.UpdateSettings(s)
+}
+
+// ImportConfig 함수는 JSON 설정 묶음을 검증해 저장/적용하고 바로 적용한 항목을 반환합니다 (관리자 배포 프로필용).
+func (a *App) ImportConfig(data string) ([]string, error) { // 단일 책임: 설정 가져오기 노출
+	if a.agent == nil {
+		return nil, nil
+	}
+	return a.agent.ImportConfig(data)
+}
+
+// SetPreviewEnabled 함수는 실시간 미리보기 생성을 켜고 끕니다 (켠 동안 capture:preview 이벤트 발생).
+func (a *App) SetPreviewEnabled(on bool) { // 단일 책임: 미리보기 전환 노출
+	if a.agent == nil {
+		return
+	}
+	a.agent.SetPreviewEnabled(on)
+}
+
+// GetPreview 함수는 마지막 미리보기를 반환합니다 (이벤트를 놓쳤을 때 data URL 조회용).
+func (a *App) GetPreview() agent.UIPreview { // 단일 책임: 미리보기 조회 노출
+	if a.agent == nil {
+		return agent.UIPreview{}
+	}
+	return a.agent.Preview()
+}
+
+// EnableAutoStart 함수는 로그인 시 에이전트가 창 없이 자동 시작하도록 등록합니다.
+func (a *App) EnableAutoStart() error { // 단일 책임: 자동 시작 등록 노출
+	return autostart.Enable()
+}
+
+// DisableAutoStart 함수는 로그인 자동 시작 등록을 지웁니다.
+func (a *App) DisableAutoStart() error { // 단일 책임: 자동 시작 해제 노출
+	return autostart.Disable()
+}
+
+// GetAutoStart 함수는 로그인 자동 시작 등록 여부를 반환합니다 (조회 실패 시 false).
+func (a *App) GetAutoStart() bool { // 단일 책임: 자동 시작 조회 노출
+	on, _ := autostart.Enabled()
+	return on
+}
+
+// StartPprof 함수는 로컬 pprof 진단 엔드포인트를 열고 주소를 반환합니다 (AGENT_PPROF_ADDR, 루프백만).
+func (a *App) StartPprof() (string, error) { // 단일 책임: pprof 시작 노출
+	if a.agent == nil {
+		return "", nil
+	}
+	return a.agent.StartPprof("")
+}
+
+// StopPprof 함수는 pprof 진단 엔드포인트를 닫습니다.
+func (a *App) StopPprof() { // 단일 책임: pprof 종료 노출
+	if a.agent == nil {
+		return
+	}
+	a.agent.StopPprof()
+}
+
+// GetPprofAddr 함수는 열려 있는 pprof 엔드포인트 주소를 반환합니다 (닫혀 있으면 빈 값).
+func (a *App) GetPprofAddr() string { // 단일 책임: pprof 주소 노출
+	if a.agent == nil {
+		return ""
+	}
+	return a.agent.PprofAddr()
+}
+
+// WriteProfile 함수는 kind(cpu | heap | goroutine ...) 프로파일을 데이터 폴더에 저장하고 경로를 반환합니다 (cpu 는 seconds 초 수집).
+func (a *App) WriteProfile(kind string, seconds int) (string, error) { // 단일 책임: 프로파일 저장 노출
+	if a.agent == nil {
+		return "", nil
+	}
+	return a.agent.WriteProfile(kind, seconds)
+}
+
+// SetClipboardConsent 함수는 클립보드 감시 사용자 동의를 바꿉니다 (CLIPBOARD_EVENTS 가 켜져 있어야 감시).
+func (a *App) SetClipboardConsent(granted bool) { // 단일 책임: 클립보드 동의 변경 노출
+	if a.agent == nil {
+		return
+	}
+	a.agent.SetClipboardConsent(granted)
+	a.agent.PersistSettings(config.ClipboardSettings)
 }
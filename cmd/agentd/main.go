@@ -0,0 +1,19 @@
+// agentd 는 Wails UI 없이 에이전트 코어(설정, 캡처, gRPC)만 실행하는 실행 파일입니다.
+// GUI 실행 파일의 --headless 와 같지만 Wails/트레이를 링크하지 않아, 웹뷰/GTK 라이브러리나
+// 디스플레이 관리자가 없는 서버, 키오스크, CI 환경에서도 시작됩니다.
+package main
+
+import (
+	"os"
+
+	"agent/internal/cli"
+)
+
+func main() {
+	_, args, err := cli.ParseGlobalFlags(os.Args[1:])
+	if err != nil {
+		os.Exit(2)
+	}
+	code, _ := cli.Run(args, true) // 서브커맨드가 없으면 run
+	os.Exit(code)
+}
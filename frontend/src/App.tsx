@@ -1,28 +1,158 @@
-import { useEffect, useCallback, useState } from 'react'
+import { useEffect, useCallback, useRef, useState } from 'react'
 import { 
   StartCapture, 
   StopCapture, 
   ListMonitors, 
   SelectMonitor, 
-  SetCombinedMode 
+  SetCombinedMode,
+  SetAllMonitorsMode,
+  SetCombinedLayout,
+  ListWindows,
+  SelectWindow,
+  GetConnectionStatus,
+  SetPreviewEnabled,
+  GetPreview,
+  GetPauseRemaining,
+  GetConsent,
+  AcceptConsent,
+  GetMessages,
+  GetRecentEvents,
+  GetBandwidth
 } from "../wailsjs/go/main/App"
+import { agent } from "../wailsjs/go/models"
+import { EventsOn } from "../wailsjs/runtime/runtime"
 
 // 상수 정의 (대문자 스네이크 케이스)
 const REFRESH_INTERVAL_MS = 5000 // 모니터 목록 자동 새로고침 주기 (ms)
 const TARGET_FPS_LABEL = '30 FPS' // 고정 출력 라벨
+const EVENT_CONNECTION_STATE = 'connection:state' // 백엔드 연결 상태 변경 이벤트
+const EVENT_PREVIEW_FRAME = 'capture:preview' // 백엔드 송신 화면 미리보기 이벤트
+const PAUSE_POLL_MS = 1000 // 일시 중지 남은 시간 갱신 주기 (ms)
+const EVENT_REPORTED = 'event:reported' // 백엔드 보고 이벤트 기록 이벤트
+const REPORTED_EVENTS_MAX = 100 // 보고 이벤트 목록에 보일 최대 수
+const BANDWIDTH_POLL_MS = 5000 // 송신량 갱신 주기 (ms)
+const COMBINED_LAYOUT_LABELS: Record<string, string> = { // combined 모드 배치 표시 문자열
+  horizontal: '가로',
+  vertical: '세로',
+  grid: '격자',
+  physical: '실제 배치',
+}
+
+// mergeEvents 함수는 보고 이벤트 목록에 새 기록을 순번 기준으로 합쳐 최근 REPORTED_EVENTS_MAX 개만 남깁니다.
+const mergeEvents = (prev: agent.EventRecord[], items: agent.EventRecord[]): agent.EventRecord[] => { // 단일 책임: 보고 이벤트 병합
+  const bySeq = new Map(prev.map((e) => [e.seq, e]))
+  items.forEach((e) => bySeq.set(e.seq, e))
+  return [...bySeq.values()].sort((x, y) => x.seq - y.seq).slice(-REPORTED_EVENTS_MAX)
+}
+
+// formatBytes 함수는 바이트 수를 KB/MB/GB 단위 문자열로 바꿉니다.
+const formatBytes = (n: number): string => { // 단일 책임: 바이트 표시
+  const units = ['B', 'KB', 'MB', 'GB']
+  let i = 0
+  while (n >= 1024 && i < units.length - 1) {
+    n /= 1024
+    i++
+  }
+  return `${i === 0 ? n : n.toFixed(1)} ${units[i]}`
+}
+
+// format 함수는 카탈로그 문구의 %s/%d 자리를 args 로 차례대로 채웁니다 (Go fmt 형식과 같은 자리표시자).
+const format = (msg: string, args: (string | number)[]): string => { // 단일 책임: 문구 자리 채우기
+  let i = 0
+  return msg.replace(/%[sd]/g, () => String(args[i++] ?? ''))
+}
 
 // App 컴포넌트는 캡처 제어 및 모니터 선택 UI를 제공합니다.
 const App = () => { // 단일 책임: 전체 UI 구성
   const [capturing, setCapturing] = useState(false) // 캡처 상태
-  const [monitors, setMonitors] = useState<string[]>([]) // 모니터 목록
+  const [monitors, setMonitors] = useState<agent.MonitorInfo[]>([]) // 모니터 목록 (썸네일 포함)
   const [selectedMonitor, setSelectedMonitor] = useState<number | null>(null) // 선택된 모니터 인덱스
   const [previousSingleMonitor, setPreviousSingleMonitor] = useState<number | null>(null) // 마지막 단일 모니터 기억
-  const [mode, setMode] = useState<'single' | 'combined'>('single') // 캡처 모드
+  const [mode, setMode] = useState<'single' | 'combined' | 'all' | 'window'>('single') // 캡처 모드
+  const [layout, setLayout] = useState<string>('horizontal') // combined 모드 배치
+  const [windows, setWindows] = useState<agent.WindowInfo[]>([]) // 캡처 가능한 창 목록
+  const [selectedWindow, setSelectedWindow] = useState<number | null>(null) // 선택된 창 ID
   const [message, setMessage] = useState<string>('') // 사용자 메시지
   const [loading, setLoading] = useState<boolean>(false) // 로딩 상태
+  const [connection, setConnection] = useState<agent.ConnectionStatus | null>(null) // 서버 연결 상태
+  const [previewOn, setPreviewOn] = useState<boolean>(true) // 미리보기 표시 여부
+  const [preview, setPreview] = useState<agent.UIPreview | null>(null) // 마지막 미리보기
+  const [pauseLeft, setPauseLeft] = useState<number>(0) // 일시 중지 남은 시간(초, 0 이면 일시 중지 아님)
+  const [consent, setConsent] = useState<agent.ConsentStatus | null>(null) // 캡처 동의 상태
+  const [messages, setMessages] = useState<Record<string, string>>({}) // 현재 로캘 문구 카탈로그 (GetMessages)
+  const [reported, setReported] = useState<agent.EventRecord[]>([]) // 서버로 보고한 최근 이벤트 (오래된 순)
+  const [bandwidth, setBandwidth] = useState<agent.BandwidthStats | null>(null) // 서버로 보낸 바이트
+  const messagesRef = useRef<Record<string, string>>({}) // 첫 렌더의 콜백(자동 캡처 시작)도 받은 카탈로그를 쓰도록 함께 보관
+
+  // t 함수는 카탈로그 key 의 현재 로캘 문구를 반환합니다 (카탈로그를 받기 전이나 없는 key 는 key 그대로).
+  const t = useCallback((key: string, ...args: (string | number)[]) => { // 단일 책임: 문구 번역
+    return format(messagesRef.current[key] ?? key, args)
+  }, [messages])
+
+  // useEffect: 연결 상태 초기 조회 및 변경 이벤트 구독
+  useEffect(() => { // 단일 책임: 연결 상태 동기화
+    GetConnectionStatus().then(setConnection).catch(() => {})
+    return EventsOn(EVENT_CONNECTION_STATE, (st: agent.ConnectionStatus) => setConnection(st))
+  }, [])
+
+  // useEffect: 미리보기를 켠 동안만 백엔드 생성을 켜고 이벤트 구독
+  useEffect(() => { // 단일 책임: 미리보기 동기화
+    if (!previewOn) {
+      setPreview(null)
+      return
+    }
+    SetPreviewEnabled(true).catch(() => {})
+    GetPreview().then((p) => p.dataUrl && setPreview(p)).catch(() => {})
+    const off = EventsOn(EVENT_PREVIEW_FRAME, (p: agent.UIPreview) => setPreview(p))
+    return () => {
+      off()
+      SetPreviewEnabled(false).catch(() => {})
+    }
+  }, [previewOn])
 
-  useEffect(() => {
-    startCapture()
+  // useEffect: 보고 이벤트 초기 조회 및 새 기록 구독 (놓친 순번은 다시 조회해 채움)
+  useEffect(() => { // 단일 책임: 보고 이벤트 동기화
+    let last = 0
+    const add = (items: agent.EventRecord[]) => setReported((prev) => {
+      const next = mergeEvents(prev, items)
+      last = next.length ? next[next.length - 1].seq : last
+      return next
+    })
+    GetRecentEvents(REPORTED_EVENTS_MAX, 0).then((items) => add(items ?? [])).catch(() => {})
+    return EventsOn(EVENT_REPORTED, (e: agent.EventRecord) => {
+      if (last > 0 && e.seq > last + 1) {
+        GetRecentEvents(REPORTED_EVENTS_MAX, last).then((items) => add(items ?? [])).catch(() => {})
+      }
+      add([e])
+    })
+  }, [])
+
+  // useEffect: 송신량 주기 조회
+  useEffect(() => { // 단일 책임: 송신량 동기화
+    const tick = () => GetBandwidth().then(setBandwidth).catch(() => {})
+    tick()
+    const id = setInterval(tick, BANDWIDTH_POLL_MS)
+    return () => clearInterval(id)
+  }, [])
+
+  // useEffect: 트레이/바인딩으로 건 일시 중지의 남은 시간 갱신
+  useEffect(() => { // 단일 책임: 일시 중지 상태 동기화
+    const tick = () => GetPauseRemaining().then(setPauseLeft).catch(() => {})
+    tick()
+    const id = setInterval(tick, PAUSE_POLL_MS)
+    return () => clearInterval(id)
+  }, [])
+
+  // useEffect: 문구 카탈로그와 동의 상태 조회 후, 동의가 있거나 필요 없으면 캡처 시작
+  useEffect(() => { // 단일 책임: 초기 문구 로드 및 동의 확인 후 자동 캡처
+    GetMessages().catch(() => ({})).then((m) => {
+      messagesRef.current = m ?? {}
+      setMessages(messagesRef.current)
+      return GetConsent()
+    }).then((st) => {
+      setConsent(st)
+      if (!st.required || st.granted) startCapture()
+    }).catch(() => startCapture())
   }, [])
 
   // loadMonitors 함수는 모니터 목록을 불러옵니다.
@@ -30,7 +160,7 @@ const App = () => { // 단일 책임: 전체 UI 구성
     try {
       setLoading(true)
       const list = await ListMonitors()
-      setMonitors(list)
+      setMonitors(list ?? [])
       if (list.length > 0 && selectedMonitor === null && mode === 'single') {
         setSelectedMonitor(0)
       }
@@ -42,18 +172,31 @@ const App = () => { // 단일 책임: 전체 UI 구성
     }
   }, [selectedMonitor, mode])
 
+  // loadWindows 함수는 캡처 가능한 창 목록을 불러옵니다.
+  const loadWindows = useCallback(async () => { // 단일 책임: 창 목록 조회
+    try {
+      setLoading(true)
+      setWindows(await ListWindows() ?? [])
+    } catch (e) {
+      console.error('창 목록 조회 실패', e)
+      setMessage(`창 목록 조회 실패: ${e}`)
+    } finally {
+      setLoading(false)
+    }
+  }, [])
+
   // startCapture 함수는 캡처를 시작합니다.
   const startCapture = useCallback(async () => { // 단일 책임: 캡처 시작
     if (capturing) return
     try {
       await StartCapture()
       setCapturing(true)
-      setMessage('캡처 시작')
+      setMessage(t('capture.start'))
     } catch (e) {
       console.error('캡처 시작 실패', e)
-      setMessage('캡처 시작 실패')
+      setMessage(t('capture.failed', String(e)))
     }
-  }, [capturing])
+  }, [capturing, t])
 
   // stopCapture 함수는 캡처를 중지합니다.
   const stopCapture = useCallback(async () => { // 단일 책임: 캡처 중지
@@ -61,12 +204,23 @@ const App = () => { // 단일 책임: 전체 UI 구성
     try {
       await StopCapture()
       setCapturing(false)
-      setMessage('캡처 중지')
+      setMessage(t('capture.stop'))
     } catch (e) {
       console.error('캡처 중지 실패', e)
       setMessage('캡처 중지 실패')
     }
-  }, [capturing])
+  }, [capturing, t])
+
+  // acceptConsent 함수는 캡처 동의를 기록하고 캡처를 시작합니다.
+  const acceptConsent = useCallback(async () => { // 단일 책임: 캡처 동의 수락
+    try {
+      setConsent(await AcceptConsent())
+      await startCapture()
+    } catch (e) {
+      console.error('동의 기록 실패', e)
+      setMessage(t('consent.failed', String(e)))
+    }
+  }, [startCapture, t])
 
   // applySingleMonitor 함수는 단일 모드로 특정 모니터를 선택합니다.
   const applySingleMonitor = useCallback(async (index: number) => { // 단일 책임: 단일 모니터 적용
@@ -99,6 +253,53 @@ const App = () => { // 단일 책임: 전체 UI 구성
     }
   }, [])
 
+  // applyCombinedLayout 함수는 combined 모드 배치를 바꿉니다.
+  const applyCombinedLayout = useCallback(async (next: string) => { // 단일 책임: combined 배치 적용
+    try {
+      await SetCombinedLayout(next)
+      setLayout(next)
+      setMode('combined')
+      setMessage(`결합 배치: ${COMBINED_LAYOUT_LABELS[next] ?? next}`)
+    } catch (e) {
+      console.error('combined 배치 적용 실패', e)
+      setMessage('결합 배치 적용 실패')
+    }
+  }, [])
+
+  // applyAllMonitorsMode 함수는 all 모드로 전환합니다 (모니터마다 별도 프레임 스트림).
+  const applyAllMonitorsMode = useCallback(async () => { // 단일 책임: all 모드 적용
+    try {
+      await SetAllMonitorsMode()
+      setMode('all')
+      setSelectedMonitor(null)
+      setMessage('전체 모니터 모드 적용')
+    } catch (e) {
+      console.error('all 모드 적용 실패', e)
+      setMessage('전체 모니터 모드 적용 실패')
+    }
+  }, [])
+
+  // applyWindow 함수는 window 모드로 전환해 선택한 창을 캡처합니다 (제목 + 프로세스 이름으로 지정).
+  const applyWindow = useCallback(async (w: agent.WindowInfo) => { // 단일 책임: 창 캡처 적용
+    try {
+      await SelectWindow(w.title, w.process)
+      setMode('window')
+      setSelectedMonitor(null)
+      setSelectedWindow(w.id)
+      setMessage(`창 선택: ${w.title || w.process}`)
+    } catch (e) {
+      console.error('창 선택 실패', e)
+      setMessage(`창 선택 실패: ${e}`)
+    }
+  }, [])
+
+  // switchToWindowMode 함수는 창 모드 버튼 클릭 시 창 목록을 불러옵니다 (창을 고르면 실제 전환).
+  const switchToWindowMode = useCallback(() => { // 단일 책임: 창 목록 표시 전환
+    setMode('window')
+    setSelectedWindow(null)
+    loadWindows()
+  }, [loadWindows])
+
   // switchToSingleMode 함수는 단일 모드 버튼 클릭 시 적절한 모니터로 전환합니다.
   const switchToSingleMode = useCallback(() => { // 단일 책임: 단일 모드 전환
     if (mode === 'single') return
@@ -125,23 +326,69 @@ const App = () => { // 단일 책임: 전체 UI 구성
 
   // renderMonitorList 함수는 모니터 선택 UI를 렌더링합니다.
   const renderMonitorList = () => { // 단일 책임: 모니터 리스트 렌더링
+    if (mode === 'window') {
+      return renderWindowList()
+    }
     if (mode === 'combined') {
-      return <div style={{ fontSize: 13, color: '#555' }}>결합 모드 - 모든 모니터를 가로로 캡처</div>
+      return (
+        <div style={{ display: 'flex', flexDirection: 'column', gap: 4, fontSize: 13, color: '#555' }}>
+          <span>결합 모드 - 모든 모니터를 한 화면으로 캡처</span>
+          <label style={{ display: 'flex', alignItems: 'center', gap: 4 }}>
+            배치
+            <select value={layout} onChange={(e) => applyCombinedLayout(e.target.value)}>
+              {Object.entries(COMBINED_LAYOUT_LABELS).map(([value, label]) => (
+                <option key={value} value={value}>{label}</option>
+              ))}
+            </select>
+          </label>
+        </div>
+      )
+    }
+    if (mode === 'all') {
+      return <div style={{ fontSize: 13, color: '#555' }}>전체 모니터 모드 - 모니터 {monitors.length}개를 각각 동시에 캡처</div>
     }
     if (monitors.length === 0) {
       return <div style={{ fontSize: 13 }}>모니터 없음</div>
     }
     return (
       <div style={{ display: 'flex', flexDirection: 'column', gap: 4 }}>
-        {monitors.map((m, i) => (
-          <label key={i} style={{ display: 'flex', alignItems: 'center', gap: 4, cursor: 'pointer' }}>
+        {monitors.map((m) => (
+          <label key={m.index} className="monitorOption">
             <input
               type="radio"
               name="monitor"
-              checked={selectedMonitor === i}
-              onChange={() => applySingleMonitor(i)}
+              checked={selectedMonitor === m.index}
+              onChange={() => applySingleMonitor(m.index)}
             />
-            <span style={{ fontSize: 13 }}>{m}</span>
+            {m.thumbnail
+              ? <img className="monitorThumb" src={m.thumbnail} alt={m.name} />
+              : <span className="monitorThumb" />}
+            <span style={{ fontSize: 13 }}>
+              {m.name}<br />
+              <span style={{ color: '#777' }}>{m.width}x{m.height} @ {m.x},{m.y}</span>
+            </span>
+          </label>
+        ))}
+      </div>
+    )
+  }
+
+  // renderWindowList 함수는 창 선택 UI를 렌더링합니다.
+  const renderWindowList = () => { // 단일 책임: 창 리스트 렌더링
+    if (windows.length === 0) {
+      return <div style={{ fontSize: 13 }}>캡처 가능한 창 없음</div>
+    }
+    return (
+      <div style={{ display: 'flex', flexDirection: 'column', gap: 4 }}>
+        {windows.map((w) => (
+          <label key={w.id} style={{ display: 'flex', alignItems: 'center', gap: 4, cursor: 'pointer' }}>
+            <input
+              type="radio"
+              name="window"
+              checked={selectedWindow === w.id}
+              onChange={() => applyWindow(w)}
+            />
+            <span style={{ fontSize: 13 }}>{w.title || '(제목 없음)'} — {w.process} {w.width}x{w.height}</span>
           </label>
         ))}
       </div>
@@ -154,7 +401,9 @@ const App = () => { // 단일 책임: 전체 UI 구성
       <div style={{ display: 'flex', gap: 8, flexWrap: 'wrap' }}>
   <button onClick={switchToSingleMode} disabled={mode === 'single'}>단일 모드</button>
         <button onClick={applyCombinedMode} disabled={mode === 'combined'}>결합 모드</button>
-        <button onClick={() => loadMonitors()} disabled={loading}>목록 새로고침</button>
+        <button onClick={applyAllMonitorsMode} disabled={mode === 'all'}>전체 모니터</button>
+        <button onClick={switchToWindowMode} disabled={mode === 'window'}>창 모드</button>
+        <button onClick={() => (mode === 'window' ? loadWindows() : loadMonitors())} disabled={loading}>목록 새로고침</button>
       </div>
     )
   }
@@ -178,20 +427,34 @@ const App = () => { // 단일 책임: 전체 UI 구성
           {renderModeButtons()}
         </div>
         <div className="panelGroup largeList">
-          <div className="groupTitle">모니터 선택 (single)</div>
+          <div className="groupTitle">{mode === 'window' ? '창 선택 (window)' : '모니터 선택 (single)'}</div>
           <div className="scrollArea">
             {renderMonitorList()}
           </div>
         </div>
-        <div className="footNote">모니터: index:WxH+X+Y</div>
+        <div className="footNote">모니터: 해상도 @ 가상 화면 위치</div>
       </div>
       <div className="detailPanel"> {/* 단일 책임: 상세(상태/제어) 패널 */}
         <div className="panelHeader">Detail</div>
         <div className="panelGroup statusBlock">
-          <div className="statusRow"><strong>캡처 상태</strong><span>{capturing ? '캡처 중' : '대기'}</span></div>
-          <div className="statusRow"><strong>목표 FPS</strong><span>{TARGET_FPS_LABEL}</span></div>
-          <div className="statusRow"><strong>모드</strong><span>{mode === 'combined' ? '결합' : `단일${selectedMonitor !== null ? ' #' + selectedMonitor : ''}`}</span></div>
-          <div className="statusRow"><strong>모니터 수</strong><span>{monitors.length}</span></div>
+          <div className="statusRow">
+            <strong>{t('status.connection')}</strong>
+            <span title={connection?.error || connection?.server}>
+              <span className={`connDot conn-${connection?.state ?? 'idle'}`} />
+              {t(`conn.${connection?.state ?? 'idle'}`)}
+              {connection?.spooling ? ` · ${t('conn.spooling')}` : ''}
+            </span>
+          </div>
+          <div className="statusRow"><strong>{t('status.capture')}</strong><span>{pauseLeft > 0 ? t('capture.paused', `${Math.floor(pauseLeft / 60)}:${String(pauseLeft % 60).padStart(2, '0')}`) : capturing ? t('capture.active') : t('capture.idle')}</span></div>
+          <div className="statusRow"><strong>{t('status.fps')}</strong><span>{TARGET_FPS_LABEL}</span></div>
+          <div className="statusRow"><strong>{t('status.mode')}</strong><span>{mode === 'window' ? '창' : mode === 'combined' ? '결합' : mode === 'all' ? '전체 모니터' : `단일${selectedMonitor !== null ? ' #' + selectedMonitor : ''}`}</span></div>
+          <div className="statusRow">
+            <strong>{t('status.bandwidth')}</strong>
+            <span title={bandwidth?.streams?.filter((s) => s.total > 0).map((s) => `${s.stream}: ${formatBytes(s.lastHour)}`).join('\n')}>
+              {t('bandwidth.summary', formatBytes(bandwidth?.total?.lastMinute ?? 0), formatBytes(bandwidth?.total?.lastHour ?? 0), formatBytes(bandwidth?.total?.lastDay ?? 0))}
+            </span>
+          </div>
+          <div className="statusRow"><strong>{t('status.monitors')}</strong><span>{monitors.length}</span></div>
         </div>
         {/*
         <div className="panelGroup">
@@ -199,14 +462,47 @@ const App = () => { // 단일 책임: 전체 UI 구성
           {renderCaptureButtons()}
         </div>
         */}
+        {consent?.required && !consent.granted && (
+          <div className="panelGroup consentBlock"> {/* 단일 책임: 캡처 동의 요청 */}
+            <div className="groupTitle">{t('consent.title')}</div>
+            <div className="consentText">
+              {consent.policyVersion
+                ? t('consent.changed', consent.policyVersion, consent.currentPolicy)
+                : t('consent.body', consent.currentPolicy)}
+            </div>
+            <button onClick={acceptConsent}>{t('consent.accept')}</button>
+          </div>
+        )}
         <div className="panelGroup messageBlock">
           {message && <div className="messageLine">알림: {message}</div>}
           {loading && <div className="loadingLine">모니터 목록 갱신 중...</div>}
         </div>
+        <div className="panelGroup"> {/* 단일 책임: 서버로 보고한 이벤트 목록 */}
+          <div className="groupTitle">{t('events.title')}</div>
+          <div className="eventList">
+            {reported.length === 0 && <div className="loadingLine">{t('events.empty')}</div>}
+            {[...reported].reverse().map((e) => (
+              <div key={e.seq} className={`eventLine sev-${e.severity}`} title={e.detail}>
+                <span className="eventTime">{new Date(e.time).toLocaleTimeString()}</span>
+                <span className="eventType">{e.type}</span>
+                <span className="eventDetail">{e.detail}</span>
+              </div>
+            ))}
+          </div>
+        </div>
         <div className="spacer" />
-        <div className="panelGroup previewPlaceholder"> {/* 단일 책임: 향후 프리뷰 자리 */}
-          <div className="groupTitle">프리뷰 (향후 추가)</div>
-          <div className="previewBox">프리뷰 영역 확장됨</div>
+        <div className="panelGroup previewPlaceholder"> {/* 단일 책임: 송신 화면 미리보기 */}
+          <div className="groupTitle">
+            <label style={{ display: 'flex', alignItems: 'center', gap: 4 }}>
+              <input type="checkbox" checked={previewOn} onChange={(e) => setPreviewOn(e.target.checked)} />
+              미리보기{preview && mode === 'all' ? ` (모니터 ${preview.monitorId})` : ''}
+            </label>
+          </div>
+          <div className="previewBox">
+            {preview
+              ? <img className="previewImage" src={preview.dataUrl} alt="송신 화면 미리보기" />
+              : (previewOn ? (capturing ? '화면 변화 대기 중' : '캡처 중 아님') : '미리보기 꺼짐')}
+          </div>
         </div>
       </div>
     </div>
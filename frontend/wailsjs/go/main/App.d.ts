@@ -1,12 +1,122 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
+import {agent} from '../models';
+import {config} from '../models';
+import {loopback} from '../models';
+import {logging} from '../models';
 
-export function ListMonitors():Promise<Array<string>>;
+export function AcceptConsent():Promise<agent.ConsentStatus>;
+
+export function CaptureNow():Promise<agent.Screenshot>;
+
+export function CollectDiagnostics():Promise<string>;
+
+export function CopyAgentID():Promise<void>;
+
+export function DisableAutoStart():Promise<void>;
+
+export function EnableAutoStart():Promise<void>;
+
+export function ExportConfig():Promise<string>;
+
+export function GetAutoStart():Promise<boolean>;
+
+export function GetBandwidth():Promise<agent.BandwidthStats>;
+
+export function GetBlockedApps():Promise<Array<string>>;
+
+export function GetCaptureStats():Promise<agent.CaptureStats>;
+
+export function GetClipboardConsent():Promise<boolean>;
+
+export function GetConfigProblems():Promise<Array<config.Problem>>;
+
+export function GetConnectionStatus():Promise<agent.ConnectionStatus>;
+
+export function GetConsent():Promise<agent.ConsentStatus>;
+
+export function GetFrameQueueStats():Promise<agent.FrameQueueStats>;
+
+export function GetIdentity():Promise<agent.Identity>;
+
+export function GetLocale():Promise<string>;
+
+export function GetLogLevel():Promise<string>;
+
+export function GetLoopbackStatus():Promise<loopback.Status>;
+
+export function GetMessages():Promise<Record<string, string>>;
+
+export function GetPauseRemaining():Promise<number>;
+
+export function GetPprofAddr():Promise<string>;
+
+export function GetPreview():Promise<agent.UIPreview>;
+
+export function GetPrivacyMasks():Promise<Array<agent.PrivacyMask>>;
+
+export function GetQualityStats():Promise<agent.QualityStats>;
+
+export function GetRecentEvents(arg1:number,arg2:number):Promise<Array<agent.EventRecord>>;
+
+export function GetRecentLogs(arg1:number,arg2:number):Promise<Array<logging.Entry>>;
+
+export function GetSettings():Promise<agent.CaptureSettings>;
+
+export function ImportConfig(arg1:string):Promise<Array<string>>;
+
+export function ListMonitors():Promise<Array<agent.MonitorInfo>>;
+
+export function ListWindows():Promise<Array<agent.WindowInfo>>;
+
+export function PauseCapture(arg1:number):Promise<void>;
+
+export function RegenerateAgentID():Promise<string>;
+
+export function ResumeCapture():Promise<void>;
+
+export function RevokeConsent():Promise<void>;
 
 export function SelectMonitor(arg1:number):Promise<boolean>;
 
+export function SelectWindow(arg1:string,arg2:string):Promise<void>;
+
+export function SetAllMonitorsMode():Promise<void>;
+
+export function SetBlockedApps(arg1:Array<string>,arg2:string):Promise<void>;
+
+export function SetClipboardConsent(arg1:boolean):Promise<void>;
+
+export function SetCombinedLayout(arg1:string):Promise<void>;
+
 export function SetCombinedMode():Promise<void>;
 
+export function SetDeviceName(arg1:string):Promise<void>;
+
+export function SetEncoding(arg1:string,arg2:number):Promise<void>;
+
+export function SetLocale(arg1:string):Promise<void>;
+
+export function SetLogLevel(arg1:string):Promise<void>;
+
+export function SetPreviewEnabled(arg1:boolean):Promise<void>;
+
+export function SetPrivacyMasks(arg1:Array<agent.PrivacyMask>,arg2:string):Promise<void>;
+
 export function StartCapture():Promise<void>;
 
+export function StartPprof():Promise<string>;
+
+export function StartRecording(arg1:number):Promise<string>;
+
 export function StopCapture():Promise<void>;
+
+export function StopPprof():Promise<void>;
+
+export function StopRecording():Promise<string>;
+
+export function UpdateSettings(arg1:agent.CaptureSettings):Promise<agent.SettingsUpdate>;
+
+export function UploadRecording(arg1:string):Promise<number>;
+
+export function WriteProfile(arg1:string,arg2:number):Promise<string>;
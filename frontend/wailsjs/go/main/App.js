@@ -2,22 +2,234 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AcceptConsent() {
+  return window['go']['main']['App']['AcceptConsent']();
+}
+
+export function CaptureNow() {
+  return window['go']['main']['App']['CaptureNow']();
+}
+
+export function CollectDiagnostics() {
+  return window['go']['main']['App']['CollectDiagnostics']();
+}
+
+export function CopyAgentID() {
+  return window['go']['main']['App']['CopyAgentID']();
+}
+
+export function DisableAutoStart() {
+  return window['go']['main']['App']['DisableAutoStart']();
+}
+
+export function EnableAutoStart() {
+  return window['go']['main']['App']['EnableAutoStart']();
+}
+
+export function ExportConfig() {
+  return window['go']['main']['App']['ExportConfig']();
+}
+
+export function GetAutoStart() {
+  return window['go']['main']['App']['GetAutoStart']();
+}
+
+export function GetBandwidth() {
+  return window['go']['main']['App']['GetBandwidth']();
+}
+
+export function GetBlockedApps() {
+  return window['go']['main']['App']['GetBlockedApps']();
+}
+
+export function GetCaptureStats() {
+  return window['go']['main']['App']['GetCaptureStats']();
+}
+
+export function GetClipboardConsent() {
+  return window['go']['main']['App']['GetClipboardConsent']();
+}
+
+export function GetConfigProblems() {
+  return window['go']['main']['App']['GetConfigProblems']();
+}
+
+export function GetConnectionStatus() {
+  return window['go']['main']['App']['GetConnectionStatus']();
+}
+
+export function GetConsent() {
+  return window['go']['main']['App']['GetConsent']();
+}
+
+export function GetFrameQueueStats() {
+  return window['go']['main']['App']['GetFrameQueueStats']();
+}
+
+export function GetIdentity() {
+  return window['go']['main']['App']['GetIdentity']();
+}
+
+export function GetLocale() {
+  return window['go']['main']['App']['GetLocale']();
+}
+
+export function GetLogLevel() {
+  return window['go']['main']['App']['GetLogLevel']();
+}
+
+export function GetLoopbackStatus() {
+  return window['go']['main']['App']['GetLoopbackStatus']();
+}
+
+export function GetMessages() {
+  return window['go']['main']['App']['GetMessages']();
+}
+
+export function GetPauseRemaining() {
+  return window['go']['main']['App']['GetPauseRemaining']();
+}
+
+export function GetPprofAddr() {
+  return window['go']['main']['App']['GetPprofAddr']();
+}
+
+export function GetPreview() {
+  return window['go']['main']['App']['GetPreview']();
+}
+
+export function GetPrivacyMasks() {
+  return window['go']['main']['App']['GetPrivacyMasks']();
+}
+
+export function GetQualityStats() {
+  return window['go']['main']['App']['GetQualityStats']();
+}
+
+export function GetRecentEvents(arg1, arg2) {
+  return window['go']['main']['App']['GetRecentEvents'](arg1, arg2);
+}
+
+export function GetRecentLogs(arg1, arg2) {
+  return window['go']['main']['App']['GetRecentLogs'](arg1, arg2);
+}
+
+export function GetSettings() {
+  return window['go']['main']['App']['GetSettings']();
+}
+
+export function ImportConfig(arg1) {
+  return window['go']['main']['App']['ImportConfig'](arg1);
+}
+
 export function ListMonitors() {
   return window['go']['main']['App']['ListMonitors']();
 }
 
+export function ListWindows() {
+  return window['go']['main']['App']['ListWindows']();
+}
+
+export function PauseCapture(arg1) {
+  return window['go']['main']['App']['PauseCapture'](arg1);
+}
+
+export function RegenerateAgentID() {
+  return window['go']['main']['App']['RegenerateAgentID']();
+}
+
+export function ResumeCapture() {
+  return window['go']['main']['App']['ResumeCapture']();
+}
+
+export function RevokeConsent() {
+  return window['go']['main']['App']['RevokeConsent']();
+}
+
 export function SelectMonitor(arg1) {
   return window['go']['main']['App']['SelectMonitor'](arg1);
 }
 
+export function SelectWindow(arg1, arg2) {
+  return window['go']['main']['App']['SelectWindow'](arg1, arg2);
+}
+
+export function SetAllMonitorsMode() {
+  return window['go']['main']['App']['SetAllMonitorsMode']();
+}
+
+export function SetBlockedApps(arg1, arg2) {
+  return window['go']['main']['App']['SetBlockedApps'](arg1, arg2);
+}
+
+export function SetClipboardConsent(arg1) {
+  return window['go']['main']['App']['SetClipboardConsent'](arg1);
+}
+
+export function SetCombinedLayout(arg1) {
+  return window['go']['main']['App']['SetCombinedLayout'](arg1);
+}
+
 export function SetCombinedMode() {
   return window['go']['main']['App']['SetCombinedMode']();
 }
 
+export function SetDeviceName(arg1) {
+  return window['go']['main']['App']['SetDeviceName'](arg1);
+}
+
+export function SetEncoding(arg1, arg2) {
+  return window['go']['main']['App']['SetEncoding'](arg1, arg2);
+}
+
+export function SetLocale(arg1) {
+  return window['go']['main']['App']['SetLocale'](arg1);
+}
+
+export function SetLogLevel(arg1) {
+  return window['go']['main']['App']['SetLogLevel'](arg1);
+}
+
+export function SetPreviewEnabled(arg1) {
+  return window['go']['main']['App']['SetPreviewEnabled'](arg1);
+}
+
+export function SetPrivacyMasks(arg1, arg2) {
+  return window['go']['main']['App']['SetPrivacyMasks'](arg1, arg2);
+}
+
 export function StartCapture() {
   return window['go']['main']['App']['StartCapture']();
 }
 
+export function StartPprof() {
+  return window['go']['main']['App']['StartPprof']();
+}
+
+export function StartRecording(arg1) {
+  return window['go']['main']['App']['StartRecording'](arg1);
+}
+
 export function StopCapture() {
   return window['go']['main']['App']['StopCapture']();
 }
+
+export function StopPprof() {
+  return window['go']['main']['App']['StopPprof']();
+}
+
+export function StopRecording() {
+  return window['go']['main']['App']['StopRecording']();
+}
+
+export function UpdateSettings(arg1) {
+  return window['go']['main']['App']['UpdateSettings'](arg1);
+}
+
+export function UploadRecording(arg1) {
+  return window['go']['main']['App']['UploadRecording'](arg1);
+}
+
+export function WriteProfile(arg1, arg2) {
+  return window['go']['main']['App']['WriteProfile'](arg1, arg2);
+}
@@ -0,0 +1,548 @@
+export namespace agent {
+	
+	export class BandwidthUsage {
+	    stream: string;
+	    total: number;
+	    lastMinute: number;
+	    lastHour: number;
+	    lastDay: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BandwidthUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.stream = source["stream"];
+	        this.total = source["total"];
+	        this.lastMinute = source["lastMinute"];
+	        this.lastHour = source["lastHour"];
+	        this.lastDay = source["lastDay"];
+	    }
+	}
+	export class BandwidthStats {
+	    total: BandwidthUsage;
+	    streams: BandwidthUsage[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BandwidthStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.total = this.convertValues(source["total"], BandwidthUsage);
+	        this.streams = this.convertValues(source["streams"], BandwidthUsage);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class PrivacyMask {
+	    x: number;
+	    y: number;
+	    width: number;
+	    height: number;
+	    monitor: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PrivacyMask(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.x = source["x"];
+	        this.y = source["y"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	        this.monitor = source["monitor"];
+	    }
+	}
+	export class CaptureSettings {
+	    targetFps: number;
+	    intervalMs: number;
+	    encoding: string;
+	    jpegQuality: number;
+	    webpQuality: number;
+	    scale: number;
+	    maxWidth: number;
+	    maxHeight: number;
+	    monitorMode: string;
+	    monitorIndex: number;
+	    combinedLayout: string;
+	    windowTitle: string;
+	    windowProcess: string;
+	    privacyMasks: PrivacyMask[];
+	    maskMode: string;
+	    schedule: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CaptureSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.targetFps = source["targetFps"];
+	        this.intervalMs = source["intervalMs"];
+	        this.encoding = source["encoding"];
+	        this.jpegQuality = source["jpegQuality"];
+	        this.webpQuality = source["webpQuality"];
+	        this.scale = source["scale"];
+	        this.maxWidth = source["maxWidth"];
+	        this.maxHeight = source["maxHeight"];
+	        this.monitorMode = source["monitorMode"];
+	        this.monitorIndex = source["monitorIndex"];
+	        this.combinedLayout = source["combinedLayout"];
+	        this.windowTitle = source["windowTitle"];
+	        this.windowProcess = source["windowProcess"];
+	        this.privacyMasks = this.convertValues(source["privacyMasks"], PrivacyMask);
+	        this.maskMode = source["maskMode"];
+	        this.schedule = source["schedule"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CaptureStats {
+	    capturing: boolean;
+	    frames_captured: number;
+	    frames_encoded: number;
+	    frames_sent: number;
+	    frames_dropped: number;
+	    frames_limited: number;
+	    frames_over_cap: number;
+	    frames_chunked: number;
+	    avg_encode_ms: number;
+	    avg_frame_bytes: number;
+	    effective_fps: number;
+	    last_frame_at: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CaptureStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.capturing = source["capturing"];
+	        this.frames_captured = source["frames_captured"];
+	        this.frames_encoded = source["frames_encoded"];
+	        this.frames_sent = source["frames_sent"];
+	        this.frames_dropped = source["frames_dropped"];
+	        this.frames_limited = source["frames_limited"];
+	        this.frames_over_cap = source["frames_over_cap"];
+	        this.frames_chunked = source["frames_chunked"];
+	        this.avg_encode_ms = source["avg_encode_ms"];
+	        this.avg_frame_bytes = source["avg_frame_bytes"];
+	        this.effective_fps = source["effective_fps"];
+	        this.last_frame_at = source["last_frame_at"];
+	    }
+	}
+	export class ConnectionStatus {
+	    state: string;
+	    error: string;
+	    since: number;
+	    server: string;
+	    spooling: boolean;
+	    reconnects: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConnectionStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.state = source["state"];
+	        this.error = source["error"];
+	        this.since = source["since"];
+	        this.server = source["server"];
+	        this.spooling = source["spooling"];
+	        this.reconnects = source["reconnects"];
+	    }
+	}
+	export class ConsentStatus {
+	    required: boolean;
+	    granted: boolean;
+	    user: string;
+	    acceptedAt: number;
+	    policyVersion: string;
+	    currentPolicy: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConsentStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.required = source["required"];
+	        this.granted = source["granted"];
+	        this.user = source["user"];
+	        this.acceptedAt = source["acceptedAt"];
+	        this.policyVersion = source["policyVersion"];
+	        this.currentPolicy = source["currentPolicy"];
+	    }
+	}
+	export class EventRecord {
+	    seq: number;
+	    time: string;
+	    type: string;
+	    detail: string;
+	    category: string;
+	    severity: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new EventRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.seq = source["seq"];
+	        this.time = source["time"];
+	        this.type = source["type"];
+	        this.detail = source["detail"];
+	        this.category = source["category"];
+	        this.severity = source["severity"];
+	    }
+	}
+	export class FrameQueueStats {
+	    capacity: number;
+	    depth: number;
+	    max_depth: number;
+	    enqueued: number;
+	    sent: number;
+	    dropped: number;
+	    avg_latency_ms: number;
+	    max_latency_ms: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FrameQueueStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.capacity = source["capacity"];
+	        this.depth = source["depth"];
+	        this.max_depth = source["max_depth"];
+	        this.enqueued = source["enqueued"];
+	        this.sent = source["sent"];
+	        this.dropped = source["dropped"];
+	        this.avg_latency_ms = source["avg_latency_ms"];
+	        this.max_latency_ms = source["max_latency_ms"];
+	    }
+	}
+	export class Identity {
+	    agentId: string;
+	    deviceName: string;
+	    source: string;
+	    pendingId: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Identity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.agentId = source["agentId"];
+	        this.deviceName = source["deviceName"];
+	        this.source = source["source"];
+	        this.pendingId = source["pendingId"];
+	    }
+	}
+	export class MonitorInfo {
+	    index: number;
+	    name: string;
+	    width: number;
+	    height: number;
+	    x: number;
+	    y: number;
+	    primary: boolean;
+	    thumbnail: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MonitorInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.index = source["index"];
+	        this.name = source["name"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	        this.x = source["x"];
+	        this.y = source["y"];
+	        this.primary = source["primary"];
+	        this.thumbnail = source["thumbnail"];
+	    }
+	}
+	
+	export class QualityStats {
+	    enabled: boolean;
+	    level: number;
+	    max_level: number;
+	    quality_pct: number;
+	    scale_pct: number;
+	    jpeg_quality: number;
+	    webp_quality: number;
+	    cap_kbps: number;
+	    measured_kbps: number;
+	    avg_send_ms: number;
+	    send_errors: number;
+	    level_changes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new QualityStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.level = source["level"];
+	        this.max_level = source["max_level"];
+	        this.quality_pct = source["quality_pct"];
+	        this.scale_pct = source["scale_pct"];
+	        this.jpeg_quality = source["jpeg_quality"];
+	        this.webp_quality = source["webp_quality"];
+	        this.cap_kbps = source["cap_kbps"];
+	        this.measured_kbps = source["measured_kbps"];
+	        this.avg_send_ms = source["avg_send_ms"];
+	        this.send_errors = source["send_errors"];
+	        this.level_changes = source["level_changes"];
+	    }
+	}
+	export class Screenshot {
+	    data: number[];
+	    encoding: string;
+	    width: number;
+	    height: number;
+	    timestamp: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Screenshot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.data = source["data"];
+	        this.encoding = source["encoding"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	        this.timestamp = source["timestamp"];
+	    }
+	}
+	export class SettingsUpdate {
+	    applied: string[];
+	    deferred: string[];
+	    problems: config.Problem[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SettingsUpdate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.applied = source["applied"];
+	        this.deferred = source["deferred"];
+	        this.problems = this.convertValues(source["problems"], config.Problem);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class UIPreview {
+	    dataUrl: string;
+	    width: number;
+	    height: number;
+	    monitorId: number;
+	    timestamp: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new UIPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dataUrl = source["dataUrl"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	        this.monitorId = source["monitorId"];
+	        this.timestamp = source["timestamp"];
+	    }
+	}
+	export class WindowInfo {
+	    id: number;
+	    title: string;
+	    process: string;
+	    pid: number;
+	    x: number;
+	    y: number;
+	    width: number;
+	    height: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WindowInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.title = source["title"];
+	        this.process = source["process"];
+	        this.pid = source["pid"];
+	        this.x = source["x"];
+	        this.y = source["y"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	    }
+	}
+
+}
+
+export namespace config {
+	
+	export class Problem {
+	    key: string;
+	    value: string;
+	    reason: string;
+	    applied: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Problem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.value = source["value"];
+	        this.reason = source["reason"];
+	        this.applied = source["applied"];
+	    }
+	}
+
+}
+
+export namespace logging {
+	
+	export class Entry {
+	    seq: number;
+	    time: string;
+	    level: string;
+	    message: string;
+	    line: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Entry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.seq = source["seq"];
+	        this.time = source["time"];
+	        this.level = source["level"];
+	        this.message = source["message"];
+	        this.line = source["line"];
+	    }
+	}
+
+}
+
+export namespace loopback {
+	
+	export class Status {
+	    addr: string;
+	    agentVersion: string;
+	    frames: number;
+	    frameBytes: number;
+	    unchangedFrames: number;
+	    previewFrames: number;
+	    chunkedFrames: number;
+	    lostChunks: number;
+	    monitors: number[];
+	    events: number;
+	    duplicateEvents: number;
+	    diagnostics: number;
+	    recordings: number;
+	    lastFrameAt: number;
+	    lastEncoding: string;
+	    lastFramePath: string;
+	    videoChunks: number;
+	    videoBytes: number;
+	    lastVideoPath: string;
+	    recentEvents: string[];
+	    outputDirectory: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Status(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.addr = source["addr"];
+	        this.agentVersion = source["agentVersion"];
+	        this.frames = source["frames"];
+	        this.frameBytes = source["frameBytes"];
+	        this.unchangedFrames = source["unchangedFrames"];
+	        this.previewFrames = source["previewFrames"];
+	        this.chunkedFrames = source["chunkedFrames"];
+	        this.lostChunks = source["lostChunks"];
+	        this.monitors = source["monitors"];
+	        this.events = source["events"];
+	        this.duplicateEvents = source["duplicateEvents"];
+	        this.diagnostics = source["diagnostics"];
+	        this.recordings = source["recordings"];
+	        this.lastFrameAt = source["lastFrameAt"];
+	        this.lastEncoding = source["lastEncoding"];
+	        this.lastFramePath = source["lastFramePath"];
+	        this.videoChunks = source["videoChunks"];
+	        this.videoBytes = source["videoBytes"];
+	        this.lastVideoPath = source["lastVideoPath"];
+	        this.recentEvents = source["recentEvents"];
+	        this.outputDirectory = source["outputDirectory"];
+	    }
+	}
+
+}
+
@@ -0,0 +1,63 @@
+package agent
+
+import (
+	"image"
+	"time"
+
+	"agent/internal/config"
+)
+
+// activityScheduler 구조체는 화면 움직임에 따라 캡처 간격을 고릅니다.
+// 연속 idleAfter 프레임 동안 휘도 격자가 한 셀도 바뀌지 않으면 유휴 간격으로 낮추고,
+// 한 셀이라도 바뀌면 즉시 목표 간격으로 돌아갑니다 (커서 이동 같은 작은 변화도 움직임으로 취급).
+type activityScheduler struct { // 단일 책임: 활동 기반 캡처 간격 결정
+	idleInterval time.Duration // 유휴 상태 캡처 간격
+	idleAfter    int           // 유휴 전환 기준 연속 무변화 프레임 수
+	prev         []uint8       // 직전 프레임 시그니처
+	cur          []uint8       // 현재 프레임 시그니처 (재사용 버퍼)
+	prevBounds   image.Rectangle
+	still        int  // 연속 무변화 프레임 수
+	idle         bool // 유휴 간격 적용 중
+}
+
+// newActivityScheduler 함수는 activityScheduler 생성자입니다. AdaptiveFPS 가 꺼져 있으면 nil 을 반환합니다.
+func newActivityScheduler(cfg *config.Config) *activityScheduler { // 단일 책임: 인스턴스 생성
+	if !cfg.AdaptiveFPS {
+		return nil
+	}
+	return &activityScheduler{
+		idleInterval: time.Second / time.Duration(cfg.IdleFPS),
+		idleAfter:    cfg.IdleAfterFrames,
+		cur:          make([]uint8, CHANGE_GRID_W*CHANGE_GRID_H),
+	}
+}
+
+// observe 함수는 프레임을 직전 프레임과 비교해 활동 상태를 갱신하고, 유휴/활동 상태가 바뀌었으면 true 를 반환합니다.
+func (s *activityScheduler) observe(img image.Image) bool { // 단일 책임: 활동 상태 갱신
+	if s == nil {
+		return false
+	}
+	lumaSignature(img, s.cur)
+	moved := s.prev == nil || img.Bounds() != s.prevBounds || changedCells(s.prev, s.cur) > 0
+	if s.prev == nil {
+		s.prev = make([]uint8, len(s.cur))
+	}
+	s.prev, s.cur = s.cur, s.prev
+	s.prevBounds = img.Bounds()
+	wasIdle := s.idle
+	if moved {
+		s.still = 0
+		s.idle = false
+	} else if s.still++; s.still >= s.idleAfter {
+		s.idle = true
+	}
+	return s.idle != wasIdle
+}
+
+// interval 함수는 현재 활동 상태에 맞는 캡처 간격을 반환합니다 (유휴 간격이 목표 간격보다 짧으면 목표 간격 유지).
+func (s *activityScheduler) interval(active time.Duration) time.Duration { // 단일 책임: 캡처 간격 선택
+	if s == nil || !s.idle || s.idleInterval <= active {
+		return active
+	}
+	return s.idleInterval
+}
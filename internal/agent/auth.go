@@ -0,0 +1,152 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"agent/internal/config"
+	"agent/internal/secrets"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	AUTH_METADATA_KEY         = "authorization" // 토큰을 싣는 gRPC 메타데이터 키
+	AUTH_BEARER_PREFIX        = "Bearer "       // authorization 값 접두사
+	AUTH_REFRESH_MIN_INTERVAL = 5 * time.Second // 연속 거부 시 토큰 재획득 최소 간격
+)
+
+// TokenRefresher 타입은 서버가 UNAUTHENTICATED 로 거부했을 때 새 토큰을 받아오는 함수입니다.
+type TokenRefresher func(ctx context.Context) (string, error)
+
+// tokenAuth 구조체는 API 키/JWT 를 gRPC 호출 메타데이터로 첨부하는 PerRPCCredentials 구현입니다.
+// 토큰 출처는 설정값, 토큰 파일, 지정한 OS 키체인 항목, 비밀 저장소(auth_token) 순이며 거부 시 등록된 refresher 또는 출처를 다시 읽습니다.
+type tokenAuth struct { // 단일 책임: 토큰 보관/첨부/재획득
+	mu          sync.Mutex
+	token       string
+	load        func() (string, error) // 설정된 출처에서 토큰 읽기 (nil 이면 인증 미사용)
+	refresher   TokenRefresher         // 외부 재획득 훅 (nil 이면 load 재실행)
+	lastRefresh time.Time
+	logger      *zap.SugaredLogger
+}
+
+// newTokenAuth 함수는 설정에서 토큰 출처를 결정하고 최초 토큰을 읽습니다. 출처가 없으면 비활성 상태입니다.
+func newTokenAuth(cfg *config.Config, logger *zap.SugaredLogger) *tokenAuth { // 단일 책임: 인스턴스 생성
+	t := &tokenAuth{logger: logger}
+	switch {
+	case cfg.AuthToken != "":
+		tok := cfg.AuthToken
+		t.load = func() (string, error) { return tok, nil }
+	case cfg.AuthTokenFile != "":
+		path := cfg.AuthTokenFile
+		t.load = func() (string, error) { // 파일은 재획득 시마다 다시 읽어 외부 갱신을 반영
+			b, err := os.ReadFile(path)
+			return strings.TrimSpace(string(b)), err
+		}
+	case cfg.AuthKeychainService != "":
+		service, user := cfg.AuthKeychainService, cfg.AuthKeychainUser
+		t.load = func() (string, error) { return secrets.Lookup(service, user) }
+	case cfg.SecretStore:
+		if _, err := secrets.Get(secrets.AUTH_TOKEN); err == nil { // 저장된 토큰이 있을 때만 인증 사용
+			t.load = func() (string, error) { return secrets.Get(secrets.AUTH_TOKEN) }
+		}
+	}
+	if t.load != nil {
+		tok, err := t.load()
+		if err != nil {
+			logger.Warnf("인증 토큰 읽기 실패: %v", err)
+		}
+		t.token = tok
+	}
+	return t
+}
+
+// enabled 함수는 토큰 인증이 설정되었는지 반환합니다.
+func (t *tokenAuth) enabled() bool { // 단일 책임: 활성 여부
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.load != nil || t.refresher != nil
+}
+
+// GetRequestMetadata 함수는 각 RPC(스트림 오픈 포함)에 현재 토큰을 authorization 메타데이터로 첨부합니다.
+func (t *tokenAuth) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) { // 단일 책임: 메타데이터 구성
+	t.mu.Lock()
+	tok := t.token
+	t.mu.Unlock()
+	if tok == "" {
+		return nil, nil
+	}
+	return map[string]string{AUTH_METADATA_KEY: AUTH_BEARER_PREFIX + tok}, nil
+}
+
+// RequireTransportSecurity 함수는 평문 연결(개발/루프백)에서도 토큰 첨부를 허용합니다.
+func (t *tokenAuth) RequireTransportSecurity() bool { // 단일 책임: 전송 보안 요구 여부
+	return false
+}
+
+// refresh 함수는 새 토큰을 받아옵니다. 최소 간격 이내 재요청은 무시해 거부 폭주 시 재획득 폭주를 막습니다.
+func (t *tokenAuth) refresh(ctx context.Context) error { // 단일 책임: 토큰 재획득
+	t.mu.Lock()
+	if time.Since(t.lastRefresh) < AUTH_REFRESH_MIN_INTERVAL {
+		t.mu.Unlock()
+		return nil
+	}
+	t.lastRefresh = time.Now()
+	refresher, load := t.refresher, t.load
+	t.mu.Unlock()
+	var tok string
+	var err error
+	switch {
+	case refresher != nil:
+		tok, err = refresher(ctx)
+	case load != nil:
+		tok, err = load()
+	default:
+		return fmt.Errorf("토큰 출처 없음")
+	}
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	t.token = tok
+	t.mu.Unlock()
+	t.logger.Info("인증 토큰 재획득 완료")
+	return nil
+}
+
+// SetTokenRefresher 함수는 UNAUTHENTICATED 응답 시 호출할 토큰 재획득 훅을 등록합니다 (연결 전에 등록).
+func (a *Agent) SetTokenRefresher(fn TokenRefresher) { // 단일 책임: 재획득 훅 등록
+	a.auth.mu.Lock()
+	a.auth.refresher = fn
+	a.auth.mu.Unlock()
+}
+
+// streamSendError 함수는 클라이언트 스트림 Send 실패의 실제 원인을 반환합니다.
+// 서버가 스트림을 끊으면 Send 는 io.EOF 만 돌려주므로 CloseAndRecv 로 상태 코드를 회수합니다.
+func streamSendError(err error, closeAndRecv func() error) error { // 단일 책임: 전송 실패 원인 회수
+	if !errors.Is(err, io.EOF) {
+		return err
+	}
+	if recvErr := closeAndRecv(); recvErr != nil {
+		return recvErr
+	}
+	return err
+}
+
+// refreshAuthIfRejected 함수는 오류가 UNAUTHENTICATED 이면 토큰을 재획득합니다 (이후 스트림 재오픈 시 새 토큰 사용).
+func (a *Agent) refreshAuthIfRejected(err error) { // 단일 책임: 인증 거부 처리
+	if status.Code(err) != codes.Unauthenticated || !a.auth.enabled() {
+		return
+	}
+	if rerr := a.auth.refresh(a.ctx); rerr != nil {
+		a.logger.Warnf("인증 토큰 재획득 실패: %v", rerr)
+	}
+}
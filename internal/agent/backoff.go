@@ -0,0 +1,60 @@
+package agent
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"agent/internal/config"
+)
+
+// backoff 구조체는 지수 증가 + 지터 재시도 간격 계산기입니다. 연결/스트림 재시도에 공통으로 사용합니다.
+type backoff struct { // 단일 책임: 재시도 간격 계산
+	base      time.Duration // 첫 재시도 간격
+	max       time.Duration // 간격 상한
+	jitterPct int           // 간격을 ±jitterPct% 범위에서 무작위화 (동시 재접속 분산)
+	attempt   int
+}
+
+// newBackoff 함수는 설정값으로 backoff 를 생성합니다.
+func newBackoff(cfg *config.Config) *backoff { // 단일 책임: 인스턴스 생성
+	return &backoff{
+		base:      time.Duration(cfg.BackoffBaseMs) * time.Millisecond,
+		max:       time.Duration(cfg.BackoffMaxMs) * time.Millisecond,
+		jitterPct: cfg.BackoffJitterPct,
+	}
+}
+
+// next 함수는 다음 대기 시간을 반환하고 시도 횟수를 늘립니다.
+func (b *backoff) next() time.Duration { // 단일 책임: 간격 계산
+	d := b.base
+	for i := 0; i < b.attempt && d < b.max; i++ {
+		d *= 2
+	}
+	if d > b.max {
+		d = b.max
+	}
+	b.attempt++
+	if b.jitterPct > 0 && d > 0 {
+		span := int64(d) * int64(b.jitterPct) / 100
+		d += time.Duration(rand.Int63n(2*span+1) - span)
+	}
+	return d
+}
+
+// reset 함수는 성공 후 간격을 처음 값으로 되돌립니다.
+func (b *backoff) reset() { // 단일 책임: 상태 초기화
+	b.attempt = 0
+}
+
+// wait 함수는 다음 간격만큼 대기합니다. 컨텍스트가 끝나면 즉시 오류를 반환합니다.
+func (b *backoff) wait(ctx context.Context) error { // 단일 책임: 재시도 대기
+	t := time.NewTimer(b.next())
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
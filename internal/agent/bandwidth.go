@@ -0,0 +1,183 @@
+package agent
+
+import (
+	"context"
+	"path"
+	"sync"
+	"time"
+
+	monitorProto "agent/proto"
+
+	grpcStats "google.golang.org/grpc/stats"
+)
+
+const (
+	BANDWIDTH_STREAM_FRAMES    = "frames"    // StreamFrames (화면 프레임)
+	BANDWIDTH_STREAM_VIDEO     = "video"     // StreamVideo (h264 조각)
+	BANDWIDTH_STREAM_EVENTS    = "events"    // StreamEvents, StreamAckedEvents
+	BANDWIDTH_STREAM_RECORDING = "recording" // UploadRecording
+	BANDWIDTH_STREAM_CONTROL   = "control"   // Control 명령 응답
+	BANDWIDTH_STREAM_OTHER     = "other"     // 등록, Heartbeat, 설정 조회, 진단 업로드
+	BANDWIDTH_BUCKETS          = 24 * 60     // 분 단위 버킷 수 (최근 24시간)
+)
+
+// bandwidthStreams 변수는 RPC 메서드 이름별 전송량 묶음입니다 (없으면 other).
+var bandwidthStreams = map[string]string{
+	"StreamFrames":      BANDWIDTH_STREAM_FRAMES,
+	"StreamVideo":       BANDWIDTH_STREAM_VIDEO,
+	"StreamEvents":      BANDWIDTH_STREAM_EVENTS,
+	"StreamAckedEvents": BANDWIDTH_STREAM_EVENTS,
+	"UploadRecording":   BANDWIDTH_STREAM_RECORDING,
+	"Control":           BANDWIDTH_STREAM_CONTROL,
+}
+
+// bandwidthOrder 변수는 BandwidthStats.Streams 순서입니다.
+var bandwidthOrder = []string{BANDWIDTH_STREAM_FRAMES, BANDWIDTH_STREAM_VIDEO, BANDWIDTH_STREAM_EVENTS, BANDWIDTH_STREAM_RECORDING, BANDWIDTH_STREAM_CONTROL, BANDWIDTH_STREAM_OTHER}
+
+// BandwidthUsage 구조체는 한 묶음의 송신 바이트입니다 (gRPC 압축/프레이밍 포함 실제 전송 크기).
+type BandwidthUsage struct {
+	Stream     string `json:"stream"`     // 묶음 이름 (BANDWIDTH_STREAM_*, 합계는 "total")
+	Total      uint64 `json:"total"`      // 시작 이후 누적
+	LastMinute uint64 `json:"lastMinute"` // 최근 1분 (직전 분 버킷을 지난 비율만큼 빼 근사)
+	LastHour   uint64 `json:"lastHour"`   // 최근 60분
+	LastDay    uint64 `json:"lastDay"`    // 최근 24시간 (재시작하면 처음부터)
+}
+
+// BandwidthStats 구조체는 묶음별 송신량과 합계입니다.
+type BandwidthStats struct {
+	Total   BandwidthUsage   `json:"total"`
+	Streams []BandwidthUsage `json:"streams"`
+}
+
+// bandwidthSeries 구조체는 한 묶음의 분 단위 송신 바이트 링 버퍼입니다.
+type bandwidthSeries struct {
+	total   uint64
+	bytes   [BANDWIDTH_BUCKETS]uint64
+	minutes [BANDWIDTH_BUCKETS]int64 // 버킷이 담은 Unix 분 (다르면 지난 값)
+}
+
+// add 함수는 minute 분 버킷에 n 바이트를 더합니다.
+func (s *bandwidthSeries) add(minute int64, n uint64) { // 단일 책임: 버킷 누적
+	i := minute % BANDWIDTH_BUCKETS
+	if s.minutes[i] != minute {
+		s.minutes[i], s.bytes[i] = minute, 0
+	}
+	s.bytes[i] += n
+	s.total += n
+}
+
+// sum 함수는 minute 분부터 거슬러 span 개 버킷의 합을 반환합니다.
+func (s *bandwidthSeries) sum(minute int64, span int) uint64 { // 단일 책임: 구간 합계
+	var n uint64
+	for m := minute - int64(span) + 1; m <= minute; m++ {
+		if i := m % BANDWIDTH_BUCKETS; s.minutes[i] == m {
+			n += s.bytes[i]
+		}
+	}
+	return n
+}
+
+// usage 함수는 now 기준 구간별 송신량을 계산합니다.
+func (s *bandwidthSeries) usage(name string, now time.Time) BandwidthUsage { // 단일 책임: 구간별 송신량 계산
+	minute := now.Unix() / 60
+	elapsed := uint64(now.Unix() % 60)
+	u := BandwidthUsage{Stream: name, Total: s.total, LastHour: s.sum(minute, 60), LastDay: s.sum(minute, BANDWIDTH_BUCKETS)}
+	u.LastMinute = s.sum(minute, 1) + s.sum(minute-1, 1)*(60-elapsed)/60
+	return u
+}
+
+// bandwidthMeter 구조체는 gRPC 연결에 붙여 RPC 별 송신 바이트를 세는 stats.Handler 입니다.
+type bandwidthMeter struct { // 단일 책임: 송신량 집계
+	mu     sync.Mutex
+	series map[string]*bandwidthSeries
+}
+
+// bandwidthStreamKey 구조체는 TagRPC 가 컨텍스트에 넣는 묶음 이름 키입니다.
+type bandwidthStreamKey struct{}
+
+// newBandwidthMeter 함수는 bandwidthMeter 생성자입니다.
+func newBandwidthMeter() *bandwidthMeter { // 단일 책임: 인스턴스 생성
+	m := &bandwidthMeter{series: make(map[string]*bandwidthSeries, len(bandwidthOrder))}
+	for _, name := range bandwidthOrder {
+		m.series[name] = &bandwidthSeries{}
+	}
+	return m
+}
+
+// TagRPC 메서드는 RPC 메서드 이름으로 묶음을 정해 컨텍스트에 붙입니다.
+func (m *bandwidthMeter) TagRPC(ctx context.Context, info *grpcStats.RPCTagInfo) context.Context { // 단일 책임: RPC 묶음 지정
+	name, ok := bandwidthStreams[path.Base(info.FullMethodName)]
+	if !ok {
+		name = BANDWIDTH_STREAM_OTHER
+	}
+	return context.WithValue(ctx, bandwidthStreamKey{}, name)
+}
+
+// HandleRPC 메서드는 보낸 메시지의 전송 크기를 묶음에 더합니다.
+func (m *bandwidthMeter) HandleRPC(ctx context.Context, s grpcStats.RPCStats) { // 단일 책임: 송신 크기 누적
+	out, ok := s.(*grpcStats.OutPayload)
+	if !ok {
+		return
+	}
+	name, _ := ctx.Value(bandwidthStreamKey{}).(string)
+	m.add(name, uint64(out.WireLength), time.Now())
+}
+
+// TagConn 메서드는 연결 단위 정보가 필요 없어 그대로 반환합니다.
+func (m *bandwidthMeter) TagConn(ctx context.Context, _ *grpcStats.ConnTagInfo) context.Context { // 단일 책임: 연결 태그 (무동작)
+	return ctx
+}
+
+// HandleConn 메서드는 연결 단위 통계를 쓰지 않습니다.
+func (m *bandwidthMeter) HandleConn(context.Context, grpcStats.ConnStats) {} // 단일 책임: 연결 통계 (무동작)
+
+// add 함수는 name 묶음에 n 바이트를 더합니다 (알 수 없는 묶음은 other).
+func (m *bandwidthMeter) add(name string, n uint64, now time.Time) { // 단일 책임: 묶음 송신량 누적
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.series[name]
+	if !ok {
+		s = m.series[BANDWIDTH_STREAM_OTHER]
+	}
+	s.add(now.Unix()/60, n)
+}
+
+// snapshot 함수는 묶음별 구간 송신량과 합계를 반환합니다.
+func (m *bandwidthMeter) snapshot(now time.Time) BandwidthStats { // 단일 책임: 송신량 스냅샷
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := BandwidthStats{Total: BandwidthUsage{Stream: "total"}, Streams: make([]BandwidthUsage, 0, len(bandwidthOrder))}
+	for _, name := range bandwidthOrder {
+		u := m.series[name].usage(name, now)
+		res.Total.Total += u.Total
+		res.Total.LastMinute += u.LastMinute
+		res.Total.LastHour += u.LastHour
+		res.Total.LastDay += u.LastDay
+		res.Streams = append(res.Streams, u)
+	}
+	return res
+}
+
+// Bandwidth 메서드는 서버로 보낸 바이트를 묶음별 최근 1분/1시간/24시간과 누적으로 반환합니다.
+func (a *Agent) Bandwidth() BandwidthStats { // 단일 책임: 송신량 조회
+	return a.bandwidth.snapshot(time.Now())
+}
+
+// bandwidthReport 함수는 Heartbeat 에 넣을 묶음별 송신량을 만듭니다 (보낸 적 없는 묶음은 생략).
+func (a *Agent) bandwidthReport() []*monitorProto.BandwidthUsage { // 단일 책임: 보고용 송신량 생성
+	st := a.Bandwidth()
+	res := make([]*monitorProto.BandwidthUsage, 0, len(st.Streams))
+	for _, u := range st.Streams {
+		if u.Total == 0 {
+			continue
+		}
+		res = append(res, &monitorProto.BandwidthUsage{
+			Stream:          u.Stream,
+			TotalBytes:      u.Total,
+			LastMinuteBytes: u.LastMinute,
+			LastHourBytes:   u.LastHour,
+			LastDayBytes:    u.LastDay,
+		})
+	}
+	return res
+}
@@ -0,0 +1,201 @@
+package agent
+
+import (
+	"fmt"
+	"image"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	BENCH_DEFAULT_WIDTH  = 1920 // 벤치마크 기본 해상도 폭
+	BENCH_DEFAULT_HEIGHT = 1080 // 벤치마크 기본 해상도 높이
+	BENCH_DEFAULT_FRAMES = 300  // 벤치마크 기본 프레임 수
+	BENCH_DEFAULT_CHANGE = 10   // 프레임당 변경 면적 기본값(%)
+	BENCH_RANDOM_SEED    = 1    // 재현 가능한 합성 프레임용 시드
+)
+
+// BenchOptions 구조체는 벤치마크 실행 조건입니다.
+type BenchOptions struct {
+	Width        int    // 합성 프레임 폭
+	Height       int    // 합성 프레임 높이
+	Frames       int    // 처리할 프레임 수
+	ChangePct    int    // 프레임당 변경 면적 비율(%)
+	Encoding     string // png | jpeg | delta | tiles | webp
+	Quality      int    // jpeg/webp 품질
+	Encoder      string // jpeg 인코더 (auto | stdlib | turbo)
+	Keyframe     int    // delta/tiles 키프레임 주기(프레임 수)
+	Lossless     bool   // webp 무손실 압축
+	TileEncoding string // tiles 변경 영역 이미지 형식 (png | jpeg | webp)
+	Grayscale    bool   // png/jpeg 회색조 변환
+	PngLevel     string // png 압축 수준 (default | speed | best | none)
+	Workers      int    // 인코딩 워커 수 (0=자동)
+}
+
+// BenchResult 구조체는 벤치마크 결과입니다.
+type BenchResult struct {
+	Frames         int           // 처리 완료 프레임 수
+	Elapsed        time.Duration // 전체 소요 시간
+	FPS            float64       // 달성 가능 처리량 (프레임/초)
+	EncodeP50      time.Duration // 인코딩 지연 중앙값
+	EncodeP90      time.Duration // 인코딩 지연 90 백분위
+	EncodeP99      time.Duration // 인코딩 지연 99 백분위
+	EncodeMax      time.Duration // 인코딩 지연 최댓값
+	AvgFrameBytes  int           // 평균 인코딩 프레임 크기
+	AllocsPerFrame uint64        // 프레임당 힙 할당 횟수
+	BytesPerFrame  uint64        // 프레임당 힙 할당 바이트
+	NumGC          uint32        // 실행 중 GC 횟수
+}
+
+// String 함수는 사람이 읽기 좋은 결과 요약을 반환합니다.
+func (r BenchResult) String() string { // 단일 책임: 결과 포맷
+	return fmt.Sprintf("frames=%d elapsed=%s fps=%.1f encode(p50=%s p90=%s p99=%s max=%s) avg_bytes=%d allocs/frame=%d alloc_bytes/frame=%d gc=%d",
+		r.Frames, r.Elapsed.Round(time.Millisecond), r.FPS, r.EncodeP50, r.EncodeP90, r.EncodeP99, r.EncodeMax,
+		r.AvgFrameBytes, r.AllocsPerFrame, r.BytesPerFrame, r.NumGC)
+}
+
+// benchCapturer 구조체는 지정 비율만큼 영역이 바뀌는 합성 프레임을 생성합니다.
+type benchCapturer struct { // 단일 책임: 합성 프레임 생성
+	base      *image.RGBA
+	changePct int
+	rnd       *rand.Rand
+	pool      rgbaPool
+	offsetY   int // 변경 띠의 현재 시작 행
+}
+
+// newBenchCapturer 함수는 노이즈 배경을 가진 benchCapturer 를 생성합니다.
+func newBenchCapturer(w, h, changePct int) *benchCapturer { // 단일 책임: 인스턴스 생성
+	rnd := rand.New(rand.NewSource(BENCH_RANDOM_SEED))
+	base := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ { // 화면 콘텐츠 유사: 행 단위 완만한 그라디언트 + 약한 노이즈
+		for x := 0; x < w; x++ {
+			off := base.PixOffset(x, y)
+			n := uint8(rnd.Intn(16))
+			base.Pix[off+0] = uint8(x*255/w) + n
+			base.Pix[off+1] = uint8(y*255/h) + n
+			base.Pix[off+2] = 128 + n
+			base.Pix[off+3] = 255
+		}
+	}
+	return &benchCapturer{base: base, changePct: changePct, rnd: rnd}
+}
+
+// grab 함수는 배경을 복사하고 changePct 면적의 가로 띠를 무작위 값으로 바꾼 프레임을 반환합니다.
+func (b *benchCapturer) grab() (image.Image, error) { // 단일 책임: 합성 프레임 반환
+	bounds := b.base.Bounds()
+	img := b.pool.get(bounds.Dx(), bounds.Dy())
+	copy(img.Pix, b.base.Pix)
+	rows := bounds.Dy() * b.changePct / 100
+	for y := 0; y < rows; y++ {
+		row := (b.offsetY + y) % bounds.Dy()
+		start := img.PixOffset(0, row)
+		b.rnd.Read(img.Pix[start : start+bounds.Dx()*4])
+	}
+	b.offsetY = (b.offsetY + rows) % bounds.Dy()
+	return img, nil
+}
+
+// release 함수는 프레임 버퍼를 재사용 풀에 반환합니다.
+func (b *benchCapturer) release(img image.Image) { // 단일 책임: 버퍼 반환
+	if rgba, ok := img.(*image.RGBA); ok {
+		b.pool.put(rgba)
+	}
+}
+
+// RunBenchmark 함수는 서버 없이 합성 프레임으로 캡처→인코딩 파이프라인을 최대 속도로 실행하고 결과를 반환합니다.
+func RunBenchmark(opts BenchOptions) (BenchResult, error) { // 단일 책임: 파이프라인 벤치마크
+	if opts.Width <= 0 || opts.Height <= 0 || opts.Frames <= 0 {
+		return BenchResult{}, fmt.Errorf("잘못된 벤치마크 옵션: %dx%d frames=%d", opts.Width, opts.Height, opts.Frames)
+	}
+	if opts.ChangePct < 0 || opts.ChangePct > 100 {
+		return BenchResult{}, fmt.Errorf("변경 비율 범위 오류: %d", opts.ChangePct)
+	}
+	encOpts := encodeOptions{encoding: opts.Encoding, jpegQuality: opts.Quality, jpegEncoder: opts.Encoder, webpQuality: opts.Quality, webpLossless: opts.Lossless, tileEncoding: opts.TileEncoding, grayscale: opts.Grayscale, pngLevel: int(pngCompressionLevel(opts.PngLevel))}
+	capt := newBenchCapturer(opts.Width, opts.Height, opts.ChangePct)
+	delta := newDeltaEncoder(opts.Keyframe)
+	tiles := newTileEncoder(opts.Keyframe)
+
+	var mu sync.Mutex
+	latencies := make([]time.Duration, 0, opts.Frames)
+	totalBytes, done := 0, 0
+	pool := newEncodePool(resolveEncodeWorkers(opts.Workers), func(data []byte, meta frameMeta, err error) {
+		if err != nil {
+			return
+		}
+		for _, region := range meta.regions { // tiles: 영역 이미지 합계
+			data = append(data, region.Data...)
+		}
+		mu.Lock()
+		totalBytes += len(data)
+		done++
+		mu.Unlock()
+	})
+	timed := func(enc encodeFunc) encodeFunc { // 인코딩 지연 측정 래퍼
+		return func(img image.Image) ([]byte, error) {
+			start := time.Now()
+			b, err := enc(img)
+			mu.Lock()
+			latencies = append(latencies, time.Since(start))
+			mu.Unlock()
+			return b, err
+		}
+	}
+
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for i := 0; i < opts.Frames; i++ {
+		img, _ := capt.grab()
+		if encOpts.encoding == ENCODING_DELTA {
+			payload, _ := delta.prepare(img)
+			pool.submit(img, timed(func(image.Image) ([]byte, error) { return compressDelta(payload), nil }), capt.release, frameMeta{})
+			continue
+		}
+		if encOpts.encoding == ENCODING_TILES {
+			src := toRGBA(img)
+			rects, _ := tiles.prepare(src)
+			regions := frameRegions(rects, src.Bounds())
+			pool.submit(img, timed(func(image.Image) ([]byte, error) { return nil, encodeRegions(src, regions, encOpts.regionOptions()) }), capt.release, frameMeta{regions: regions})
+			continue
+		}
+		pool.submit(img, timed(encOpts.encode), capt.release, frameMeta{})
+	}
+	pool.close()
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	res := BenchResult{Frames: done, Elapsed: elapsed, NumGC: after.NumGC - before.NumGC}
+	if done > 0 {
+		res.FPS = float64(done) / elapsed.Seconds()
+		res.AvgFrameBytes = totalBytes / done
+		res.AllocsPerFrame = (after.Mallocs - before.Mallocs) / uint64(done)
+		res.BytesPerFrame = (after.TotalAlloc - before.TotalAlloc) / uint64(done)
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	res.EncodeP50 = percentile(latencies, 50)
+	res.EncodeP90 = percentile(latencies, 90)
+	res.EncodeP99 = percentile(latencies, 99)
+	if len(latencies) > 0 {
+		res.EncodeMax = latencies[len(latencies)-1]
+	}
+	return res, nil
+}
+
+// percentile 함수는 정렬된 지연 목록의 p 백분위 값을 반환합니다.
+func percentile(sorted []time.Duration, p int) time.Duration { // 단일 책임: 백분위 계산
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := (len(sorted)*p + 99) / 100
+	if idx < 1 {
+		idx = 1
+	}
+	if idx > len(sorted) {
+		idx = len(sorted)
+	}
+	return sorted[idx-1]
+}
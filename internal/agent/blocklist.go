@@ -0,0 +1,165 @@
+package agent
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"strings"
+	"sync"
+	"time"
+
+	"agent/internal/i18n"
+)
+
+const (
+	BLOCKED_ACTION_BLANK  = "blank"           // 금지 앱이 전면인 동안 검은 프레임으로 대체
+	BLOCKED_ACTION_PAUSE  = "pause"           // 금지 앱이 전면인 동안 캡처하지 않음
+	BLOCKED_TITLE_PREFIX  = "title:"          // 창 제목 부분 일치 규칙 접두사 (없으면 프로세스 이름 규칙)
+	BLOCKED_CHECK_MS      = 250               // 전면 창 확인 최소 간격 (X11 은 조회마다 연결을 새로 엶)
+	PRIVACY_BLOCKED_EVENT = "privacy_blocked" // 캡처 금지 앱 전면 전환 (규칙과 처리 방식만 기록, 창 제목은 남기지 않음)
+	PRIVACY_CLEARED_EVENT = "privacy_cleared" // 캡처 금지 앱이 전면에서 벗어남
+)
+
+// errCapturePaused 변수는 캡처 금지 앱이 전면이라 pause 처리 중임을 나타냅니다.
+var errCapturePaused = i18n.NewError("error.blocked_app")
+
+// appBlocker 구조체는 전면 창이 캡처 금지 목록(프로세스 이름/창 제목)에 해당하는지 주기적으로 확인합니다.
+type appBlocker struct { // 단일 책임: 캡처 금지 앱 판별
+	mu        sync.Mutex
+	rules     []string  // 정규화된 규칙 (프로세스 이름 또는 "title:" 접두 소문자 제목)
+	action    string    // blank | pause
+	checkedAt time.Time // 마지막 전면 창 확인 시각
+	rule      string    // 현재 일치한 규칙 (빈 값이면 차단 아님)
+}
+
+// newAppBlocker 함수는 쉼표로 구분한 규칙 목록으로 appBlocker 를 생성합니다.
+func newAppBlocker(list, action string) *appBlocker { // 단일 책임: 인스턴스 생성
+	b := &appBlocker{}
+	b.set(strings.Split(list, ","), action)
+	return b
+}
+
+// set 함수는 규칙과 처리 방식을 바꾸고 다음 확인에서 즉시 다시 판별하게 합니다.
+func (b *appBlocker) set(rules []string, action string) { // 단일 책임: 규칙 교체
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rules = b.rules[:0]
+	for _, r := range rules {
+		if r = strings.TrimSpace(r); r == "" {
+			continue
+		}
+		if title, ok := strings.CutPrefix(r, BLOCKED_TITLE_PREFIX); ok {
+			r = BLOCKED_TITLE_PREFIX + strings.ToLower(strings.TrimSpace(title))
+		}
+		b.rules = append(b.rules, r)
+	}
+	b.action, b.checkedAt = action, time.Time{}
+}
+
+// list 함수는 현재 규칙 목록을 반환합니다.
+func (b *appBlocker) list() []string { // 단일 책임: 규칙 조회
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string{}, b.rules...)
+}
+
+// match 함수는 창에 해당하는 첫 규칙을 반환합니다 (없으면 빈 값).
+func (b *appBlocker) match(info WindowInfo) string { // 단일 책임: 규칙 비교
+	for _, r := range b.rules {
+		if title, ok := strings.CutPrefix(r, BLOCKED_TITLE_PREFIX); ok {
+			if title != "" && strings.Contains(strings.ToLower(info.Title), title) {
+				return r
+			}
+			continue
+		}
+		if info.Process != "" && strings.EqualFold(processBaseName(info.Process), processBaseName(r)) {
+			return r
+		}
+	}
+	return ""
+}
+
+// matches 함수는 창이 규칙 중 하나에 해당하는지 반환합니다 (전면 창을 다시 확인하지 않음).
+func (b *appBlocker) matches(info WindowInfo) bool { // 단일 책임: 규칙 일치 조회
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.match(info) != ""
+}
+
+// check 함수는 BLOCKED_CHECK_MS 마다 전면 창을 다시 확인해 차단 중이면 처리 방식을, 아니면 빈 값을 반환합니다.
+// 차단 상태가 바뀌면 changed 와 함께 새 규칙(해제 시에는 직전 규칙)을 반환합니다.
+func (b *appBlocker) check(now time.Time) (action, rule string, changed bool) { // 단일 책임: 차단 상태 갱신
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.rules) == 0 && b.rule == "" {
+		return "", "", false
+	}
+	if now.Sub(b.checkedAt) >= time.Duration(BLOCKED_CHECK_MS)*time.Millisecond {
+		b.checkedAt = now
+		cur := ""
+		if info, err := foregroundWindow(); err == nil { // 조회 실패(미지원/전면 창 없음)는 차단 아님
+			cur = b.match(info)
+		}
+		if cur != b.rule {
+			prev := b.rule
+			b.rule = cur
+			if cur == "" {
+				return "", prev, true
+			}
+			return b.action, cur, true
+		}
+	}
+	if b.rule == "" {
+		return "", "", false
+	}
+	return b.action, b.rule, false
+}
+
+// blanking 함수는 마지막 확인 결과 blank 처리 중인지 반환합니다 (전면 창을 다시 확인하지 않음).
+func (b *appBlocker) blanking() bool { // 단일 책임: blank 상태 조회
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.rule != "" && b.action == BLOCKED_ACTION_BLANK
+}
+
+// privacyBlock 함수는 캡처 금지 앱 상태를 갱신하고 전환 시 이벤트를 발행한 뒤 현재 처리 방식을 반환합니다.
+func (a *Agent) privacyBlock() string { // 단일 책임: 캡처 금지 판별 + 이벤트
+	action, rule, changed := a.blocker.check(time.Now())
+	if changed && action != "" {
+		a.logger.Infow("캡처 금지 앱 전면 전환", "rule", rule, "action", action)
+		a.emitEvent(PRIVACY_BLOCKED_EVENT, fmt.Sprintf("rule=%s action=%s", rule, action))
+	} else if changed {
+		a.logger.Infow("캡처 금지 앱 해제", "rule", rule)
+		a.emitEvent(PRIVACY_CLEARED_EVENT, "rule="+rule)
+	}
+	return action
+}
+
+// blankImage 함수는 프레임 전체를 검은색으로 칠합니다.
+func blankImage(img image.Image) { // 단일 책임: 프레임 비우기
+	if dst, ok := img.(draw.Image); ok {
+		draw.Draw(dst, dst.Bounds(), image.Black, image.Point{}, draw.Src)
+	}
+}
+
+// SetBlockedApps 메서드는 캡처 금지 앱 규칙과 처리 방식(blank | pause, 빈 값이면 유지)을 바꿉니다.
+func (a *Agent) SetBlockedApps(rules []string, action string) error { // 단일 책임: 금지 목록 변경
+	if action == "" {
+		action = a.cfg.BlockedAppAction
+	}
+	if action != BLOCKED_ACTION_BLANK && action != BLOCKED_ACTION_PAUSE {
+		return fmt.Errorf("지원하지 않는 처리 방식: %q (blank | pause)", action)
+	}
+	a.blocker.set(rules, action)
+	rules = a.blocker.list()
+	a.capMu.Lock()
+	a.cfg.BlockedApps, a.cfg.BlockedAppAction = strings.Join(rules, ","), action
+	a.capMu.Unlock()
+	a.logger.Infow("캡처 금지 앱 목록 변경", "count", len(rules), "action", action)
+	return nil
+}
+
+// BlockedApps 메서드는 캡처 금지 앱 규칙 목록을 반환합니다.
+func (a *Agent) BlockedApps() []string { // 단일 책임: 금지 목록 조회
+	return a.blocker.list()
+}
@@ -0,0 +1,115 @@
+package agent
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"sync"
+)
+
+const (
+	RGBA_POOL_MAX_FREE      = 8        // 크기별 보관할 최대 유휴 RGBA 버퍼 수
+	ENCODE_BUF_INITIAL_SIZE = 1 << 20  // 인코딩 버퍼 초기 용량 (1MiB)
+	ENCODE_BUF_MAX_RETAIN   = 64 << 20 // 풀에 반환할 인코딩 버퍼 최대 용량 (초과 시 폐기)
+)
+
+// rgbaPool 구조체는 동일 크기 RGBA 프레임 버퍼를 재사용해 프레임당 대용량 할당을 방지합니다.
+type rgbaPool struct { // 단일 책임: RGBA 버퍼 재사용
+	mu   sync.Mutex
+	w, h int           // 현재 보관 중인 버퍼 크기
+	free []*image.RGBA // 유휴 버퍼 목록
+}
+
+// get 함수는 w x h 크기의 RGBA 버퍼를 반환합니다. 재사용 버퍼는 이전 내용이 남아 있을 수 있습니다.
+func (p *rgbaPool) get(w, h int) *image.RGBA { // 단일 책임: 버퍼 획득
+	p.mu.Lock()
+	if p.w == w && p.h == h && len(p.free) > 0 {
+		img := p.free[len(p.free)-1]
+		p.free = p.free[:len(p.free)-1]
+		p.mu.Unlock()
+		return img
+	}
+	p.mu.Unlock()
+	return image.NewRGBA(image.Rect(0, 0, w, h))
+}
+
+// put 함수는 사용이 끝난 버퍼를 반환합니다. 크기가 바뀌었으면 기존 유휴 버퍼를 버립니다.
+func (p *rgbaPool) put(img *image.RGBA) { // 단일 책임: 버퍼 반환
+	if img == nil {
+		return
+	}
+	b := img.Bounds()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.w != b.Dx() || p.h != b.Dy() { // 해상도 변경: 이전 크기 버퍼 폐기
+		p.w, p.h = b.Dx(), b.Dy()
+		p.free = p.free[:0]
+	}
+	if len(p.free) < RGBA_POOL_MAX_FREE {
+		p.free = append(p.free, img)
+	}
+}
+
+// encodeBufPool 변수는 인코딩 출력용 bytes.Buffer 풀입니다.
+var encodeBufPool = sync.Pool{New: func() interface{} {
+	return bytes.NewBuffer(make([]byte, 0, ENCODE_BUF_INITIAL_SIZE))
+}}
+
+// getEncodeBuffer 함수는 비워진 인코딩 버퍼를 풀에서 가져옵니다.
+func getEncodeBuffer() *bytes.Buffer { // 단일 책임: 인코딩 버퍼 획득
+	buf := encodeBufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// putEncodeBuffer 함수는 인코딩 버퍼를 풀에 반환합니다 (과도하게 커진 버퍼는 폐기).
+func putEncodeBuffer(buf *bytes.Buffer) { // 단일 책임: 인코딩 버퍼 반환
+	if buf.Cap() > ENCODE_BUF_MAX_RETAIN {
+		return
+	}
+	encodeBufPool.Put(buf)
+}
+
+// finishEncodeBuffer 함수는 버퍼 내용을 정확한 크기의 새 슬라이스로 복사한 뒤 버퍼를 풀에 반환합니다.
+// 전송 큐/녹화 등 하위 단계가 프레임을 보관해도 풀 버퍼와 메모리를 공유하지 않도록 보장합니다.
+func finishEncodeBuffer(buf *bytes.Buffer) []byte { // 단일 책임: 결과 복사 + 반환
+	out := bytes.Clone(buf.Bytes())
+	putEncodeBuffer(buf)
+	return out
+}
+
+// pngBufferPool 구조체는 png.Encoder 의 압축기/행 버퍼를 프레임 간 재사용하는 png.EncoderBufferPool 구현입니다.
+type pngBufferPool struct { // 단일 책임: PNG 인코더 버퍼 재사용
+	pool sync.Pool
+}
+
+// Get 메서드는 유휴 인코더 버퍼를 반환합니다 (없으면 nil 을 반환해 인코더가 새로 만듭니다).
+func (p *pngBufferPool) Get() *png.EncoderBuffer { // 단일 책임: 버퍼 획득
+	b, _ := p.pool.Get().(*png.EncoderBuffer)
+	return b
+}
+
+// Put 메서드는 인코딩이 끝난 버퍼를 풀에 반환합니다.
+func (p *pngBufferPool) Put(b *png.EncoderBuffer) { // 단일 책임: 버퍼 반환
+	p.pool.Put(b)
+}
+
+// pngEncoderPool 변수는 모든 PNG 인코딩이 공유하는 인코더 버퍼 풀입니다.
+var pngEncoderPool = &pngBufferPool{}
+
+// grayBufPool 변수는 회색조 변환용 *image.Gray 풀입니다.
+var grayBufPool sync.Pool
+
+// getGrayBuffer 함수는 w x h 크기의 회색조 버퍼를 풀에서 가져옵니다 (용량이 부족하면 새로 할당).
+func getGrayBuffer(w, h int) *image.Gray { // 단일 책임: 회색조 버퍼 획득
+	if g, ok := grayBufPool.Get().(*image.Gray); ok && cap(g.Pix) >= w*h {
+		g.Pix, g.Stride, g.Rect = g.Pix[:w*h], w, image.Rect(0, 0, w, h)
+		return g
+	}
+	return image.NewGray(image.Rect(0, 0, w, h))
+}
+
+// putGrayBuffer 함수는 인코딩이 끝난 회색조 버퍼를 풀에 반환합니다.
+func putGrayBuffer(g *image.Gray) { // 단일 책임: 회색조 버퍼 반환
+	grayBufPool.Put(g)
+}
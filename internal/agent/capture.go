@@ -1,33 +1,134 @@
 package agent
 
 import (
+	"fmt"
+	"image"
 	"time"
 
+	"agent/internal/config"
 	monitorProto "agent/proto"
 )
 
-// StartCapture 함수는 주기적인 화면 캡처 루프를 시작합니다.
+// StartCapture 함수는 주기적인 화면 캡처 루프를 시작합니다. 캡처 일정(CaptureSchedule) 밖이거나 일시 중지 중이면 시작하지 않고 오류를 반환합니다.
 func (a *Agent) StartCapture() error { // 단일 책임: 캡처 루프 시작
+	_, err := a.startCapture()
+	return err
+}
+
+// startCapture 함수는 StartCapture 와 같고, 이번 호출이 새 루프를 시작했는지(이미 실행 중이면 false)도 반환합니다.
+func (a *Agent) startCapture() (bool, error) { // 단일 책임: 캡처 루프 시작 + 시작 여부 보고
 	if a == nil || a.ctx == nil {
-		return nil
+		return false, nil
 	}
-	if a.captureStopCh != nil { // 이미 실행 중
-		return nil
+	a.captureMu.Lock()
+	started, err := a.startCaptureLocked()
+	a.captureMu.Unlock()
+	if !started {
+		return false, err
 	}
-	a.captureStopCh = make(chan struct{})
-	go a.captureLoop(a.captureStopCh)
 	a.logger.Info("캡처 루프 시작")
+	if a.onCapture != nil {
+		a.onCapture(true)
+	}
+	return true, nil
+}
+
+// startCaptureLocked 함수는 captureMu 를 쥔 채로 시작 조건을 확인하고 새 캡처 루프를 띄웁니다.
+// 직전 루프가 아직 끝나지 않았으면 끝날 때까지 기다립니다 (두 루프가 delta/h264/인코딩 풀 상태를 함께 쓰지 않도록).
+func (a *Agent) startCaptureLocked() (bool, error) { // 단일 책임: 캡처 루프 생성
+	if a.captureStopCh != nil { // 이미 실행 중
+		return false, nil
+	}
+	if err := a.captureBlocked(); err != nil {
+		return false, err
+	}
+	if a.captureDone != nil {
+		select {
+		case <-a.captureDone:
+		case <-a.ctx.Done():
+			return false, a.ctx.Err()
+		}
+	}
+	a.captureStopCh, a.captureDone = make(chan struct{}), make(chan struct{})
+	go a.superviseCapture(a.captureStopCh, a.captureDone)
+	return true, nil
+}
+
+// captureBlocked 함수는 캡처 일정 밖, 사용자 일시 중지, 동의 없음 순으로 지금 화면을 캡처할 수 없는 이유를 반환합니다 (없으면 nil).
+func (a *Agent) captureBlocked() error { // 단일 책임: 캡처 허용 조건 확인
+	if a.schedule.outside.Load() {
+		return errOutsideSchedule
+	}
+	if a.pause.active() {
+		return errUserPaused
+	}
+	if a.consentMissing() {
+		return errConsentRequired
+	}
 	return nil
 }
 
-// StopCapture 함수는 캡처 루프를 중지합니다.
+// StopCapture 함수는 캡처 루프를 중지합니다. 일시 중지 중에 부르면 일시 중지가 끝나도 캡처를 다시 시작하지 않습니다.
 func (a *Agent) StopCapture() { // 단일 책임: 캡처 루프 중지
-	if a.captureStopCh == nil {
+	a.pause.cancelResume()
+	a.stopCapture()
+}
+
+// stopCapture 함수는 실행 중인 캡처 루프를 중지하고 리스너에 알립니다 (일시 중지의 재개 여부는 건드리지 않음).
+func (a *Agent) stopCapture() { // 단일 책임: 캡처 루프 중지 + 알림
+	if _, ok := a.haltCapture(); !ok {
 		return
 	}
+	a.logger.Info("캡처 루프 중지 요청")
+	if a.onCapture != nil {
+		a.onCapture(false)
+	}
+}
+
+// haltCapture 함수는 captureMu 를 쥔 채로 실행 중인 루프의 중지 채널을 한 번만 닫고, 그 루프의 종료 신호를 반환합니다.
+// 실행 중이 아니면 ok=false 입니다.
+func (a *Agent) haltCapture() (done chan struct{}, ok bool) { // 단일 책임: 캡처 루프 중지 신호
+	a.captureMu.Lock()
+	defer a.captureMu.Unlock()
+	if a.captureStopCh == nil {
+		return nil, false
+	}
 	close(a.captureStopCh)
 	a.captureStopCh = nil
-	a.logger.Info("캡처 루프 중지 요청")
+	return a.captureDone, true
+}
+
+// SetCaptureListener 메서드는 캡처 루프가 시작/중지될 때 호출될 함수를 등록합니다 (Init 전에 등록, 캡처 중 표시용).
+func (a *Agent) SetCaptureListener(fn func(capturing bool)) { // 단일 책임: 리스너 등록
+	a.onCapture = fn
+}
+
+// Capturing 메서드는 캡처 루프가 실행 중인지 반환합니다.
+func (a *Agent) Capturing() bool { // 단일 책임: 캡처 실행 여부 조회
+	a.captureMu.Lock()
+	defer a.captureMu.Unlock()
+	return a.captureStopCh != nil
+}
+
+// SetTargetFPS 메서드는 목표 FPS 를 바꿉니다. 캡처 중이면 루프를 재시작하지 않고 다음 프레임부터 새 간격을 적용합니다.
+func (a *Agent) SetTargetFPS(fps int) error { // 단일 책임: 목표 FPS 변경
+	if fps < config.MIN_TARGET_FPS || fps > config.MAX_TARGET_FPS {
+		return fmt.Errorf("FPS 범위 오류: %d (%d~%d)", fps, config.MIN_TARGET_FPS, config.MAX_TARGET_FPS)
+	}
+	a.capMu.Lock()
+	a.cfg.TargetFPS = fps
+	a.capMu.Unlock()
+	interval := time.Second / time.Duration(fps)
+	select { // 아직 반영되지 않은 이전 변경은 버리고 최신 값만 전달
+	case <-a.intervalCh:
+	default:
+	}
+	select {
+	case a.intervalCh <- interval:
+	default:
+	}
+	a.logger.Infow("목표 FPS 변경", "fps", fps)
+	return nil
 }
 
 // captureLoop 함수는 설정된 주기에 따라 이미지를 캡처 후 전송합니다.
@@ -37,9 +138,34 @@ func (a *Agent) captureLoop(stopCh chan struct{}) { // 단일 책임: 캡처 반
 	if a.cfg.TargetFPS > 0 { // TargetFPS 설정 시 재계산
 		frameInterval = time.Second / time.Duration(a.cfg.TargetFPS)
 	}
+	a.scaler.reset(frameInterval)
+	st := a.newCaptureState(int(time.Second / frameInterval))
+	st.stop = stopCh
+	// 인코딩은 항상 별도 비동기 단계에서 수행 (캡처는 인코딩 완료를 기다리지 않고 다음 프레임으로 진행)
+	st.pool = newEncodePool(resolveEncodeWorkers(a.cfg.EncodeWorkers), a.deliverEncoded)
+	defer func() {
+		identical := st.identicalSkipped()
+		st.closeVideo()
+		st.resetMonitors()
+		st.pool.close()
+		if n := st.pool.skipped.Load(); n > 0 {
+			a.logger.Infof("인코딩 단계 포화로 생략된 캡처 수: %d", n)
+		}
+		if identical > 0 {
+			a.logger.Infof("동일 화면으로 생략된 프레임 수: %d", identical)
+		}
+		if cs := a.stats.snapshot(time.Now()); cs.FramesLimited > 0 {
+			a.logger.Infof("프레임 크기 제한으로 다시 인코딩한 누적 프레임 수: %d (제한 초과 유지 %d)", cs.FramesLimited, cs.FramesOverCap)
+		}
+	}()
 	// 드리프트 누적 방지를 위한 nextFrameTime 사용
 	nextFrameTime := time.Now()
 	for {
+		if st.stopped() { // 막힌 호출에서 풀려난 버려진 루프: 새 루프의 감시 기록/FPS 변경을 가로채지 않고 종료
+			a.logger.Info("캡처 루프 종료")
+			return
+		}
+		a.watchdog.beat(frameInterval)
 		select {
 		case <-a.ctx.Done():
 			a.logger.Info("캡처 루프 컨텍스트 종료")
@@ -47,34 +173,52 @@ func (a *Agent) captureLoop(stopCh chan struct{}) { // 단일 책임: 캡처 반
 		case <-stopCh:
 			a.logger.Info("캡처 루프 종료")
 			return
+		case d := <-a.intervalCh: // SetTargetFPS 반영
+			frameInterval = d
+			st.fps = int(time.Second / frameInterval)
+			a.scaler.reset(frameInterval)
+			nextFrameTime = time.Now()
 		default:
+			if a.lock.locked.Load() { // 화면 잠금 중: 해제(또는 중지)될 때까지 캡처하지 않음
+				a.watchdog.park()
+				select {
+				case <-a.ctx.Done():
+				case <-stopCh:
+				case <-a.lock.changed:
+				}
+				nextFrameTime = time.Now()
+				continue
+			}
 			// 현재 시간이 예정 시간보다 이전이면 대기
 			now := time.Now()
 			if wait := nextFrameTime.Sub(now); wait > 0 {
 				// 짧은 sleep 으로 CPU 낭비 최소화
+				a.watchdog.beat(max(frameInterval, wait))
 				time.Sleep(wait)
 				continue
 			}
+			if a.privacyBlock() == BLOCKED_ACTION_PAUSE { // 캡처 금지 앱 전면: 이번 틱 캡처 생략
+				nextFrameTime = time.Now().Add(frameInterval)
+				continue
+			}
 			// 캡처 수행
 			start := time.Now()
 			// 캡처러 동시성 보호 (모니터 전환 중 안전성 확보)
 			a.capMu.RLock()
 			capt := a.capturer
 			a.capMu.RUnlock()
-			imgBytes, err := capt.Capture()
-			if err != nil {
+			if err := a.captureOnce(capt, st); err != nil {
 				a.logger.Warnf("캡처 실패: %v", err)
 				// 오류 시에도 다음 프레임 시간은 고정 간격으로 진행
 				nextFrameTime = nextFrameTime.Add(frameInterval)
 				continue
 			}
-			frame := &monitorProto.FrameData{AgentId: a.agentID, ImageData: imgBytes, Timestamp: time.Now().UnixMilli(), IsPreview: a.computePreviewFlag()}
-			_ = a.sendFrameData(frame)
-			// 실제 처리 시간 측정 후 다음 예정 시간 계산
-			nextFrameTime = nextFrameTime.Add(frameInterval)
+			// 실제 처리 시간 측정 후 다음 예정 시간 계산 (정지 화면이면 유휴 간격)
+			interval := st.interval(frameInterval)
+			nextFrameTime = nextFrameTime.Add(interval)
 			// 프레임 드롭 상황: 너무 뒤쳐진 경우 현재 시간으로 재조정 (버스트 방지)
-			if lag := time.Since(nextFrameTime); lag > frameInterval {
-				nextFrameTime = time.Now().Add(frameInterval)
+			if lag := time.Since(nextFrameTime); lag > interval {
+				nextFrameTime = time.Now().Add(interval)
 			}
 			// FPS 로그 (저빈도: 5초마다 1회) - 필요시 추후 개선
 			_ = start // 현재는 start 변수 사용 최소화(확장 포인트)
@@ -82,6 +226,258 @@ func (a *Agent) captureLoop(stopCh chan struct{}) { // 단일 책임: 캡처 반
 	}
 }
 
+// captureState 구조체는 captureLoop 한 번의 실행 동안 유지되는 파이프라인 상태입니다.
+type captureState struct {
+	pool         *encodePool        // 비동기 인코딩 단계
+	detector     *changeDetector    // 정적 화면 프레임 생략 (nil 이면 비활성)
+	same         *identicalFilter   // 픽셀 동일 프레임 생략 (nil 이면 비활성)
+	activity     *activityScheduler // 정지 화면 유휴 FPS 전환 (nil 이면 비활성)
+	delta        *deltaEncoder      // delta 인코딩 직전 프레임 상태
+	tiles        *tileEncoder       // tiles 인코딩 직전 프레임 타일 해시
+	output       outputScale        // 인코딩 전 출력 축소 설정
+	video        *videoEncoder      // h264 인코더 세션 (nil 이면 첫 프레임에서 시작)
+	videoRetryAt time.Time          // h264 인코더 시작 실패 후 재시도 가능 시각
+	fps          int                // 현재 목표 FPS (h264 인코더 입력 속도)
+	monitors     []*captureState    // all 모드 모니터별 상태 (인덱스 = 모니터 ID, 인코딩 풀은 공유)
+	dual         *dualStream        // preview/원본 해상도 이중 스트림 분기 (nil 이면 비활성)
+	encoding     string             // 직전 프레임 인코딩 (실행 중 전환 감지)
+	stop         chan struct{}      // 이 루프 실행의 중지 채널 (멈춤으로 교체되면 닫힘)
+}
+
+// newCaptureState 함수는 설정에서 파이프라인 상태를 구성합니다 (인코딩 풀은 호출자가 지정).
+func (a *Agent) newCaptureState(fps int) *captureState { // 단일 책임: 인스턴스 생성
+	return &captureState{
+		fps:      fps,
+		delta:    newDeltaEncoder(a.cfg.DeltaKeyframeInterval),
+		tiles:    newTileEncoder(a.cfg.DeltaKeyframeInterval),
+		output:   newOutputScale(a.cfg),
+		detector: newChangeDetector(a.cfg.ChangeThresholdPct, time.Duration(a.cfg.KeepaliveFrameMs)*time.Millisecond),
+		same:     newIdenticalFilter(a.cfg.SkipIdenticalFrames, unchangedMarkerInterval(a.cfg)),
+		activity: newActivityScheduler(a.cfg),
+		dual:     newDualStream(a.cfg),
+	}
+}
+
+// stopped 함수는 이 루프 실행이 중지되었거나 멈춤으로 교체되어 버려졌는지 반환합니다.
+// 캡처처럼 막힐 수 있는 단계 뒤에 확인해, 버려진 루프가 새 루프와 겹쳐 프레임을 보내지 않게 합니다.
+func (st *captureState) stopped() bool { // 단일 책임: 중지 확인
+	select {
+	case <-st.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// interval 함수는 다음 캡처까지의 간격을 반환합니다. 모니터별 상태가 있으면 가장 활발한 모니터를 따릅니다.
+func (st *captureState) interval(active time.Duration) time.Duration { // 단일 책임: 캡처 간격 선택
+	if len(st.monitors) == 0 {
+		return st.activity.interval(active)
+	}
+	d := st.monitors[0].activity.interval(active)
+	for _, sub := range st.monitors[1:] {
+		d = min(d, sub.activity.interval(active))
+	}
+	return d
+}
+
+// resetEncoding 함수는 인코딩이 바뀔 때 delta/tiles 직전 프레임 상태와 h264 세션을 버립니다.
+func (st *captureState) resetEncoding(encoding string) { // 단일 책임: 인코딩 전환 상태 초기화
+	st.closeVideo()
+	st.resetChain()
+	st.encoding = encoding
+}
+
+// resetChain 함수는 delta/tiles 직전 프레임 상태를 버려 다음 프레임을 키프레임으로 만듭니다.
+func (st *captureState) resetChain() { // 단일 책임: 프레임 체인 초기화
+	st.delta = newDeltaEncoder(st.delta.keyframeInterval)
+	st.tiles = newTileEncoder(st.tiles.keyframeInterval)
+}
+
+// identicalSkipped 함수는 동일 화면으로 생략한 프레임 수를 모니터별 상태까지 합쳐 반환합니다.
+func (st *captureState) identicalSkipped() uint64 { // 단일 책임: 생략 수 집계
+	var n uint64
+	for _, s := range append([]*captureState{st}, st.monitors...) {
+		if s.same != nil {
+			n += s.same.skipped.Load()
+		}
+	}
+	return n
+}
+
+// captureOnce 함수는 한 프레임을 캡처합니다. 캡처러가 원본 이미지를 제공하면 변화 감지 후
+// 변화가 없으면 프레임을 생략하고, 인코딩은 비동기 단계에 위임합니다.
+// 인코딩 단계가 포화 상태면 캡처 타이밍을 지키기 위해 이번 프레임 캡처 자체를 생략합니다.
+func (a *Agent) captureOnce(capt screenCapturer, st *captureState) error { // 단일 책임: 단일 프레임 캡처
+	if ms, ok := capt.(*monitorSetCapturer); ok {
+		return a.captureMonitors(ms, st)
+	}
+	st.resetMonitors()
+	meta := frameMeta{preview: a.computePreviewFlag(), monitorID: capturerMonitorID(capt)}
+	rc, ok := capt.(rawCapturer)
+	if !ok { // 인코딩까지 캡처러가 수행
+		start := time.Now()
+		imgBytes, err := capt.Capture()
+		if err != nil {
+			return err
+		}
+		if st.stopped() {
+			return nil
+		}
+		meta.trace.grabStart, meta.trace.grabDur = start, time.Since(start)
+		a.stats.noteCaptured()
+		meta.timestamp = time.Now().UnixMilli()
+		a.deliverFrame(imgBytes, meta)
+		return nil
+	}
+	if !st.pool.hasRoom(1) { // 인코딩 대기열 포화: 블로킹 대신 이번 틱 생략
+		a.scaler.notePressure()
+		return nil
+	}
+	start := time.Now()
+	img, err := rc.grab()
+	if err != nil {
+		return err
+	}
+	if st.stopped() { // 캡처 중 중지/교체됨: 잡은 프레임 버림
+		rc.release(img)
+		return nil
+	}
+	meta.trace.grabStart, meta.trace.grabDur = start, time.Since(start)
+	return a.processFrame(rc, img, time.Now(), st, meta)
+}
+
+// processFrame 함수는 캡처한 원본 이미지를 변화 감지/축소 후 인코딩 단계에 넘깁니다. img 는 처리 후 rc.release 로 반환됩니다.
+func (a *Agent) processFrame(rc rawCapturer, img image.Image, now time.Time, st *captureState, meta frameMeta) error { // 단일 책임: 단일 프레임 처리
+	meta.timestamp = now.UnixMilli()
+	a.stats.noteCaptured()
+	// 가림 영역과 캡처 금지 앱 blank 는 변화 감지/인코딩/녹화 모두에 앞서 적용
+	a.masks.Load().apply(img, meta.monitorID)
+	if a.blocker.blanking() {
+		blankImage(img)
+	}
+	if enc := rc.options().encoding; enc != st.encoding { // SetEncoding 으로 전환됨: 프레임 간 상태를 버려 새 키프레임부터 시작
+		st.resetEncoding(enc)
+	}
+	if rc.options().encoding == ENCODING_H264 { // 영상 인코더가 정적 화면을 거의 0 비트로 처리하므로 변화 감지 생략
+		defer rc.release(img)
+		a.watermark.apply(img, now, meta.monitorID)
+		a.offerPreview(img, now, meta.monitorID)
+		return a.captureVideo(img, st, a.scaler.current(), meta.monitorID)
+	}
+	if st.activity.observe(img) { // h264 는 인코더 입력 속도가 고정되어 있어 적용하지 않음
+		a.logger.Infow("화면 활동에 따른 캡처 FPS 전환", "idle", st.activity.idle, "target_fps", st.fps, "idle_fps", a.cfg.IdleFPS, "monitor", meta.monitorID)
+	}
+	if identical, marker := st.same.check(img, now); identical { // 완전히 같은 화면: 인코딩/전송 생략
+		rc.release(img)
+		if marker {
+			meta.unchanged = true
+			a.deliverFrame(nil, meta)
+		}
+		return nil
+	}
+	if !st.detector.shouldSend(img, now) { // 정적 화면: keepalive 주기까지 생략
+		rc.release(img)
+		return nil
+	}
+	// 워터마크는 변화 감지 뒤에 그려 초마다 바뀌는 시각이 화면 변화로 잡히지 않게 함
+	a.watermark.apply(img, now, meta.monitorID)
+	a.offerPreview(img, now, meta.monitorID)
+	opts := a.quality.apply(rc.options()) // 대역폭 상한 단계의 손실 압축 품질
+	release := rc.release
+	if st.dual != nil { // 이중 스트림: preview 는 매 틱, 원본 해상도 프레임은 FullStreamFPS 주기에만 이어서 제출
+		full := st.pool.free() >= 2 && st.dual.fullDue(now) // 두 작업 자리가 없으면 preview 만 (원본은 다음 틱에 다시 차례 확인)
+		shared := st.dual.submitPreview(st.pool, img, opts, meta, rc.release, full)
+		if !full {
+			return nil
+		}
+		release = shared
+	}
+	meta.encoding = opts.encoding
+	pct := min(a.scaler.current(), a.quality.scale())
+	if ratio := st.output.ratio(img.Bounds(), pct); ratio < 100 { // 고정 출력 축소 또는 대역폭 압박: 축소 후 인코딩
+		meta.scalePct = int32(ratio)
+	}
+	if chainedEncoding(meta.encoding) && a.keyframes.take(meta.monitorID) { // 송신 경로에서 체인이 끊김: 주기를 기다리지 않고 키프레임
+		st.resetChain()
+	}
+	if meta.encoding == ENCODING_DELTA { // 델타는 순서 의존: XOR 은 여기서, 압축만 비동기 단계에서 수행
+		src := st.output.apply(img, pct)
+		payload, keyframe := st.delta.prepare(src)
+		meta.keyframe = keyframe
+		meta.width, meta.height = int32(src.Bounds().Dx()), int32(src.Bounds().Dy())
+		st.pool.submit(img, func(image.Image) ([]byte, error) { return compressDelta(payload), nil }, release, meta)
+		return nil
+	}
+	if meta.encoding == ENCODING_TILES { // 타일 해시 비교도 순서 의존: 변경 영역 판별은 여기서, 영역 압축은 비동기 단계에서 수행
+		src := toRGBA(st.output.apply(img, pct))
+		rects, keyframe := st.tiles.prepare(src)
+		opts := opts.regionOptions()
+		meta.keyframe = keyframe
+		meta.width, meta.height = int32(src.Bounds().Dx()), int32(src.Bounds().Dy())
+		meta.regions, meta.regionEnc = frameRegions(rects, src.Bounds()), opts.encoding
+		regions := meta.regions
+		st.pool.submit(img, func(image.Image) ([]byte, error) { return nil, encodeRegions(src, regions, opts) }, release, meta)
+		return nil
+	}
+	enc := encodeFunc(opts.encode)
+	if meta.scalePct > 0 { // 축소는 워커에서 병렬 수행
+		output := st.output
+		enc = func(img image.Image) ([]byte, error) {
+			return opts.encode(output.apply(img, pct))
+		}
+	}
+	if a.cfg.MaxFrameBytes > 0 { // 크기 제한 재인코딩도 워커에서 수행 (축소 포함)
+		meta.limit = &frameLimit{}
+		enc = a.limitedEncode(opts, st.output, pct, a.cfg.MaxFrameBytes, meta.limit)
+	}
+	st.pool.submit(img, enc, release, meta)
+	return nil
+}
+
+// deliverEncoded 함수는 인코딩 풀 결과를 받아 실패는 로그로 남기고 성공 프레임을 전달합니다.
+func (a *Agent) deliverEncoded(data []byte, meta frameMeta, err error) { // 단일 책임: 풀 결과 처리
+	if err != nil {
+		a.logger.Warnf("프레임 인코딩 실패: %v", err)
+		return
+	}
+	a.stats.noteEncoded(meta.encodeDur)
+	if meta.limit != nil && meta.limit.scalePct > 0 { // 크기 제한으로 해상도를 더 줄임
+		meta.scalePct = meta.limit.scalePct
+	}
+	a.deliverFrame(data, meta)
+}
+
+// deliverFrame 함수는 인코딩된 프레임을 녹화하고 송신 큐에 투입합니다. 큐가 가득 차 가장 오래된 프레임이
+// 버려지면 송신 백로그로 보고 해상도 제어에 알립니다.
+func (a *Agent) deliverFrame(data []byte, meta frameMeta) { // 단일 책임: 프레임 전달
+	frame := &monitorProto.FrameData{AgentId: a.agentID, ImageData: data, Timestamp: meta.timestamp, IsPreview: meta.preview, ScalePct: meta.scalePct, Encoding: meta.encoding, Keyframe: meta.keyframe, Width: meta.width, Height: meta.height, Regions: meta.regions, RegionEncoding: meta.regionEnc}
+	frame.Unchanged, frame.MonitorId = meta.unchanged, meta.monitorID
+	if meta.unchanged { // 생존 표시 프레임은 녹화/백로그 판단 대상이 아님
+		a.frameQueue.push(frame, meta.trace)
+		return
+	}
+	size := len(data)
+	for _, region := range meta.regions { // tiles: 영역 이미지 합계
+		size += len(region.GetData())
+	}
+	a.stats.noteDelivered(size, time.Now())
+	if err := a.rec.write(frame); err != nil { // 녹화 중이면 로컬 기록
+		a.logger.Warnf("녹화 프레임 기록 실패: %v", err)
+	}
+	if a.frameQueue.push(frame, meta.trace) {
+		a.scaler.notePressure()
+	}
+}
+
+// unchangedMarkerInterval 함수는 동일 프레임 생략 중 unchanged 표시 프레임 주기를 반환합니다 (비활성 시 0).
+func unchangedMarkerInterval(cfg *config.Config) time.Duration { // 단일 책임: 표시 프레임 주기 계산
+	if !cfg.UnchangedMarker {
+		return 0
+	}
+	return time.Duration(cfg.KeepaliveFrameMs) * time.Millisecond
+}
+
 // computePreviewFlag 함수는 프레임의 preview 여부를 계산합니다.
 func (a *Agent) computePreviewFlag() bool { // 단일 책임: preview 판단
 	if a.cfg.ForcePreview { // 강제 설정 우선
@@ -0,0 +1,134 @@
+package agent
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	CAPTURE_STATS_WINDOW_MS = 1000 // 실효 FPS/평균값을 계산하는 집계 구간(ms)
+)
+
+// CaptureStats 구조체는 캡처 파이프라인 누적 계수와 직전 집계 구간 평균값입니다.
+type CaptureStats struct {
+	Capturing      bool    `json:"capturing"`       // 캡처 루프 실행 여부
+	FramesCaptured uint64  `json:"frames_captured"` // 누적 캡처 프레임 수 (변화 없음으로 생략된 프레임 포함)
+	FramesEncoded  uint64  `json:"frames_encoded"`  // 누적 인코딩 완료 프레임 수
+	FramesSent     uint64  `json:"frames_sent"`     // 누적 서버 송신 프레임 수
+	FramesDropped  uint64  `json:"frames_dropped"`  // 누적 드롭 프레임 수 (송신 큐 + 오프라인 스풀 + 송신 속도 제한)
+	FramesLimited  uint64  `json:"frames_limited"`  // MaxFrameBytes 초과로 낮춰 다시 인코딩한 누적 프레임 수
+	FramesOverCap  uint64  `json:"frames_over_cap"` // 최저 품질/해상도로도 MaxFrameBytes 를 넘겨 그대로 보낸 누적 프레임 수
+	FramesChunked  uint64  `json:"frames_chunked"`  // GRPC_MAX_MESSAGE_BYTES 를 넘어 FrameChunk 조각으로 나눠 보낸 누적 프레임 수
+	AvgEncodeMs    float64 `json:"avg_encode_ms"`   // 프레임이 있던 직전 구간의 프레임당 평균 인코딩 시간(ms)
+	AvgFrameBytes  float64 `json:"avg_frame_bytes"` // 프레임이 있던 직전 구간의 프레임당 평균 인코딩 크기(바이트)
+	EffectiveFPS   float64 `json:"effective_fps"`   // 직전 구간 실제 전달 FPS (H.264 인코더 입력 포함)
+	LastFrameAt    int64   `json:"last_frame_at"`   // 마지막 캡처 시각 (Unix ms, 캡처한 적 없으면 0)
+}
+
+// captureStats 구조체는 캡처/인코딩/전달 단계 계수를 모아 CAPTURE_STATS_WINDOW_MS 구간마다 평균을 갱신합니다.
+// 송신/드롭 수는 송신 큐와 스풀이 이미 세고 있으므로 조회 시 합칩니다.
+type captureStats struct { // 단일 책임: 캡처 파이프라인 계측
+	mu       sync.Mutex
+	captured uint64
+	encoded  uint64
+	limited  uint64    // 크기 제한 재인코딩 프레임 수
+	overCap  uint64    // 크기 제한을 끝내 맞추지 못한 프레임 수
+	lastAt   time.Time // 마지막 캡처 시각
+
+	windowStart  time.Time     // 현재 구간 시작 시각
+	windowFrames int           // 현재 구간 전달 프레임 수
+	windowBytes  int           // 현재 구간 인코딩 바이트 합계
+	windowSized  int           // 현재 구간 크기를 센 프레임 수 (H.264 입력 제외)
+	windowEncode time.Duration // 현재 구간 인코딩 시간 합계
+	windowCoded  int           // 현재 구간 인코딩 시간을 센 프레임 수
+
+	fps, avgEncodeMs, avgBytes float64 // 직전 완료 구간 값
+}
+
+// noteCaptured 함수는 캡처한 프레임 한 장을 셉니다.
+func (s *captureStats) noteCaptured() { // 단일 책임: 캡처 계수
+	s.mu.Lock()
+	s.captured++
+	s.lastAt = time.Now()
+	s.mu.Unlock()
+}
+
+// noteEncoded 함수는 인코딩 풀에서 끝난 프레임 한 장과 소요 시간을 셉니다.
+func (s *captureStats) noteEncoded(elapsed time.Duration) { // 단일 책임: 인코딩 계수
+	s.mu.Lock()
+	s.encoded++
+	s.windowEncode += elapsed
+	s.windowCoded++
+	s.mu.Unlock()
+}
+
+// noteLimited 함수는 크기 제한으로 다시 인코딩한 프레임을 셉니다 (fits 가 false 면 제한을 맞추지 못함).
+func (s *captureStats) noteLimited(fits bool) { // 단일 책임: 크기 제한 계수
+	s.mu.Lock()
+	s.limited++
+	if !fits {
+		s.overCap++
+	}
+	s.mu.Unlock()
+}
+
+// noteDelivered 함수는 송신 큐에 넘긴 프레임을 셉니다. size 가 음수이면(H.264 입력) 크기 평균에서 제외합니다.
+func (s *captureStats) noteDelivered(size int, now time.Time) { // 단일 책임: 전달 계수
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.windowFrames++
+	if size >= 0 {
+		s.windowBytes += size
+		s.windowSized++
+	}
+	s.rollLocked(now)
+}
+
+// rollLocked 함수는 현재 구간이 끝났으면 평균값을 갱신하고 새 구간을 시작합니다.
+func (s *captureStats) rollLocked(now time.Time) { // 단일 책임: 구간 전환
+	if s.windowStart.IsZero() {
+		s.windowStart = now
+		return
+	}
+	elapsed := now.Sub(s.windowStart)
+	if elapsed < time.Duration(CAPTURE_STATS_WINDOW_MS)*time.Millisecond {
+		return
+	}
+	s.fps = float64(s.windowFrames) / elapsed.Seconds()
+	if s.windowCoded > 0 { // 프레임이 없던 구간은 직전 평균 유지
+		s.avgEncodeMs = float64(s.windowEncode) / float64(s.windowCoded) / float64(time.Millisecond)
+	}
+	if s.windowSized > 0 {
+		s.avgBytes = float64(s.windowBytes) / float64(s.windowSized)
+	}
+	s.windowStart = now
+	s.windowFrames, s.windowBytes, s.windowSized, s.windowEncode, s.windowCoded = 0, 0, 0, 0, 0
+}
+
+// snapshot 함수는 now 시각 기준 계수를 반환합니다. 전달이 멈춘 채 구간이 지났으면 그 구간을 마저 집계해 FPS 가 0 으로 내려가게 합니다.
+func (s *captureStats) snapshot(now time.Time) CaptureStats { // 단일 책임: 상태 조회
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rollLocked(now)
+	st := CaptureStats{FramesCaptured: s.captured, FramesEncoded: s.encoded, FramesLimited: s.limited, FramesOverCap: s.overCap, AvgEncodeMs: s.avgEncodeMs, AvgFrameBytes: s.avgBytes, EffectiveFPS: s.fps}
+	if !s.lastAt.IsZero() {
+		st.LastFrameAt = s.lastAt.UnixMilli()
+	}
+	return st
+}
+
+// CaptureStats 메서드는 캡처 파이프라인 계수(캡처/인코딩/송신/드롭)와 직전 구간 평균값을 반환합니다.
+func (a *Agent) CaptureStats() CaptureStats { // 단일 책임: 캡처 계측 노출
+	st := a.stats.snapshot(time.Now())
+	q := a.frameQueue.stats()
+	st.Capturing = a.Capturing()
+	st.FramesSent = q.Sent
+	st.FramesDropped = a.framesDropped(q)
+	st.FramesChunked = a.chunkSeq.Load()
+	return st
+}
+
+// framesDropped 함수는 송신 큐(drop-oldest), 오프라인 스풀 용량 초과, 송신 속도 제한으로 버린 프레임을 합칩니다.
+func (a *Agent) framesDropped(q FrameQueueStats) uint64 { // 단일 책임: 드롭 프레임 합산
+	return q.Dropped + a.OfflineSpoolStats().FramesDropped + a.SendLimitStats().Dropped
+}
@@ -1,13 +1,16 @@
 package agent
 
 import (
-	"bytes"
 	"fmt"
 	"image"
+	"image/color"
 	"image/draw"
-	"image/jpeg"
 	"image/png"
 	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/kbinani/screenshot"
 )
@@ -17,10 +20,25 @@ type screenCapturer interface { // 단일 책임: 캡처 추상화
 	Capture() ([]byte, error)
 }
 
+// rawCapturer 인터페이스는 인코딩 전 원본 이미지를 제공해 인코딩을 별도 단계(병렬 풀)로 분리할 수 있는 캡처러입니다.
+type rawCapturer interface { // 단일 책임: 캡처/인코딩 분리 추상화
+	screenCapturer
+	grab() (image.Image, error)
+	encode(img image.Image) ([]byte, error)
+	release(img image.Image) // 인코딩이 끝난 원본 이미지 버퍼 반환
+	options() encodeOptions  // 현재 인코딩 옵션
+}
+
 // dummyCapturer 구조체는 더미 이미지를 생성합니다.
 type dummyCapturer struct { // 단일 책임: 더미 이미지 생성
 	width  int
 	height int
+
+	mu          sync.Mutex
+	cached      []byte     // 캐시된 PNG 바이트 (크기/색상 변경 시에만 재생성)
+	cachedW     int        // 캐시 생성 당시 폭
+	cachedH     int        // 캐시 생성 당시 높이
+	cachedColor color.RGBA // 캐시 생성 당시 색상
 }
 
 // newDummyCapturer 함수는 dummyCapturer 생성자입니다.
@@ -28,45 +46,65 @@ func newDummyCapturer(width, height int) *dummyCapturer { // 단일 책임: 인
 	return &dummyCapturer{width: width, height: height}
 }
 
-// Capture 함수는 단색 PNG 바이트 배열을 생성합니다.
-func (d *dummyCapturer) Capture() ([]byte, error) { // 단일 책임: PNG 생성
-	img := image.NewRGBA(image.Rect(0, 0, d.width, d.height))
-	var r, g, b uint8 = 50, 100, 150
+// dummyColor 함수는 OS 별 더미 프레임 색상을 반환합니다.
+func dummyColor() color.RGBA { // 단일 책임: 색상 결정
 	switch runtime.GOOS { // OS 별 색상 차등
 	case "windows":
-		r, g, b = 0, 120, 215
+		return color.RGBA{R: 0, G: 120, B: 215, A: 255}
 	case "darwin":
-		r, g, b = 50, 50, 50
+		return color.RGBA{R: 50, G: 50, B: 50, A: 255}
 	case "linux":
-		r, g, b = 60, 120, 60
-	}
-	for y := 0; y < d.height; y++ {
-		for x := 0; x < d.width; x++ {
-			offset := y*img.Stride + x*4
-			img.Pix[offset+0] = r
-			img.Pix[offset+1] = g
-			img.Pix[offset+2] = b
-			img.Pix[offset+3] = 255
-		}
+		return color.RGBA{R: 60, G: 120, B: 60, A: 255}
+	}
+	return color.RGBA{R: 50, G: 100, B: 150, A: 255}
+}
+
+// Capture 함수는 단색 PNG 바이트 배열을 반환합니다. 크기/색상이 같으면 캐시된 바이트를 재사용합니다.
+// 반환 슬라이스는 프레임 간 공유되므로 호출자는 수정하면 안 됩니다.
+func (d *dummyCapturer) Capture() ([]byte, error) { // 단일 책임: PNG 생성
+	c := dummyColor()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.cached != nil && d.cachedW == d.width && d.cachedH == d.height && d.cachedColor == c {
+		return d.cached, nil
 	}
-	buf := &bytes.Buffer{}
-	if err := png.Encode(buf, img); err != nil {
+	img := image.NewRGBA(image.Rect(0, 0, d.width, d.height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(c), image.Point{}, draw.Src) // 단색 고속 채우기
+	b, err := encodePNG(img, png.DefaultCompression)
+	if err != nil {
 		return nil, err
 	}
-	return buf.Bytes(), nil
+	d.cached, d.cachedW, d.cachedH, d.cachedColor = b, d.width, d.height, c
+	return b, nil
 }
 
 // screenshotCapturer 구조체는 실제 모니터 화면을 캡처합니다.
 type screenshotCapturer struct { // 단일 책임: 실제 화면 캡처
-	mode         string // single | combined
-	monitorIndex int    // 대상 모니터 인덱스
-	encoding     string // png | jpeg
-	jpegQuality  int    // jpeg 품질
+	mode         string        // single | combined
+	monitorIndex int           // 대상 모니터 인덱스
+	layout       string        // combined 모드 배치 (horizontal | vertical | grid | physical)
+	opts         encodeOptions // 인코딩 옵션
+	canvasPool   rgbaPool      // combined 모드 캔버스 재사용 풀
+	backend      string        // 캡처 백엔드 (auto | screenshot) - combined 모드는 모니터별 캡처러에 적용
+
+	nativeMu     sync.Mutex
+	native       displayBackend        // OS 전용 백엔드 세션 (nil 이면 screenshot 사용)
+	nativeBounds image.Rectangle       // 세션을 만든 모니터 영역 (바뀌면 재생성)
+	nativeRetry  time.Time             // 백엔드 실패 후 다시 시도할 수 있는 시각
+	nativeErr    error                 // 마지막 백엔드 오류 (진단용)
+	parts        []*screenshotCapturer // combined 모드 모니터 인덱스별 single 캡처러 (백엔드 세션/버퍼 재사용, nativeMu 보호)
+}
+
+// newMonitorCapturer 함수는 모니터 idx 하나를 캡처하는 single 모드 screenshotCapturer 를 생성합니다.
+// backend 가 auto 이면 OS 전용 백엔드를 먼저 시도합니다.
+func newMonitorCapturer(idx int, backend string, opts encodeOptions) *screenshotCapturer { // 단일 책임: 인스턴스 생성
+	return &screenshotCapturer{mode: "single", monitorIndex: idx, backend: backend, opts: opts}
 }
 
-// newScreenshotCapturer 함수는 screenshotCapturer 인스턴스를 생성합니다.
-func newScreenshotCapturer(mode string, idx int, encoding string, quality int) *screenshotCapturer { // 단일 책임: 인스턴스 생성
-	return &screenshotCapturer{mode: mode, monitorIndex: idx, encoding: encoding, jpegQuality: quality}
+// newCombinedCapturer 함수는 모든 모니터를 layout 배치로 한 화면에 합쳐 캡처하는 screenshotCapturer 를 생성합니다.
+// 모니터마다 backend 로 캡처한 뒤 캔버스에 그리고 바로 반환하므로, 모니터별 프레임 버퍼도 재사용됩니다.
+func newCombinedCapturer(layout, backend string, opts encodeOptions) *screenshotCapturer { // 단일 책임: 인스턴스 생성
+	return &screenshotCapturer{mode: "combined", layout: layout, backend: backend, opts: opts}
 }
 
 // listMonitors 함수는 사용 가능한 모니터 개수와 각 해상도 정보를 반환합니다.
@@ -104,85 +142,113 @@ func (a *Agent) SelectSingleMonitor(index int) bool { // 단일 책임: 모니
 	}
 	a.cfg.MonitorMode = "single"
 	a.cfg.MonitorIndex = index
-	a.capturer = newScreenshotCapturer("single", index, a.cfg.CaptureEncoding, a.cfg.JpegQuality)
+	a.setCapturerLocked(newMonitorCapturer(index, a.cfg.CaptureBackend, encodeOptionsFromConfig(a.cfg)))
 	return true
 }
 
-// SetCombinedMode 메서드는 combined 모드로 전환합니다.
+// SetCombinedMode 메서드는 combined 모드로 전환합니다 (배치는 설정의 CombinedLayout).
 func (a *Agent) SetCombinedMode() { // 단일 책임: combined 모드 전환
 	a.capMu.Lock()
 	defer a.capMu.Unlock()
 	a.cfg.MonitorMode = "combined"
-	a.capturer = newScreenshotCapturer("combined", 0, a.cfg.CaptureEncoding, a.cfg.JpegQuality)
+	a.setCapturerLocked(newCombinedCapturer(a.cfg.CombinedLayout, a.cfg.CaptureBackend, encodeOptionsFromConfig(a.cfg)))
 }
 
-// Capture 함수는 모니터 모드에 따라 실제 화면 PNG 를 반환합니다.
-func (s *screenshotCapturer) Capture() ([]byte, error) { // 단일 책임: 실제 화면 캡처
+// SetCombinedLayout 메서드는 combined 모드 배치를 바꾸고 combined 모드로 전환합니다.
+func (a *Agent) SetCombinedLayout(layout string) error { // 단일 책임: combined 배치 변경
+	if !slices.Contains(COMBINED_LAYOUTS, layout) {
+		return fmt.Errorf("지원하지 않는 combined 배치: %q (%s)", layout, strings.Join(COMBINED_LAYOUTS, " | "))
+	}
+	a.capMu.Lock()
+	a.cfg.CombinedLayout = layout
+	a.capMu.Unlock()
+	a.SetCombinedMode()
+	a.logger.Infow("combined 배치 변경", "layout", layout)
+	return nil
+}
+
+// Capture 함수는 모니터 모드에 따라 실제 화면을 캡처해 인코딩된 바이트를 반환합니다.
+func (s *screenshotCapturer) Capture() ([]byte, error) { // 단일 책임: 캡처 + 인코딩
+	img, err := s.grab()
+	if err != nil {
+		return nil, err
+	}
+	defer s.release(img)
+	return s.encode(img)
+}
+
+// grab 함수는 모니터 모드에 따라 인코딩 전 원본 화면 이미지를 반환합니다.
+func (s *screenshotCapturer) grab() (image.Image, error) { // 단일 책임: 실제 화면 캡처
 	count := screenshot.NumActiveDisplays()
 	if count == 0 { // 모니터 없음
-		img := image.NewRGBA(image.Rect(0, 0, 1, 1))
-		return s.encode(img)
+		return image.NewRGBA(image.Rect(0, 0, 1, 1)), nil
 	}
 	if s.mode == "single" { // 단일 모니터 캡처
 		if s.monitorIndex >= count {
 			s.monitorIndex = 0
 		}
-		b := screenshot.GetDisplayBounds(s.monitorIndex)
-		img, err := screenshot.CaptureRect(b)
-		if err != nil {
-			return nil, err
-		}
-		return s.encode(img)
+		return s.grabMonitor(s.monitorIndex, screenshot.GetDisplayBounds(s.monitorIndex))
 	}
-	// combined 모드: 가로로 이어붙이기
-	totalWidth := 0
-	maxHeight := 0
+	// combined 모드: 배치 방식에 따라 캔버스에 모니터별 영역 배치
 	bounds := make([]image.Rectangle, 0, count)
 	for i := 0; i < count; i++ {
-		b := screenshot.GetDisplayBounds(i)
-		bounds = append(bounds, b)
-		totalWidth += b.Dx()
-		if b.Dy() > maxHeight {
-			maxHeight = b.Dy()
-		}
+		bounds = append(bounds, screenshot.GetDisplayBounds(i))
 	}
-	canvas := image.NewRGBA(image.Rect(0, 0, totalWidth, maxHeight))
-	offsetX := 0
-	for i := 0; i < count; i++ {
-		b := bounds[i]
-		img, err := screenshot.CaptureRect(b)
+	targets, size := combinedLayout(s.layout, bounds)
+	canvas := s.canvasPool.get(size.X, size.Y)
+	clear(canvas.Pix) // 재사용 버퍼의 이전 프레임 잔상 제거 (모니터가 덮지 않는 빈 영역)
+	s.nativeMu.Lock()
+	defer s.nativeMu.Unlock()
+	s.resizePartsLocked(count)
+	for i, b := range bounds {
+		part := s.parts[i]
+		img, err := part.grabMonitor(i, b)
 		if err != nil {
+			s.canvasPool.put(canvas)
 			return nil, err
 		}
-		target := image.Rect(offsetX, 0, offsetX+b.Dx(), b.Dy())
-		draw.Draw(canvas, target, img, image.Point{}, draw.Src)
-		offsetX += b.Dx()
+		draw.Draw(canvas, targets[i], img, img.Bounds().Min, draw.Src)
+		part.release(img) // 캔버스에 옮긴 모니터 프레임은 바로 백엔드 풀로
 	}
-	return s.encode(canvas)
+	return canvas, nil
 }
 
-// encodePNG 함수는 이미지를 PNG 바이트로 인코딩합니다.
-func encodePNG(img image.Image) ([]byte, error) { // 단일 책임: PNG 인코딩
-	buf := &bytes.Buffer{}
-	if err := png.Encode(buf, img); err != nil {
-		return nil, err
+// grabMonitor 함수는 모니터 index(영역 b)를 OS 전용 백엔드로 캡처하고, 쓸 수 없으면 kbinani/screenshot 으로 대신 캡처합니다.
+func (s *screenshotCapturer) grabMonitor(index int, b image.Rectangle) (image.Image, error) { // 단일 책임: 모니터 하나 캡처
+	if img, ok := s.grabNative(index, b); ok {
+		return img, nil
 	}
-	return buf.Bytes(), nil
+	return screenshot.CaptureRect(b)
 }
 
-// encodeJPEG 함수는 이미지를 JPEG 바이트로 인코딩합니다.
-func encodeJPEG(img image.Image, quality int) ([]byte, error) { // 단일 책임: JPEG 인코딩
-	buf := &bytes.Buffer{}
-	if err := jpeg.Encode(buf, img, &jpeg.Options{Quality: quality}); err != nil {
-		return nil, err
+// resizePartsLocked 함수는 combined 모드 모니터별 캡처러를 count 개로 맞춥니다. 빠진 모니터의 세션은 닫습니다 (nativeMu 보유 상태).
+func (s *screenshotCapturer) resizePartsLocked(count int) { // 단일 책임: 모니터별 캡처러 유지
+	for len(s.parts) > count {
+		s.parts[len(s.parts)-1].close()
+		s.parts = s.parts[:len(s.parts)-1]
+	}
+	for i := len(s.parts); i < count; i++ {
+		s.parts = append(s.parts, newMonitorCapturer(i, s.backend, s.opts))
 	}
-	return buf.Bytes(), nil
 }
 
-// encode 함수는 선택한 인코딩으로 이미지를 인코딩합니다.
-func (s *screenshotCapturer) encode(img image.Image) ([]byte, error) { // 단일 책임: 선택 인코딩 처리
-	if s.encoding == "jpeg" {
-		return encodeJPEG(img, s.jpegQuality)
+// release 함수는 combined 모드 캔버스를 재사용 풀에, single 모드 백엔드 프레임을 백엔드에 반환합니다.
+func (s *screenshotCapturer) release(img image.Image) { // 단일 책임: 캔버스 반환
+	if s.mode != "combined" {
+		s.releaseNative(img)
+		return
 	}
-	return encodePNG(img)
+	if rgba, ok := img.(*image.RGBA); ok {
+		s.canvasPool.put(rgba)
+	}
+}
+
+// options 함수는 캡처러의 인코딩 옵션을 반환합니다.
+func (s *screenshotCapturer) options() encodeOptions { // 단일 책임: 옵션 조회
+	return s.opts
+}
+
+// encode 함수는 캡처러의 인코딩 옵션으로 이미지를 인코딩합니다.
+func (s *screenshotCapturer) encode(img image.Image) ([]byte, error) { // 단일 책임: 선택 인코딩 처리
+	return s.opts.encode(img)
 }
@@ -0,0 +1,109 @@
+package agent
+
+import (
+	"image"
+	"time"
+)
+
+const (
+	CHANGE_GRID_W     = 64 // 변화 감지용 다운샘플 격자 폭
+	CHANGE_GRID_H     = 36 // 변화 감지용 다운샘플 격자 높이
+	CHANGE_LUMA_DELTA = 8  // 셀이 변경된 것으로 간주할 최소 휘도 차이 (0~255)
+)
+
+// changeDetector 구조체는 다운샘플 휘도 격자를 비교해 화면 변화 여부를 저비용으로 판단합니다.
+// 비교 기준은 마지막으로 "전송한" 프레임이므로 느린 점진적 변화도 누적되어 감지됩니다.
+type changeDetector struct { // 단일 책임: 프레임 변화 감지
+	thresholdPct int           // 변경 셀 비율 임계값(%) - 0 이면 비활성
+	keepalive    time.Duration // 정적 화면에서도 프레임을 보내는 최소 주기
+	sent         []uint8       // 마지막 전송 프레임 시그니처
+	cur          []uint8       // 현재 프레임 시그니처 (재사용 버퍼)
+	sentBounds   image.Rectangle
+	lastSent     time.Time
+}
+
+// newChangeDetector 함수는 changeDetector 생성자입니다. thresholdPct 가 0 이하이면 nil 을 반환합니다.
+func newChangeDetector(thresholdPct int, keepalive time.Duration) *changeDetector { // 단일 책임: 인스턴스 생성
+	if thresholdPct <= 0 {
+		return nil
+	}
+	return &changeDetector{
+		thresholdPct: thresholdPct,
+		keepalive:    keepalive,
+		cur:          make([]uint8, CHANGE_GRID_W*CHANGE_GRID_H),
+	}
+}
+
+// shouldSend 함수는 프레임을 전송해야 하는지 반환합니다 (변화가 임계값 이상이거나 keepalive 주기 도달).
+func (d *changeDetector) shouldSend(img image.Image, now time.Time) bool { // 단일 책임: 전송 여부 판단
+	if d == nil {
+		return true
+	}
+	lumaSignature(img, d.cur)
+	changed := d.sent == nil || img.Bounds() != d.sentBounds || changedPct(d.sent, d.cur) >= d.thresholdPct
+	if !changed && now.Sub(d.lastSent) < d.keepalive {
+		return false
+	}
+	if d.sent == nil {
+		d.sent = make([]uint8, len(d.cur))
+	}
+	copy(d.sent, d.cur)
+	d.sentBounds = img.Bounds()
+	d.lastSent = now
+	return true
+}
+
+// changedPct 함수는 휘도 차이가 CHANGE_LUMA_DELTA 를 넘는 셀 비율(%)을 반환합니다.
+func changedPct(a, b []uint8) int { // 단일 책임: 변경 비율 계산
+	return changedCells(a, b) * 100 / len(a)
+}
+
+// changedCells 함수는 휘도 차이가 CHANGE_LUMA_DELTA 를 넘는 셀 수를 반환합니다.
+func changedCells(a, b []uint8) int { // 단일 책임: 변경 셀 계산
+	changed := 0
+	for i := range a {
+		diff := int(a[i]) - int(b[i])
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > CHANGE_LUMA_DELTA {
+			changed++
+		}
+	}
+	return changed
+}
+
+// lumaSignature 함수는 이미지를 격자 셀당 4개 샘플 평균 휘도로 다운샘플해 out 에 기록합니다.
+func lumaSignature(img image.Image, out []uint8) { // 단일 책임: 휘도 시그니처 계산
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w == 0 || h == 0 {
+		clear(out)
+		return
+	}
+	rgba, fast := img.(*image.RGBA)
+	for gy := 0; gy < CHANGE_GRID_H; gy++ {
+		for gx := 0; gx < CHANGE_GRID_W; gx++ {
+			x0 := b.Min.X + gx*w/CHANGE_GRID_W
+			y0 := b.Min.Y + gy*h/CHANGE_GRID_H
+			x1 := b.Min.X + ((gx+1)*w/CHANGE_GRID_W+gx*w/CHANGE_GRID_W)/2
+			y1 := b.Min.Y + ((gy+1)*h/CHANGE_GRID_H+gy*h/CHANGE_GRID_H)/2
+			sum := 0
+			for _, p := range [4]image.Point{{x0, y0}, {x1, y0}, {x0, y1}, {x1, y1}} {
+				if fast {
+					off := rgba.PixOffset(p.X, p.Y)
+					sum += luma8(uint32(rgba.Pix[off]), uint32(rgba.Pix[off+1]), uint32(rgba.Pix[off+2]))
+					continue
+				}
+				r, g, bl, _ := img.At(p.X, p.Y).RGBA()
+				sum += luma8(r>>8, g>>8, bl>>8)
+			}
+			out[gy*CHANGE_GRID_W+gx] = uint8(sum / 4)
+		}
+	}
+}
+
+// luma8 함수는 8비트 RGB 값의 근사 휘도(BT.601)를 반환합니다.
+func luma8(r, g, b uint32) int { // 단일 책임: 휘도 계산
+	return int((77*r + 150*g + 29*b) >> 8)
+}
@@ -0,0 +1,111 @@
+package agent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	CLIPBOARD_CHANGED_EVENT = "clipboard_changed" // 클립보드 변경 (type, size - 설정 시 sha256 또는 잘린 text)
+	CLIPBOARD_CONSENT_EVENT = "clipboard_consent" // 클립보드 감시 동의 변경 (granted)
+	CLIPBOARD_EVENT_SOURCE  = "clipboard"         // 클립보드 이벤트 발생원 이름
+	CLIPBOARD_POLL_MS       = 500                 // 변경 알림이 없는 플랫폼(Windows, macOS)의 클립보드 순번 확인 간격(ms)
+	CLIPBOARD_DETAIL_NONE   = "none"              // 종류/크기만 기록
+	CLIPBOARD_DETAIL_HASH   = "hash"              // 내용 SHA-256 추가 (같은 내용 반복 복사 대조용)
+	CLIPBOARD_DETAIL_TEXT   = "text"              // 텍스트는 앞부분 일부, 그 외 형식은 SHA-256 추가
+	CLIPBOARD_KIND_TEXT     = "text"
+	CLIPBOARD_KIND_IMAGE    = "image"
+	CLIPBOARD_KIND_FILES    = "files"
+	CLIPBOARD_KIND_OTHER    = "other"
+)
+
+// errClipboardUnsupported 변수는 현재 플랫폼/빌드에서 클립보드 감시를 지원하지 않음을 나타냅니다.
+var errClipboardUnsupported = errors.New("이 플랫폼에서는 클립보드 감시를 지원하지 않음")
+
+// clipboardContent 구조체는 클립보드 한 번의 내용 요약입니다.
+type clipboardContent struct {
+	kind  string // text | image | files | other (비었으면 other)
+	size  int    // 내용 크기(바이트, 텍스트는 UTF-8 기준, 대용량 X11 전송은 하한값)
+	items int    // files 인 경우 파일 수
+	data  []byte // 해시/일부 텍스트용 원본 (요청했고 읽을 수 있을 때만, 텍스트는 UTF-8)
+}
+
+// clipboardWatch 구조체는 클립보드 감시 동의 상태입니다. 설정 플래그(ClipboardEvents)와 동의가 모두 있어야 내용을 읽습니다.
+type clipboardWatch struct {
+	consent atomic.Bool
+}
+
+// clipboardSource 함수는 ClipboardEvents 설정 시 클립보드 변경을 감시하는 발생원을 만듭니다 (꺼져 있으면 nil).
+// 동의가 없는 동안에는 변경 알림만 받고 클립보드를 열지 않습니다.
+func (a *Agent) clipboardSource() EventSource { // 단일 책임: 클립보드 발생원 생성
+	if !a.cfg.ClipboardEvents {
+		return nil
+	}
+	return newWatchSource(CLIPBOARD_EVENT_SOURCE, func(ctx context.Context, emit eventEmitter) {
+		err := watchClipboard(ctx, func() { a.onClipboardChange(emit) })
+		if err != nil && ctx.Err() == nil {
+			a.logger.Infof("클립보드 감시 비활성: %v", err)
+		}
+	})
+}
+
+// onClipboardChange 함수는 동의 상태이면 클립보드 요약을 읽어 clipboard_changed 이벤트를 냅니다.
+func (a *Agent) onClipboardChange(emit eventEmitter) { // 단일 책임: 클립보드 변경 처리
+	if !a.clipboard.consent.Load() {
+		return
+	}
+	c, err := readClipboard(a.cfg.ClipboardDetail != CLIPBOARD_DETAIL_NONE)
+	if err != nil { // 다른 앱이 클립보드를 잡고 있는 등 일시 실패는 이번 변경만 건너뜀
+		a.logger.Debugf("클립보드 조회 실패: %v", err)
+		return
+	}
+	emit(CLIPBOARD_CHANGED_EVENT, a.clipboardDetail(c))
+}
+
+// clipboardDetail 함수는 ClipboardDetail 설정에 맞춰 이벤트 상세 문자열을 만듭니다.
+func (a *Agent) clipboardDetail(c clipboardContent) string { // 단일 책임: 이벤트 상세 생성
+	if c.kind == "" {
+		c.kind = CLIPBOARD_KIND_OTHER
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "type=%s size=%d", c.kind, c.size)
+	if c.kind == CLIPBOARD_KIND_FILES {
+		fmt.Fprintf(&b, " items=%d", c.items)
+	}
+	switch {
+	case c.data == nil || a.cfg.ClipboardDetail == CLIPBOARD_DETAIL_NONE:
+	case a.cfg.ClipboardDetail == CLIPBOARD_DETAIL_TEXT && c.kind == CLIPBOARD_KIND_TEXT:
+		text := []rune(string(c.data))
+		if len(text) > a.cfg.ClipboardTextMax {
+			text = text[:a.cfg.ClipboardTextMax]
+		}
+		fmt.Fprintf(&b, " text=%q", string(text))
+	default:
+		sum := sha256.Sum256(c.data)
+		b.WriteString(" sha256=" + hex.EncodeToString(sum[:]))
+	}
+	return b.String()
+}
+
+// SetClipboardConsent 메서드는 사용자 클립보드 감시 동의를 바꾸고 변경 시 이벤트를 발행합니다.
+// ClipboardEvents 설정이 꺼져 있으면 동의해도 감시하지 않습니다.
+func (a *Agent) SetClipboardConsent(granted bool) { // 단일 책임: 클립보드 동의 변경
+	if a.clipboard.consent.Swap(granted) == granted {
+		return
+	}
+	a.capMu.Lock()
+	a.cfg.ClipboardConsent = granted
+	a.capMu.Unlock()
+	a.logger.Infow("클립보드 감시 동의 변경", "granted", granted, "enabled", a.cfg.ClipboardEvents)
+	a.emitEvent(CLIPBOARD_CONSENT_EVENT, fmt.Sprintf("granted=%t", granted))
+}
+
+// ClipboardConsent 메서드는 클립보드 감시 동의 여부를 반환합니다.
+func (a *Agent) ClipboardConsent() bool { // 단일 책임: 클립보드 동의 조회
+	return a.clipboard.consent.Load()
+}
@@ -0,0 +1,108 @@
+//go:build darwin && cgo
+
+package agent
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework AppKit
+#import <AppKit/AppKit.h>
+#include <stdlib.h>
+#include <string.h>
+
+typedef struct {
+	int kind; // 0: other, 1: text, 2: image, 3: files
+	long size;
+	int items;
+	void *data; // malloc 버퍼 (want 이고 내용이 있을 때만)
+} agent_clipboard;
+
+// 일반 클립보드 변경 순번을 반환합니다.
+static long agent_clipboard_count(void) {
+	@autoreleasepool {
+		return (long)[[NSPasteboard generalPasteboard] changeCount];
+	}
+}
+
+// 일반 클립보드 내용 형식과 크기를 out 에 채웁니다. want 이면 내용을 복사합니다.
+static void agent_clipboard_read(int want, agent_clipboard *out) {
+	@autoreleasepool {
+		NSPasteboard *pb = [NSPasteboard generalPasteboard];
+		NSArray *types = [pb types];
+		NSData *data = nil;
+		memset(out, 0, sizeof(*out));
+		if ([types containsObject:NSPasteboardTypeFileURL]) {
+			out->kind = 3;
+			NSMutableString *urls = [NSMutableString string];
+			for (NSPasteboardItem *item in [pb pasteboardItems]) {
+				NSString *u = [item stringForType:NSPasteboardTypeFileURL];
+				if (u) {
+					out->items++;
+					[urls appendFormat:@"%@\n", u];
+				}
+			}
+			data = [urls dataUsingEncoding:NSUTF8StringEncoding];
+		} else if ([types containsObject:NSPasteboardTypeString]) {
+			out->kind = 1;
+			data = [[pb stringForType:NSPasteboardTypeString] dataUsingEncoding:NSUTF8StringEncoding];
+		} else if ([types containsObject:NSPasteboardTypePNG]) {
+			out->kind = 2;
+			data = [pb dataForType:NSPasteboardTypePNG];
+		} else if ([types containsObject:NSPasteboardTypeTIFF]) {
+			out->kind = 2;
+			data = [pb dataForType:NSPasteboardTypeTIFF];
+		}
+		if (!data) {
+			return;
+		}
+		out->size = (long)[data length];
+		if (want && out->size > 0) {
+			out->data = malloc(out->size);
+			memcpy(out->data, [data bytes], out->size);
+		}
+	}
+}
+*/
+import "C"
+
+import (
+	"context"
+	"time"
+	"unsafe"
+)
+
+// clipboardKinds 변수는 agent_clipboard.kind 값에 해당하는 종류 이름입니다.
+var clipboardKinds = [...]string{CLIPBOARD_KIND_OTHER, CLIPBOARD_KIND_TEXT, CLIPBOARD_KIND_IMAGE, CLIPBOARD_KIND_FILES}
+
+// watchClipboard 함수는 NSPasteboard changeCount 를 CLIPBOARD_POLL_MS 마다 비교해 바뀌면 onChange 를 호출합니다.
+func watchClipboard(ctx context.Context, onChange func()) error { // 단일 책임: macOS 클립보드 변경 감지
+	ticker := time.NewTicker(time.Duration(CLIPBOARD_POLL_MS) * time.Millisecond)
+	defer ticker.Stop()
+	last := C.agent_clipboard_count()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		if n := C.agent_clipboard_count(); n != last {
+			last = n
+			onChange()
+		}
+	}
+}
+
+// readClipboard 함수는 일반 클립보드의 형식(파일 > 텍스트 > 이미지)과 크기를 조회합니다. withData 이면 내용도 복사합니다.
+func readClipboard(withData bool) (clipboardContent, error) { // 단일 책임: macOS 클립보드 요약 조회
+	var out C.agent_clipboard
+	want := C.int(0)
+	if withData {
+		want = 1
+	}
+	C.agent_clipboard_read(want, &out)
+	c := clipboardContent{kind: clipboardKinds[int(out.kind)], size: int(out.size), items: int(out.items)}
+	if out.data != nil {
+		c.data = C.GoBytes(out.data, C.int(out.size))
+		C.free(unsafe.Pointer(out.data))
+	}
+	return c, nil
+}
@@ -0,0 +1,15 @@
+//go:build !windows && !linux && !freebsd && !(darwin && cgo)
+
+package agent
+
+import "context"
+
+// watchClipboard 함수는 클립보드 감시를 지원하지 않는 플랫폼에서 errClipboardUnsupported 를 반환합니다.
+func watchClipboard(context.Context, func()) error { // 단일 책임: 미지원 플랫폼 처리
+	return errClipboardUnsupported
+}
+
+// readClipboard 함수는 클립보드 감시를 지원하지 않는 플랫폼에서 errClipboardUnsupported 를 반환합니다.
+func readClipboard(bool) (clipboardContent, error) { // 단일 책임: 미지원 플랫폼 처리
+	return clipboardContent{}, errClipboardUnsupported
+}
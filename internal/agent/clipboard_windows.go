@@ -0,0 +1,108 @@
+//go:build windows
+
+package agent
+
+import (
+	"context"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	CF_DIB         = 8
+	CF_UNICODETEXT = 13
+	CF_HDROP       = 15
+	CF_DIBV5       = 17
+)
+
+var (
+	procGetClipboardSequenceNumber = user32.NewProc("GetClipboardSequenceNumber")
+	procOpenClipboard              = user32.NewProc("OpenClipboard")
+	procCloseClipboard             = user32.NewProc("CloseClipboard")
+	procIsClipboardFormatAvailable = user32.NewProc("IsClipboardFormatAvailable")
+	procGetClipboardData           = user32.NewProc("GetClipboardData")
+	procDragQueryFileW             = windows.NewLazySystemDLL("shell32.dll").NewProc("DragQueryFileW")
+	procGlobalLock                 = windows.NewLazySystemDLL("kernel32.dll").NewProc("GlobalLock")
+	procGlobalUnlock               = windows.NewLazySystemDLL("kernel32.dll").NewProc("GlobalUnlock")
+	procGlobalSize                 = windows.NewLazySystemDLL("kernel32.dll").NewProc("GlobalSize")
+)
+
+// watchClipboard 함수는 GetClipboardSequenceNumber 를 CLIPBOARD_POLL_MS 마다 비교해 바뀌면 onChange 를 호출합니다.
+// 순번 조회는 클립보드를 열지 않으므로 다른 앱의 복사/붙여넣기를 방해하지 않습니다.
+func watchClipboard(ctx context.Context, onChange func()) error { // 단일 책임: Win32 클립보드 변경 감지
+	ticker := time.NewTicker(time.Duration(CLIPBOARD_POLL_MS) * time.Millisecond)
+	defer ticker.Stop()
+	last, _, _ := procGetClipboardSequenceNumber.Call()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		if seq, _, _ := procGetClipboardSequenceNumber.Call(); seq != last {
+			last = seq
+			onChange()
+		}
+	}
+}
+
+// readClipboard 함수는 클립보드를 열어 우선순위(파일 > 텍스트 > 이미지) 순으로 형식을 고르고 크기를 조회합니다.
+// withData 이면 해시/일부 텍스트용 내용도 복사합니다 (텍스트는 UTF-8 로 변환).
+func readClipboard(withData bool) (clipboardContent, error) { // 단일 책임: Win32 클립보드 요약 조회
+	if r, _, err := procOpenClipboard.Call(0); r == 0 {
+		return clipboardContent{}, err
+	}
+	defer procCloseClipboard.Call()
+	var c clipboardContent
+	var format uintptr
+	switch {
+	case clipboardHas(CF_HDROP):
+		c.kind, format = CLIPBOARD_KIND_FILES, CF_HDROP
+	case clipboardHas(CF_UNICODETEXT):
+		c.kind, format = CLIPBOARD_KIND_TEXT, CF_UNICODETEXT
+	case clipboardHas(CF_DIBV5):
+		c.kind, format = CLIPBOARD_KIND_IMAGE, CF_DIBV5
+	case clipboardHas(CF_DIB):
+		c.kind, format = CLIPBOARD_KIND_IMAGE, CF_DIB
+	default:
+		return clipboardContent{kind: CLIPBOARD_KIND_OTHER}, nil
+	}
+	h, _, err := procGetClipboardData.Call(format)
+	if h == 0 {
+		return clipboardContent{}, err
+	}
+	size, _, _ := procGlobalSize.Call(h)
+	c.size = int(size)
+	if format == CF_HDROP {
+		n, _, _ := procDragQueryFileW.Call(h, 0xFFFFFFFF, 0, 0)
+		c.items = int(n)
+	}
+	if !withData && format != CF_UNICODETEXT {
+		return c, nil
+	}
+	p, _, err := procGlobalLock.Call(h)
+	if p == 0 {
+		return clipboardContent{}, err
+	}
+	defer procGlobalUnlock.Call(h)
+	ptr := *(*unsafe.Pointer)(unsafe.Pointer(&p)) // 잠긴 전역 메모리는 GC 대상이 아니므로 uintptr 를 그대로 포인터로 사용
+	raw := unsafe.Slice((*byte)(ptr), c.size)
+	if format == CF_UNICODETEXT { // 크기는 UTF-8 기준으로 보고
+		text := windows.UTF16ToString(unsafe.Slice((*uint16)(ptr), c.size/2))
+		c.size = len(text)
+		if withData {
+			c.data = []byte(text)
+		}
+		return c, nil
+	}
+	c.data = append([]byte(nil), raw...)
+	return c, nil
+}
+
+// clipboardHas 함수는 클립보드에 해당 형식이 있는지 반환합니다.
+func clipboardHas(format uintptr) bool { // 단일 책임: 형식 확인
+	r, _, _ := procIsClipboardFormatAvailable.Call(format)
+	return r != 0
+}
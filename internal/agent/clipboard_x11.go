@@ -0,0 +1,184 @@
+//go:build linux || freebsd
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jezek/xgb"
+	"github.com/jezek/xgb/xfixes"
+	"github.com/jezek/xgb/xproto"
+)
+
+const (
+	CLIPBOARD_READ_TIMEOUT_MS = 1000    // 클립보드 소유 앱의 선택 변환 응답 대기 한도(ms)
+	CLIPBOARD_MAX_READ        = 4 << 20 // 한 번에 읽는 선택 속성 최대 크기(바이트)
+)
+
+// errClipboardTimeout 변수는 클립보드 소유 앱이 제한 시간 안에 내용을 넘기지 않았음을 나타냅니다.
+var errClipboardTimeout = errors.New("클립보드 응답 시간 초과")
+
+// clipboardTargets 변수는 X11 선택 대상(MIME/아톰 이름)을 우선순위 순으로 종류에 대응시킵니다.
+var clipboardTargets = []struct{ target, kind string }{
+	{"text/uri-list", CLIPBOARD_KIND_FILES},
+	{"UTF8_STRING", CLIPBOARD_KIND_TEXT},
+	{"STRING", CLIPBOARD_KIND_TEXT},
+	{"image/png", CLIPBOARD_KIND_IMAGE},
+}
+
+// watchClipboard 함수는 XFixes 선택 소유자 변경 알림으로 CLIPBOARD 선택이 바뀔 때마다 onChange 를 호출합니다.
+// 앱은 복사할 때마다 소유권을 다시 가져가므로 같은 앱에서의 반복 복사도 감지됩니다.
+func watchClipboard(ctx context.Context, onChange func()) (err error) { // 단일 책임: X11 클립보드 변경 감지
+	defer func() { // xgb 는 연결 오류 시 panic 할 수 있음
+		if r := recover(); r != nil {
+			err = fmt.Errorf("X11 클립보드 감시 실패: %v", r)
+		}
+	}()
+	conn, err := xgb.NewConn()
+	if err != nil {
+		return fmt.Errorf("X11 연결 실패: %w", err)
+	}
+	defer conn.Close()
+	if err := xfixes.Init(conn); err != nil {
+		return fmt.Errorf("XFixes 확장 없음: %w", err)
+	}
+	if _, err := xfixes.QueryVersion(conn, 5, 0).Reply(); err != nil {
+		return err
+	}
+	win, err := x11SelectionWindow(conn)
+	if err != nil {
+		return err
+	}
+	mask := uint32(xfixes.SelectionEventMaskSetSelectionOwner | xfixes.SelectionEventMaskSelectionWindowDestroy | xfixes.SelectionEventMaskSelectionClientClose)
+	if err := xfixes.SelectSelectionInputChecked(conn, win, x11InternAtom(conn, "CLIPBOARD"), mask).Check(); err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		conn.Close() // WaitForEvent 해제
+	}()
+	for {
+		ev, xerr := conn.WaitForEvent()
+		if ev == nil && xerr == nil { // 연결 종료
+			return nil
+		}
+		if n, ok := ev.(xfixes.SelectionNotifyEvent); ok && n.Owner != win {
+			onChange()
+		}
+	}
+}
+
+// readClipboard 함수는 CLIPBOARD 선택의 TARGETS 로 종류를 고르고 해당 대상으로 변환해 크기를 조회합니다.
+// 대용량(INCR) 전송은 내용을 받지 않고 소유 앱이 알린 하한 크기만 보고합니다.
+func readClipboard(withData bool) (c clipboardContent, err error) { // 단일 책임: X11 클립보드 요약 조회
+	defer func() {
+		if r := recover(); r != nil {
+			c, err = clipboardContent{}, fmt.Errorf("X11 클립보드 조회 실패: %v", r)
+		}
+	}()
+	conn, err := xgb.NewConn()
+	if err != nil {
+		return clipboardContent{}, fmt.Errorf("X11 연결 실패: %w", err)
+	}
+	defer conn.Close()
+	win, err := x11SelectionWindow(conn)
+	if err != nil {
+		return clipboardContent{}, err
+	}
+	clip := x11InternAtom(conn, "CLIPBOARD")
+	prop := x11InternAtom(conn, "AGENT_CLIPBOARD")
+	targets, _, err := x11ConvertSelection(conn, win, clip, x11InternAtom(conn, "TARGETS"), prop)
+	if err != nil {
+		return clipboardContent{}, err
+	}
+	available := map[xproto.Atom]bool{}
+	for i := 0; i+4 <= len(targets); i += 4 {
+		available[xproto.Atom(xgb.Get32(targets[i:]))] = true
+	}
+	for _, t := range clipboardTargets {
+		target := x11InternAtom(conn, t.target)
+		if !available[target] {
+			continue
+		}
+		data, incr, err := x11ConvertSelection(conn, win, clip, target, prop)
+		if err != nil {
+			return clipboardContent{}, err
+		}
+		c = clipboardContent{kind: t.kind, size: len(data)}
+		if incr {
+			if len(data) >= 4 {
+				c.size = int(xgb.Get32(data))
+			}
+			data = nil
+		}
+		if t.kind == CLIPBOARD_KIND_FILES {
+			for _, line := range strings.Split(string(data), "\n") {
+				if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+					c.items++
+				}
+			}
+		}
+		if withData {
+			c.data = data
+		}
+		return c, nil
+	}
+	return clipboardContent{kind: CLIPBOARD_KIND_OTHER}, nil
+}
+
+// x11SelectionWindow 함수는 선택 변환 요청/알림을 받을 보이지 않는 창을 만듭니다.
+func x11SelectionWindow(conn *xgb.Conn) (xproto.Window, error) { // 단일 책임: 수신 창 생성
+	screen := xproto.Setup(conn).DefaultScreen(conn)
+	win, err := xproto.NewWindowId(conn)
+	if err != nil {
+		return 0, err
+	}
+	err = xproto.CreateWindowChecked(conn, 0, win, screen.Root, 0, 0, 1, 1, 0, xproto.WindowClassInputOnly, screen.RootVisual, 0, nil).Check()
+	return win, err
+}
+
+// x11ConvertSelection 함수는 선택을 target 으로 변환해 prop 속성으로 받은 값을 읽습니다.
+// 소유자가 INCR 로 응답하면 incr 와 함께 크기 하한 값을 반환합니다.
+func x11ConvertSelection(conn *xgb.Conn, win xproto.Window, selection, target, prop xproto.Atom) (data []byte, incr bool, err error) { // 단일 책임: 선택 변환
+	xproto.ConvertSelection(conn, win, selection, target, prop, xproto.TimeCurrentTime)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-done:
+		case <-time.After(time.Duration(CLIPBOARD_READ_TIMEOUT_MS) * time.Millisecond):
+			conn.Close() // 응답 없는 소유 앱: WaitForEvent 해제
+		}
+	}()
+	for {
+		ev, xerr := conn.WaitForEvent()
+		if ev == nil && xerr == nil {
+			return nil, false, errClipboardTimeout
+		}
+		n, ok := ev.(xproto.SelectionNotifyEvent)
+		if !ok || n.Target != target {
+			continue
+		}
+		if n.Property == xproto.AtomNone { // 소유자가 해당 형식 변환 거부
+			return nil, false, nil
+		}
+		reply, err := xproto.GetProperty(conn, true, win, prop, xproto.GetPropertyTypeAny, 0, CLIPBOARD_MAX_READ/4).Reply()
+		if err != nil {
+			return nil, false, err
+		}
+		return reply.Value, reply.Type == x11InternAtom(conn, "INCR"), nil
+	}
+}
+
+// x11InternAtom 함수는 이름에 해당하는 아톰을 반환하며, 없으면 새로 만듭니다 (실패 시 0).
+func x11InternAtom(c *xgb.Conn, name string) xproto.Atom { // 단일 책임: 아톰 생성/조회
+	reply, err := xproto.InternAtom(c, false, uint16(len(name)), name).Reply()
+	if err != nil {
+		return 0
+	}
+	return reply.Atom
+}
@@ -0,0 +1,207 @@
+package agent
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"agent/internal/config"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	CONFIG_RELOADED_EVENT     = "config_reloaded" // 설정 파일 변경 적용 (changed: 바뀐 항목 목록)
+	CONFIG_INVALID_EVENT      = "config_invalid"  // 잘못된 설정 값을 대체 값으로 실행 (count, keys: 문제 항목)
+	CONFIG_RELOAD_DEBOUNCE_MS = 500               // 편집기의 연속 쓰기/교체를 한 번의 재적용으로 묶는 시간(ms)
+
+	CONFIG_ITEM_FPS       = "fps"       // 목표 FPS
+	CONFIG_ITEM_QUALITY   = "quality"   // jpeg/webp 품질
+	CONFIG_ITEM_ENCODING  = "encoding"  // 프레임 인코딩
+	CONFIG_ITEM_MONITOR   = "monitor"   // 모니터 모드/인덱스/배치/대상 창
+	CONFIG_ITEM_LOG_LEVEL = "log_level" // 로그 수준
+	CONFIG_ITEM_PRIVACY   = "privacy"   // 가림 영역/캡처 금지 앱 (서버 관리 설정 전용, 설정 화면은 가림 영역만)
+	CONFIG_ITEM_SCALE     = "scale"     // 출력 배율/최대 크기 (다음 캡처 시작부터)
+	CONFIG_ITEM_INTERVAL  = "interval"  // 캡처 주기 (다음 캡처 시작부터)
+	CONFIG_ITEM_SCHEDULE  = "schedule"  // 캡처 허용 시간대 (재시작 후)
+)
+
+// startConfigWatch 함수는 ConfigReload 설정 시 설정 파일이 있는 디렉터리를 감시해 파일이 바뀌면 다시 읽어 적용합니다.
+// 저장할 때 파일을 교체하는 편집기도 있어 파일이 아닌 디렉터리를 감시합니다.
+func (a *Agent) startConfigWatch() { // 단일 책임: 설정 파일 감시 시작
+	if !a.cfg.ConfigReload {
+		return
+	}
+	path, _ := config.FilePath()
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		a.logger.Warnf("설정 파일 감시 비활성: %v", err)
+		return
+	}
+	if err := w.Add(filepath.Dir(path)); err != nil {
+		a.logger.Debugf("설정 파일 감시 비활성 (%s): %v", path, err)
+		w.Close()
+		return
+	}
+	go a.runConfigWatch(w, path)
+}
+
+// runConfigWatch 함수는 설정 파일 알림을 CONFIG_RELOAD_DEBOUNCE_MS 동안 모았다가 설정을 다시 읽어 ApplyConfig 로 적용합니다.
+// 해석에 실패한 파일은 적용하지 않고 현재 설정을 유지합니다.
+func (a *Agent) runConfigWatch(w *fsnotify.Watcher, path string) { // 단일 책임: 설정 변경 수집/적용
+	defer w.Close()
+	name := filepath.Clean(path)
+	debounce := time.NewTimer(time.Hour)
+	debounce.Stop()
+	defer debounce.Stop()
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case err, ok := <-w.Errors:
+			if !ok {
+				return
+			}
+			a.logger.Debugf("설정 파일 감시 오류: %v", err)
+		case ev, ok := <-w.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(ev.Name) == name && !ev.Has(fsnotify.Chmod) {
+				debounce.Reset(time.Duration(CONFIG_RELOAD_DEBOUNCE_MS) * time.Millisecond)
+			}
+		case <-debounce.C:
+			if _, err := os.Stat(path); err != nil { // 교체 중 삭제 상태이거나 파일 제거 - 현재 설정 유지
+				continue
+			}
+			next := config.Load()
+			if next.ConfigFileError != "" {
+				a.logger.Warnf("설정 파일 재적용 실패 (현재 설정 유지): %s", next.ConfigFileError)
+				continue
+			}
+			a.ApplyConfig(next)
+			a.reportConfigProblems(next.Problems)
+		}
+	}
+}
+
+// ApplyConfig 메서드는 next 의 항목 중 실행 중 바꿔도 안전한 FPS/품질/인코딩/모니터 모드/로그 수준을 적용하고 바뀐 항목을 반환합니다.
+// 비교 기준은 마지막으로 적용한 설정이라, 설정에서 바뀌지 않은 항목은 UI/원격 명령/전원 절약으로 바꾼 현재 값을 유지합니다.
+// 서버 관리 설정(GetConfig)이 적용된 항목은 건너뛰며, 그 밖의 항목(서버 주소, 데이터 경로 등)은 재시작해야 적용됩니다.
+// 바뀐 항목이 있으면 config_reloaded 이벤트를 보냅니다.
+func (a *Agent) ApplyConfig(next *config.Config) []string { // 단일 책임: 설정 재적용
+	a.capMu.Lock()
+	cur := a.loaded
+	a.loaded = *next
+	a.capMu.Unlock()
+	var changed []string
+	if next.TargetFPS != cur.TargetFPS && !a.remote.manages(CONFIG_ITEM_FPS) {
+		if err := a.SetTargetFPS(next.TargetFPS); err != nil {
+			a.logger.Warnf("설정 FPS 무시: %v", err)
+		} else {
+			changed = append(changed, CONFIG_ITEM_FPS)
+		}
+	}
+	var enc string
+	var jpegQ, webpQ int // 0 이면 현재 값 유지
+	if next.CaptureEncoding != cur.CaptureEncoding && !a.remote.manages(CONFIG_ITEM_ENCODING) {
+		enc = next.CaptureEncoding
+	}
+	if next.JpegQuality != cur.JpegQuality && !a.remote.manages(CONFIG_ITEM_QUALITY) {
+		jpegQ = next.JpegQuality
+	}
+	if next.WebpQuality != cur.WebpQuality && !a.remote.manages(CONFIG_ITEM_QUALITY) {
+		webpQ = next.WebpQuality
+	}
+	encChanged, qualityChanged := enc != "", jpegQ > 0 || webpQ > 0
+	if encChanged || qualityChanged {
+		if err := a.setEncodingQuality(enc, jpegQ, webpQ); err != nil {
+			a.logger.Warnf("설정 인코딩 무시: %v", err)
+		} else {
+			if encChanged {
+				changed = append(changed, CONFIG_ITEM_ENCODING)
+			}
+			if qualityChanged {
+				changed = append(changed, CONFIG_ITEM_QUALITY)
+			}
+		}
+	}
+	if monitorConfigChanged(&cur, next) {
+		if err := a.applyMonitorConfig(next); err != nil {
+			a.logger.Warnf("설정 모니터 모드 무시: %v", err)
+		} else {
+			changed = append(changed, CONFIG_ITEM_MONITOR)
+		}
+	}
+	if next.LogLevel != cur.LogLevel {
+		if err := a.SetLogLevel(next.LogLevel); err != nil {
+			a.logger.Warnf("설정 로그 수준 무시: %v", err)
+		} else {
+			changed = append(changed, CONFIG_ITEM_LOG_LEVEL)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	a.logger.Infow("설정 재적용", "changed", changed)
+	a.emitEvent(CONFIG_RELOADED_EVENT, fmt.Sprintf("changed=%s", strings.Join(changed, ",")))
+	return changed
+}
+
+// reportConfigProblems 함수는 설정 검증 문제를 항목별 경고 로그와 config_invalid 이벤트 하나로 알립니다.
+func (a *Agent) reportConfigProblems(problems []config.Problem) { // 단일 책임: 설정 문제 보고
+	if len(problems) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(problems))
+	for _, p := range problems {
+		a.logger.Warnw("잘못된 설정 값 대체", "key", p.Key, "value", p.Value, "reason", p.Reason, "applied", p.Applied)
+		keys = append(keys, p.Key)
+	}
+	a.emitEvent(CONFIG_INVALID_EVENT, fmt.Sprintf("count=%d keys=%s", len(problems), strings.Join(keys, ",")))
+}
+
+// ConfigProblems 메서드는 마지막으로 읽은 설정의 검증 문제 목록을 반환합니다 (UI 표시용, 없으면 빈 슬라이스).
+func (a *Agent) ConfigProblems() []config.Problem { // 단일 책임: 설정 문제 조회
+	a.capMu.Lock()
+	defer a.capMu.Unlock()
+	return append([]config.Problem{}, a.loaded.Problems...)
+}
+
+// monitorConfigChanged 함수는 모니터 모드와 모드별 대상 항목이 바뀌었는지 반환합니다.
+func monitorConfigChanged(cur, next *config.Config) bool { // 단일 책임: 모니터 설정 비교
+	if cur.MonitorMode != next.MonitorMode {
+		return true
+	}
+	switch next.MonitorMode {
+	case "single":
+		return cur.MonitorIndex != next.MonitorIndex
+	case "combined", MONITOR_MODE_ALL:
+		return cur.CombinedLayout != next.CombinedLayout
+	case MONITOR_MODE_WINDOW:
+		return cur.WindowTitle != next.WindowTitle || cur.WindowProcess != next.WindowProcess
+	}
+	return false
+}
+
+// applyMonitorConfig 함수는 next 의 모니터 모드를 UI/원격 명령과 같은 전환 경로로 적용합니다.
+func (a *Agent) applyMonitorConfig(next *config.Config) error { // 단일 책임: 모니터 모드 적용
+	switch next.MonitorMode {
+	case "combined":
+		return a.SetCombinedLayout(next.CombinedLayout)
+	case MONITOR_MODE_ALL:
+		a.capMu.Lock()
+		a.cfg.CombinedLayout = next.CombinedLayout
+		a.capMu.Unlock()
+		a.SetAllMonitorsMode()
+		return nil
+	case MONITOR_MODE_WINDOW:
+		return a.SelectWindow(next.WindowTitle, next.WindowProcess)
+	}
+	if !a.SelectSingleMonitor(next.MonitorIndex) {
+		return fmt.Errorf("모니터 인덱스 범위 오류: %d", next.MonitorIndex)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package agent
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	CONN_STATE_IDLE         = "idle"         // 연결 시도 전
+	CONN_STATE_CONNECTING   = "connecting"   // 백그라운드 연결 시도 중
+	CONN_STATE_READY        = "ready"        // 연결 및 스트림 준비 완료
+	CONN_STATE_RECONNECTING = "reconnecting" // 연결이 끊겨 다시 연결 중
+	CONN_STATE_OFFLINE      = "offline"      // 모든 서버 주소 연결 실패 - 백오프 후 계속 재시도
+	CONN_STATE_FAILED       = "failed"       // 재시도 소진으로 연결 실패
+	CONN_STATE_UNREACHABLE  = "unreachable"  // 연결은 유지되나 Heartbeat 가 연속 실패
+)
+
+// ConnectionStatus 구조체는 서버 연결 준비 상태입니다.
+type ConnectionStatus struct {
+	State      string `json:"state"`      // idle | connecting | ready | reconnecting | offline | failed | unreachable
+	Error      string `json:"error"`      // 마지막 실패 사유 (없으면 빈 값)
+	Since      int64  `json:"since"`      // 현재 상태 진입 시각 (ms)
+	Server     string `json:"server"`     // 현재 접속 대상 서버 주소
+	Spooling   bool   `json:"spooling"`   // 프레임/이벤트를 오프라인 스풀에 보관 중 (재전송 완료 시 false)
+	Reconnects int    `json:"reconnects"` // 시작 후 연결 재구성 횟수
+}
+
+// connTracker 구조체는 연결 상태를 보관하고 변경 시 리스너에게 알립니다.
+type connTracker struct { // 단일 책임: 연결 상태 보관 + 통지
+	mu       sync.Mutex
+	status   ConnectionStatus
+	listener func(ConnectionStatus)
+}
+
+// set 함수는 상태를 갱신하고 리스너를 호출합니다 (리스너는 잠금 밖에서 호출).
+func (t *connTracker) set(state, errMsg string) { // 단일 책임: 상태 갱신
+	t.mu.Lock()
+	t.status.State, t.status.Error, t.status.Since = state, errMsg, time.Now().UnixMilli()
+	st, l := t.status, t.listener
+	t.mu.Unlock()
+	if l != nil {
+		l(st)
+	}
+}
+
+// note 함수는 상태가 바뀔 때만 리스너를 호출합니다. 같은 상태면 실패 사유만 갱신합니다 (재시도마다 통지하지 않음).
+func (t *connTracker) note(state, errMsg string) { // 단일 책임: 변경 시에만 상태 통지
+	t.mu.Lock()
+	if t.status.State == state {
+		t.status.Error = errMsg
+		t.mu.Unlock()
+		return
+	}
+	t.mu.Unlock()
+	t.set(state, errMsg)
+}
+
+// setSpooling 함수는 오프라인 스풀 보관 여부를 갱신하고, 바뀌었으면 리스너를 호출합니다.
+func (t *connTracker) setSpooling(on bool) { // 단일 책임: 스풀 보관 여부 통지
+	t.mu.Lock()
+	if t.status.Spooling == on {
+		t.mu.Unlock()
+		return
+	}
+	t.status.Spooling = on
+	st, l := t.status, t.listener
+	t.mu.Unlock()
+	if l != nil {
+		l(st)
+	}
+}
+
+// reconnecting 함수는 재연결 상태로 바꾸고 재구성 횟수를 늘립니다.
+func (t *connTracker) reconnecting(reason string) { // 단일 책임: 재연결 시작 기록
+	t.mu.Lock()
+	t.status.Reconnects++
+	t.mu.Unlock()
+	t.set(CONN_STATE_RECONNECTING, reason)
+}
+
+// transition 함수는 현재 상태가 from 일 때만 to 로 바꾸고 리스너를 호출합니다. 바꿨으면 true 를 반환합니다.
+func (t *connTracker) transition(from, to, errMsg string) bool { // 단일 책임: 조건부 상태 갱신
+	t.mu.Lock()
+	if t.status.State != from {
+		t.mu.Unlock()
+		return false
+	}
+	t.status.State, t.status.Error, t.status.Since = to, errMsg, time.Now().UnixMilli()
+	st, l := t.status, t.listener
+	t.mu.Unlock()
+	if l != nil {
+		l(st)
+	}
+	return true
+}
+
+// setServer 함수는 현재 접속 대상 서버 주소를 바꿉니다 (다음 상태 통지에 반영).
+func (t *connTracker) setServer(addr string) { // 단일 책임: 서버 주소 갱신
+	t.mu.Lock()
+	t.status.Server = addr
+	t.mu.Unlock()
+}
+
+// get 함수는 현재 상태를 반환합니다.
+func (t *connTracker) get() ConnectionStatus { // 단일 책임: 상태 조회
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.status
+}
+
+// SetConnectionListener 함수는 연결 상태 변경 시 호출될 함수를 등록합니다 (Init 전에 등록).
+func (a *Agent) SetConnectionListener(fn func(ConnectionStatus)) { // 단일 책임: 리스너 등록
+	a.conn.mu.Lock()
+	a.conn.listener = fn
+	a.conn.mu.Unlock()
+}
+
+// ConnectionStatus 함수는 현재 서버 연결 준비 상태를 반환합니다.
+func (a *Agent) ConnectionStatus() ConnectionStatus { // 단일 책임: 연결 상태 노출
+	return a.conn.get()
+}
@@ -0,0 +1,158 @@
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"agent/internal/config"
+	"agent/internal/i18n"
+	monitorProto "agent/proto"
+)
+
+const (
+	CONSENT_ACCEPTED_EVENT = "consent_accepted" // 캡처 동의 기록 (user, policy)
+	CONSENT_REVOKED_EVENT  = "consent_revoked"  // 캡처 동의 철회 (policy, 진행 중 캡처는 중지)
+)
+
+// errConsentRequired 변수는 현재 정책 버전에 대한 동의 없이 캡처 시작을 요청했을 때의 오류입니다.
+var errConsentRequired = i18n.NewError("error.consent_required")
+
+// ConsentRecord 구조체는 consent.json 에 남기는 동의 기록입니다.
+type ConsentRecord struct {
+	User          string `json:"user"`          // 동의한 OS 사용자
+	AcceptedAt    int64  `json:"acceptedAt"`    // 동의 시각 (Unix ms)
+	PolicyVersion string `json:"policyVersion"` // 동의한 정책 버전
+}
+
+// ConsentStatus 구조체는 UI 와 CLI 에 보일 동의 상태입니다.
+type ConsentStatus struct {
+	Required      bool   `json:"required"`      // 동의가 있어야 캡처 시작 (CONSENT_REQUIRED)
+	Granted       bool   `json:"granted"`       // 현재 정책 버전에 대한 동의 기록 있음
+	User          string `json:"user"`          // 기록된 동의 사용자 (없으면 빈 값)
+	AcceptedAt    int64  `json:"acceptedAt"`    // 기록된 동의 시각 (Unix ms, 없으면 0)
+	PolicyVersion string `json:"policyVersion"` // 기록된 동의의 정책 버전 (CurrentPolicy 와 다르면 다시 동의 필요)
+	CurrentPolicy string `json:"currentPolicy"` // 동의 대상 정책 버전 (CONSENT_POLICY_VERSION)
+}
+
+// consentState 구조체는 실행 중 동의 기록입니다 (New 에서 파일을 읽고, 수락/철회 시 파일과 함께 갱신).
+type consentState struct { // 단일 책임: 동의 기록 보관
+	mu  sync.Mutex
+	rec ConsentRecord
+}
+
+// ReadConsent 함수는 consent.json 의 동의 기록을 읽습니다. 없거나 깨졌으면 빈 기록입니다.
+func ReadConsent() ConsentRecord { // 단일 책임: 동의 기록 로드
+	var rec ConsentRecord
+	if data, err := os.ReadFile(config.ConsentPath()); err == nil {
+		_ = json.Unmarshal(data, &rec)
+	}
+	return rec
+}
+
+// RecordConsent 함수는 현재 OS 사용자의 policy 버전 동의를 지금 시각으로 consent.json 에 기록합니다.
+func RecordConsent(policy string) (ConsentRecord, error) { // 단일 책임: 동의 기록 저장
+	rec := ConsentRecord{User: consentUser(), AcceptedAt: time.Now().UnixMilli(), PolicyVersion: policy}
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return ConsentRecord{}, err
+	}
+	path := config.ConsentPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return ConsentRecord{}, err
+	}
+	if err := os.WriteFile(path+".tmp", data, 0o600); err != nil {
+		return ConsentRecord{}, err
+	}
+	return rec, os.Rename(path+".tmp", path)
+}
+
+// ClearConsent 함수는 consent.json 을 지웁니다 (없으면 무동작).
+func ClearConsent() error { // 단일 책임: 동의 기록 삭제
+	if err := os.Remove(config.ConsentPath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// consentUser 함수는 동의 기록에 남길 OS 사용자 이름을 반환합니다.
+func consentUser() string { // 단일 책임: 동의 사용자 결정
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
+
+// Consent 메서드는 동의 필요 여부와 기록된 동의를 반환합니다.
+func (a *Agent) Consent() ConsentStatus { // 단일 책임: 동의 상태 조회
+	a.consent.mu.Lock()
+	rec := a.consent.rec
+	a.consent.mu.Unlock()
+	policy := a.cfg.ConsentPolicyVersion
+	return ConsentStatus{
+		Required:      a.cfg.ConsentRequired,
+		Granted:       rec.AcceptedAt > 0 && rec.PolicyVersion == policy,
+		User:          rec.User,
+		AcceptedAt:    rec.AcceptedAt,
+		PolicyVersion: rec.PolicyVersion,
+		CurrentPolicy: policy,
+	}
+}
+
+// consentMissing 함수는 동의가 필요한데 현재 정책 버전에 대한 동의가 없는지 반환합니다.
+func (a *Agent) consentMissing() bool { // 단일 책임: 캡처 동의 확인
+	st := a.Consent()
+	return st.Required && !st.Granted
+}
+
+// AcceptConsent 메서드는 현재 사용자의 현재 정책 버전 동의를 기록하고 consent_accepted 이벤트를 발행합니다.
+func (a *Agent) AcceptConsent() (ConsentStatus, error) { // 단일 책임: 캡처 동의 수락
+	rec, err := RecordConsent(a.cfg.ConsentPolicyVersion)
+	if err != nil {
+		return a.Consent(), err
+	}
+	a.consent.mu.Lock()
+	a.consent.rec = rec
+	a.consent.mu.Unlock()
+	a.logger.Infow("캡처 동의 기록", "user", rec.User, "policy", rec.PolicyVersion)
+	a.emitEvent(CONSENT_ACCEPTED_EVENT, fmt.Sprintf("user=%s policy=%s", rec.User, rec.PolicyVersion))
+	return a.Consent(), nil
+}
+
+// RevokeConsent 메서드는 동의 기록을 지우고, 동의가 필요하면 진행 중인 캡처를 중지한 뒤 consent_revoked 이벤트를 발행합니다.
+func (a *Agent) RevokeConsent() error { // 단일 책임: 캡처 동의 철회
+	if err := ClearConsent(); err != nil {
+		return err
+	}
+	a.consent.mu.Lock()
+	policy := a.consent.rec.PolicyVersion
+	a.consent.rec = ConsentRecord{}
+	a.consent.mu.Unlock()
+	if a.cfg.ConsentRequired {
+		a.StopCapture()
+	}
+	a.logger.Infow("캡처 동의 철회", "policy", policy)
+	a.emitEvent(CONSENT_REVOKED_EVENT, "policy="+policy)
+	return nil
+}
+
+// consentInfo 함수는 등록 요청에 넣을 동의 상태를 만듭니다.
+func (a *Agent) consentInfo() *monitorProto.ConsentInfo { // 단일 책임: 등록용 동의 정보 생성
+	st := a.Consent()
+	return &monitorProto.ConsentInfo{
+		Required:      st.Required,
+		Granted:       st.Granted,
+		User:          st.User,
+		AcceptedAt:    st.AcceptedAt,
+		PolicyVersion: st.PolicyVersion,
+		CurrentPolicy: st.CurrentPolicy,
+	}
+}
@@ -0,0 +1,369 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	monitorProto "agent/proto"
+
+	grpcPkg "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	CONTROL_HELLO_MESSAGE = "control channel opened" // 제어 채널 개시 메시지
+	COMMAND_EVENT         = "remote_command"         // 원격 명령 처리 감사 이벤트
+
+	CMD_START_CAPTURE    = "start_capture"       // 캡처 시작
+	CMD_STOP_CAPTURE     = "stop_capture"        // 캡처 중지
+	CMD_SET_FPS          = "set_fps"             // 목표 FPS 변경 (args: fps)
+	CMD_SELECT_MONITOR   = "select_monitor"      // 모니터 선택 (args: index 또는 "combined"/"all", combined 는 선택적 layout)
+	CMD_LIST_WINDOWS     = "list_windows"        // 캡처 가능한 창 목록 응답 (data: WindowInfo JSON 배열)
+	CMD_SELECT_WINDOW    = "select_window"       // 창 캡처 대상 선택 (args: title 부분 일치, process 이름 중 하나 이상)
+	CMD_SCREENSHOT       = "screenshot"          // 단일 화면 캡처 응답 (args: encoding 선택)
+	CMD_CAPTURE_NOW      = "capture_now"         // 원본 해상도 무손실 PNG 즉시 캡처 응답
+	CMD_START_RECORDING  = "start_recording"     // 로컬 녹화 시작 (args: minutes)
+	CMD_STOP_RECORDING   = "stop_recording"      // 로컬 녹화 종료 + 업로드
+	CMD_UPLOAD_RECORDING = "upload_recording"    // 저장된 녹화 업로드 (args: 선택적 recording_id, 없으면 업로드되지 않은 전체)
+	CMD_DIAGNOSTICS      = "collect_diagnostics" // 진단 번들 생성 + 업로드
+	CMD_SET_LOG_LEVEL    = "set_log_level"       // 로그 수준 변경 (args: level, 재시작 시 설정 값으로 복귀)
+	CMD_WRITE_PROFILE    = "write_profile"       // 프로파일 수집 응답 (args: kind, cpu 는 선택적 seconds, data: pprof 형식)
+
+	CMD_ARG_COMBINED = "combined" // select_monitor 의 combined 모드 지정 값
+	CMD_ARG_ALL      = "all"      // select_monitor 의 all(모니터별 동시 캡처) 모드 지정 값
+)
+
+// commandHandler 함수 타입은 원격 명령 하나를 처리합니다. 결과 데이터는 ack 에 채우고 실패 시 오류를 반환합니다.
+type commandHandler func(a *Agent, args map[string]string, ack *monitorProto.CommandAck) error
+
+// commandHandlers 변수는 명령 타입별 처리기 목록입니다.
+var commandHandlers = map[string]commandHandler{
+	CMD_START_CAPTURE: func(a *Agent, _ map[string]string, _ *monitorProto.CommandAck) error {
+		return a.StartCapture()
+	},
+	CMD_STOP_CAPTURE: func(a *Agent, _ map[string]string, _ *monitorProto.CommandAck) error {
+		a.StopCapture()
+		return nil
+	},
+	CMD_SET_FPS: func(a *Agent, args map[string]string, ack *monitorProto.CommandAck) error {
+		fps, err := commandIntArg(args, "fps")
+		if err != nil {
+			return err
+		}
+		if err := a.SetTargetFPS(fps); err != nil {
+			return err
+		}
+		ack.Message = fmt.Sprintf("fps=%d", fps)
+		return nil
+	},
+	CMD_SELECT_MONITOR: func(a *Agent, args map[string]string, ack *monitorProto.CommandAck) error {
+		if args["index"] == CMD_ARG_COMBINED {
+			if layout := args["layout"]; layout != "" {
+				return a.SetCombinedLayout(layout)
+			}
+			a.SetCombinedMode()
+			return nil
+		}
+		if args["index"] == CMD_ARG_ALL {
+			a.SetAllMonitorsMode()
+			return nil
+		}
+		idx, err := commandIntArg(args, "index")
+		if err != nil {
+			return err
+		}
+		if !a.SelectSingleMonitor(idx) {
+			return fmt.Errorf("모니터 인덱스 범위 오류: %d", idx)
+		}
+		return nil
+	},
+	CMD_LIST_WINDOWS: func(a *Agent, _ map[string]string, ack *monitorProto.CommandAck) error {
+		list, err := a.ListWindows()
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(list)
+		if err != nil {
+			return err
+		}
+		ack.Data, ack.Encoding = data, "json"
+		ack.Message = fmt.Sprintf("windows=%d", len(list))
+		return nil
+	},
+	CMD_SELECT_WINDOW: func(a *Agent, args map[string]string, _ *monitorProto.CommandAck) error {
+		return a.SelectWindow(args["title"], args["process"])
+	},
+	CMD_SCREENSHOT: func(a *Agent, args map[string]string, ack *monitorProto.CommandAck) error {
+		data, enc, err := a.takeScreenshot(args["encoding"])
+		if err != nil {
+			return err
+		}
+		ack.Data, ack.Encoding = data, enc
+		ack.Message = fmt.Sprintf("bytes=%d", len(data))
+		return nil
+	},
+	CMD_CAPTURE_NOW: func(a *Agent, _ map[string]string, ack *monitorProto.CommandAck) error {
+		shot, err := a.CaptureNow()
+		if err != nil {
+			return err
+		}
+		ack.Data, ack.Encoding = shot.Data, shot.Encoding
+		ack.Message = fmt.Sprintf("bytes=%d size=%dx%d", len(shot.Data), shot.Width, shot.Height)
+		return nil
+	},
+	CMD_START_RECORDING: func(a *Agent, args map[string]string, ack *monitorProto.CommandAck) error {
+		minutes, err := commandIntArg(args, "minutes")
+		if err != nil {
+			return err
+		}
+		id, err := a.StartRecording(minutes)
+		ack.Message = id
+		return err
+	},
+	CMD_STOP_RECORDING: func(a *Agent, _ map[string]string, ack *monitorProto.CommandAck) error {
+		id, err := a.StopRecording()
+		ack.Message = id
+		return err
+	},
+	CMD_UPLOAD_RECORDING: func(a *Agent, args map[string]string, ack *monitorProto.CommandAck) error {
+		n, err := a.UploadRecording(args["recording_id"])
+		ack.Message = fmt.Sprintf("uploaded=%d", n)
+		return err
+	},
+	CMD_DIAGNOSTICS: func(a *Agent, _ map[string]string, ack *monitorProto.CommandAck) error {
+		path, err := a.CollectDiagnostics("", true)
+		ack.Message = filepath.Base(path)
+		return err
+	},
+	CMD_SET_LOG_LEVEL: func(a *Agent, args map[string]string, ack *monitorProto.CommandAck) error {
+		if err := a.SetLogLevel(args["level"]); err != nil {
+			return err
+		}
+		ack.Message = "level=" + args["level"]
+		return nil
+	},
+	CMD_WRITE_PROFILE: func(a *Agent, args map[string]string, ack *monitorProto.CommandAck) error {
+		seconds := 0
+		if _, ok := args["seconds"]; ok {
+			n, err := commandIntArg(args, "seconds")
+			if err != nil {
+				return err
+			}
+			seconds = n
+		}
+		path, err := a.WriteProfile(args["kind"], seconds)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		ack.Data, ack.Encoding = data, "pprof"
+		ack.Message = fmt.Sprintf("file=%s bytes=%d", filepath.Base(path), len(data))
+		return nil
+	},
+}
+
+// SupportedCommands 함수는 처리 가능한 원격 명령 타입 목록을 정렬해 반환합니다.
+func SupportedCommands() []string { // 단일 책임: 명령 목록 노출
+	res := make([]string, 0, len(commandHandlers))
+	for k := range commandHandlers {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// commandIntArg 함수는 명령 인자를 정수로 변환합니다.
+func commandIntArg(args map[string]string, key string) (int, error) { // 단일 책임: 인자 파싱
+	v, ok := args[key]
+	if !ok {
+		return 0, fmt.Errorf("인자 누락: %s", key)
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("인자 %s 형식 오류: %q", key, v)
+	}
+	return n, nil
+}
+
+// openControlStream 함수는 현재 연결로 원격 제어 채널 수신 고루틴을 시작합니다.
+func (a *Agent) openControlStream() { // 단일 책임: 제어 채널 시작
+	a.mu.Lock()
+	client, conn := a.agentClient, a.grpcConn
+	a.mu.Unlock()
+	if client == nil {
+		return
+	}
+	go a.guardLoop(LOOP_CONTROL, func() { a.runControl(client, conn) })
+}
+
+// runControl 함수는 제어 채널을 열어 명령을 처리하고, 끊기면 같은 연결에서 백오프로 다시 엽니다.
+// 연결이 교체되었거나(재연결이 새 채널을 염) 서버가 Control 을 지원하지 않으면 반환합니다.
+func (a *Agent) runControl(client monitorProto.AgentServiceClient, conn *grpcPkg.ClientConn) { // 단일 책임: 제어 채널 유지
+	bo := newBackoff(a.cfg)
+	for {
+		stream, err := client.Control(a.ctx)
+		if err == nil {
+			err = stream.Send(&monitorProto.CommandAck{AgentId: a.agentID, Success: true, Message: CONTROL_HELLO_MESSAGE, Timestamp: time.Now().UnixMilli()})
+		}
+		if err == nil {
+			a.logger.Infow("제어 채널 생성", "agent_id", a.agentID)
+			bo.reset()
+			err = a.serveControl(stream)
+		}
+		if a.closing.Load() || a.ctx.Err() != nil {
+			return
+		}
+		if status.Code(err) == codes.Unimplemented {
+			a.logger.Info("서버가 원격 제어 채널을 지원하지 않음")
+			return
+		}
+		a.mu.Lock()
+		current := a.agentClient
+		a.mu.Unlock()
+		if current != client { // 재연결이 새 연결에서 채널을 다시 염
+			return
+		}
+		a.logger.Warnf("제어 채널 끊김: %v", err)
+		if a.requestReconnect(conn, "control stream failed: "+err.Error()) {
+			return
+		}
+		if werr := bo.wait(a.ctx); werr != nil {
+			return
+		}
+	}
+}
+
+// serveControl 함수는 채널이 끊길 때까지 명령을 받아 순서대로 처리하고 응답합니다.
+func (a *Agent) serveControl(stream monitorProto.AgentService_ControlClient) error { // 단일 책임: 명령 수신 + 응답
+	for {
+		cmd, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(a.dispatchCommand(cmd)); err != nil {
+			return err
+		}
+	}
+}
+
+// dispatchCommand 함수는 명령 타입에 맞는 처리기를 실행하고 응답을 구성합니다.
+func (a *Agent) dispatchCommand(cmd *monitorProto.AgentCommand) *monitorProto.CommandAck { // 단일 책임: 명령 분배
+	ack := &monitorProto.CommandAck{AgentId: a.agentID, CommandId: cmd.GetCommandId()}
+	var err error
+	if handler, ok := commandHandlers[cmd.GetType()]; ok {
+		err = handler(a, cmd.GetArgs(), ack)
+	} else {
+		err = fmt.Errorf("알 수 없는 명령: %q", cmd.GetType())
+	}
+	ack.Success = err == nil
+	if err != nil {
+		ack.Message = err.Error()
+	}
+	ack.Timestamp = time.Now().UnixMilli()
+	a.logger.Infow("원격 명령 처리", "command_id", cmd.GetCommandId(), "type", cmd.GetType(), "success", ack.Success, "message", ack.Message)
+	a.emitEvent(COMMAND_EVENT, fmt.Sprintf("type=%s command_id=%s success=%t", cmd.GetType(), cmd.GetCommandId(), ack.Success))
+	if a.onCommand != nil {
+		a.onCommand(RemoteCommand{Type: cmd.GetType(), CommandID: cmd.GetCommandId(), Success: ack.Success, Message: ack.Message})
+	}
+	return ack
+}
+
+// RemoteCommand 구조체는 처리를 마친 원격 명령 요약입니다 (사용자 알림용).
+type RemoteCommand struct {
+	Type      string `json:"type"`      // 명령 타입 (CMD_*)
+	CommandID string `json:"commandId"` // 서버 명령 ID
+	Success   bool   `json:"success"`   // 처리 성공 여부
+	Message   string `json:"message"`   // 실패 사유
+}
+
+// SetCommandListener 메서드는 원격 명령을 처리할 때마다 호출될 함수를 등록합니다 (Init 전에 등록).
+func (a *Agent) SetCommandListener(fn func(RemoteCommand)) { // 단일 책임: 리스너 등록
+	a.onCommand = fn
+}
+
+// Screenshot 구조체는 스트리밍과 별개로 즉시 캡처한 단일 프레임입니다.
+type Screenshot struct {
+	Data      []byte `json:"data"`      // 인코딩된 이미지 (JSON 에서는 base64)
+	Encoding  string `json:"encoding"`  // png | jpeg | webp
+	Width     int    `json:"width"`     // 원본 해상도 폭
+	Height    int    `json:"height"`    // 원본 해상도 높이
+	Timestamp int64  `json:"timestamp"` // 캡처 시각 (unix ms)
+}
+
+// CaptureNow 메서드는 현재 캡처 대상을 원본 해상도 무손실 PNG 로 한 장 캡처합니다.
+// 캡처 루프 실행 여부, 축소/품질 단계, 회색조 설정과 무관하며 가림 영역/금지 앱/워터마크는 그대로 적용합니다.
+func (a *Agent) CaptureNow() (Screenshot, error) { // 단일 책임: 최고 품질 즉시 캡처
+	return a.captureStill(func(o *encodeOptions) {
+		o.encoding, o.grayscale, o.pngLevel = "png", false, int(png.DefaultCompression)
+	})
+}
+
+// takeScreenshot 함수는 현재 캡처러로 한 장을 캡처해 encoding(png|jpeg|webp, 빈 값이면 설정값)으로 인코딩합니다.
+// delta/tiles 는 프레임 간 상태가 필요하므로 PNG 로 대체합니다.
+func (a *Agent) takeScreenshot(encoding string) ([]byte, string, error) { // 단일 책임: 단일 화면 캡처
+	shot, err := a.captureStill(func(o *encodeOptions) {
+		if encoding != "" {
+			o.encoding = encoding
+		}
+		if o.encoding != "jpeg" && o.encoding != ENCODING_WEBP {
+			o.encoding = "png"
+		}
+	})
+	return shot.Data, shot.Encoding, err
+}
+
+// captureStill 함수는 현재 캡처러로 한 장을 캡처해 adjust 로 조정한 인코딩 옵션으로 인코딩합니다.
+// 캡처 루프와 같은 조건(동의, 일시 중지, 캡처 일정)을 지켜 원격 명령이나 UI 의 단일 캡처로 우회할 수 없게 합니다.
+func (a *Agent) captureStill(adjust func(*encodeOptions)) (Screenshot, error) { // 단일 책임: 단일 프레임 캡처 + 인코딩
+	if err := a.captureBlocked(); err != nil {
+		return Screenshot{}, err
+	}
+	a.capMu.RLock()
+	capt := a.capturer
+	a.capMu.RUnlock()
+	block := a.privacyBlock()
+	if block == BLOCKED_ACTION_PAUSE {
+		return Screenshot{}, errCapturePaused
+	}
+	shot := Screenshot{Timestamp: time.Now().UnixMilli()}
+	rc, ok := capt.(rawCapturer)
+	if !ok { // 더미 캡처러는 PNG 만 생성
+		b, err := capt.Capture()
+		if err != nil {
+			return Screenshot{}, err
+		}
+		if c, err := png.DecodeConfig(bytes.NewReader(b)); err == nil {
+			shot.Width, shot.Height = c.Width, c.Height
+		}
+		shot.Data, shot.Encoding = b, "png"
+		return shot, nil
+	}
+	opts := rc.options()
+	adjust(&opts)
+	img, err := rc.grab()
+	if err != nil {
+		return Screenshot{}, err
+	}
+	defer rc.release(img)
+	a.masks.Load().apply(img, capturerMonitorID(capt))
+	if block == BLOCKED_ACTION_BLANK {
+		blankImage(img)
+	}
+	a.watermark.apply(img, time.UnixMilli(shot.Timestamp), capturerMonitorID(capt))
+	if shot.Data, err = opts.encode(img); err != nil {
+		return Screenshot{}, err
+	}
+	shot.Encoding, shot.Width, shot.Height = opts.encoding, img.Bounds().Dx(), img.Bounds().Dy()
+	return shot, nil
+}
@@ -0,0 +1,79 @@
+package agent
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"agent/internal/config"
+
+	"go.uber.org/zap"
+)
+
+const (
+	CPU_LOW_PRIORITY_NICE = 10 // 저우선순위 모드의 unix nice 값
+)
+
+// applyCPUBudget 함수는 설정된 CPU 예산(GOMAXPROCS, 프로세스 우선순위, 효율 코어/친화성)을 프로세스에 적용합니다.
+// 실패한 항목은 경고만 남기고 계속 진행합니다.
+func applyCPUBudget(cfg *config.Config, logger *zap.SugaredLogger) { // 단일 책임: CPU 예산 적용
+	if cfg.CPUMaxProcs > 0 {
+		prev := runtime.GOMAXPROCS(cfg.CPUMaxProcs)
+		logger.Infow("GOMAXPROCS 제한 적용", "from", prev, "to", cfg.CPUMaxProcs)
+	}
+	if cfg.CPULowPriority {
+		if err := lowerProcessPriority(); err != nil {
+			logger.Warnf("프로세스 우선순위 낮추기 실패: %v", err)
+		} else {
+			logger.Info("프로세스 우선순위 낮춤")
+		}
+	}
+	if cfg.CPUEfficiencyMode {
+		if err := enableEfficiencyMode(); err != nil {
+			logger.Warnf("효율 모드 적용 실패: %v", err)
+		} else {
+			logger.Info("효율(에너지 절약) 코어 우선 모드 적용")
+		}
+	}
+	if cfg.CPUAffinity != "" {
+		cpus, err := parseCPUList(cfg.CPUAffinity)
+		if err == nil {
+			err = setCPUAffinity(cpus)
+		}
+		if err != nil {
+			logger.Warnf("CPU 친화성 적용 실패 (%s): %v", cfg.CPUAffinity, err)
+		} else {
+			logger.Infow("CPU 친화성 적용", "cpus", cpus)
+		}
+	}
+}
+
+// parseCPUList 함수는 "0,2,4-7" 형식의 CPU 목록을 파싱합니다.
+func parseCPUList(s string) ([]int, error) { // 단일 책임: CPU 목록 파싱
+	var cpus []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		lo, hi, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(lo))
+		if err != nil {
+			return nil, fmt.Errorf("잘못된 CPU 번호: %q", part)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(strings.TrimSpace(hi)); err != nil || end < start {
+				return nil, fmt.Errorf("잘못된 CPU 범위: %q", part)
+			}
+		}
+		for c := start; c <= end; c++ {
+			cpus = append(cpus, c)
+		}
+	}
+	if len(cpus) == 0 {
+		return nil, fmt.Errorf("빈 CPU 목록")
+	}
+	return cpus, nil
+}
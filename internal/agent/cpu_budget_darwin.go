@@ -0,0 +1,29 @@
+//go:build darwin
+
+package agent
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	PRIO_DARWIN_PROCESS = 4      // setpriority which: 프로세스 단위 darwin 정책
+	PRIO_DARWIN_BG      = 0x1000 // 백그라운드 밴드 (Apple Silicon 에서 효율 코어 우선 배치)
+)
+
+// lowerProcessPriority 함수는 프로세스 nice 값을 높여 스케줄링 우선순위를 낮춥니다.
+func lowerProcessPriority() error { // 단일 책임: 우선순위 하향
+	return unix.Setpriority(unix.PRIO_PROCESS, 0, CPU_LOW_PRIORITY_NICE)
+}
+
+// enableEfficiencyMode 함수는 프로세스를 백그라운드 밴드로 옮겨 효율 코어 위주로 실행되게 합니다.
+func enableEfficiencyMode() error { // 단일 책임: 효율 모드 적용
+	return unix.Setpriority(PRIO_DARWIN_PROCESS, 0, PRIO_DARWIN_BG)
+}
+
+// setCPUAffinity 함수는 macOS 가 CPU 고정을 지원하지 않음을 알립니다.
+func setCPUAffinity(cpus []int) error { // 단일 책임: CPU 친화성 (미지원)
+	return fmt.Errorf("macOS 는 CPU 친화성 설정을 지원하지 않음 (CPU_EFFICIENCY_MODE 사용)")
+}
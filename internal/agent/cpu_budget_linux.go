@@ -0,0 +1,29 @@
+//go:build linux
+
+package agent
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// lowerProcessPriority 함수는 프로세스 nice 값을 높여 스케줄링 우선순위를 낮춥니다.
+func lowerProcessPriority() error { // 단일 책임: 우선순위 하향
+	return unix.Setpriority(unix.PRIO_PROCESS, 0, CPU_LOW_PRIORITY_NICE)
+}
+
+// enableEfficiencyMode 함수는 Linux 에서 효율 코어 지정을 지원하지 않으므로 CPU_AFFINITY 사용을 안내합니다.
+func enableEfficiencyMode() error { // 단일 책임: 효율 모드 (미지원)
+	return fmt.Errorf("linux 는 CPU_AFFINITY 로 효율 코어를 직접 지정하세요")
+}
+
+// setCPUAffinity 함수는 프로세스를 지정한 CPU 집합에 고정합니다.
+func setCPUAffinity(cpus []int) error { // 단일 책임: CPU 친화성 설정
+	var set unix.CPUSet
+	set.Zero()
+	for _, c := range cpus {
+		set.Set(c)
+	}
+	return unix.SchedSetaffinity(0, &set)
+}
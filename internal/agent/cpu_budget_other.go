@@ -0,0 +1,20 @@
+//go:build !linux && !darwin && !windows
+
+package agent
+
+import "fmt"
+
+// lowerProcessPriority 함수는 미지원 OS 임을 알립니다.
+func lowerProcessPriority() error { // 단일 책임: 우선순위 하향 (미지원)
+	return fmt.Errorf("지원하지 않는 OS")
+}
+
+// enableEfficiencyMode 함수는 미지원 OS 임을 알립니다.
+func enableEfficiencyMode() error { // 단일 책임: 효율 모드 (미지원)
+	return fmt.Errorf("지원하지 않는 OS")
+}
+
+// setCPUAffinity 함수는 미지원 OS 임을 알립니다.
+func setCPUAffinity(cpus []int) error { // 단일 책임: CPU 친화성 (미지원)
+	return fmt.Errorf("지원하지 않는 OS")
+}
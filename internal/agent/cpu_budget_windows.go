@@ -0,0 +1,70 @@
+//go:build windows
+
+package agent
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	PROCESS_POWER_THROTTLING_INFO_CLASS      = 4   // ProcessPowerThrottling (PROCESS_INFORMATION_CLASS)
+	PROCESS_POWER_THROTTLING_CURRENT_VERSION = 1   // 구조체 버전
+	PROCESS_POWER_THROTTLING_EXECUTION_SPEED = 0x1 // EcoQoS (효율 코어 우선, 클럭 절감)
+)
+
+// processPowerThrottlingState 구조체는 PROCESS_POWER_THROTTLING_STATE 입니다.
+type processPowerThrottlingState struct {
+	Version     uint32
+	ControlMask uint32
+	StateMask   uint32
+}
+
+// procSetProcessInformation 변수는 Windows 8+ kernel32 SetProcessInformation 입니다.
+var procSetProcessInformation = windows.NewLazySystemDLL("kernel32.dll").NewProc("SetProcessInformation")
+
+// lowerProcessPriority 함수는 프로세스 우선순위 클래스를 BELOW_NORMAL 로 낮춥니다.
+func lowerProcessPriority() error { // 단일 책임: 우선순위 하향
+	return windows.SetPriorityClass(windows.CurrentProcess(), windows.BELOW_NORMAL_PRIORITY_CLASS)
+}
+
+// enableEfficiencyMode 함수는 EcoQoS(실행 속도 제한)를 켜서 효율 코어 위주로 실행되게 합니다 (Windows 11 효율 모드).
+func enableEfficiencyMode() error { // 단일 책임: 효율 모드 적용
+	if err := procSetProcessInformation.Find(); err != nil {
+		return err
+	}
+	state := processPowerThrottlingState{
+		Version:     PROCESS_POWER_THROTTLING_CURRENT_VERSION,
+		ControlMask: PROCESS_POWER_THROTTLING_EXECUTION_SPEED,
+		StateMask:   PROCESS_POWER_THROTTLING_EXECUTION_SPEED,
+	}
+	r, _, err := procSetProcessInformation.Call(uintptr(windows.CurrentProcess()), PROCESS_POWER_THROTTLING_INFO_CLASS, uintptr(unsafe.Pointer(&state)), unsafe.Sizeof(state))
+	if r == 0 {
+		return err
+	}
+	return nil
+}
+
+// setCPUAffinity 함수는 프로세스 친화성 마스크를 지정한 CPU 집합으로 설정합니다 (최대 64 CPU).
+func setCPUAffinity(cpus []int) error { // 단일 책임: CPU 친화성 설정
+	var mask uintptr
+	for _, c := range cpus {
+		if c >= 0 && c < int(unsafe.Sizeof(mask)*8) {
+			mask |= 1 << uint(c)
+		}
+	}
+	return setProcessAffinityMask(windows.CurrentProcess(), mask)
+}
+
+// procSetProcessAffinityMask 변수는 kernel32 SetProcessAffinityMask 입니다.
+var procSetProcessAffinityMask = windows.NewLazySystemDLL("kernel32.dll").NewProc("SetProcessAffinityMask")
+
+// setProcessAffinityMask 함수는 SetProcessAffinityMask 호출 래퍼입니다.
+func setProcessAffinityMask(process windows.Handle, mask uintptr) error { // 단일 책임: Win32 호출
+	r, _, err := procSetProcessAffinityMask.Call(uintptr(process), mask)
+	if r == 0 {
+		return err
+	}
+	return nil
+}
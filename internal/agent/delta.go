@@ -0,0 +1,64 @@
+package agent
+
+import (
+	"crypto/subtle"
+	"image"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	ENCODING_DELTA = "delta" // 프레임 간 XOR 델타 + zstd 무손실 인코딩
+)
+
+// zstdEncoder 변수는 프로세스 공용 zstd 인코더입니다 (EncodeAll 은 동시 호출 안전).
+var zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest), zstd.WithEncoderConcurrency(1))
+
+// deltaEncoder 구조체는 직전 프레임 원본 픽셀을 보관하고 XOR 델타 페이로드를 생성합니다.
+// 상태를 가지므로 캡처 순서대로(captureLoop 고루틴에서) 호출되어야 합니다.
+type deltaEncoder struct { // 단일 책임: 프레임 간 델타 계산
+	keyframeInterval int    // 키프레임 주기 (프레임 수)
+	prev             []byte // 직전 프레임 RGBA 픽셀 (행 패딩 없음)
+	prevW, prevH     int
+	sinceKey         int // 마지막 키프레임 이후 프레임 수
+}
+
+// newDeltaEncoder 함수는 deltaEncoder 생성자입니다.
+func newDeltaEncoder(keyframeInterval int) *deltaEncoder { // 단일 책임: 인스턴스 생성
+	return &deltaEncoder{keyframeInterval: keyframeInterval}
+}
+
+// prepare 함수는 이미지의 델타(또는 키프레임) 페이로드를 만들고 키프레임 여부를 반환합니다.
+// 해상도가 바뀌거나 키프레임 주기에 도달하면 키프레임을 생성합니다.
+func (d *deltaEncoder) prepare(img image.Image) ([]byte, bool) { // 단일 책임: 델타 페이로드 생성
+	rgba := toRGBA(img)
+	b := rgba.Bounds()
+	w, h := b.Dx(), b.Dy()
+	size := w * h * 4
+	if len(d.prev) != size {
+		d.prev = make([]byte, size)
+	}
+	keyframe := d.prevW != w || d.prevH != h || d.sinceKey >= d.keyframeInterval
+	payload := make([]byte, size)
+	for y := 0; y < h; y++ {
+		row := rgba.Pix[rgba.PixOffset(b.Min.X, b.Min.Y+y) : rgba.PixOffset(b.Min.X, b.Min.Y+y)+w*4]
+		off := y * w * 4
+		if keyframe {
+			copy(payload[off:off+w*4], row)
+		} else {
+			subtle.XORBytes(payload[off:off+w*4], row, d.prev[off:off+w*4]) // SIMD 최적화 XOR
+		}
+		copy(d.prev[off:off+w*4], row)
+	}
+	d.prevW, d.prevH = w, h
+	if keyframe {
+		d.sinceKey = 0
+	}
+	d.sinceKey++
+	return payload, keyframe
+}
+
+// compressDelta 함수는 델타 페이로드를 zstd 로 압축합니다 (정적 영역은 0 바이트 연속이라 압축률이 매우 높음).
+func compressDelta(payload []byte) []byte { // 단일 책임: 델타 압축
+	return zstdEncoder.EncodeAll(payload, make([]byte, 0, len(payload)/8))
+}
@@ -0,0 +1,242 @@
+package agent
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"agent/internal/logging"
+	monitorProto "agent/proto"
+
+	"github.com/kbinani/screenshot"
+)
+
+const (
+	DIAG_LOG_LINES          = 300          // 번들에 포함할 최근 로그 라인 수
+	DIAG_DIAL_TIMEOUT_MS    = 3000         // 연결 테스트 TCP 다이얼 타임아웃
+	DIAG_UPLOAD_TIMEOUT_MS  = 15000        // 번들 업로드 타임아웃
+	DIAG_REDACTED_VALUE     = "***"        // 민감 값 대체 문자열
+	DIAG_FILE_PREFIX        = "agent-diag" // 번들 파일명 접두사
+	DIAG_PERMISSION_PROBE_W = 1            // 권한 확인용 테스트 캡처 폭
+	DIAG_PERMISSION_PROBE_H = 1            // 권한 확인용 테스트 캡처 높이
+)
+
+// diagSensitiveKeys 변수는 값이 마스킹되어야 하는 설정 필드명 키워드 목록입니다.
+var diagSensitiveKeys = []string{"token", "secret", "password", "key", "cert"}
+
+// CollectDiagnostics 메서드는 진단 번들(zip)을 생성해 dir 에 저장하고, upload 가 true 이면 서버로 업로드합니다.
+func (a *Agent) CollectDiagnostics(dir string, upload bool) (string, error) { // 단일 책임: 진단 번들 생성/업로드
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	archive, err := a.buildDiagnosticsArchive()
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s-%s-%s.zip", DIAG_FILE_PREFIX, a.agentID, time.Now().Format("20060102-150405"))
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, archive, 0o600); err != nil {
+		return "", err
+	}
+	a.logger.Infow("진단 번들 생성", "path", path, "bytes", len(archive))
+	if !upload {
+		return path, nil
+	}
+	if err := a.uploadDiagnostics(name, archive); err != nil {
+		a.logger.Warnf("진단 번들 업로드 실패: %v", err)
+		return path, err
+	}
+	return path, nil
+}
+
+// uploadDiagnostics 함수는 UploadDiagnostics RPC 로 번들을 전송합니다.
+func (a *Agent) uploadDiagnostics(name string, archive []byte) error { // 단일 책임: 번들 업로드
+	a.mu.Lock()
+	client := a.agentClient
+	a.mu.Unlock()
+	if client == nil {
+		return fmt.Errorf("gRPC 클라이언트 없음")
+	}
+	ctx, cancel := context.WithTimeout(a.ctx, time.Duration(DIAG_UPLOAD_TIMEOUT_MS)*time.Millisecond)
+	defer cancel()
+	ack, err := client.UploadDiagnostics(ctx, &monitorProto.DiagnosticsBundle{
+		AgentId:   a.agentID,
+		FileName:  name,
+		Archive:   archive,
+		Timestamp: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+	if !ack.GetSuccess() {
+		return fmt.Errorf("서버 거부: %s", ack.GetMessage())
+	}
+	a.logger.Infow("진단 번들 업로드 완료", "file", name)
+	return nil
+}
+
+// buildDiagnosticsArchive 함수는 진단 항목을 수집해 zip 바이트로 묶습니다.
+func (a *Agent) buildDiagnosticsArchive() ([]byte, error) { // 단일 책임: 아카이브 구성
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"config.json", redactConfig(a.cfg)},
+		{"metrics.json", a.diagMetrics()},
+		{"monitors.json", a.diagMonitors()},
+		{"permissions.json", diagPermissions()},
+		{"connectivity.json", a.diagConnectivity()},
+	}
+	for _, f := range files {
+		b, err := json.MarshalIndent(f.data, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if err := writeZipEntry(zw, f.name, b); err != nil {
+			return nil, err
+		}
+	}
+	logs := strings.Join(logging.RecentLines(DIAG_LOG_LINES), "\n")
+	if err := writeZipEntry(zw, "logs.txt", []byte(logs)); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeZipEntry 함수는 zip 에 단일 파일 엔트리를 기록합니다.
+func writeZipEntry(zw *zip.Writer, name string, data []byte) error { // 단일 책임: zip 엔트리 기록
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// redactConfig 함수는 설정을 맵으로 변환하며 민감 필드 값을 마스킹합니다.
+func redactConfig(cfg interface{}) map[string]interface{} { // 단일 책임: 설정 마스킹
+	out := map[string]interface{}{}
+	v := reflect.Indirect(reflect.ValueOf(cfg))
+	if v.Kind() != reflect.Struct {
+		return out
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		val := v.Field(i).Interface()
+		lower := strings.ToLower(field.Name)
+		for _, k := range diagSensitiveKeys {
+			if strings.Contains(lower, k) && !v.Field(i).IsZero() {
+				val = DIAG_REDACTED_VALUE
+				break
+			}
+		}
+		out[field.Name] = val
+	}
+	return out
+}
+
+// diagMetrics 함수는 런타임/캡처 상태 스냅샷을 반환합니다.
+func (a *Agent) diagMetrics() map[string]interface{} { // 단일 책임: 메트릭 스냅샷
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	a.mu.Lock()
+	frameStream, eventStream := a.frameStream != nil, a.eventStream != nil
+	a.mu.Unlock()
+	return map[string]interface{}{
+		"agent_id":        a.agentID,
+		"version":         Version,
+		"hostname":        a.hostname,
+		"device_name":     a.deviceName(),
+		"os":              runtime.GOOS,
+		"arch":            runtime.GOARCH,
+		"go_version":      runtime.Version(),
+		"num_cpu":         runtime.NumCPU(),
+		"goroutines":      runtime.NumGoroutine(),
+		"heap_alloc":      ms.HeapAlloc,
+		"heap_sys":        ms.HeapSys,
+		"num_gc":          ms.NumGC,
+		"uptime_sec":      int64(time.Since(a.startedAt).Seconds()),
+		"capturing":       a.Capturing(),
+		"frame_stream":    frameStream,
+		"event_stream":    eventStream,
+		"collected_at":    time.Now().Format(time.RFC3339),
+		"monitor_mode":    a.cfg.MonitorMode,
+		"capture_format":  a.cfg.CaptureEncoding,
+		"frame_queue":     a.FrameQueueStats(),
+		"send_limit":      a.SendLimitStats(),
+		"connection":      a.ConnectionStatus(),
+		"offline_spool":   a.OfflineSpoolStats(),
+		"event_ack":       a.EventAckStats(),
+		"quality":         a.QualityStats(),
+		"capture_stats":   a.CaptureStats(),
+		"capture_backend": a.captureBackendStatus(),
+		"privacy_masks":   len(a.PrivacyMasks()),
+	}
+}
+
+// diagMonitors 함수는 모니터 목록을 반환합니다.
+func (a *Agent) diagMonitors() []string { // 단일 책임: 모니터 목록 수집
+	return a.ListMonitors()
+}
+
+// diagPermissions 함수는 테스트 캡처로 화면 캡처 권한 상태를 확인합니다.
+func diagPermissions() map[string]interface{} { // 단일 책임: 권한 상태 확인
+	res := map[string]interface{}{"displays": screenshot.NumActiveDisplays()}
+	if screenshot.NumActiveDisplays() == 0 {
+		res["screen_capture"] = "no_display"
+		return res
+	}
+	b := screenshot.GetDisplayBounds(0)
+	probe := image.Rect(b.Min.X, b.Min.Y, b.Min.X+DIAG_PERMISSION_PROBE_W, b.Min.Y+DIAG_PERMISSION_PROBE_H)
+	if _, err := screenshot.CaptureRect(probe); err != nil {
+		res["screen_capture"] = "denied"
+		res["error"] = err.Error()
+		return res
+	}
+	res["screen_capture"] = "granted"
+	return res
+}
+
+// diagConnectivity 함수는 서버 주소 TCP 연결 테스트 및 gRPC 연결 상태를 반환합니다.
+func (a *Agent) diagConnectivity() map[string]interface{} { // 단일 책임: 연결 테스트
+	addr := a.endpoints.current()
+	res := map[string]interface{}{"server_addr": addr, "server_endpoints": a.endpoints.list()}
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", addr, time.Duration(DIAG_DIAL_TIMEOUT_MS)*time.Millisecond)
+	if err != nil {
+		res["tcp_reachable"] = false
+		res["tcp_error"] = err.Error()
+	} else {
+		res["tcp_reachable"] = true
+		res["tcp_dial_ms"] = time.Since(start).Milliseconds()
+		_ = conn.Close()
+	}
+	a.mu.Lock()
+	grpcConn := a.grpcConn
+	a.mu.Unlock()
+	if grpcConn != nil {
+		res["grpc_state"] = grpcConn.GetState().String()
+	} else {
+		res["grpc_state"] = "not_connected"
+	}
+	return res
+}
@@ -0,0 +1,8 @@
+//go:build !linux && !darwin && !freebsd && !windows
+
+package agent
+
+// diskUsedPct 함수는 디스크 사용률 조회를 지원하지 않는 플랫폼에서 false 를 반환합니다.
+func diskUsedPct(string) (float64, bool) { // 단일 책임: 미지원 플랫폼 처리
+	return 0, false
+}
@@ -0,0 +1,19 @@
+//go:build linux || darwin || freebsd
+
+package agent
+
+import "golang.org/x/sys/unix"
+
+// diskUsedPct 함수는 path 가 속한 볼륨의 사용률을 statfs 로 계산합니다 (일반 사용자 가용 공간 기준).
+func diskUsedPct(path string) (float64, bool) { // 단일 책임: 디스크 사용률 조회
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return 0, false
+	}
+	total := uint64(st.Blocks) * uint64(st.Bsize)
+	avail := uint64(st.Bavail) * uint64(st.Bsize)
+	if total == 0 {
+		return 0, false
+	}
+	return 100 * float64(total-min(avail, total)) / float64(total), true
+}
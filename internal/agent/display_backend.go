@@ -0,0 +1,131 @@
+package agent
+
+import (
+	"errors"
+	"image"
+	"time"
+)
+
+const (
+	CAPTURE_BACKEND_AUTO       = "auto"       // OS 전용 백엔드 우선, 사용할 수 없으면 screenshot 으로 대체
+	CAPTURE_BACKEND_SCREENSHOT = "screenshot" // 항상 kbinani/screenshot (GDI BitBlt / CoreGraphics / X11)
+	DISPLAY_BACKEND_RETRY_MS   = 2000         // 백엔드 생성/캡처 실패 후 screenshot 으로 대신 캡처하는 최소 시간
+)
+
+// errDisplayBackendUnsupported 변수는 현재 플랫폼/빌드에 OS 전용 디스플레이 백엔드가 없음을 나타냅니다.
+var errDisplayBackendUnsupported = errors.New("이 플랫폼에는 전용 디스플레이 캡처 백엔드가 없음")
+
+// displayBackend 인터페이스는 모니터 하나를 캡처하는 OS 전용 백엔드 세션입니다
+// (Windows: DXGI Desktop Duplication, macOS 12.3+: ScreenCaptureKit, Linux X11: MIT-SHM).
+// grab 이 실패하면 screenshotCapturer 가 세션을 닫고 kbinani/screenshot 으로 대신 캡처합니다.
+type displayBackend interface {
+	name() string
+	grab() (*image.RGBA, error) // 호출자 소유 프레임 (사용 후 release)
+	release(img *image.RGBA)
+	close()
+}
+
+// closableCapturer 인터페이스는 교체될 때 해제해야 할 OS 캡처 세션을 가진 캡처러입니다.
+type closableCapturer interface {
+	close()
+}
+
+// setCapturerLocked 함수는 캡처러를 교체하고 이전 캡처러의 OS 세션을 닫습니다 (capMu 보유 상태).
+// 캡처 루프가 이전 캡처러로 캡처 중이면 그 프레임이 끝난 뒤 닫히고, 이후 호출은 screenshot 으로 처리됩니다.
+func (a *Agent) setCapturerLocked(c screenCapturer) { // 단일 책임: 캡처러 교체
+	if old, ok := a.capturer.(closableCapturer); ok && a.capturer != c {
+		old.close()
+	}
+	a.capturer = c
+}
+
+// grabNative 함수는 OS 전용 백엔드로 모니터 영역 b 를 캡처합니다. 백엔드를 쓸 수 없으면 false 를 반환합니다.
+// 모니터 영역이 바뀌면 세션을 새로 만들고, 실패하면 DISPLAY_BACKEND_RETRY_MS 동안 다시 시도하지 않습니다.
+func (s *screenshotCapturer) grabNative(index int, b image.Rectangle) (image.Image, bool) { // 단일 책임: 전용 백엔드 캡처
+	if s.backend != CAPTURE_BACKEND_AUTO {
+		return nil, false
+	}
+	s.nativeMu.Lock()
+	defer s.nativeMu.Unlock()
+	if s.native != nil && s.nativeBounds != b { // 해상도/배치 변경
+		s.closeNativeLocked()
+	}
+	if s.native == nil {
+		if time.Now().Before(s.nativeRetry) {
+			return nil, false
+		}
+		native, err := newDisplayBackend(index, b)
+		if errors.Is(err, errDisplayBackendUnsupported) {
+			s.backend = CAPTURE_BACKEND_SCREENSHOT
+			return nil, false
+		}
+		if err != nil {
+			s.nativeErr = err
+			s.nativeRetry = time.Now().Add(time.Duration(DISPLAY_BACKEND_RETRY_MS) * time.Millisecond)
+			return nil, false
+		}
+		s.native, s.nativeBounds, s.nativeErr = native, b, nil
+	}
+	img, err := s.native.grab()
+	if err != nil {
+		s.closeNativeLocked()
+		s.nativeErr = err
+		s.nativeRetry = time.Now().Add(time.Duration(DISPLAY_BACKEND_RETRY_MS) * time.Millisecond)
+		return nil, false
+	}
+	return img, true
+}
+
+// releaseNative 함수는 single 모드 프레임을 백엔드 버퍼 풀에 반환합니다 (screenshot 으로 캡처한 이미지는 무시).
+func (s *screenshotCapturer) releaseNative(img image.Image) { // 단일 책임: 백엔드 버퍼 반환
+	rgba, ok := img.(*image.RGBA)
+	if !ok {
+		return
+	}
+	s.nativeMu.Lock()
+	defer s.nativeMu.Unlock()
+	if s.native != nil {
+		s.native.release(rgba)
+	}
+}
+
+// closeNativeLocked 함수는 백엔드 세션을 닫습니다 (nativeMu 보유 상태).
+func (s *screenshotCapturer) closeNativeLocked() { // 단일 책임: 세션 정리
+	if s.native != nil {
+		s.native.close()
+		s.native = nil
+	}
+}
+
+// close 함수는 OS 전용 백엔드 세션을 닫습니다 (combined 모드는 모니터별 캡처러 세션까지).
+func (s *screenshotCapturer) close() { // 단일 책임: closableCapturer 구현
+	s.nativeMu.Lock()
+	defer s.nativeMu.Unlock()
+	s.closeNativeLocked()
+	s.resizePartsLocked(0)
+}
+
+// backendStatus 함수는 현재 사용 중인 캡처 백엔드 이름과 마지막 백엔드 오류를 반환합니다 (진단용).
+func (s *screenshotCapturer) backendStatus() (string, error) { // 단일 책임: 백엔드 상태 조회
+	s.nativeMu.Lock()
+	defer s.nativeMu.Unlock()
+	if s.native != nil {
+		return s.native.name(), nil
+	}
+	return CAPTURE_BACKEND_SCREENSHOT, s.nativeErr
+}
+
+// captureBackendStatus 함수는 설정된 캡처 백엔드와 현재 single 모드 캡처러가 실제로 쓰는 백엔드를 반환합니다 (진단용).
+func (a *Agent) captureBackendStatus() map[string]string { // 단일 책임: 백엔드 진단 정보
+	a.capMu.RLock()
+	defer a.capMu.RUnlock()
+	res := map[string]string{"configured": a.cfg.CaptureBackend}
+	if s, ok := a.capturer.(*screenshotCapturer); ok && s.mode == "single" {
+		name, err := s.backendStatus()
+		res["active"] = name
+		if err != nil {
+			res["error"] = err.Error()
+		}
+	}
+	return res
+}
@@ -0,0 +1,357 @@
+//go:build darwin && cgo
+
+package agent
+
+/*
+#cgo CFLAGS: -x objective-c -fobjc-arc
+#cgo LDFLAGS: -framework Foundation -framework CoreGraphics -framework CoreMedia -framework CoreVideo -framework ScreenCaptureKit
+#include <stdint.h>
+#include <stdlib.h>
+#include <string.h>
+#import <Foundation/Foundation.h>
+#import <CoreGraphics/CoreGraphics.h>
+#import <CoreMedia/CoreMedia.h>
+#import <CoreVideo/CoreVideo.h>
+#import <ScreenCaptureKit/ScreenCaptureKit.h>
+
+#define SCK_OK           0
+#define SCK_UNSUPPORTED  1
+#define SCK_DENIED       2
+#define SCK_FAILED       3
+#define SCK_WAIT_SECONDS 5
+
+// ScreenCaptureKit 심볼은 모두 API_AVAILABLE(macos(12.3)) 이라 약한 참조로 링크되므로,
+// 배포 대상이 12.3 미만이어도 실행되며 @available 검사에서 SCK_UNSUPPORTED 로 기존 경로를 사용합니다.
+
+// AgentStreamOutput 는 SCStream 이 전달한 최신 완성 프레임(CVPixelBuffer)을 보관합니다.
+API_AVAILABLE(macos(12.3))
+@interface AgentStreamOutput : NSObject <SCStreamOutput, SCStreamDelegate>
+@property (atomic) BOOL stopped;
+@end
+
+@implementation AgentStreamOutput {
+	CVPixelBufferRef _latest;
+	uint64_t _seq;
+}
+
+- (void)stream:(SCStream *)stream didOutputSampleBuffer:(CMSampleBufferRef)sb ofType:(SCStreamOutputType)type {
+	if (type != SCStreamOutputTypeScreen) {
+		return;
+	}
+	CFArrayRef attachments = CMSampleBufferGetSampleAttachmentsArray(sb, false);
+	if (attachments == NULL || CFArrayGetCount(attachments) == 0) {
+		return;
+	}
+	NSDictionary *info = (__bridge NSDictionary *)CFArrayGetValueAtIndex(attachments, 0);
+	NSNumber *status = info[SCStreamFrameInfoStatus];
+	if (status == nil || status.integerValue != SCFrameStatusComplete) { // idle/blank 프레임은 내용 변화 없음
+		return;
+	}
+	CVPixelBufferRef pb = CMSampleBufferGetImageBuffer(sb);
+	if (pb == NULL) {
+		return;
+	}
+	CVPixelBufferRetain(pb);
+	@synchronized (self) {
+		if (_latest != NULL) {
+			CVPixelBufferRelease(_latest);
+		}
+		_latest = pb;
+		_seq++;
+	}
+}
+
+- (void)stream:(SCStream *)stream didStopWithError:(NSError *)error {
+	self.stopped = YES;
+}
+
+// copyInto 는 seq 이후 새 프레임이 있으면 BGRA → RGBA 로 변환해 dst 에 쓰고 새 seq 를 반환합니다.
+// 새 프레임이 없으면 seq 를, 스트림이 멈췄으면 0 을 반환합니다.
+- (uint64_t)copyInto:(uint8_t *)dst width:(int)w height:(int)h stride:(int)stride since:(uint64_t)seq {
+	if (self.stopped) {
+		return 0;
+	}
+	CVPixelBufferRef pb = NULL;
+	uint64_t cur;
+	@synchronized (self) {
+		cur = _seq;
+		if (cur != seq && _latest != NULL) {
+			pb = CVPixelBufferRetain(_latest);
+		}
+	}
+	if (pb == NULL) {
+		return seq;
+	}
+	CVPixelBufferLockBaseAddress(pb, kCVPixelBufferLock_ReadOnly);
+	const uint8_t *src = CVPixelBufferGetBaseAddress(pb);
+	size_t srcStride = CVPixelBufferGetBytesPerRow(pb);
+	int cw = (int)CVPixelBufferGetWidth(pb), ch = (int)CVPixelBufferGetHeight(pb);
+	if (cw > w) cw = w;
+	if (ch > h) ch = h;
+	for (int y = 0; y < ch; y++) {
+		const uint8_t *s = src + y * srcStride;
+		uint8_t *d = dst + y * stride;
+		for (int x = 0; x < cw * 4; x += 4) {
+			d[x] = s[x + 2];
+			d[x + 1] = s[x + 1];
+			d[x + 2] = s[x];
+			d[x + 3] = 255;
+		}
+	}
+	CVPixelBufferUnlockBaseAddress(pb, kCVPixelBufferLock_ReadOnly);
+	CVPixelBufferRelease(pb);
+	return cur;
+}
+
+- (void)dealloc {
+	if (_latest != NULL) {
+		CVPixelBufferRelease(_latest);
+	}
+}
+@end
+
+API_AVAILABLE(macos(12.3))
+@interface AgentCaptureSession : NSObject
+@property (strong) SCStream *stream;
+@property (strong) AgentStreamOutput *output;
+@end
+
+@implementation AgentCaptureSession
+@end
+
+// sckDisplayID 는 kbinani/screenshot 과 같은 규칙(0=메인, 이후 메인 제외 활성 목록 순)으로 display ID 를 찾습니다.
+static CGDirectDisplayID sckDisplayID(int index) {
+	CGDirectDisplayID main = CGMainDisplayID();
+	if (index == 0) {
+		return main;
+	}
+	uint32_t n = 0;
+	if (CGGetActiveDisplayList(0, NULL, &n) != kCGErrorSuccess || n == 0) {
+		return 0;
+	}
+	CGDirectDisplayID ids[n];
+	if (CGGetActiveDisplayList(n, ids, NULL) != kCGErrorSuccess) {
+		return 0;
+	}
+	int i = 0;
+	for (uint32_t k = 0; k < n; k++) {
+		if (ids[k] == main) {
+			continue;
+		}
+		if (++i == index) {
+			return ids[k];
+		}
+	}
+	return 0;
+}
+
+static void sckRequestPermission(void) {
+	CGRequestScreenCaptureAccess();
+}
+
+static char *sckError(NSError *err, const char *fallback) {
+	return strdup(err != nil ? err.localizedDescription.UTF8String : fallback);
+}
+
+// sckStart 는 display index 를 width x height(포인트 단위) 로 내보내는 SCStream 을 시작합니다.
+static void *sckStart(int index, int width, int height, int fps, int *code, char **msg) {
+	if (@available(macOS 12.3, *)) {
+		if (!CGPreflightScreenCaptureAccess()) {
+			*code = SCK_DENIED;
+			return NULL;
+		}
+		CGDirectDisplayID displayID = sckDisplayID(index);
+		dispatch_semaphore_t sem = dispatch_semaphore_create(0);
+		__block SCDisplay *target = nil;
+		__block NSError *contentErr = nil;
+		[SCShareableContent getShareableContentWithCompletionHandler:^(SCShareableContent *content, NSError *error) {
+			contentErr = error;
+			for (SCDisplay *d in content.displays) {
+				if (d.displayID == displayID) {
+					target = d;
+					break;
+				}
+			}
+			dispatch_semaphore_signal(sem);
+		}];
+		if (dispatch_semaphore_wait(sem, dispatch_time(DISPATCH_TIME_NOW, SCK_WAIT_SECONDS * NSEC_PER_SEC)) != 0) {
+			*code = SCK_FAILED;
+			*msg = strdup("SCShareableContent timeout");
+			return NULL;
+		}
+		if (target == nil) {
+			*code = contentErr != nil && contentErr.code == SCStreamErrorUserDeclined ? SCK_DENIED : SCK_FAILED;
+			*msg = sckError(contentErr, "display not found");
+			return NULL;
+		}
+		SCContentFilter *filter = [[SCContentFilter alloc] initWithDisplay:target excludingWindows:@[]];
+		SCStreamConfiguration *conf = [[SCStreamConfiguration alloc] init];
+		conf.width = width;
+		conf.height = height;
+		conf.minimumFrameInterval = CMTimeMake(1, fps);
+		conf.pixelFormat = kCVPixelFormatType_32BGRA;
+		conf.showsCursor = NO; // 기존 CoreGraphics 경로와 같게 커서 제외
+		conf.queueDepth = 3;
+		AgentCaptureSession *session = [[AgentCaptureSession alloc] init];
+		session.output = [[AgentStreamOutput alloc] init];
+		session.stream = [[SCStream alloc] initWithFilter:filter configuration:conf delegate:session.output];
+		NSError *addErr = nil;
+		dispatch_queue_t queue = dispatch_queue_create("agent.screencapturekit", DISPATCH_QUEUE_SERIAL);
+		if (![session.stream addStreamOutput:session.output type:SCStreamOutputTypeScreen sampleHandlerQueue:queue error:&addErr]) {
+			*code = SCK_FAILED;
+			*msg = sckError(addErr, "addStreamOutput failed");
+			return NULL;
+		}
+		__block NSError *startErr = nil;
+		sem = dispatch_semaphore_create(0);
+		[session.stream startCaptureWithCompletionHandler:^(NSError *error) {
+			startErr = error;
+			dispatch_semaphore_signal(sem);
+		}];
+		if (dispatch_semaphore_wait(sem, dispatch_time(DISPATCH_TIME_NOW, SCK_WAIT_SECONDS * NSEC_PER_SEC)) != 0 || startErr != nil) {
+			*code = startErr != nil && startErr.code == SCStreamErrorUserDeclined ? SCK_DENIED : SCK_FAILED;
+			*msg = sckError(startErr, "startCapture timeout");
+			return NULL;
+		}
+		*code = SCK_OK;
+		return (void *)CFBridgingRetain(session);
+	}
+	*code = SCK_UNSUPPORTED;
+	return NULL;
+}
+
+static uint64_t sckCopy(void *handle, uint8_t *dst, int width, int height, int stride, uint64_t seq) {
+	if (@available(macOS 12.3, *)) {
+		AgentCaptureSession *session = (__bridge AgentCaptureSession *)handle;
+		return [session.output copyInto:dst width:width height:height stride:stride since:seq];
+	}
+	return 0;
+}
+
+static void sckStop(void *handle) {
+	if (@available(macOS 12.3, *)) {
+		AgentCaptureSession *session = CFBridgingRelease(handle);
+		dispatch_semaphore_t sem = dispatch_semaphore_create(0);
+		[session.stream stopCaptureWithCompletionHandler:^(NSError *error) {
+			dispatch_semaphore_signal(sem);
+		}];
+		dispatch_semaphore_wait(sem, dispatch_time(DISPATCH_TIME_NOW, SCK_WAIT_SECONDS * NSEC_PER_SEC));
+	}
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sync"
+	"time"
+	"unsafe"
+
+	"agent/internal/i18n"
+)
+
+const (
+	SCK_BACKEND_NAME        = "screencapturekit" // 진단에 표시되는 백엔드 이름
+	SCK_MAX_FPS             = 120                // SCStream 최소 프레임 간격 (변화가 없으면 프레임을 보내지 않음)
+	SCK_FIRST_FRAME_WAIT_MS = 500                // 스트림 시작 후 첫 프레임 대기 시간
+	SCK_FIRST_FRAME_POLL_MS = 10                 // 첫 프레임 대기 중 확인 간격
+)
+
+// errScreenRecordingDenied 변수는 macOS 화면 기록 권한이 없음을 나타냅니다.
+// 권한이 없으면 기존 CoreGraphics 경로도 배경화면만 캡처하므로, 진단 정보에 이 오류가 표시됩니다.
+var errScreenRecordingDenied = i18n.NewError("error.screen_recording")
+
+// screenRecordingPrompt 변수는 화면 기록 권한 요청 대화상자를 프로세스당 한 번만 띄웁니다.
+var screenRecordingPrompt sync.Once
+
+// sckBackend 구조체는 ScreenCaptureKit SCStream 으로 모니터 하나를 캡처합니다 (macOS 12.3+).
+// 화면이 바뀔 때만 WindowServer 가 프레임을 보내고, grab 은 마지막 프레임 이후 변화가 있을 때만 픽셀을 변환합니다.
+type sckBackend struct { // 단일 책임: ScreenCaptureKit 모니터 캡처
+	mu     sync.Mutex
+	handle unsafe.Pointer // AgentCaptureSession (CFBridgingRetain)
+	frame  *image.RGBA    // 마지막으로 변환한 화면
+	seq    C.uint64_t     // frame 에 반영된 스트림 프레임 번호
+	pool   rgbaPool       // grab 반환 버퍼 풀
+}
+
+// newDisplayBackend 함수는 모니터 index 를 bounds 크기(포인트 단위, 기존 경로와 같은 해상도)로 내보내는 스트림을 시작합니다.
+// macOS 12.3 미만이면 errDisplayBackendUnsupported 를, 화면 기록 권한이 없으면 권한 요청 후 errScreenRecordingDenied 를 반환합니다.
+func newDisplayBackend(index int, bounds image.Rectangle) (displayBackend, error) { // 단일 책임: SCStream 세션 생성
+	var code C.int
+	var msg *C.char
+	handle := C.sckStart(C.int(index), C.int(bounds.Dx()), C.int(bounds.Dy()), SCK_MAX_FPS, &code, &msg)
+	if msg != nil {
+		defer C.free(unsafe.Pointer(msg))
+	}
+	switch code {
+	case C.SCK_OK:
+	case C.SCK_UNSUPPORTED:
+		return nil, errDisplayBackendUnsupported
+	case C.SCK_DENIED:
+		screenRecordingPrompt.Do(func() { C.sckRequestPermission() })
+		return nil, errScreenRecordingDenied
+	default:
+		return nil, fmt.Errorf("ScreenCaptureKit 스트림 시작 실패: %s", C.GoString(msg))
+	}
+	b := &sckBackend{handle: handle, frame: image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))}
+	deadline := time.Now().Add(SCK_FIRST_FRAME_WAIT_MS * time.Millisecond)
+	for b.update() == nil && b.seq == 0 && time.Now().Before(deadline) {
+		time.Sleep(SCK_FIRST_FRAME_POLL_MS * time.Millisecond)
+	}
+	if b.seq == 0 {
+		b.close()
+		return nil, errors.New("ScreenCaptureKit 첫 프레임 대기 시간 초과")
+	}
+	return b, nil
+}
+
+// name 함수는 백엔드 이름을 반환합니다.
+func (b *sckBackend) name() string { // 단일 책임: 이름 조회
+	return SCK_BACKEND_NAME
+}
+
+// update 함수는 새 스트림 프레임이 있으면 frame 에 변환합니다. 스트림이 멈췄으면(권한 철회, 디스플레이 분리) 오류를 반환합니다.
+func (b *sckBackend) update() error { // 단일 책임: 최신 프레임 반영
+	if b.handle == nil {
+		return errors.New("ScreenCaptureKit 세션이 닫힘")
+	}
+	f := b.frame
+	seq := C.sckCopy(b.handle, (*C.uint8_t)(unsafe.Pointer(&f.Pix[0])), C.int(f.Rect.Dx()), C.int(f.Rect.Dy()), C.int(f.Stride), b.seq)
+	if seq == 0 && b.seq != 0 {
+		return errors.New("ScreenCaptureKit 스트림 중지됨")
+	}
+	b.seq = seq
+	return nil
+}
+
+// grab 함수는 최신 화면을 반영한 뒤 복사본을 반환합니다.
+func (b *sckBackend) grab() (*image.RGBA, error) { // 단일 책임: 프레임 획득
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.update(); err != nil {
+		return nil, err
+	}
+	img := b.pool.get(b.frame.Rect.Dx(), b.frame.Rect.Dy())
+	copy(img.Pix, b.frame.Pix)
+	return img, nil
+}
+
+// release 함수는 grab 이 반환한 버퍼를 풀에 반환합니다 (원점이 아닌 screenshot 이미지는 받지 않음).
+func (b *sckBackend) release(img *image.RGBA) { // 단일 책임: 버퍼 반환
+	if img.Rect.Min != (image.Point{}) {
+		return
+	}
+	b.pool.put(img)
+}
+
+// close 함수는 스트림을 멈추고 세션을 해제합니다.
+func (b *sckBackend) close() { // 단일 책임: 세션 정리
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.handle != nil {
+		C.sckStop(b.handle)
+		b.handle = nil
+	}
+}
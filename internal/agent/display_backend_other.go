@@ -0,0 +1,10 @@
+//go:build !windows && !(darwin && cgo) && !linux
+
+package agent
+
+import "image"
+
+// newDisplayBackend 함수는 전용 백엔드가 없는 플랫폼에서 항상 errDisplayBackendUnsupported 를 반환합니다.
+func newDisplayBackend(int, image.Rectangle) (displayBackend, error) { // 단일 책임: 미지원 플랫폼 처리
+	return nil, errDisplayBackendUnsupported
+}
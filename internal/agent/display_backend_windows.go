@@ -0,0 +1,357 @@
+//go:build windows
+
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	DXGI_ERROR_ACCESS_LOST     = 0x887A0026 // 모드 변경/보안 데스크톱 전환으로 복제 세션 무효화
+	DXGI_ERROR_WAIT_TIMEOUT    = 0x887A0027 // AcquireNextFrame 시간 내 새 프레임 없음
+	DXGI_FORMAT_B8G8R8A8_UNORM = 87         // 데스크톱 복제 기본 픽셀 형식
+	D3D11_SDK_VERSION          = 7          // D3D11CreateDevice SDKVersion
+	D3D11_USAGE_STAGING        = 3          // CPU 읽기용 스테이징 텍스처
+	D3D11_CPU_ACCESS_READ      = 0x20000    // 스테이징 텍스처 CPU 읽기 허용
+	D3D11_MAP_READ             = 1          // Map 읽기 전용
+	DXGI_FIRST_FRAME_WAIT_MS   = 500        // 세션 첫 프레임 대기 시간 (이후 프레임은 대기하지 않고 이전 화면 재사용)
+	DXGI_BACKEND_NAME          = "dxgi"     // 진단에 표시되는 백엔드 이름
+)
+
+// COM vtable 인덱스 (IUnknown → IDXGIObject/ID3D11DeviceChild 상속 순서 기준)
+const (
+	vtblQueryInterface     = 0
+	vtblRelease            = 2
+	vtblEnumAdapters1      = 12 // IDXGIFactory1
+	vtblEnumOutputs        = 7  // IDXGIAdapter
+	vtblOutputGetDesc      = 7  // IDXGIOutput
+	vtblDuplicateOutput    = 22 // IDXGIOutput1
+	vtblAcquireNextFrame   = 8  // IDXGIOutputDuplication
+	vtblGetFrameDirtyRects = 9
+	vtblGetFrameMoveRects  = 10
+	vtblReleaseFrame       = 14
+	vtblCreateTexture2D    = 5  // ID3D11Device
+	vtblTextureGetDesc     = 10 // ID3D11Texture2D
+	vtblMap                = 14 // ID3D11DeviceContext
+	vtblUnmap              = 15
+	vtblCopyResource       = 47
+)
+
+var (
+	procCreateDXGIFactory1 = windows.NewLazySystemDLL("dxgi.dll").NewProc("CreateDXGIFactory1")
+	procD3D11CreateDevice  = windows.NewLazySystemDLL("d3d11.dll").NewProc("D3D11CreateDevice")
+
+	iidIDXGIFactory1   = windows.GUID{Data1: 0x770aae78, Data2: 0xf26f, Data3: 0x4dba, Data4: [8]byte{0xa8, 0x29, 0x25, 0x3c, 0x83, 0xd1, 0xb3, 0x87}}
+	iidIDXGIOutput1    = windows.GUID{Data1: 0x00cddea8, Data2: 0x939b, Data3: 0x4b83, Data4: [8]byte{0xa3, 0x40, 0xa6, 0x85, 0x22, 0x66, 0x66, 0xcc}}
+	iidID3D11Texture2D = windows.GUID{Data1: 0x6f15aaf2, Data2: 0xd208, Data3: 0x4e89, Data4: [8]byte{0x9a, 0xb4, 0x48, 0x95, 0x35, 0xd3, 0x4f, 0x9c}}
+)
+
+// comObject 구조체는 COM 인터페이스 포인터가 가리키는 객체 머리(vtable 포인터)입니다.
+type comObject struct {
+	vtbl *[64]uintptr
+}
+
+// call 함수는 vtable 의 method 번째 메서드를 호출하고 HRESULT 를 반환합니다.
+//
+//go:uintptrescapes
+func (o *comObject) call(method int, args ...uintptr) uint32 { // 단일 책임: COM 메서드 호출
+	r, _, _ := syscall.SyscallN(o.vtbl[method], append([]uintptr{uintptr(unsafe.Pointer(o))}, args...)...)
+	return uint32(r)
+}
+
+// release 함수는 COM 참조를 해제합니다 (nil 허용).
+func (o *comObject) release() { // 단일 책임: 참조 해제
+	if o != nil {
+		o.call(vtblRelease)
+	}
+}
+
+// dxgiOutputDesc 구조체는 DXGI_OUTPUT_DESC 입니다.
+type dxgiOutputDesc struct {
+	DeviceName         [32]uint16
+	DesktopCoordinates windows.Rect
+	AttachedToDesktop  int32
+	Rotation           uint32
+	Monitor            uintptr
+}
+
+// dxgiFrameInfo 구조체는 DXGI_OUTDUPL_FRAME_INFO 입니다.
+type dxgiFrameInfo struct {
+	LastPresentTime           int64
+	LastMouseUpdateTime       int64
+	AccumulatedFrames         uint32
+	RectsCoalesced            int32
+	ProtectedContentMaskedOut int32
+	PointerX, PointerY        int32
+	PointerVisible            int32
+	TotalMetadataBufferSize   uint32
+	PointerShapeBufferSize    uint32
+}
+
+// dxgiMoveRect 구조체는 DXGI_OUTDUPL_MOVE_RECT 입니다.
+type dxgiMoveRect struct {
+	SourceX, SourceY int32
+	Destination      windows.Rect
+}
+
+// d3d11Texture2DDesc 구조체는 D3D11_TEXTURE2D_DESC 입니다.
+type d3d11Texture2DDesc struct {
+	Width, Height, MipLevels, ArraySize uint32
+	Format                              uint32
+	SampleCount, SampleQuality          uint32
+	Usage, BindFlags                    uint32
+	CPUAccessFlags, MiscFlags           uint32
+}
+
+// d3d11MappedSubresource 구조체는 D3D11_MAPPED_SUBRESOURCE 입니다.
+type d3d11MappedSubresource struct {
+	PData      unsafe.Pointer
+	RowPitch   uint32
+	DepthPitch uint32
+}
+
+// dxgiBackend 구조체는 DXGI Desktop Duplication 으로 모니터 하나를 캡처합니다.
+// GPU 에서 데스크톱 텍스처를 스테이징 텍스처로 복사한 뒤 변경(dirty/move) 영역만 누적 프레임에 변환하므로
+// 화면 일부만 바뀌는 고 FPS 캡처에서 GDI BitBlt 보다 CPU 사용량이 크게 낮습니다.
+type dxgiBackend struct { // 단일 책임: DXGI 모니터 캡처
+	mu      sync.Mutex
+	device  *comObject // ID3D11Device
+	context *comObject // ID3D11DeviceContext
+	dupl    *comObject // IDXGIOutputDuplication
+	staging *comObject // CPU 읽기용 ID3D11Texture2D (desktop 크기가 바뀌면 재생성)
+	stageW  int
+	stageH  int
+	frame   *image.RGBA // 지금까지 받은 변경이 모두 반영된 누적 화면
+	rects   []windows.Rect
+	moves   []dxgiMoveRect
+	pool    rgbaPool // grab 반환 버퍼 풀
+}
+
+// newDisplayBackend 함수는 bounds 와 데스크톱 좌표가 같은 DXGI 출력을 찾아 Desktop Duplication 세션을 엽니다.
+// 회전된 출력이나 복제를 지원하지 않는 환경(원격 데스크톱 일부, 권한 부족)은 오류를 반환해 screenshot 으로 대체됩니다.
+func newDisplayBackend(_ int, bounds image.Rectangle) (displayBackend, error) { // 단일 책임: DXGI 세션 생성
+	if procCreateDXGIFactory1.Find() != nil || procD3D11CreateDevice.Find() != nil { // Windows 7 이하 등
+		return nil, errDisplayBackendUnsupported
+	}
+	adapter, output, err := findDXGIOutput(bounds)
+	if err != nil {
+		return nil, err
+	}
+	defer adapter.release()
+	defer output.release()
+	var output1 *comObject
+	if hr := output.call(vtblQueryInterface, uintptr(unsafe.Pointer(&iidIDXGIOutput1)), uintptr(unsafe.Pointer(&output1))); hr != 0 {
+		return nil, errDisplayBackendUnsupported // DXGI 1.2 미만 (Windows 7)
+	}
+	defer output1.release()
+	b := &dxgiBackend{}
+	var featureLevel uint32
+	if hr, _, _ := procD3D11CreateDevice.Call(uintptr(unsafe.Pointer(adapter)), 0, 0, 0, 0, 0, D3D11_SDK_VERSION,
+		uintptr(unsafe.Pointer(&b.device)), uintptr(unsafe.Pointer(&featureLevel)), uintptr(unsafe.Pointer(&b.context))); uint32(hr) != 0 {
+		return nil, fmt.Errorf("D3D11CreateDevice 실패: hr=0x%08X", uint32(hr))
+	}
+	if hr := output1.call(vtblDuplicateOutput, uintptr(unsafe.Pointer(b.device)), uintptr(unsafe.Pointer(&b.dupl))); hr != 0 {
+		b.close()
+		return nil, fmt.Errorf("DuplicateOutput 실패: hr=0x%08X", hr)
+	}
+	return b, nil
+}
+
+// findDXGIOutput 함수는 데스크톱에 연결된 출력 중 좌표가 bounds 와 같은 출력과 그 어댑터를 반환합니다.
+func findDXGIOutput(bounds image.Rectangle) (adapter, output *comObject, err error) { // 단일 책임: 출력 검색
+	var factory *comObject
+	if hr, _, _ := procCreateDXGIFactory1.Call(uintptr(unsafe.Pointer(&iidIDXGIFactory1)), uintptr(unsafe.Pointer(&factory))); uint32(hr) != 0 {
+		return nil, nil, fmt.Errorf("CreateDXGIFactory1 실패: hr=0x%08X", uint32(hr))
+	}
+	defer factory.release()
+	for ai := uintptr(0); ; ai++ {
+		var a *comObject
+		if hr := factory.call(vtblEnumAdapters1, ai, uintptr(unsafe.Pointer(&a))); hr != 0 {
+			break // DXGI_ERROR_NOT_FOUND: 어댑터 끝
+		}
+		for oi := uintptr(0); ; oi++ {
+			var o *comObject
+			if hr := a.call(vtblEnumOutputs, oi, uintptr(unsafe.Pointer(&o))); hr != 0 {
+				break
+			}
+			var desc dxgiOutputDesc
+			if hr := o.call(vtblOutputGetDesc, uintptr(unsafe.Pointer(&desc))); hr == 0 && desc.AttachedToDesktop != 0 {
+				r := desc.DesktopCoordinates
+				if image.Rect(int(r.Left), int(r.Top), int(r.Right), int(r.Bottom)) == bounds {
+					if desc.Rotation > 1 { // 회전된 출력: 텍스처 회전 처리 대신 screenshot 사용
+						o.release()
+						a.release()
+						return nil, nil, errors.New("회전된 디스플레이는 DXGI 백엔드 미지원")
+					}
+					return a, o, nil
+				}
+			}
+			o.release()
+		}
+		a.release()
+	}
+	return nil, nil, fmt.Errorf("모니터 %v 에 해당하는 DXGI 출력 없음", bounds)
+}
+
+// name 함수는 백엔드 이름을 반환합니다.
+func (b *dxgiBackend) name() string { // 단일 책임: 이름 조회
+	return DXGI_BACKEND_NAME
+}
+
+// grab 함수는 새 데스크톱 프레임이 있으면 변경 영역을 누적 화면에 반영하고, 누적 화면의 복사본을 반환합니다.
+// 새 프레임이 없으면(화면 정지) 기다리지 않고 직전 화면을 그대로 반환합니다.
+func (b *dxgiBackend) grab() (*image.RGBA, error) { // 단일 책임: 프레임 획득
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.dupl == nil {
+		return nil, errors.New("DXGI 세션이 닫힘")
+	}
+	timeout := uintptr(0)
+	if b.frame == nil {
+		timeout = DXGI_FIRST_FRAME_WAIT_MS
+	}
+	var info dxgiFrameInfo
+	var resource *comObject
+	hr := b.dupl.call(vtblAcquireNextFrame, timeout, uintptr(unsafe.Pointer(&info)), uintptr(unsafe.Pointer(&resource)))
+	switch {
+	case hr == DXGI_ERROR_WAIT_TIMEOUT:
+		if b.frame == nil {
+			return nil, errors.New("DXGI 첫 프레임 대기 시간 초과")
+		}
+		return b.snapshot(), nil
+	case hr == DXGI_ERROR_ACCESS_LOST: // 세션 재생성 필요 (screenshotCapturer 가 닫고 다시 엶)
+		return nil, errors.New("DXGI 복제 세션 무효화 (해상도 변경 또는 보안 데스크톱 전환)")
+	case hr != 0:
+		return nil, fmt.Errorf("AcquireNextFrame 실패: hr=0x%08X", hr)
+	}
+	defer b.dupl.call(vtblReleaseFrame)
+	defer resource.release()
+	if info.LastPresentTime == 0 && b.frame != nil { // 마우스만 움직임: 화면 내용 변화 없음
+		return b.snapshot(), nil
+	}
+	var texture *comObject
+	if hr := resource.call(vtblQueryInterface, uintptr(unsafe.Pointer(&iidID3D11Texture2D)), uintptr(unsafe.Pointer(&texture))); hr != 0 {
+		return nil, fmt.Errorf("desktop texture 조회 실패: hr=0x%08X", hr)
+	}
+	defer texture.release()
+	full, err := b.ensureStaging(texture)
+	if err != nil {
+		return nil, err
+	}
+	b.context.call(vtblCopyResource, uintptr(unsafe.Pointer(b.staging)), uintptr(unsafe.Pointer(texture)))
+	var mapped d3d11MappedSubresource
+	if hr := b.context.call(vtblMap, uintptr(unsafe.Pointer(b.staging)), 0, D3D11_MAP_READ, 0, uintptr(unsafe.Pointer(&mapped))); hr != 0 {
+		return nil, fmt.Errorf("staging texture Map 실패: hr=0x%08X", hr)
+	}
+	src := unsafe.Slice((*byte)(mapped.PData), int(mapped.RowPitch)*b.stageH)
+	if full {
+		b.convert(src, int(mapped.RowPitch), b.frame.Rect)
+	} else {
+		for _, r := range b.changedRects(info.TotalMetadataBufferSize) {
+			b.convert(src, int(mapped.RowPitch), r)
+		}
+	}
+	b.context.call(vtblUnmap, uintptr(unsafe.Pointer(b.staging)), 0)
+	return b.snapshot(), nil
+}
+
+// ensureStaging 함수는 desktop texture 와 크기가 같은 스테이징 텍스처와 누적 화면을 준비합니다.
+// 새로 만들었으면 전체 화면을 변환해야 하므로 true 를 반환합니다.
+func (b *dxgiBackend) ensureStaging(texture *comObject) (bool, error) { // 단일 책임: 스테이징 텍스처 준비
+	var desc d3d11Texture2DDesc
+	texture.call(vtblTextureGetDesc, uintptr(unsafe.Pointer(&desc)))
+	if desc.Format != DXGI_FORMAT_B8G8R8A8_UNORM {
+		return false, fmt.Errorf("지원하지 않는 데스크톱 픽셀 형식: %d", desc.Format)
+	}
+	if b.staging != nil && b.stageW == int(desc.Width) && b.stageH == int(desc.Height) {
+		return false, nil
+	}
+	b.staging.release()
+	b.staging = nil
+	desc.MipLevels, desc.ArraySize = 1, 1
+	desc.SampleCount, desc.SampleQuality = 1, 0
+	desc.Usage, desc.BindFlags, desc.CPUAccessFlags, desc.MiscFlags = D3D11_USAGE_STAGING, 0, D3D11_CPU_ACCESS_READ, 0
+	if hr := b.device.call(vtblCreateTexture2D, uintptr(unsafe.Pointer(&desc)), 0, uintptr(unsafe.Pointer(&b.staging))); hr != 0 {
+		return false, fmt.Errorf("staging texture 생성 실패: hr=0x%08X", hr)
+	}
+	b.stageW, b.stageH = int(desc.Width), int(desc.Height)
+	b.frame = image.NewRGBA(image.Rect(0, 0, b.stageW, b.stageH))
+	return true, nil
+}
+
+// changedRects 함수는 이번 프레임의 move 대상 영역과 dirty 영역을 반환합니다.
+// 스테이징 텍스처가 항상 완성된 현재 화면이므로 move 는 원본 위치와 무관하게 대상 영역만 다시 변환하면 됩니다.
+// 메타데이터를 읽지 못하면 화면 전체를 반환합니다.
+func (b *dxgiBackend) changedRects(metaSize uint32) []image.Rectangle { // 단일 책임: 변경 영역 수집
+	whole := []image.Rectangle{b.frame.Rect}
+	if metaSize == 0 {
+		return whole
+	}
+	if n := int(metaSize)/int(unsafe.Sizeof(dxgiMoveRect{})) + 1; len(b.moves) < n {
+		b.moves = make([]dxgiMoveRect, n)
+	}
+	if n := int(metaSize)/int(unsafe.Sizeof(windows.Rect{})) + 1; len(b.rects) < n {
+		b.rects = make([]windows.Rect, n)
+	}
+	var moveBytes, dirtyBytes uint32
+	if hr := b.dupl.call(vtblGetFrameMoveRects, uintptr(len(b.moves))*unsafe.Sizeof(dxgiMoveRect{}), uintptr(unsafe.Pointer(&b.moves[0])), uintptr(unsafe.Pointer(&moveBytes))); hr != 0 {
+		return whole // DXGI_ERROR_MORE_DATA 등
+	}
+	if hr := b.dupl.call(vtblGetFrameDirtyRects, uintptr(len(b.rects))*unsafe.Sizeof(windows.Rect{}), uintptr(unsafe.Pointer(&b.rects[0])), uintptr(unsafe.Pointer(&dirtyBytes))); hr != 0 {
+		return whole
+	}
+	res := make([]image.Rectangle, 0, 8)
+	for _, m := range b.moves[:int(moveBytes)/int(unsafe.Sizeof(dxgiMoveRect{}))] {
+		d := m.Destination
+		res = append(res, image.Rect(int(d.Left), int(d.Top), int(d.Right), int(d.Bottom)))
+	}
+	for _, d := range b.rects[:int(dirtyBytes)/int(unsafe.Sizeof(windows.Rect{}))] {
+		res = append(res, image.Rect(int(d.Left), int(d.Top), int(d.Right), int(d.Bottom)))
+	}
+	return res
+}
+
+// convert 함수는 스테이징 텍스처(BGRA)의 r 영역을 누적 화면(RGBA)에 변환해 씁니다.
+func (b *dxgiBackend) convert(src []byte, pitch int, r image.Rectangle) { // 단일 책임: BGRA → RGBA 변환
+	r = r.Intersect(b.frame.Rect)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		s := src[y*pitch+r.Min.X*4 : y*pitch+r.Max.X*4]
+		d := b.frame.Pix[b.frame.PixOffset(r.Min.X, y):]
+		for i := 0; i < len(s); i += 4 { // 데스크톱 알파는 정의되지 않으므로 불투명 처리
+			d[i], d[i+1], d[i+2], d[i+3] = s[i+2], s[i+1], s[i], 255
+		}
+	}
+}
+
+// snapshot 함수는 누적 화면의 복사본을 풀 버퍼에 만들어 반환합니다 (인코딩 중에도 다음 프레임 반영 가능).
+func (b *dxgiBackend) snapshot() *image.RGBA { // 단일 책임: 누적 화면 복사
+	img := b.pool.get(b.frame.Rect.Dx(), b.frame.Rect.Dy())
+	copy(img.Pix, b.frame.Pix)
+	return img
+}
+
+// release 함수는 grab 이 반환한 버퍼를 풀에 반환합니다 (원점이 아닌 screenshot 이미지는 받지 않음).
+func (b *dxgiBackend) release(img *image.RGBA) { // 단일 책임: 버퍼 반환
+	if img.Rect.Min != (image.Point{}) {
+		return
+	}
+	b.pool.put(img)
+}
+
+// close 함수는 복제 세션과 D3D 객체를 해제합니다.
+func (b *dxgiBackend) close() { // 단일 책임: COM 자원 해제
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.staging.release()
+	b.dupl.release()
+	b.context.release()
+	b.device.release()
+	b.staging, b.dupl, b.context, b.device = nil, nil, nil, nil
+}
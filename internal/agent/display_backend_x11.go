@@ -0,0 +1,154 @@
+//go:build linux
+
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"os"
+	"sync"
+
+	"github.com/jezek/xgb"
+	mshm "github.com/jezek/xgb/shm"
+	"github.com/jezek/xgb/xinerama"
+	"github.com/jezek/xgb/xproto"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	X11_BACKEND_NAME = "x11-shm" // 진단에 표시되는 백엔드 이름
+)
+
+// x11ShmBackend 구조체는 X 서버 연결과 MIT-SHM 공유 메모리 세그먼트를 세션 동안 유지하는 X11 캡처 세션입니다.
+// kbinani/screenshot 은 프레임마다 연결/세그먼트/RGBA 를 새로 만들므로, 같은 모니터를 반복 캡처할 때는 이 세션을 씁니다.
+type x11ShmBackend struct { // 단일 책임: X11 공유 메모리 캡처
+	mu   sync.Mutex
+	conn *xgb.Conn
+	root xproto.Drawable
+	rect image.Rectangle // 루트 창 좌표 캡처 영역
+	seg  mshm.Seg
+	data []byte   // X 서버가 GetImage 결과를 쓰는 공유 메모리 (BGRX)
+	pool rgbaPool // grab 반환 버퍼 풀
+}
+
+// newDisplayBackend 함수는 모니터 영역 bounds 를 MIT-SHM 으로 캡처하는 X11 세션을 엽니다.
+// Wayland 세션, X 서버 없음, MIT-SHM 미지원(원격 X), 32비트 픽셀이 아닌 화면은 errDisplayBackendUnsupported 로 screenshot 에 맡깁니다.
+func newDisplayBackend(_ int, bounds image.Rectangle) (be displayBackend, err error) { // 단일 책임: X11 세션 생성
+	if os.Getenv("XDG_SESSION_TYPE") == "wayland" {
+		return nil, errDisplayBackendUnsupported
+	}
+	defer func() { // xgb 는 연결 오류 시 panic 할 수 있음
+		if r := recover(); r != nil {
+			be, err = nil, fmt.Errorf("X11 캡처 세션 생성 실패: %v", r)
+		}
+	}()
+	c, err := xgb.NewConn()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errDisplayBackendUnsupported, err)
+	}
+	b := &x11ShmBackend{conn: c}
+	if err := b.open(bounds); err != nil {
+		b.close()
+		return nil, err
+	}
+	return b, nil
+}
+
+// open 함수는 캡처 영역을 루트 창 좌표로 바꾸고 공유 메모리 세그먼트를 만들어 X 서버에 붙입니다.
+func (b *x11ShmBackend) open(bounds image.Rectangle) error { // 단일 책임: 세그먼트 준비
+	c := b.conn
+	if mshm.Init(c) != nil {
+		return errDisplayBackendUnsupported
+	}
+	setup := xproto.Setup(c)
+	screen := setup.DefaultScreen(c)
+	bpp := 0
+	for _, f := range setup.PixmapFormats {
+		if f.Depth == screen.RootDepth {
+			bpp = int(f.BitsPerPixel)
+		}
+	}
+	if bpp != 32 {
+		return errDisplayBackendUnsupported
+	}
+	origin := image.Point{} // screenshot 의 모니터 좌표는 주 모니터(xinerama 0번) 기준
+	if xinerama.Init(c) == nil {
+		if reply, err := xinerama.QueryScreens(c).Reply(); err == nil && len(reply.ScreenInfo) > 0 {
+			origin = image.Pt(int(reply.ScreenInfo[0].XOrg), int(reply.ScreenInfo[0].YOrg))
+		}
+	}
+	b.root = xproto.Drawable(screen.Root)
+	b.rect = bounds.Add(origin).Intersect(image.Rect(0, 0, int(screen.WidthInPixels), int(screen.HeightInPixels)))
+	if b.rect.Dx() != bounds.Dx() || b.rect.Dy() != bounds.Dy() {
+		return fmt.Errorf("모니터 %v 가 X 화면 밖으로 벗어남", bounds)
+	}
+	id, err := unix.SysvShmGet(unix.IPC_PRIVATE, b.rect.Dx()*b.rect.Dy()*4, unix.IPC_CREAT|0o600)
+	if err != nil {
+		return fmt.Errorf("공유 메모리 생성 실패: %w", err)
+	}
+	defer func() { _, _ = unix.SysvShmCtl(id, unix.IPC_RMID, nil) }() // 양쪽이 붙은 뒤 삭제 표시 (프로세스가 죽어도 남지 않음)
+	if b.data, err = unix.SysvShmAttach(id, 0, 0); err != nil {
+		return fmt.Errorf("공유 메모리 연결 실패: %w", err)
+	}
+	if b.seg, err = mshm.NewSegId(c); err != nil {
+		return err
+	}
+	if err := mshm.AttachChecked(c, b.seg, uint32(id), false).Check(); err != nil {
+		b.seg = 0
+		return fmt.Errorf("%w: %v", errDisplayBackendUnsupported, err) // 다른 호스트의 X 서버
+	}
+	return nil
+}
+
+// name 함수는 백엔드 이름을 반환합니다.
+func (b *x11ShmBackend) name() string { // 단일 책임: 이름 조회
+	return X11_BACKEND_NAME
+}
+
+// grab 함수는 X 서버가 공유 메모리에 쓴 화면을 풀 버퍼로 옮겨(BGRX → RGBA) 반환합니다.
+func (b *x11ShmBackend) grab() (img *image.RGBA, err error) { // 단일 책임: 프레임 획득
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.data == nil {
+		return nil, errors.New("X11 캡처 세션이 닫힘")
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			img, err = nil, fmt.Errorf("X11 캡처 실패: %v", r)
+		}
+	}()
+	r := b.rect
+	if _, err := mshm.GetImage(b.conn, b.root, int16(r.Min.X), int16(r.Min.Y), uint16(r.Dx()), uint16(r.Dy()),
+		0xffffffff, byte(xproto.ImageFormatZPixmap), b.seg, 0).Reply(); err != nil {
+		return nil, fmt.Errorf("X11 GetImage 실패: %w", err)
+	}
+	img = b.pool.get(r.Dx(), r.Dy())
+	pix, src := img.Pix, b.data[:len(img.Pix)]
+	for i := 0; i < len(pix); i += 4 {
+		pix[i], pix[i+1], pix[i+2], pix[i+3] = src[i+2], src[i+1], src[i], 0xff
+	}
+	return img, nil
+}
+
+// release 함수는 grab 이 반환한 버퍼를 풀에 돌려놓습니다.
+func (b *x11ShmBackend) release(img *image.RGBA) { // 단일 책임: 버퍼 반환
+	b.pool.put(img)
+}
+
+// close 함수는 X 서버 쪽 세그먼트와 공유 메모리 연결, X 연결을 닫습니다.
+func (b *x11ShmBackend) close() { // 단일 책임: 세션 자원 해제
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.conn == nil {
+		return
+	}
+	if b.seg != 0 {
+		_ = mshm.DetachChecked(b.conn, b.seg).Check()
+	}
+	if b.data != nil {
+		_ = unix.SysvShmDetach(b.data)
+	}
+	b.conn.Close()
+	b.conn, b.data, b.seg = nil, nil, 0
+}
@@ -0,0 +1,63 @@
+package agent
+
+import (
+	"fmt"
+	"image"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	DISPLAY_CHANGED_EVENT = "display_changed" // 모니터 연결/해제 또는 해상도/배치 변경 (count, monitors, mode, monitor_index, index_reset)
+	DISPLAY_POLL_MS       = 2000              // 모니터 구성 확인 주기(ms)
+)
+
+// startDisplayWatch 함수는 DisplayWatch 설정 시 DISPLAY_POLL_MS 마다 모니터 구성을 확인해,
+// 바뀌면 캡처러를 다시 만들고 display_changed 이벤트를 발행하는 고루틴을 시작합니다.
+func (a *Agent) startDisplayWatch() { // 단일 책임: 모니터 구성 감시 시작
+	if !a.cfg.DisplayWatch || a.cfg.TestPattern {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(time.Duration(DISPLAY_POLL_MS) * time.Millisecond)
+		defer ticker.Stop()
+		prev := listMonitors()
+		for {
+			select {
+			case <-a.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if cur := listMonitors(); !slices.Equal(cur, prev) {
+				a.onDisplayChange(prev, cur)
+				prev = cur
+			}
+		}
+	}()
+}
+
+// onDisplayChange 함수는 모니터 구성 변경을 반영합니다. single 모드 대상 모니터가 사라졌으면 주 모니터(0)로 되돌리고,
+// 화면 캡처러를 새 구성으로 다시 만들어 잘못된 화면이나 옛 해상도로 계속 캡처하지 않게 합니다.
+func (a *Agent) onDisplayChange(prev, cur []image.Rectangle) { // 단일 책임: 모니터 구성 변경 처리
+	a.capMu.Lock()
+	reset := false
+	if a.cfg.MonitorMode == "single" && a.cfg.MonitorIndex >= len(cur) && len(cur) > 0 {
+		a.cfg.MonitorIndex, reset = 0, true
+	}
+	switch a.capturer.(type) { // 화면 캡처러만 교체 (창/테스트 패턴/더미는 모니터 구성과 무관)
+	case *screenshotCapturer, *monitorSetCapturer:
+		if len(cur) > 0 {
+			a.setCapturerLocked(newRealCapturer(a.cfg))
+		}
+	}
+	mode, index := a.cfg.MonitorMode, a.cfg.MonitorIndex
+	a.capMu.Unlock()
+	layout := make([]string, len(cur))
+	for i, b := range cur {
+		layout[i] = formatMonitorInfo(i, b)
+	}
+	monitors := strings.Join(layout, ";")
+	a.logger.Infow("모니터 구성 변경", "previous_count", len(prev), "count", len(cur), "monitors", monitors, "mode", mode, "monitor_index", index, "index_reset", reset)
+	a.emitEvent(DISPLAY_CHANGED_EVENT, fmt.Sprintf("count=%d monitors=%s mode=%s monitor_index=%d index_reset=%t", len(cur), monitors, mode, index, reset))
+}
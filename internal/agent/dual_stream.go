@@ -0,0 +1,65 @@
+package agent
+
+import (
+	"image"
+	"sync/atomic"
+	"time"
+
+	"agent/internal/config"
+)
+
+const (
+	PREVIEW_STREAM_ENCODING = "jpeg" // 이중 스트림 preview 프레임 인코딩 (축소 썸네일은 손실 압축이 유리)
+)
+
+// previewScale 변수는 preview 프레임 축소에 쓰는 출력 설정입니다. 고정 축소 없이 비율만 적용해 저비용 보간을 사용합니다.
+var previewScale = outputScale{factor: 1}
+
+// dualStream 구조체는 한 번 캡처한 프레임을 매 틱 저해상도 preview 프레임과 낮은 주기의 원본 해상도 프레임으로 나눕니다.
+// 모니터별 상태마다 하나씩 두어 all 모드에서도 모니터마다 원본 프레임 주기를 따로 셉니다.
+type dualStream struct { // 단일 책임: preview/원본 프레임 분기
+	previewPct   int           // preview 프레임 해상도 비율(%)
+	fullInterval time.Duration // 원본 해상도 프레임 최소 간격
+	nextFull     time.Time     // 다음 원본 해상도 프레임 허용 시각
+}
+
+// newDualStream 함수는 설정에서 dualStream 을 생성합니다. 비활성이면 nil 을 반환합니다.
+func newDualStream(cfg *config.Config) *dualStream { // 단일 책임: 인스턴스 생성
+	if !cfg.DualStream {
+		return nil
+	}
+	return &dualStream{previewPct: cfg.PreviewScalePct, fullInterval: time.Second / time.Duration(cfg.FullStreamFPS)}
+}
+
+// fullDue 함수는 now 시각 프레임을 원본 해상도로도 보낼 차례인지 판단하고, 그렇다면 다음 허용 시각을 갱신합니다.
+func (d *dualStream) fullDue(now time.Time) bool { // 단일 책임: 원본 프레임 주기 판단
+	if now.Before(d.nextFull) {
+		return false
+	}
+	d.nextFull = now.Add(d.fullInterval)
+	return true
+}
+
+// submitPreview 함수는 img 를 preview 비율로 축소해 인코딩하는 작업을 풀에 제출합니다.
+// withFull 이면 원본 프레임 작업과 img 를 공유하므로, 두 작업이 모두 끝나야 release 를 호출하는 반환 함수를
+// 원본 프레임 작업의 반환 함수로 써야 합니다 (withFull 이 아니면 nil 반환).
+func (d *dualStream) submitPreview(pool *encodePool, img image.Image, opts encodeOptions, meta frameMeta, release func(image.Image), withFull bool) func(image.Image) { // 단일 책임: preview 프레임 제출
+	opts.encoding = PREVIEW_STREAM_ENCODING
+	pct := d.previewPct
+	meta.preview, meta.encoding = true, opts.encoding
+	meta.scalePct = int32(previewScale.ratio(img.Bounds(), pct))
+	enc := func(img image.Image) ([]byte, error) { return opts.encode(previewScale.apply(img, pct)) }
+	if !withFull {
+		pool.submit(img, enc, release, meta)
+		return nil
+	}
+	var refs atomic.Int32
+	refs.Store(2)
+	shared := func(img image.Image) {
+		if refs.Add(-1) == 0 {
+			release(img)
+		}
+	}
+	pool.submit(img, enc, shared, meta)
+	return shared
+}
@@ -0,0 +1,162 @@
+package agent
+
+import (
+	"fmt"
+	"image"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	monitorProto "agent/proto"
+)
+
+const (
+	ENCODE_WORKERS_AUTO_MAX = 4 // 자동 설정 시 최대 인코딩 워커 수
+)
+
+// encodeFunc 타입은 원본 이미지를 전송용 바이트로 인코딩하는 함수입니다.
+type encodeFunc func(img image.Image) ([]byte, error)
+
+// encodeResult 구조체는 인코딩 결과를 보관합니다.
+type encodeResult struct {
+	data    []byte
+	err     error
+	started time.Time     // 워커 인코딩 시작 시각
+	elapsed time.Duration // 워커 인코딩 소요 시간
+}
+
+// encodeJob 구조체는 워커가 처리할 단일 프레임 인코딩 작업입니다.
+type encodeJob struct {
+	img    image.Image
+	enc    encodeFunc
+	free   func(img image.Image) // 인코딩 완료 후 원본 버퍼 반환 (nil 허용)
+	meta   frameMeta
+	result chan encodeResult // 완료 시 1회 전달 (버퍼 1)
+}
+
+// frameMeta 구조체는 캡처 시점에 결정되는 프레임 메타데이터입니다.
+type frameMeta struct {
+	timestamp int64                       // 캡처 시각 (ms)
+	preview   bool                        // preview 여부
+	scalePct  int32                       // 전송 해상도 비율(%)
+	encoding  string                      // 인코딩 방식
+	keyframe  bool                        // delta/tiles 키프레임 여부
+	width     int32                       // delta/tiles 프레임 폭
+	height    int32                       // delta/tiles 프레임 높이
+	regions   []*monitorProto.FrameRegion // tiles 변경 영역 (data 는 인코딩 단계에서 채움)
+	regionEnc string                      // tiles 영역 이미지 형식
+	unchanged bool                        // 직전 전송 프레임과 동일 (이미지 없는 생존 표시)
+	monitorID int32                       // 캡처한 모니터 인덱스 (MONITOR_ID_NONE: 특정 모니터 아님)
+	encodeDur time.Duration               // 인코딩 소요 시간 (풀 수집기가 채움)
+	limit     *frameLimit                 // 크기 제한 재인코딩 결과 (nil 이면 제한 없음)
+	trace     frameTrace                  // 파이프라인 단계 시각 (추적용)
+}
+
+// encodePool 구조체는 캡처와 분리된 비동기 인코딩 단계입니다. 독립 프레임을 여러 고루틴에서
+// 병렬 인코딩하고 제출 순서대로 결과를 전달합니다.
+type encodePool struct { // 단일 책임: 비동기 병렬 인코딩 + 순서 보장
+	jobs    chan *encodeJob // 워커 입력 큐
+	order   chan *encodeJob // 제출 순서 큐 (수집기가 순서대로 대기, 용량 = 진행 중 작업 상한)
+	sink    func(data []byte, meta frameMeta, err error)
+	wg      sync.WaitGroup
+	done    chan struct{} // 수집기 종료 신호
+	skipped atomic.Uint64 // 단계 포화로 캡처를 생략한 횟수
+}
+
+// resolveEncodeWorkers 함수는 설정값(0 이하=자동)을 실제 워커 수로 변환합니다.
+// 자동 모드는 GOMAXPROCS(CPU 예산 반영) 기준이며, 명시 값도 GOMAXPROCS 를 넘지 않습니다.
+func resolveEncodeWorkers(configured int) int { // 단일 책임: 워커 수 계산
+	procs := runtime.GOMAXPROCS(0)
+	if configured > 0 {
+		if configured > procs {
+			return procs
+		}
+		return configured
+	}
+	n := procs
+	if n > ENCODE_WORKERS_AUTO_MAX {
+		n = ENCODE_WORKERS_AUTO_MAX
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// newEncodePool 함수는 workers 개 인코딩 고루틴과 순서 보장 수집기를 시작합니다.
+func newEncodePool(workers int, sink func(data []byte, meta frameMeta, err error)) *encodePool { // 단일 책임: 풀 생성
+	p := &encodePool{
+		jobs:  make(chan *encodeJob, workers),
+		order: make(chan *encodeJob, workers*2), // 진행 중 작업 상한 = 워커 수의 2배
+		sink:  sink,
+		done:  make(chan struct{}),
+	}
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go p.worker()
+	}
+	go p.collect()
+	return p
+}
+
+// worker 함수는 작업을 받아 인코딩한 뒤 결과 채널로 전달합니다.
+func (p *encodePool) worker() { // 단일 책임: 인코딩 수행
+	defer p.wg.Done()
+	for job := range p.jobs {
+		start := time.Now()
+		var data []byte
+		var err error
+		if p := catchPanic(func() { data, err = job.enc(job.img) }); p != nil { // 인코더 panic 은 해당 프레임 실패로 처리 (워커가 죽으면 프로세스 종료)
+			data, err = nil, fmt.Errorf("인코딩 panic: %v", p.value)
+		}
+		elapsed := time.Since(start)
+		if job.free != nil {
+			job.free(job.img)
+		}
+		job.img = nil
+		job.result <- encodeResult{data: data, err: err, started: start, elapsed: elapsed}
+	}
+}
+
+// collect 함수는 제출 순서대로 결과를 기다려 sink 로 전달합니다.
+func (p *encodePool) collect() { // 단일 책임: 순서 보장 전달
+	defer close(p.done)
+	for job := range p.order {
+		res := <-job.result
+		job.meta.encodeDur = res.elapsed
+		job.meta.trace.encodeStart, job.meta.trace.encodeDur = res.started, res.elapsed
+		p.sink(res.data, job.meta, res.err)
+	}
+}
+
+// free 함수는 블로킹 없이 더 제출할 수 있는 작업 수를 반환합니다.
+// 제출자는 captureLoop 하나뿐이고 수집기는 자리를 비우기만 하므로, 제출자가 본 값보다 줄지 않습니다.
+func (p *encodePool) free() int { // 단일 책임: 남은 자리 조회
+	return cap(p.order) - len(p.order)
+}
+
+// hasRoom 함수는 n 개 작업을 블로킹 없이 제출할 수 있는지 반환하고, 자리가 모자라면 생략한 캡처로 셉니다.
+// 한 틱에 여러 작업을 내는 경우(이중 스트림, all 모드)는 내기 전에 필요한 수만큼 확인합니다.
+func (p *encodePool) hasRoom(n int) bool { // 단일 책임: 제출 가능 여부 조회
+	if p.free() >= n {
+		return true
+	}
+	p.skipped.Add(1)
+	return false
+}
+
+// submit 함수는 인코딩 작업을 제출합니다. 진행 중 작업이 상한에 도달하면 블로킹되므로 먼저 hasRoom 으로 확인합니다.
+func (p *encodePool) submit(img image.Image, enc encodeFunc, free func(image.Image), meta frameMeta) { // 단일 책임: 작업 제출
+	job := &encodeJob{img: img, enc: enc, free: free, meta: meta, result: make(chan encodeResult, 1)}
+	p.order <- job
+	p.jobs <- job
+}
+
+// close 함수는 신규 작업을 막고 남은 작업의 인코딩/전달이 끝날 때까지 기다립니다.
+func (p *encodePool) close() { // 단일 책임: 풀 종료
+	close(p.jobs)
+	close(p.order)
+	p.wg.Wait()
+	<-p.done
+}
@@ -0,0 +1,158 @@
+package agent
+
+import (
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+
+	"agent/internal/config"
+
+	"github.com/HugoSmits86/nativewebp"
+)
+
+const (
+	ENCODING_WEBP = "webp" // WebP (libwebp 빌드: 손실/무손실, 기본 빌드: 순수 Go 무손실)
+)
+
+// encodeOptions 구조체는 프레임 인코딩 방식과 품질 설정을 보관합니다.
+type encodeOptions struct { // 단일 책임: 인코딩 설정 보관
+	encoding     string // png | jpeg | delta | tiles | webp
+	jpegQuality  int    // jpeg 품질 (1~100)
+	jpegEncoder  string // auto | stdlib | turbo
+	webpQuality  int    // webp 손실 압축 품질 (1~100)
+	webpLossless bool   // webp 무손실 압축 여부
+	tileEncoding string // tiles 인코딩 변경 영역 이미지 형식 (png | jpeg | webp)
+	grayscale    bool   // png/jpeg 인코딩 전에 8비트 회색조로 변환
+	pngLevel     int    // png 압축 수준 (png.CompressionLevel 값)
+}
+
+// encodeOptionsFromConfig 함수는 설정에서 인코딩 옵션을 구성합니다.
+func encodeOptionsFromConfig(cfg *config.Config) encodeOptions { // 단일 책임: 옵션 변환
+	return encodeOptions{
+		encoding:     cfg.CaptureEncoding,
+		jpegQuality:  cfg.JpegQuality,
+		jpegEncoder:  cfg.JpegEncoder,
+		webpQuality:  cfg.WebpQuality,
+		webpLossless: cfg.WebpLossless,
+		tileEncoding: cfg.TileEncoding,
+		grayscale:    cfg.Grayscale,
+		pngLevel:     int(pngCompressionLevel(cfg.PngCompression)),
+	}
+}
+
+// encode 함수는 선택한 인코딩으로 이미지를 인코딩합니다.
+// delta/tiles 는 프레임 간 상태가 필요하므로 captureLoop 에서 처리하며, 단독 호출 시에는 무손실 PNG 로 대체합니다.
+func (o encodeOptions) encode(img image.Image) ([]byte, error) { // 단일 책임: 선택 인코딩 처리
+	if _, gray := img.(*image.Gray); o.grayscale && !gray && o.encoding != ENCODING_WEBP { // 단일 성분 JPEG / 8비트 PNG 로 크기 절감
+		g := toGray(img)
+		defer putGrayBuffer(g)
+		img = g
+	}
+	if o.encoding == "jpeg" {
+		if o.useTurboJPEG() && !o.grayscale { // 가속 인코더는 RGBA 입력만 받으므로 회색조는 표준 인코더 사용
+			if b, err := encodeJPEGTurbo(img, o.jpegQuality); err == nil {
+				return b, nil
+			}
+			// 가속 인코더 실패 시 표준 인코더로 폴백
+		}
+		return encodeJPEG(img, o.jpegQuality)
+	}
+	if o.encoding == ENCODING_WEBP {
+		return encodeWebP(img, o.webpQuality, o.webpLossless)
+	}
+	return encodePNG(img, png.CompressionLevel(o.pngLevel))
+}
+
+// regionOptions 함수는 tiles 인코딩의 변경 영역을 압축할 인코딩 옵션을 반환합니다.
+func (o encodeOptions) regionOptions() encodeOptions { // 단일 책임: 영역 인코딩 옵션 변환
+	o.encoding = o.tileEncoding
+	return o
+}
+
+// useTurboJPEG 함수는 libjpeg-turbo 가속 인코더 사용 여부를 판단합니다.
+func (o encodeOptions) useTurboJPEG() bool { // 단일 책임: 가속 인코더 선택
+	if !turboJPEGAvailable {
+		return false
+	}
+	return o.jpegEncoder == "auto" || o.jpegEncoder == "turbo"
+}
+
+// pngCompressionLevel 함수는 설정 이름(default | speed | best | none)을 PNG 압축 수준으로 변환합니다.
+func pngCompressionLevel(name string) png.CompressionLevel { // 단일 책임: 압축 수준 변환
+	switch name {
+	case "speed":
+		return png.BestSpeed
+	case "best":
+		return png.BestCompression
+	case "none":
+		return png.NoCompression
+	}
+	return png.DefaultCompression
+}
+
+// encodePNG 함수는 이미지를 PNG 바이트로 인코딩합니다. 압축기 상태는 공용 풀에서 재사용합니다.
+func encodePNG(img image.Image, level png.CompressionLevel) ([]byte, error) { // 단일 책임: PNG 인코딩
+	buf := getEncodeBuffer()
+	enc := png.Encoder{CompressionLevel: level, BufferPool: pngEncoderPool}
+	if err := enc.Encode(buf, img); err != nil {
+		putEncodeBuffer(buf)
+		return nil, err
+	}
+	return finishEncodeBuffer(buf), nil
+}
+
+// encodeJPEG 함수는 이미지를 JPEG 바이트로 인코딩합니다.
+func encodeJPEG(img image.Image, quality int) ([]byte, error) { // 단일 책임: JPEG 인코딩
+	buf := getEncodeBuffer()
+	if err := jpeg.Encode(buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		putEncodeBuffer(buf)
+		return nil, err
+	}
+	return finishEncodeBuffer(buf), nil
+}
+
+// encodeWebP 함수는 이미지를 WebP 바이트로 인코딩합니다. libwebp 가 빌드에 없으면 순수 Go 무손실(VP8L) 인코더를 사용하므로
+// 손실 압축을 요청해도 무손실로 인코딩됩니다.
+func encodeWebP(img image.Image, quality int, lossless bool) ([]byte, error) { // 단일 책임: WebP 인코딩
+	if libwebpAvailable {
+		return encodeWebPLib(img, quality, lossless)
+	}
+	buf := getEncodeBuffer()
+	if err := nativewebp.Encode(buf, img, &nativewebp.Options{CompressionLevel: nativewebp.BestSpeed}); err != nil { // 실시간 전송용: 압축률보다 속도 우선
+		putEncodeBuffer(buf)
+		return nil, err
+	}
+	return finishEncodeBuffer(buf), nil
+}
+
+// toRGBA 함수는 이미지를 *image.RGBA 로 변환합니다 (이미 RGBA 이면 그대로 반환).
+func toRGBA(img image.Image) *image.RGBA { // 단일 책임: RGBA 변환
+	if rgba, ok := img.(*image.RGBA); ok {
+		return rgba
+	}
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Src)
+	return rgba
+}
+
+// toGray 함수는 이미지를 풀 버퍼의 8비트 *image.Gray 로 변환합니다. RGBA 는 color.GrayModel 과 같은 가중치로 직접 계산합니다.
+func toGray(img image.Image) *image.Gray { // 단일 책임: 회색조 변환
+	b := img.Bounds()
+	gray := getGrayBuffer(b.Dx(), b.Dy())
+	rgba, ok := img.(*image.RGBA)
+	if !ok {
+		draw.Draw(gray, gray.Bounds(), img, b.Min, draw.Src)
+		return gray
+	}
+	for y := 0; y < b.Dy(); y++ {
+		src := rgba.Pix[rgba.PixOffset(b.Min.X, b.Min.Y+y):]
+		dst := gray.Pix[y*gray.Stride : y*gray.Stride+b.Dx()]
+		for x := range dst {
+			r, g, bl := uint32(src[x*4]), uint32(src[x*4+1]), uint32(src[x*4+2])
+			dst[x] = uint8((19595*r + 38470*g + 7471*bl + 1<<15) >> 16)
+		}
+	}
+	return gray
+}
@@ -0,0 +1,53 @@
+package agent
+
+import (
+	"strings"
+	"sync"
+)
+
+// endpointList 구조체는 AGENT_SERVER_ADDR 의 서버 주소 목록과 현재 접속 대상입니다.
+// 앞의 주소부터 시도하고, 접속 실패나 지속 장애 시 다음 주소로 넘어가 끝에 닿으면 처음으로 돌아갑니다.
+type endpointList struct { // 단일 책임: 서버 주소 순환
+	mu    sync.Mutex
+	addrs []string
+	cur   int
+}
+
+// newEndpointList 함수는 쉼표로 구분한 서버 주소 목록으로 endpointList 를 생성합니다 (빈 항목 제외, 비어 있으면 원문 그대로 한 개).
+func newEndpointList(list string) *endpointList { // 단일 책임: 인스턴스 생성
+	var addrs []string
+	for _, addr := range strings.Split(list, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	if len(addrs) == 0 {
+		addrs = []string{list}
+	}
+	return &endpointList{addrs: addrs}
+}
+
+// current 함수는 현재 접속 대상 주소를 반환합니다.
+func (e *endpointList) current() string { // 단일 책임: 현재 주소 조회
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.addrs[e.cur]
+}
+
+// advance 함수는 다음 주소로 넘어가고, 목록을 한 바퀴 돌아 처음 주소로 돌아왔으면 true 를 반환합니다.
+func (e *endpointList) advance() bool { // 단일 책임: 다음 주소 선택
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.cur = (e.cur + 1) % len(e.addrs)
+	return e.cur == 0
+}
+
+// count 함수는 주소 개수를 반환합니다.
+func (e *endpointList) count() int { // 단일 책임: 주소 개수 조회
+	return len(e.addrs)
+}
+
+// list 함수는 주소 목록 복사본을 반환합니다.
+func (e *endpointList) list() []string { // 단일 책임: 주소 목록 조회
+	return append([]string(nil), e.addrs...)
+}
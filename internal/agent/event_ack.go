@@ -0,0 +1,287 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"agent/internal/config"
+	"agent/internal/spool"
+	monitorProto "agent/proto"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	EVENT_ACK_DIR_NAME     = "acked_events" // 스풀 폴더 하위 확인 대기 이벤트 큐 폴더
+	EVENT_ACK_SEQ_FILE     = "acked.seq"    // 서버가 마지막으로 확인한 순번 파일 (재시작 후 순번 이어 쓰기)
+	EVENT_ACK_SEGMENT_SIZE = 1 << 20        // 확인 대기 큐 세그먼트 크기
+	EVENT_ACK_WINDOW       = 64             // 확인 없이 연속으로 보낼 최대 이벤트 수
+)
+
+// errEventAckTimeout 변수는 보낸 이벤트의 확인이 제한 시간 안에 오지 않았음을 나타냅니다.
+var errEventAckTimeout = errors.New("이벤트 수신 확인 시간 초과")
+
+// eventAckQueue 구조체는 서버 확인 전까지 이벤트를 디스크에 보관하는 영속 큐입니다.
+// 스풀 읽기 위치가 보낸 위치, 커밋 위치가 확인된 위치이며 보낸 이벤트가 모두 확인될 때만 커밋합니다.
+// 확인 전에 끊기거나 재시작하면 마지막 커밋 위치부터 같은 순번으로 다시 보내므로 전달은 최소 1회이고 중복 제거는 서버가 순번으로 합니다.
+type eventAckQueue struct { // 단일 책임: 확인 기반 이벤트 보관
+	mu      sync.Mutex // 순번 발급과 기록 순서 보호
+	sendMu  sync.Mutex // 스트림 하나만 큐를 읽도록 보호 (연결 교체 중 겹침 방지)
+	sp      *spool.Spool
+	seqPath string
+	nextSeq uint64          // 다음 발급 순번
+	types   map[string]bool // 보관할 이벤트 타입/분류 (비어 있으면 전부)
+	timeout time.Duration   // 확인 대기 제한 시간
+	wake    chan struct{}   // 새 이벤트 알림
+
+	queued atomic.Uint64 // 누적 보관 이벤트 수
+	sent   atomic.Uint64 // 누적 송신 수 (재전송 포함)
+	acked  atomic.Uint64 // 서버가 확인한 마지막 순번
+	failed atomic.Uint64 // 큐에 넣지 못해 일반 경로로 보낸 수
+}
+
+// openEventAckQueue 함수는 설정이 켜져 있으면 데이터 디렉터리의 확인 대기 큐를 엽니다 (비활성 또는 실패 시 nil).
+func openEventAckQueue(cfg *config.Config, logger *zap.SugaredLogger) *eventAckQueue { // 단일 책임: 큐 열기
+	if !cfg.EventAckQueue {
+		return nil
+	}
+	dir := filepath.Join(cfg.DataDir, SPOOL_DIR_NAME, EVENT_ACK_DIR_NAME)
+	segments := max(2, int(int64(cfg.EventAckMaxMB)<<20/EVENT_ACK_SEGMENT_SIZE))
+	sp, err := spool.Open(dir, spool.Options{SegmentSize: EVENT_ACK_SEGMENT_SIZE, MaxSegments: segments, DropNewest: true})
+	if err != nil {
+		logger.Warnf("이벤트 확인 대기 큐 열기 실패 (일반 전송으로 대체): %v", err)
+		return nil
+	}
+	q := &eventAckQueue{sp: sp, seqPath: filepath.Join(dir, EVENT_ACK_SEQ_FILE), types: eventNameSet(cfg.EventAckTypes), timeout: time.Duration(cfg.EventAckTimeoutSec) * time.Second, wake: make(chan struct{}, 1)}
+	if b, err := os.ReadFile(q.seqPath); err == nil {
+		if n, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64); err == nil {
+			q.acked.Store(n)
+		}
+	}
+	last := q.acked.Load()
+	for { // 확인 대기 중인 이벤트의 최대 순번 이후부터 발급
+		ev, ok, err := q.next()
+		if err != nil || !ok {
+			break
+		}
+		last = max(last, ev.GetSeq())
+	}
+	sp.Rewind()
+	q.nextSeq = last + 1
+	return q
+}
+
+// accepts 함수는 이벤트를 확인 대기 큐로 보낼 대상인지 반환합니다.
+func (q *eventAckQueue) accepts(ev *monitorProto.EventData) bool { // 단일 책임: 대상 판별
+	return q != nil && (len(q.types) == 0 || q.types[ev.GetEventType()] || q.types[ev.GetCategory()])
+}
+
+// enqueue 함수는 이벤트에 순번을 붙여 디스크에 기록하고 송신 고루틴을 깨웁니다. 기록에 실패하면 false 입니다.
+func (q *eventAckQueue) enqueue(ev *monitorProto.EventData) bool { // 단일 책임: 순번 발급 + 기록
+	q.mu.Lock()
+	ev.Seq = q.nextSeq
+	b, err := proto.Marshal(ev)
+	if err == nil {
+		err = q.sp.Append(SPOOL_RECORD_KIND, b)
+	}
+	if err != nil {
+		ev.Seq = 0
+		q.mu.Unlock()
+		q.failed.Add(1)
+		return false
+	}
+	q.nextSeq++
+	q.mu.Unlock()
+	q.queued.Add(1)
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// next 함수는 아직 보내지 않은 다음 이벤트를 읽습니다. 손상된 레코드는 건너뜁니다.
+func (q *eventAckQueue) next() (*monitorProto.EventData, bool, error) { // 단일 책임: 다음 이벤트 읽기
+	for {
+		rec, ok, err := q.sp.Next()
+		if err != nil || !ok {
+			return nil, false, err
+		}
+		ev := &monitorProto.EventData{}
+		if proto.Unmarshal(rec.Data, ev) == nil {
+			return ev, true, nil
+		}
+	}
+}
+
+// commit 함수는 보낸 이벤트가 모두 seq 까지 확인되었을 때 큐 위치를 확정하고 확인 순번을 저장합니다.
+func (q *eventAckQueue) commit(seq uint64) error { // 단일 책임: 확인 위치 확정
+	if err := q.sp.Commit(); err != nil {
+		return err
+	}
+	tmp := q.seqPath + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.FormatUint(seq, 10)), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, q.seqPath)
+}
+
+// close 함수는 큐를 닫습니다 (확인되지 않은 이벤트는 다음 실행에서 재전송).
+func (q *eventAckQueue) close() { // 단일 책임: 큐 닫기
+	if q != nil {
+		_ = q.sp.Close()
+	}
+}
+
+// openAckedEventStream 함수는 확인 대기 큐가 켜져 있으면 현재 연결에서 확인 기반 이벤트 스트림을 시작합니다.
+func (a *Agent) openAckedEventStream() { // 단일 책임: 확인 스트림 시작
+	a.mu.Lock()
+	client := a.agentClient
+	a.mu.Unlock()
+	if client == nil || a.durable == nil {
+		return
+	}
+	go a.guardLoop(LOOP_ACKED_EVENTS, func() { a.runAckedEvents(client) })
+}
+
+// runAckedEvents 함수는 확인 기반 이벤트 스트림을 열어 큐를 비우고, 끊기면 같은 연결에서 백오프로 다시 엽니다.
+// 연결이 교체되었거나 서버가 StreamAckedEvents 를 지원하지 않으면 반환합니다 (보관된 이벤트는 그대로 남음).
+func (a *Agent) runAckedEvents(client monitorProto.AgentServiceClient) { // 단일 책임: 확인 스트림 유지
+	bo := newBackoff(a.cfg)
+	for {
+		err := a.serveAckedEvents(client, bo)
+		if a.closing.Load() || a.ctx.Err() != nil {
+			return
+		}
+		if status.Code(err) == codes.Unimplemented {
+			a.logger.Warn("서버가 확인 기반 이벤트 스트림을 지원하지 않음 (확인 대기 이벤트는 디스크에 보관)")
+			return
+		}
+		a.mu.Lock()
+		current := a.agentClient
+		a.mu.Unlock()
+		if current != client {
+			return
+		}
+		a.logger.Warnf("확인 기반 이벤트 스트림 끊김: %v", err)
+		if werr := bo.wait(a.ctx); werr != nil {
+			return
+		}
+	}
+}
+
+// serveAckedEvents 함수는 스트림 하나가 끊길 때까지 큐의 이벤트를 EVENT_ACK_WINDOW 개씩 보내고 확인을 받습니다.
+// 스풀 커밋은 보낸 이벤트가 모두 확인된 시점에만 가능하므로, 일부만 확인되면 창이 빌 때까지 더 보내지 않습니다
+// (계속 채우면 이벤트가 꾸준히 들어올 때 마지막 송신 순번이 계속 앞서 나가 커밋이 일어나지 않음).
+func (a *Agent) serveAckedEvents(client monitorProto.AgentServiceClient, bo *backoff) error { // 단일 책임: 송신 + 확인 처리
+	q := a.durable
+	q.sendMu.Lock()
+	defer q.sendMu.Unlock()
+	defer q.sp.Rewind() // 확인되지 않은 이벤트는 다음 스트림에서 재전송
+	ctx, cancel := context.WithCancel(a.ctx)
+	defer cancel() // 수신 고루틴 종료
+	stream, err := client.StreamAckedEvents(ctx, a.streamCallOptions()...)
+	if err != nil {
+		return err
+	}
+	acks := make(chan uint64, EVENT_ACK_WINDOW)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			ack, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case acks <- ack.GetSeq():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	a.logger.Infow("확인 기반 이벤트 스트림 생성", "agent_id", a.agentID, "acked_seq", q.acked.Load())
+	inflight, lastSent, draining := 0, uint64(0), false
+	timer := time.NewTimer(q.timeout)
+	defer timer.Stop()
+	for {
+		for !draining && inflight < EVENT_ACK_WINDOW {
+			ev, ok, err := q.next()
+			if err != nil {
+				return err
+			}
+			if !ok {
+				break
+			}
+			ev.AgentId = a.agentID // 재시작 전 보관분도 현재 에이전트 ID 로 보고
+			if err := stream.Send(ev); err != nil {
+				return err
+			}
+			if inflight == 0 {
+				timer.Reset(q.timeout)
+			}
+			q.sent.Add(1)
+			inflight, lastSent = inflight+1, ev.GetSeq()
+		}
+		var expire <-chan time.Time
+		if inflight > 0 {
+			expire = timer.C
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-recvErr:
+			return err
+		case <-expire:
+			return errEventAckTimeout
+		case <-q.wake:
+		case seq := <-acks:
+			if seq > q.acked.Load() {
+				q.acked.Store(seq)
+			}
+			if seq < lastSent { // 일부만 확인: 남은 이벤트 확인까지 채우지 않음
+				inflight, draining = int(lastSent-seq), true
+				timer.Reset(q.timeout)
+				continue
+			}
+			if err := q.commit(q.acked.Load()); err != nil {
+				a.logger.Warnf("이벤트 확인 위치 저장 실패: %v", err)
+			}
+			bo.reset()
+			inflight, draining = 0, false
+		}
+	}
+}
+
+// EventAckStats 구조체는 확인 기반 이벤트 큐 계측값입니다.
+type EventAckStats struct {
+	Enabled  bool        `json:"enabled"`   // 확인 대기 큐 사용 여부
+	Queued   uint64      `json:"queued"`    // 누적 보관 이벤트 수
+	Sent     uint64      `json:"sent"`      // 누적 송신 수 (재전송 포함)
+	AckedSeq uint64      `json:"acked_seq"` // 서버가 확인한 마지막 순번
+	NextSeq  uint64      `json:"next_seq"`  // 다음 발급 순번
+	Failed   uint64      `json:"failed"`    // 큐에 넣지 못해 일반 경로로 보낸 수
+	Spool    spool.Stats `json:"spool"`     // 큐 파일 상태
+}
+
+// EventAckStats 메서드는 확인 기반 이벤트 큐 계측값을 반환합니다.
+func (a *Agent) EventAckStats() EventAckStats { // 단일 책임: 확인 큐 계측 노출
+	q := a.durable
+	if q == nil {
+		return EventAckStats{}
+	}
+	q.mu.Lock()
+	next := q.nextSeq
+	q.mu.Unlock()
+	return EventAckStats{Enabled: true, Queued: q.queued.Load(), Sent: q.sent.Load(), AckedSeq: q.acked.Load(), NextSeq: next, Failed: q.failed.Load(), Spool: q.sp.Stats()}
+}
@@ -0,0 +1,79 @@
+package agent
+
+import (
+	"sync"
+	"time"
+
+	monitorProto "agent/proto"
+)
+
+const (
+	EVENT_BATCH_TYPE     = "batch" // 묶음 이벤트 타입 (하위 이벤트는 Batch 필드)
+	EVENT_BATCH_MAX_SIZE = 256     // 창이 끝나기 전이라도 즉시 전송할 최대 묶음 크기
+)
+
+// eventBatcher 구조체는 같은 시간 창에 발생한 이벤트를 하나의 메시지로 묶어 전송 횟수를 줄입니다.
+type eventBatcher struct { // 단일 책임: 이벤트 묶음 전송
+	mu      sync.Mutex
+	window  time.Duration                       // 묶음 시간 창 (0 이면 즉시 전송)
+	pending []*monitorProto.EventData           // 현재 창에 쌓인 이벤트
+	timer   *time.Timer                         // 창 종료 타이머
+	send    func(*monitorProto.EventData) error // 실제 전송 함수
+	agentID string
+}
+
+// newEventBatcher 함수는 eventBatcher 생성자입니다.
+func newEventBatcher(agentID string, window time.Duration, send func(*monitorProto.EventData) error) *eventBatcher { // 단일 책임: 인스턴스 생성
+	return &eventBatcher{agentID: agentID, window: window, send: send}
+}
+
+// add 함수는 이벤트를 현재 창에 추가합니다. 창의 첫 이벤트면 창 종료 시 전송을 예약합니다.
+func (b *eventBatcher) add(ev *monitorProto.EventData) { // 단일 책임: 이벤트 적재
+	if b.window <= 0 {
+		_ = b.send(ev)
+		return
+	}
+	b.mu.Lock()
+	b.pending = append(b.pending, ev)
+	if len(b.pending) >= EVENT_BATCH_MAX_SIZE {
+		batch := b.takeLocked()
+		b.mu.Unlock()
+		b.sendBatch(batch)
+		return
+	}
+	if b.timer == nil {
+		b.timer = time.AfterFunc(b.window, b.flush)
+	}
+	b.mu.Unlock()
+}
+
+// flush 함수는 쌓인 이벤트를 즉시 전송합니다.
+func (b *eventBatcher) flush() { // 단일 책임: 창 종료 전송
+	b.mu.Lock()
+	batch := b.takeLocked()
+	b.mu.Unlock()
+	b.sendBatch(batch)
+}
+
+// takeLocked 함수는 대기 이벤트를 꺼내고 타이머를 정리합니다 (mu 보유 상태에서 호출).
+func (b *eventBatcher) takeLocked() []*monitorProto.EventData { // 단일 책임: 대기열 회수
+	batch := b.pending
+	b.pending = nil
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	return batch
+}
+
+// sendBatch 함수는 이벤트가 1개면 그대로, 여러 개면 batch 메시지로 묶어 전송합니다.
+func (b *eventBatcher) sendBatch(batch []*monitorProto.EventData) { // 단일 책임: 묶음 전송
+	switch len(batch) {
+	case 0:
+		return
+	case 1:
+		_ = b.send(batch[0])
+		return
+	}
+	_ = b.send(&monitorProto.EventData{AgentId: b.agentID, EventType: EVENT_BATCH_TYPE, Timestamp: batch[0].GetTimestamp(), Batch: batch})
+}
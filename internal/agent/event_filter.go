@@ -0,0 +1,125 @@
+package agent
+
+import (
+	"strings"
+
+	"agent/internal/config"
+	monitorProto "agent/proto"
+)
+
+const (
+	SEVERITY_DEBUG    = "debug"    // 빈번하고 단독으로는 의미가 적은 집계/상세 이벤트
+	SEVERITY_INFO     = "info"     // 일반 상태 변화 (분류표에 없는 이벤트 기본값)
+	SEVERITY_WARNING  = "warning"  // 수집 공백이나 자원 부족처럼 확인이 필요한 상태
+	SEVERITY_CRITICAL = "critical" // 즉시 대응이 필요한 상태
+
+	CATEGORY_SESSION  = "session"  // 로그인/로그아웃, 화면 잠금
+	CATEGORY_ACTIVITY = "activity" // 사용자 작업 (전면 앱, 프로세스, 입력, 클립보드, 파일)
+	CATEGORY_PRIVACY  = "privacy"  // 캡처 금지 앱, 감시 동의
+	CATEGORY_SYSTEM   = "system"   // 전원, 모니터, 자원 사용률
+	CATEGORY_NETWORK  = "network"  // 인터페이스/주소/기본 경로
+	CATEGORY_AGENT    = "agent"    // 연결, 원격 명령, 녹화, 스풀, 인코딩 등 에이전트 자체 동작
+	CATEGORY_OTHER    = "other"    // 분류표에 없는 이벤트 타입
+)
+
+// eventClass 구조체는 이벤트 타입의 분류와 심각도입니다.
+type eventClass struct {
+	category string
+	severity string
+}
+
+// eventClasses 변수는 에이전트가 발행하는 이벤트 타입별 분류표입니다. 새 이벤트 타입을 추가하면 여기에도 등록합니다.
+var eventClasses = map[string]eventClass{
+	INITIAL_EVENT_TYPE:       {CATEGORY_AGENT, SEVERITY_INFO},
+	CONNECTED_EVENT:          {CATEGORY_AGENT, SEVERITY_INFO},
+	DISCONNECTED_EVENT:       {CATEGORY_AGENT, SEVERITY_WARNING},
+	COMMAND_EVENT:            {CATEGORY_AGENT, SEVERITY_INFO},
+	RECORDING_START_EVENT:    {CATEGORY_AGENT, SEVERITY_INFO},
+	RECORDING_STOP_EVENT:     {CATEGORY_AGENT, SEVERITY_INFO},
+	RECORDING_UPLOADED_EVENT: {CATEGORY_AGENT, SEVERITY_INFO},
+	SPOOL_REPLAY_EVENT:       {CATEGORY_AGENT, SEVERITY_INFO},
+	ENCODING_CHANGED_EVENT:   {CATEGORY_AGENT, SEVERITY_DEBUG},
+	CONFIG_RELOADED_EVENT:    {CATEGORY_AGENT, SEVERITY_INFO},
+	CONFIG_INVALID_EVENT:     {CATEGORY_AGENT, SEVERITY_WARNING},
+	SCHEDULE_STARTED_EVENT:   {CATEGORY_AGENT, SEVERITY_INFO},
+	SCHEDULE_STOPPED_EVENT:   {CATEGORY_AGENT, SEVERITY_INFO},
+	CAPTURE_PAUSED_EVENT:     {CATEGORY_AGENT, SEVERITY_INFO},
+	CAPTURE_RESUMED_EVENT:    {CATEGORY_AGENT, SEVERITY_INFO},
+	LOOP_PANIC_EVENT:         {CATEGORY_AGENT, SEVERITY_CRITICAL},
+	CAPTURE_STALLED_EVENT:    {CATEGORY_AGENT, SEVERITY_WARNING},
+	SESSION_LOGIN_EVENT:      {CATEGORY_SESSION, SEVERITY_INFO},
+	SESSION_LOGOUT_EVENT:     {CATEGORY_SESSION, SEVERITY_INFO},
+	SESSION_LOCK_EVENT:       {CATEGORY_SESSION, SEVERITY_INFO},
+	SESSION_UNLOCK_EVENT:     {CATEGORY_SESSION, SEVERITY_INFO},
+	SCREEN_LOCKED_EVENT:      {CATEGORY_SESSION, SEVERITY_INFO},
+	SCREEN_UNLOCKED_EVENT:    {CATEGORY_SESSION, SEVERITY_INFO},
+	FOREGROUND_CHANGED_EVENT: {CATEGORY_ACTIVITY, SEVERITY_DEBUG},
+	PROCESS_STARTED_EVENT:    {CATEGORY_ACTIVITY, SEVERITY_INFO},
+	PROCESS_EXITED_EVENT:     {CATEGORY_ACTIVITY, SEVERITY_INFO},
+	INPUT_ACTIVITY_EVENT:     {CATEGORY_ACTIVITY, SEVERITY_DEBUG},
+	CLIPBOARD_CHANGED_EVENT:  {CATEGORY_ACTIVITY, SEVERITY_INFO},
+	FILE_CREATED_EVENT:       {CATEGORY_ACTIVITY, SEVERITY_INFO},
+	FILE_MODIFIED_EVENT:      {CATEGORY_ACTIVITY, SEVERITY_DEBUG},
+	FILE_DELETED_EVENT:       {CATEGORY_ACTIVITY, SEVERITY_INFO},
+	PRIVACY_BLOCKED_EVENT:    {CATEGORY_PRIVACY, SEVERITY_WARNING},
+	PRIVACY_CLEARED_EVENT:    {CATEGORY_PRIVACY, SEVERITY_INFO},
+	CLIPBOARD_CONSENT_EVENT:  {CATEGORY_PRIVACY, SEVERITY_INFO},
+	CONSENT_ACCEPTED_EVENT:   {CATEGORY_PRIVACY, SEVERITY_INFO},
+	CONSENT_REVOKED_EVENT:    {CATEGORY_PRIVACY, SEVERITY_WARNING},
+	POWER_AC_EVENT:           {CATEGORY_SYSTEM, SEVERITY_INFO},
+	POWER_BATTERY_EVENT:      {CATEGORY_SYSTEM, SEVERITY_INFO},
+	BATTERY_LOW_EVENT:        {CATEGORY_SYSTEM, SEVERITY_WARNING},
+	DISPLAY_CHANGED_EVENT:    {CATEGORY_SYSTEM, SEVERITY_INFO},
+	RESOURCE_HIGH_EVENT:      {CATEGORY_SYSTEM, SEVERITY_WARNING},
+	RESOURCE_NORMAL_EVENT:    {CATEGORY_SYSTEM, SEVERITY_INFO},
+	NETWORK_UP_EVENT:         {CATEGORY_NETWORK, SEVERITY_INFO},
+	NETWORK_DOWN_EVENT:       {CATEGORY_NETWORK, SEVERITY_INFO},
+	NETWORK_ADDR_EVENT:       {CATEGORY_NETWORK, SEVERITY_INFO},
+	NETWORK_ROUTE_EVENT:      {CATEGORY_NETWORK, SEVERITY_INFO},
+}
+
+// severityRank 변수는 최소 심각도 비교용 순위입니다.
+var severityRank = map[string]int{SEVERITY_DEBUG: 0, SEVERITY_INFO: 1, SEVERITY_WARNING: 2, SEVERITY_CRITICAL: 3}
+
+// classifyEvent 함수는 이벤트 타입의 분류와 심각도를 반환합니다. 분류표에 없으면 other/info 입니다.
+func classifyEvent(eventType string) eventClass { // 단일 책임: 이벤트 분류
+	if c, ok := eventClasses[eventType]; ok {
+		return c
+	}
+	return eventClass{CATEGORY_OTHER, SEVERITY_INFO}
+}
+
+// eventFilter 구조체는 설정의 허용/거부 목록과 최소 심각도로 발행할 이벤트를 고릅니다.
+// 목록 항목은 이벤트 타입 또는 분류 이름이며, 거부 목록이 허용 목록보다 우선합니다.
+type eventFilter struct { // 단일 책임: 이벤트 발행 여부 판별
+	allow   map[string]bool // 비어 있으면 모든 타입/분류 허용
+	deny    map[string]bool
+	minRank int // 이 순위 미만 심각도는 버림
+}
+
+// newEventFilter 함수는 설정에서 eventFilter 를 생성합니다.
+func newEventFilter(cfg *config.Config) *eventFilter { // 단일 책임: 인스턴스 생성
+	return &eventFilter{allow: eventNameSet(cfg.EventAllow), deny: eventNameSet(cfg.EventDeny), minRank: severityRank[cfg.EventMinSeverity]}
+}
+
+// eventNameSet 함수는 쉼표로 구분한 이벤트 타입/분류 목록을 소문자 집합으로 만듭니다.
+func eventNameSet(list string) map[string]bool { // 단일 책임: 목록 파싱
+	set := map[string]bool{}
+	for _, n := range strings.Split(list, ",") {
+		if n = strings.ToLower(strings.TrimSpace(n)); n != "" {
+			set[n] = true
+		}
+	}
+	return set
+}
+
+// allows 함수는 분류가 채워진 이벤트를 발행할지 판단합니다.
+func (f *eventFilter) allows(ev *monitorProto.EventData) bool { // 단일 책임: 필터 적용
+	if severityRank[ev.GetSeverity()] < f.minRank {
+		return false
+	}
+	if f.deny[ev.GetEventType()] || f.deny[ev.GetCategory()] {
+		return false
+	}
+	return len(f.allow) == 0 || f.allow[ev.GetEventType()] || f.allow[ev.GetCategory()]
+}
@@ -0,0 +1,95 @@
+package agent
+
+import (
+	"sync"
+	"time"
+
+	monitorProto "agent/proto"
+)
+
+// EventRecord 구조체는 서버로 보고한 이벤트 한 건입니다 (UI 의 보고 이벤트 목록용).
+type EventRecord struct {
+	Seq      uint64 `json:"seq"`      // 1부터 증가하는 기록 순번 (이어 받기 기준, 서버 확인 순번과 무관)
+	Time     string `json:"time"`     // 발생 시각 (RFC3339)
+	Type     string `json:"type"`     // 이벤트 타입
+	Detail   string `json:"detail"`   // 서버로 보낸 내용 그대로
+	Category string `json:"category"` // 이벤트 분류
+	Severity string `json:"severity"` // 심각도
+}
+
+// eventHistory 구조체는 필터를 통과해 보고한 최근 이벤트를 고정 크기 링 버퍼로 보관합니다 (용량 0 이면 비활성).
+type eventHistory struct { // 단일 책임: 보고 이벤트 보관
+	mu       sync.Mutex
+	entries  []EventRecord
+	next     int
+	full     bool
+	seq      uint64            // 마지막으로 기록한 순번
+	listener func(EventRecord) // 새 기록 알림 (이벤트를 낸 고루틴에서 호출, nil 이면 생략)
+}
+
+// newEventHistory 함수는 eventHistory 생성자입니다.
+func newEventHistory(capacity int) *eventHistory { // 단일 책임: 인스턴스 생성
+	return &eventHistory{entries: make([]EventRecord, max(capacity, 0))}
+}
+
+// add 함수는 보고한 이벤트를 기록하고 리스너에 알립니다 (가득 차면 가장 오래된 기록 덮어쓰기).
+func (h *eventHistory) add(ev *monitorProto.EventData) { // 단일 책임: 보고 이벤트 기록
+	h.mu.Lock()
+	if len(h.entries) == 0 {
+		h.mu.Unlock()
+		return
+	}
+	h.seq++
+	rec := EventRecord{
+		Seq:      h.seq,
+		Time:     time.UnixMilli(ev.Timestamp).Format(time.RFC3339),
+		Type:     ev.EventType,
+		Detail:   ev.EventDetail,
+		Category: ev.Category,
+		Severity: ev.Severity,
+	}
+	h.entries[h.next] = rec
+	h.next = (h.next + 1) % len(h.entries)
+	if h.next == 0 {
+		h.full = true
+	}
+	fn := h.listener
+	h.mu.Unlock()
+	if fn != nil {
+		fn(rec)
+	}
+}
+
+// snapshot 함수는 오래된 순서로 순번이 after 보다 큰 기록을 최대 n 개 복사해 반환합니다.
+func (h *eventHistory) snapshot(n int, after uint64) []EventRecord { // 단일 책임: 기록 스냅샷
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	size := h.next
+	if h.full {
+		size = len(h.entries)
+	}
+	if newer := h.seq - after; after > 0 && newer < uint64(size) {
+		size = int(newer)
+	}
+	if n <= 0 || n > size {
+		n = size
+	}
+	out := make([]EventRecord, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, h.entries[(h.next-n+i+len(h.entries))%len(h.entries)])
+	}
+	return out
+}
+
+// RecentEvents 메서드는 순번이 after 보다 큰 최근 보고 이벤트를 오래된 순으로 최대 limit 개 반환합니다 (limit<=0 이면 전체).
+func (a *Agent) RecentEvents(limit int, after uint64) []EventRecord { // 단일 책임: 최근 보고 이벤트 조회
+	return a.history.snapshot(limit, after)
+}
+
+// SetEventListener 메서드는 이벤트를 보고 목록에 기록할 때마다 호출될 함수를 등록합니다.
+// fn 은 이벤트를 낸 고루틴에서 바로 호출되므로 막히지 않아야 합니다.
+func (a *Agent) SetEventListener(fn func(EventRecord)) { // 단일 책임: 리스너 등록
+	a.history.mu.Lock()
+	a.history.listener = fn
+	a.history.mu.Unlock()
+}
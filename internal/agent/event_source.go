@@ -0,0 +1,184 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	monitorProto "agent/proto"
+)
+
+const (
+	EVENT_SOURCE_BUFFER = 64 // 발생원 이벤트 채널 버퍼 (가득 차면 발생원이 대기)
+)
+
+// EventSource 인터페이스는 설정으로 따로 켜고 끌 수 있는 이벤트 발생원입니다.
+// Start 는 발생원을 시작해 이벤트 채널을 반환하며, 발생원이 끝나면(Stop, 컨텍스트 종료, 미지원 플랫폼) 채널을 닫습니다.
+// 채널 이벤트는 EventType/EventDetail/Timestamp 만 채우면 되고, 에이전트 ID·분류·심각도는 에이전트가 붙입니다.
+type EventSource interface {
+	Name() string
+	Start(ctx context.Context) (<-chan *monitorProto.EventData, error)
+	Stop()
+}
+
+// eventEmitter 타입은 감시 루프가 이벤트 하나를 내보내는 함수입니다.
+type eventEmitter func(eventType, detail string)
+
+// watchSource 구조체는 컨텍스트가 끝날 때까지 도는 감시 함수를 EventSource 로 감쌉니다 (내장 발생원 공용).
+type watchSource struct { // 단일 책임: 감시 루프 → 이벤트 채널 변환
+	name string
+	run  func(ctx context.Context, emit eventEmitter) // 컨텍스트 종료 또는 감시 불가 시 반환
+	mu   sync.Mutex
+	stop context.CancelFunc
+}
+
+// newWatchSource 함수는 watchSource 생성자입니다.
+func newWatchSource(name string, run func(ctx context.Context, emit eventEmitter)) *watchSource { // 단일 책임: 인스턴스 생성
+	return &watchSource{name: name, run: run}
+}
+
+// Name 함수는 발생원 이름을 반환합니다.
+func (s *watchSource) Name() string { // 단일 책임: 이름 조회
+	return s.name
+}
+
+// Start 함수는 감시 함수를 고루틴으로 실행하고, 감시 함수가 반환하면 채널을 닫습니다.
+func (s *watchSource) Start(ctx context.Context) (<-chan *monitorProto.EventData, error) { // 단일 책임: 감시 시작
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return nil, fmt.Errorf("이벤트 발생원 %s 이미 실행 중", s.name)
+	}
+	ctx, s.stop = context.WithCancel(ctx)
+	ch := make(chan *monitorProto.EventData, EVENT_SOURCE_BUFFER)
+	go func() {
+		defer close(ch)
+		s.run(ctx, func(eventType, detail string) {
+			select {
+			case ch <- &monitorProto.EventData{EventType: eventType, EventDetail: detail, Timestamp: time.Now().UnixMilli()}:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return ch, nil
+}
+
+// Stop 함수는 감시 함수의 컨텍스트를 취소합니다.
+func (s *watchSource) Stop() { // 단일 책임: 감시 중지
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		s.stop()
+		s.stop = nil
+	}
+}
+
+// eventSourceRegistry 구조체는 에이전트에 등록된 이벤트 발생원 목록입니다. Init 이후 등록한 발생원은 바로 시작합니다.
+type eventSourceRegistry struct { // 단일 책임: 발생원 등록/시작/중지
+	mu      sync.Mutex
+	sources []EventSource
+	running map[string]bool // 실행 중인 발생원 이름
+	started bool            // startEventSources 호출 여부
+}
+
+// RegisterEventSource 메서드는 이벤트 발생원을 등록합니다. 이름이 겹치면 오류이며,
+// DisabledSources 설정에 있는 이름은 등록만 하고 시작하지 않습니다.
+func (a *Agent) RegisterEventSource(src EventSource) error { // 단일 책임: 발생원 등록
+	r := &a.sources
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.sources {
+		if s.Name() == src.Name() {
+			return fmt.Errorf("이벤트 발생원 이름 중복: %s", src.Name())
+		}
+	}
+	r.sources = append(r.sources, src)
+	if r.started {
+		a.startEventSourceLocked(src)
+	}
+	return nil
+}
+
+// EventSources 메서드는 등록된 발생원 이름과 실행 여부를 반환합니다.
+func (a *Agent) EventSources() map[string]bool { // 단일 책임: 발생원 상태 조회
+	r := &a.sources
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make(map[string]bool, len(r.sources))
+	for _, s := range r.sources {
+		out[s.Name()] = r.running[s.Name()]
+	}
+	return out
+}
+
+// startEventSources 함수는 설정으로 켜진 내장 발생원을 등록하고 등록된 발생원을 모두 시작합니다 (Init 에서 한 번).
+func (a *Agent) startEventSources() { // 단일 책임: 발생원 일괄 시작
+	for _, src := range []EventSource{
+		a.focusSource(),
+		a.processSource(),
+		a.inputSource(),
+		a.clipboardSource(),
+		a.networkSource(),
+		a.resourceSource(),
+		a.fileSource(),
+	} {
+		if src == nil { // 설정에서 꺼짐
+			continue
+		}
+		if err := a.RegisterEventSource(src); err != nil {
+			a.logger.Warnf("%v", err)
+		}
+	}
+	r := &a.sources
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.started = true
+	for _, src := range r.sources {
+		a.startEventSourceLocked(src)
+	}
+}
+
+// startEventSourceLocked 함수는 비활성 목록에 없으면 발생원을 시작하고 채널 이벤트를 에이전트 이벤트로 발행합니다 (r.mu 보유 상태).
+func (a *Agent) startEventSourceLocked(src EventSource) { // 단일 책임: 발생원 시작 + 중계
+	r := &a.sources
+	name := src.Name()
+	if eventNameSet(a.cfg.DisabledSources)[strings.ToLower(name)] {
+		a.logger.Infow("이벤트 발생원 비활성 (설정)", "source", name)
+		return
+	}
+	ch, err := src.Start(a.ctx)
+	if err != nil {
+		a.logger.Warnw("이벤트 발생원 시작 실패", "source", name, "error", err)
+		return
+	}
+	if r.running == nil {
+		r.running = make(map[string]bool)
+	}
+	r.running[name] = true
+	go func() {
+		for ev := range ch {
+			at := time.Now()
+			if ev.GetTimestamp() > 0 {
+				at = time.UnixMilli(ev.GetTimestamp())
+			}
+			a.emitEventAt(ev.GetEventType(), ev.GetEventDetail(), at)
+		}
+		r.mu.Lock()
+		delete(r.running, name)
+		r.mu.Unlock()
+	}()
+}
+
+// stopEventSources 함수는 실행 중인 발생원을 모두 중지합니다.
+func (a *Agent) stopEventSources() { // 단일 책임: 발생원 일괄 중지
+	r := &a.sources
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, src := range r.sources {
+		if r.running[src.Name()] {
+			src.Stop()
+		}
+	}
+}
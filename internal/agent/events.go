@@ -0,0 +1,29 @@
+package agent
+
+import (
+	"time"
+
+	monitorProto "agent/proto"
+)
+
+// emitEvent 메서드는 이벤트를 생성해 분류/필터를 거쳐 묶음 전송 단계로 전달합니다. 모든 에이전트 이벤트의 단일 진입점입니다.
+func (a *Agent) emitEvent(eventType, detail string) { // 단일 책임: 이벤트 발행
+	a.emitEventAt(eventType, detail, time.Now())
+}
+
+// emitEventAt 메서드는 발생 시각을 지정해 이벤트를 발행합니다 (전송 불가 구간에 발생한 사건을 나중에 보고할 때).
+func (a *Agent) emitEventAt(eventType, detail string, at time.Time) { // 단일 책임: 시각 지정 이벤트 발행
+	class := classifyEvent(eventType)
+	ev := &monitorProto.EventData{AgentId: a.agentID, EventType: eventType, EventDetail: detail, Timestamp: at.UnixMilli(), Severity: class.severity, Category: class.category}
+	if !a.filter.allows(ev) {
+		return
+	}
+	a.history.add(ev)
+	if a.durable.accepts(ev) {
+		if a.durable.enqueue(ev) {
+			return
+		}
+		a.logger.Warnw("이벤트 확인 대기 큐 기록 실패 - 일반 전송으로 대체", "event_type", eventType)
+	}
+	a.events.add(ev)
+}
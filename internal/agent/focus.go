@@ -0,0 +1,75 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	FOREGROUND_CHANGED_EVENT = "foreground_changed" // 전면 앱/창 제목 변경 (process, pid, title)
+	FOCUS_EVENT_SOURCE       = "focus"              // 전면 창 이벤트 발생원 이름
+	FOCUS_TITLE_FULL         = "full"               // 창 제목을 그대로 기록
+	FOCUS_TITLE_NONE         = "none"               // 창 제목 없이 프로세스만 기록
+	FOCUS_REDACTED_TITLE     = "[redacted]"         // 가림 규칙에 해당하는 창 제목 대체 문구
+)
+
+// focusWindow 구조체는 전면 창 변경 이벤트에 기록하는 값입니다 (개인정보 필터 적용 후).
+type focusWindow struct {
+	process string
+	pid     int
+	title   string
+}
+
+// focusSource 함수는 FocusEvents 설정 시 전면 창을 주기적으로 확인해 바뀔 때마다 이벤트를 내는 발생원을 만듭니다 (꺼져 있으면 nil).
+// 전면 창 조회를 지원하지 않는 플랫폼이면 한 번 기록하고 종료합니다.
+func (a *Agent) focusSource() EventSource { // 단일 책임: 전면 창 발생원 생성
+	if !a.cfg.FocusEvents {
+		return nil
+	}
+	redact := newAppBlocker(a.cfg.FocusRedactApps, "")
+	return newWatchSource(FOCUS_EVENT_SOURCE, func(ctx context.Context, emit eventEmitter) {
+		ticker := time.NewTicker(time.Duration(a.cfg.FocusPollMs) * time.Millisecond)
+		defer ticker.Stop()
+		var last focusWindow
+		for {
+			info, err := foregroundWindow()
+			if errors.Is(err, errWindowCaptureUnsupported) {
+				a.logger.Infof("전면 창 변경 이벤트 비활성: %v", err)
+				return
+			}
+			if err == nil { // 전면 창 없음(잠금 화면 등)은 직전 창 유지
+				if cur := a.focusWindowOf(info, redact); cur != last {
+					last = cur
+					emit(FOREGROUND_CHANGED_EVENT, cur.detail())
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+}
+
+// focusWindowOf 함수는 창 정보에 제목 기록 방식과 가림 규칙(FocusRedactApps, 캡처 금지 앱)을 적용합니다.
+func (a *Agent) focusWindowOf(info WindowInfo, redact *appBlocker) focusWindow { // 단일 책임: 개인정보 필터 적용
+	w := focusWindow{process: processBaseName(info.Process), pid: info.PID, title: info.Title}
+	switch {
+	case a.cfg.FocusTitleMode == FOCUS_TITLE_NONE:
+		w.title = ""
+	case redact.matches(info) || a.blocker.matches(info):
+		w.title = FOCUS_REDACTED_TITLE
+	}
+	return w
+}
+
+// detail 함수는 이벤트 상세 문자열을 만듭니다. 제목은 공백/따옴표를 포함할 수 있어 인용합니다.
+func (w focusWindow) detail() string { // 단일 책임: 이벤트 상세 생성
+	if w.title == "" {
+		return fmt.Sprintf("process=%s pid=%d", w.process, w.pid)
+	}
+	return fmt.Sprintf("process=%s pid=%d title=%q", w.process, w.pid, w.title)
+}
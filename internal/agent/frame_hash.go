@@ -0,0 +1,58 @@
+package agent
+
+import (
+	"hash/maphash"
+	"image"
+	"sync/atomic"
+	"time"
+)
+
+// identicalFilter 구조체는 원본 픽셀 해시를 마지막으로 전송한 프레임과 비교해 완전히 같은 화면을 걸러냅니다.
+// 변화 감지(changeDetector)와 달리 임계값이 없고, 같은 화면이면 keepalive 프레임도 보내지 않습니다.
+type identicalFilter struct { // 단일 책임: 동일 프레임 판별
+	seed       maphash.Seed
+	sent       uint64 // 마지막 전송 프레임 해시
+	sentBounds image.Rectangle
+	hasSent    bool
+	marker     time.Duration // unchanged 표시 프레임 주기 (0 이면 표시 프레임 없음)
+	lastMarker time.Time
+	skipped    atomic.Uint64 // 동일 화면으로 생략한 프레임 수
+}
+
+// newIdenticalFilter 함수는 identicalFilter 생성자입니다. enabled 가 false 이면 nil 을 반환합니다.
+func newIdenticalFilter(enabled bool, marker time.Duration) *identicalFilter { // 단일 책임: 인스턴스 생성
+	if !enabled {
+		return nil
+	}
+	return &identicalFilter{seed: maphash.MakeSeed(), marker: marker}
+}
+
+// check 함수는 프레임이 직전 전송 프레임과 같은지와, 같다면 unchanged 표시 프레임을 보낼 차례인지 반환합니다.
+// 다른 프레임이면 전송 기준을 현재 프레임으로 갱신합니다.
+func (f *identicalFilter) check(img image.Image, now time.Time) (identical, marker bool) { // 단일 책임: 동일 여부 판단
+	if f == nil {
+		return false, false
+	}
+	sum := hashPixels(toRGBA(img), f.seed)
+	if f.hasSent && sum == f.sent && img.Bounds() == f.sentBounds {
+		f.skipped.Add(1)
+		if f.marker > 0 && now.Sub(f.lastMarker) >= f.marker {
+			f.lastMarker = now
+			return true, true
+		}
+		return true, false
+	}
+	f.sent, f.sentBounds, f.hasSent, f.lastMarker = sum, img.Bounds(), true, now
+	return false, false
+}
+
+// hashPixels 함수는 이미지 영역의 RGBA 픽셀을 행 단위로 해시합니다 (행 패딩 제외).
+func hashPixels(img *image.RGBA, seed maphash.Seed) uint64 { // 단일 책임: 픽셀 해시
+	var h maphash.Hash
+	h.SetSeed(seed)
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		h.Write(img.Pix[img.PixOffset(b.Min.X, y):img.PixOffset(b.Max.X, y)])
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,57 @@
+package agent
+
+import (
+	"image"
+)
+
+const (
+	FRAME_LIMIT_QUALITY_STEP = 15 // 크기 초과 시 한 단계에 낮추는 jpeg/webp 품질
+	FRAME_LIMIT_MIN_QUALITY  = 20 // 크기 제한 재인코딩에서 내려갈 최저 품질
+	FRAME_LIMIT_SCALE_STEP   = 75 // 품질로 부족할 때 한 단계마다 곱하는 해상도 비율(%)
+	FRAME_LIMIT_MIN_SCALE    = 25 // 크기 제한 재인코딩에서 내려갈 최저 해상도 비율(%)
+)
+
+// frameLimit 구조체는 크기 제한 재인코딩 결과입니다. 인코딩 워커가 채우고 결과 전달 뒤 deliverEncoded 가 읽습니다.
+type frameLimit struct {
+	scalePct int32 // 해상도를 더 줄였으면 최종 전송 해상도 비율(%) (0=변경 없음)
+}
+
+// limitedEncode 함수는 output/pct 로 축소해 인코딩하고, 결과가 maxBytes 를 넘으면 손실 압축 품질을 먼저 낮추고
+// 그래도 넘치면 해상도를 단계적으로 줄여 다시 인코딩하는 인코딩 함수를 만듭니다. 최저 단계에서도 넘치면 마지막 결과를 그대로 보냅니다.
+func (a *Agent) limitedEncode(opts encodeOptions, output outputScale, pct, maxBytes int, limit *frameLimit) encodeFunc { // 단일 책임: 프레임 크기 제한 인코딩
+	return func(img image.Image) ([]byte, error) {
+		src := output.apply(img, pct)
+		b, err := opts.encode(src)
+		if err != nil || len(b) <= maxBytes {
+			return b, err
+		}
+		first := len(b)
+		var quality *int // 낮출 손실 압축 품질 (무손실 형식이면 nil)
+		if opts.encoding == "jpeg" {
+			quality = &opts.jpegQuality
+		} else if opts.encoding == ENCODING_WEBP && !opts.webpLossless {
+			quality = &opts.webpQuality
+		}
+		for quality != nil && len(b) > maxBytes && *quality > FRAME_LIMIT_MIN_QUALITY {
+			*quality = max(FRAME_LIMIT_MIN_QUALITY, *quality-FRAME_LIMIT_QUALITY_STEP)
+			if b, err = opts.encode(src); err != nil {
+				return nil, err
+			}
+		}
+		scale := 100
+		if pct > 0 {
+			scale = pct
+		}
+		for len(b) > maxBytes && scale > FRAME_LIMIT_MIN_SCALE {
+			scale = max(FRAME_LIMIT_MIN_SCALE, scale*FRAME_LIMIT_SCALE_STEP/100)
+			if b, err = opts.encode(output.apply(img, scale)); err != nil {
+				return nil, err
+			}
+			limit.scalePct = int32(output.ratio(img.Bounds(), scale))
+		}
+		fits := len(b) <= maxBytes
+		a.stats.noteLimited(fits)
+		a.logger.Debugw("프레임 크기 제한 재인코딩", "bytes_before", first, "bytes_after", len(b), "max_bytes", maxBytes, "encoding", opts.encoding, "scale_pct", limit.scalePct, "fits", fits)
+		return b, nil
+	}
+}
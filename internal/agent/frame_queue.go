@@ -0,0 +1,259 @@
+package agent
+
+import (
+	"sync"
+	"time"
+
+	monitorProto "agent/proto"
+)
+
+// FrameQueueStats 구조체는 프레임 송신 큐 계측값입니다.
+type FrameQueueStats struct {
+	Capacity     int     `json:"capacity"`       // 큐 용량
+	Depth        int     `json:"depth"`          // 현재 대기 프레임 수
+	MaxDepth     int     `json:"max_depth"`      // 관측된 최대 대기 프레임 수
+	Enqueued     uint64  `json:"enqueued"`       // 누적 투입 프레임 수
+	Sent         uint64  `json:"sent"`           // 누적 송신 프레임 수
+	Dropped      uint64  `json:"dropped"`        // 큐 가득 참으로 버린 (가장 오래된) 프레임 수 (끊긴 delta/tiles 뒤 비키프레임 포함)
+	AvgLatencyMs float64 `json:"avg_latency_ms"` // 투입부터 송신 시작까지 평균 대기(ms)
+	MaxLatencyMs float64 `json:"max_latency_ms"` // 투입부터 송신 시작까지 최대 대기(ms)
+}
+
+// queuedFrame 구조체는 큐에 대기 중인 프레임과 투입 시각입니다.
+type queuedFrame struct {
+	frame      *monitorProto.FrameData
+	enqueuedAt time.Time
+	trace      frameTrace // 송신 후 추적 기록용 단계 시각
+}
+
+// frameQueue 구조체는 캡처/인코딩과 네트워크 송신을 분리하는 고정 크기 링 버퍼입니다.
+// 가득 차면 가장 오래된 프레임을 버려 송신이 밀려도 최신 화면이 우선 전달되도록 합니다.
+// 버린 프레임이 delta/tiles 이면 뒤따르는 같은 모니터의 비키프레임은 복원할 수 없으므로 다음 키프레임까지 함께 버리고
+// keys 로 캡처 루프에 즉시 키프레임을 요청합니다.
+type frameQueue struct { // 단일 책임: drop-oldest 프레임 큐 + 계측
+	mu     sync.Mutex
+	cond   *sync.Cond
+	buf    []queuedFrame
+	head   int // 가장 오래된 항목 위치
+	size   int // 대기 항목 수
+	closed bool
+	broken map[int32]bool    // delta/tiles 프레임을 버려 다음 키프레임 전까지 복원할 수 없는 모니터
+	keys   *keyframeRequests // 끊긴 모니터의 키프레임 요청 대상 (nil 이면 요청 안 함)
+
+	maxDepth     int
+	enqueued     uint64
+	sent         uint64
+	dropped      uint64
+	latencyTotal time.Duration
+	latencyMax   time.Duration
+}
+
+// newFrameQueue 함수는 capacity 크기의 frameQueue 를 생성합니다 (최소 1).
+func newFrameQueue(capacity int, keys *keyframeRequests) *frameQueue { // 단일 책임: 인스턴스 생성
+	if capacity < 1 {
+		capacity = 1
+	}
+	q := &frameQueue{buf: make([]queuedFrame, capacity), broken: make(map[int32]bool), keys: keys}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// push 함수는 프레임을 투입합니다. 가득 차 있으면 가장 오래된 프레임을 버리고 true 를 반환합니다.
+// 기준 프레임을 잃은 모니터의 delta/tiles 비키프레임은 투입하지 않고 true 를 반환합니다.
+// 닫힌 큐에 투입된 프레임은 버려집니다.
+func (q *frameQueue) push(frame *monitorProto.FrameData, tr frameTrace) (dropped bool) { // 단일 책임: 프레임 투입
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		q.dropped++
+		return true
+	}
+	if chainedEncoding(frame.GetEncoding()) && frame.GetKeyframe() {
+		delete(q.broken, frame.GetMonitorId())
+	}
+	if q.unusableLocked(frame) {
+		return true
+	}
+	if q.size == len(q.buf) { // drop-oldest: 가장 오래된 항목 자리를 비움
+		oldest := q.buf[q.head].frame
+		q.buf[q.head] = queuedFrame{}
+		q.head = (q.head + 1) % len(q.buf)
+		q.size--
+		q.dropped++
+		dropped = true
+		if chainedEncoding(oldest.GetEncoding()) {
+			q.breakChainLocked(oldest.GetMonitorId())
+			if q.unusableLocked(frame) { // 방금 끊긴 모니터의 비키프레임
+				return true
+			}
+		}
+	}
+	now := time.Now()
+	tr.enqueuedAt = now
+	q.buf[(q.head+q.size)%len(q.buf)] = queuedFrame{frame: frame, enqueuedAt: now, trace: tr}
+	q.size++
+	q.enqueued++
+	if q.size > q.maxDepth {
+		q.maxDepth = q.size
+	}
+	q.cond.Signal()
+	return dropped
+}
+
+// unusableLocked 함수는 frame 이 기준 프레임을 잃은 모니터의 delta/tiles 비키프레임이면 버린 것으로 세고 true 를 반환합니다 (mu 보유 상태).
+func (q *frameQueue) unusableLocked(frame *monitorProto.FrameData) bool { // 단일 책임: 복원 불가 프레임 판별
+	if !chainedEncoding(frame.GetEncoding()) || frame.GetKeyframe() || !q.broken[frame.GetMonitorId()] {
+		return false
+	}
+	q.dropped++
+	return true
+}
+
+// breakChainLocked 함수는 모니터 id 의 delta/tiles 프레임을 버린 뒤 처리입니다. 대기 중인 같은 모니터의 비키프레임을
+// 다음 키프레임 전까지 큐에서 빼고, 대기 중인 키프레임이 없으면 끊긴 것으로 표시해 키프레임을 요청합니다 (mu 보유 상태).
+func (q *frameQueue) breakChainLocked(id int32) { // 단일 책임: 끊긴 프레임 체인 정리
+	kept, recovered := 0, false
+	for i := 0; i < q.size; i++ {
+		item := q.buf[(q.head+i)%len(q.buf)]
+		if f := item.frame; !recovered && f.GetMonitorId() == id && chainedEncoding(f.GetEncoding()) {
+			if !f.GetKeyframe() {
+				q.dropped++
+				continue
+			}
+			recovered = true // 이후 프레임은 이 키프레임 기준이라 복원 가능
+		}
+		q.buf[(q.head+kept)%len(q.buf)] = item
+		kept++
+	}
+	for i := kept; i < q.size; i++ {
+		q.buf[(q.head+i)%len(q.buf)] = queuedFrame{}
+	}
+	q.size = kept
+	if recovered {
+		return
+	}
+	q.broken[id] = true
+	q.keys.request(id)
+}
+
+// chainedEncoding 함수는 직전 프레임을 기준으로 복원하는 인코딩(delta/tiles)인지 반환합니다.
+func chainedEncoding(encoding string) bool { // 단일 책임: 프레임 간 의존 인코딩 판별
+	return encoding == ENCODING_DELTA || encoding == ENCODING_TILES
+}
+
+// keyframeRequests 구조체는 송신 경로에서 delta/tiles 프레임을 잃은 모니터를 기록해, 캡처 루프가 그 모니터의 다음 프레임을
+// 주기를 기다리지 않고 키프레임으로 만들게 합니다.
+type keyframeRequests struct { // 단일 책임: 모니터별 키프레임 요청
+	mu   sync.Mutex
+	ids  map[int32]bool
+	gen  uint64           // requestAll 호출 횟수 (모든 모니터 요청)
+	seen map[int32]uint64 // 모니터별로 소비한 마지막 gen
+}
+
+// newKeyframeRequests 함수는 keyframeRequests 생성자입니다.
+func newKeyframeRequests() *keyframeRequests { // 단일 책임: 인스턴스 생성
+	return &keyframeRequests{ids: make(map[int32]bool), seen: make(map[int32]uint64)}
+}
+
+// request 함수는 모니터 id 의 다음 delta/tiles 프레임을 키프레임으로 요청합니다 (nil 이면 무동작).
+func (k *keyframeRequests) request(id int32) { // 단일 책임: 키프레임 요청
+	if k == nil {
+		return
+	}
+	k.mu.Lock()
+	k.ids[id] = true
+	k.mu.Unlock()
+}
+
+// requestAll 함수는 모든 모니터의 다음 delta/tiles 프레임을 키프레임으로 요청합니다 (nil 이면 무동작).
+func (k *keyframeRequests) requestAll() { // 단일 책임: 전체 키프레임 요청
+	if k == nil {
+		return
+	}
+	k.mu.Lock()
+	k.gen++
+	k.mu.Unlock()
+}
+
+// take 함수는 모니터 id 에 대기 중인 키프레임 요청이 있으면 지우고 true 를 반환합니다.
+func (k *keyframeRequests) take(id int32) bool { // 단일 책임: 키프레임 요청 소비
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if !k.ids[id] && k.seen[id] == k.gen {
+		return false
+	}
+	delete(k.ids, id)
+	k.seen[id] = k.gen
+	return true
+}
+
+// pop 함수는 가장 오래된 프레임과 단계 시각을 꺼냅니다. 비어 있으면 대기하며, 닫힌 뒤 비면 ok=false 를 반환합니다.
+func (q *frameQueue) pop() (frame *monitorProto.FrameData, tr frameTrace, ok bool) { // 단일 책임: 프레임 인출
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for q.size == 0 && !q.closed {
+		q.cond.Wait()
+	}
+	if q.size == 0 {
+		return nil, frameTrace{}, false
+	}
+	item := q.buf[q.head]
+	q.buf[q.head] = queuedFrame{}
+	q.head = (q.head + 1) % len(q.buf)
+	q.size--
+	q.sent++
+	wait := time.Since(item.enqueuedAt)
+	q.latencyTotal += wait
+	if wait > q.latencyMax {
+		q.latencyMax = wait
+	}
+	return item.frame, item.trace, true
+}
+
+// close 함수는 신규 투입을 막고 대기 중인 pop 을 깨웁니다 (남은 프레임은 계속 인출 가능).
+func (q *frameQueue) close() { // 단일 책임: 큐 종료
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	q.cond.Broadcast()
+}
+
+// stats 함수는 현재 계측값 스냅샷을 반환합니다.
+func (q *frameQueue) stats() FrameQueueStats { // 단일 책임: 계측 조회
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	st := FrameQueueStats{
+		Capacity:     len(q.buf),
+		Depth:        q.size,
+		MaxDepth:     q.maxDepth,
+		Enqueued:     q.enqueued,
+		Sent:         q.sent,
+		Dropped:      q.dropped,
+		MaxLatencyMs: float64(q.latencyMax) / float64(time.Millisecond),
+	}
+	if q.sent > 0 {
+		st.AvgLatencyMs = float64(q.latencyTotal) / float64(q.sent) / float64(time.Millisecond)
+	}
+	return st
+}
+
+// runFrameSender 함수는 큐에서 프레임을 꺼내 서버로 송신(미연결 시 오프라인 스풀 보관)하고 송신 시간을 해상도 제어에 보고합니다.
+// 큐가 닫히고 비워지면 종료합니다.
+func (a *Agent) runFrameSender() { // 단일 책임: 큐 → 스트림 송신
+	for {
+		frame, tr, ok := a.frameQueue.pop()
+		if !ok {
+			return
+		}
+		sendStart := time.Now()
+		a.sendOrSpoolFrame(frame)
+		sendEnd := time.Now()
+		a.scaler.observeSend(sendEnd.Sub(sendStart))
+		a.tracer.recordFrame(&tr, frame.GetEncoding(), frame.GetMonitorId(), len(frame.GetImageData()), sendStart, sendEnd)
+	}
+}
+
+// FrameQueueStats 함수는 프레임 송신 큐 계측값을 반환합니다.
+func (a *Agent) FrameQueueStats() FrameQueueStats { // 단일 책임: 큐 계측 노출
+	return a.frameQueue.stats()
+}
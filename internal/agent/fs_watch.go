@@ -0,0 +1,126 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	FILE_CREATED_EVENT   = "file_created"  // 감시 경로에 파일 생성 (path, size)
+	FILE_MODIFIED_EVENT  = "file_modified" // 감시 경로 파일 내용 변경 (path, size)
+	FILE_DELETED_EVENT   = "file_deleted"  // 감시 경로 파일 삭제 또는 밖으로 이동 (path)
+	FS_WATCH_DEBOUNCE_MS = 500             // 같은 파일의 연속 쓰기를 한 이벤트로 묶는 시간 창(ms)
+	FILE_EVENT_SOURCE    = "file"          // 파일 이벤트 발생원 이름
+)
+
+// fileSource 함수는 FileWatchPaths 설정 시 fsnotify 로 지정 디렉터리의 파일 생성/변경/삭제를 감시하는 발생원을 만듭니다 (경로가 없으면 nil).
+// 다운로드처럼 여러 번 나눠 쓰는 파일은 FS_WATCH_DEBOUNCE_MS 동안 모아 최종 상태만 보고합니다.
+func (a *Agent) fileSource() EventSource { // 단일 책임: 파일 발생원 생성
+	var roots []string
+	for _, p := range strings.Split(a.cfg.FileWatchPaths, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			roots = append(roots, p)
+		}
+	}
+	if len(roots) == 0 {
+		return nil
+	}
+	return newWatchSource(FILE_EVENT_SOURCE, func(ctx context.Context, emit eventEmitter) {
+		w, err := fsnotify.NewWatcher()
+		if err != nil {
+			a.logger.Warnf("파일 감시 비활성: %v", err)
+			return
+		}
+		for _, root := range roots {
+			if err := a.addWatchDir(w, root); err != nil {
+				a.logger.Warnw("파일 감시 경로 추가 실패", "path", root, "error", err)
+			}
+		}
+		a.runFileWatch(ctx, w, emit)
+	})
+}
+
+// addWatchDir 함수는 디렉터리를 감시 목록에 추가합니다. FileWatchRecursive 이면 하위 디렉터리도 모두 추가합니다.
+func (a *Agent) addWatchDir(w *fsnotify.Watcher, dir string) error { // 단일 책임: 감시 디렉터리 추가
+	if !a.cfg.FileWatchRecursive {
+		return w.Add(dir)
+	}
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // 권한 없는 하위 디렉터리는 건너뜀
+		}
+		if d.IsDir() {
+			return w.Add(path)
+		}
+		return nil
+	})
+}
+
+// runFileWatch 함수는 fsnotify 알림을 경로별 보류 상태로 모았다가 시간 창마다 이벤트로 발행합니다.
+// 생성 후 시간 창 안에 삭제된 임시 파일은 보고하지 않습니다.
+func (a *Agent) runFileWatch(ctx context.Context, w *fsnotify.Watcher, emit eventEmitter) { // 단일 책임: 파일 변경 수집/발행
+	defer w.Close()
+	ticker := time.NewTicker(time.Duration(FS_WATCH_DEBOUNCE_MS) * time.Millisecond)
+	defer ticker.Stop()
+	pending := map[string]string{} // 경로 → 보고할 이벤트 종류
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err, ok := <-w.Errors:
+			if !ok {
+				return
+			}
+			a.logger.Debugf("파일 감시 오류: %v", err)
+		case ev, ok := <-w.Events:
+			if !ok {
+				return
+			}
+			prev := pending[ev.Name]
+			switch {
+			case ev.Has(fsnotify.Create):
+				if info, err := os.Stat(ev.Name); err == nil && info.IsDir() {
+					if a.cfg.FileWatchRecursive {
+						_ = a.addWatchDir(w, ev.Name)
+					}
+					continue
+				}
+				if prev == FILE_DELETED_EVENT { // 삭제 후 같은 이름으로 다시 생성 (저장 시 교체하는 편집기)
+					pending[ev.Name] = FILE_MODIFIED_EVENT
+				} else {
+					pending[ev.Name] = FILE_CREATED_EVENT
+				}
+			case ev.Has(fsnotify.Write):
+				if prev == "" {
+					pending[ev.Name] = FILE_MODIFIED_EVENT
+				}
+			case ev.Has(fsnotify.Remove), ev.Has(fsnotify.Rename):
+				if prev == FILE_CREATED_EVENT {
+					delete(pending, ev.Name)
+				} else {
+					pending[ev.Name] = FILE_DELETED_EVENT
+				}
+			}
+		case <-ticker.C:
+			for path, event := range pending {
+				detail := fmt.Sprintf("path=%q", path)
+				if event != FILE_DELETED_EVENT {
+					info, err := os.Stat(path)
+					if err != nil || info.IsDir() {
+						continue
+					}
+					detail += fmt.Sprintf(" size=%d", info.Size())
+				}
+				emit(event, detail)
+			}
+			clear(pending)
+		}
+	}
+}
@@ -5,25 +5,28 @@ import (
 	"os"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"agent/internal/compression"
 	"agent/internal/config"
+	"agent/internal/framechunk"
+	"agent/internal/logging"
+	"agent/internal/secrets"
 	monitorProto "agent/proto"
 
-	"github.com/google/uuid"
 	"go.uber.org/zap"
 	grpcPkg "google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
-	GRPC_CONNECT_MAX_ATTEMPTS  = 5                                  // gRPC 최초 연결 재시도 횟수
-	GRPC_RETRY_DELAY_MS        = 1000                               // gRPC 최초 연결 재시도 지연
-	INITIAL_FRAME_IS_PREVIEW   = true                               // 초기 프레임 프리뷰 여부
-	INITIAL_EVENT_TYPE         = "agent_init"                       // 초기 이벤트 타입
-	INITIAL_EVENT_DETAIL       = "agent started and streams opened" // 초기 이벤트 상세
-	STREAM_REOPEN_MAX_ATTEMPTS = 3                                  // 스트림 재오픈 최대 시도
-	STREAM_REOPEN_DELAY_MS     = 500                                // 스트림 재오픈 간격(ms)
+	GRPC_DIAL_TIMEOUT_MS     = 5000                               // gRPC 연결 시도 1회 타임아웃
+	INITIAL_FRAME_IS_PREVIEW = true                               // 초기 프레임 프리뷰 여부
+	INITIAL_EVENT_TYPE       = "agent_init"                       // 초기 이벤트 타입
+	INITIAL_EVENT_DETAIL     = "agent started and streams opened" // 초기 이벤트 상세
+	SHUTDOWN_ABORT_WAIT_MS   = 1000                               // 종료 대기 초과로 전송을 취소한 뒤 스풀 보관을 기다리는 상한
 )
 
 type Agent struct {
@@ -34,102 +37,285 @@ type Agent struct {
 
 	frameStream monitorProto.AgentService_StreamFramesClient // 프레임 스트림 클라이언트
 	eventStream monitorProto.AgentService_StreamEventsClient // 이벤트 스트림 클라이언트
+	videoStream monitorProto.AgentService_StreamVideoClient  // H.264 영상 스트림 클라이언트 (첫 청크 전송 시 오픈)
+	videoMu     sync.Mutex                                   // 영상 스트림 열기/Send 직렬화 (모니터별 인코더 출력이 동시에 보냄, a.mu 와 별개)
 
-	agentID  string // 에이전트 고유 ID
-	hostname string // 호스트 이름
+	agentID   string    // 에이전트 고유 ID
+	hostname  string    // 호스트 이름
+	startedAt time.Time // 에이전트 생성 시각
 
 	cfg    *config.Config     // 설정
+	loaded config.Config      // 마지막으로 읽어 적용한 설정 (ApplyConfig 변경 비교 기준, capMu 보호)
 	logger *zap.SugaredLogger // 구조화 로거
 	mu     sync.Mutex         // 스트림/연결 보호
 
-	capturer      screenCapturer // 캡처 구현
-	captureStopCh chan struct{}  // 캡처 중지 채널
-	capMu         sync.RWMutex   // 캡처러 교체 보호
+	capturer      screenCapturer     // 캡처 구현
+	captureMu     sync.Mutex         // captureStopCh/captureDone 확인·교체 보호 (시작/중지 직렬화)
+	captureStopCh chan struct{}      // 캡처 중지 채널
+	captureDone   chan struct{}      // 캡처 루프(인코딩 중 프레임 전달 포함) 종료 신호
+	watchdog      captureWatchdog    // 캡처 루프 진행 기록 (superviseCapture 멈춤 감지)
+	intervalCh    chan time.Duration // 실행 중 캡처 루프에 새 프레임 간격 전달
+	capMu         sync.RWMutex       // 캡처러 교체 보호
+
+	rec       *recorder        // 로컬 녹화 세션
+	scaler    *adaptiveScaler  // 대역폭 기반 해상도 단계 제어
+	quality   *qualityGovernor // 대역폭 상한 기반 품질/해상도 단계 제어
+	sendLimit *sendLimiter     // 프레임 송신 토큰 버킷 (FRAME_SEND_LIMIT_KBS)
+	events    *eventBatcher    // 이벤트 묶음 전송
+	filter    *eventFilter     // 설정 기반 이벤트 허용/거부/최소 심각도
+	durable   *eventAckQueue   // 서버 확인 전까지 디스크에 보관하는 이벤트 큐 (nil 이면 비활성)
+
+	frameQueue *frameQueue       // 캡처와 송신 사이 drop-oldest 큐
+	keyframes  *keyframeRequests // 송신 경로에서 delta/tiles 체인이 끊긴 모니터의 키프레임 요청
+	senderDone chan struct{}     // 프레임 송신 고루틴 종료 신호
+	conn       *connTracker      // 서버 연결 준비 상태
+	endpoints  *endpointList     // 서버 주소 목록 (장애 조치 순환)
+	auth       *tokenAuth        // 토큰 인증 메타데이터
+	tracer     *pipelineTracer   // 파이프라인 추적 (nil 이면 비활성)
+	offline    *offlineSpool     // 서버 미연결 중 프레임/이벤트 디스크 보관
+
+	reconnectCh chan reconnectRequest        // 송신 경로 → 연결 감시 고루틴 재연결 요청
+	closing     atomic.Bool                  // Close 진행 중 (감시 고루틴 재연결 억제)
+	videoFrames atomic.Uint64                // H.264 인코더에 입력한 누적 프레임 수
+	chunkSeq    atomic.Uint64                // 조각으로 나눠 보낸 누적 프레임 수 (FrameChunk.frame_id)
+	lock        *screenLockState             // 화면 잠금 상태 (잠긴 동안 캡처 일시 중지)
+	masks       atomic.Pointer[privacyMasks] // 인코딩 전 가릴 영역 (캡처 루프가 잠금 없이 읽음)
+	blocker     *appBlocker                  // 캡처 금지 앱 전면 감지
+	schedule    scheduleState                // 캡처 허용 시간대 (밖이면 캡처 중지)
+	pprof       pprofState                   // 로컬 pprof 진단 엔드포인트
+	health      healthState                  // 로컬 상태 확인 엔드포인트
+	uiPreview   uiPreview                    // UI 실시간 미리보기
+	pause       pauseState                   // 캡처 일시 중지 (끝나면 자동 재개)
+	identity    identityState                // 장치 이름/ID 출처 (에이전트 ID 는 agentID)
+	consent     consentState                 // 기록된 캡처 동의
+	history     *eventHistory                // 최근 보고 이벤트 (UI 표시용)
+	bandwidth   *bandwidthMeter              // 스트림 묶음별 송신 바이트
+	onCapture   func(capturing bool)         // 캡처 루프 시작/중지 알림 (Init 전에 등록, nil 이면 생략)
+	onCommand   func(RemoteCommand)          // 원격 명령 처리 알림 (Init 전에 등록, nil 이면 생략)
+	watermark   *watermark                   // 감사용 워터마크 (nil 이면 비활성)
+	stats       captureStats                 // 캡처/인코딩/전달 계수
+	clipboard   clipboardWatch               // 클립보드 감시 동의 상태
+	sources     eventSourceRegistry          // 등록된 이벤트 발생원
+	remote      remoteConfigState            // 서버 관리 설정 적용 상태
 }
 
 func New(ctx context.Context, cancel context.CancelFunc, cfg *config.Config, logger *zap.SugaredLogger) *Agent { // 단일 책임: 에이전트 초기화
 	host, _ := os.Hostname() // 호스트명 조회 (실패 시 빈 문자열)
-	id := os.Getenv("AGENT_ID")
-	if id == "" {
-		id = uuid.New().String()
-	}
-	if logger == nil { // nil 안전성 확보
+	if logger == nil {       // nil 안전성 확보
 		l, _ := zap.NewDevelopment()
 		logger = l.Sugar()
 	}
-	// OS 지원 시 실제 화면 캡처, 그렇지 않으면 더미
+	secrets.SetEnabled(cfg.SecretStore)
+	id, idSource := cfg.AgentID, IDENTITY_SOURCE_CONFIG
+	if id == "" {
+		id, idSource = storedAgentID(logger)
+	}
+	if cfg.ConfigFileError != "" {
+		logger.Warnf("설정 파일 무시 (환경 변수와 기본값 사용): %s", cfg.ConfigFileError)
+	} else if cfg.ConfigFile != "" {
+		logger.Infow("설정 파일 적용", "path", cfg.ConfigFile)
+	}
+	_ = logging.SetLevel(cfg.LogLevel) // Load 에서 검증된 값
+	applyCPUBudget(cfg, logger)
+	if cfg.CaptureEncoding == ENCODING_WEBP && !libwebpAvailable {
+		logger.Warn("libwebp 없이 빌드됨: webp 는 순수 Go 무손실 인코더로 처리되며 프레임당 수백 ms 이상 걸릴 수 있음 (낮은 FPS 권장)")
+	}
+	// OS 지원 시 실제 화면 캡처(테스트 패턴 설정 시 합성 패턴), 그렇지 않으면 더미
 	var capt screenCapturer
-	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" || runtime.GOOS == "linux" {
-		capt = newScreenshotCapturer(cfg.MonitorMode, cfg.MonitorIndex, cfg.CaptureEncoding, cfg.JpegQuality)
+	if cfg.TestPattern || runtime.GOOS == "windows" || runtime.GOOS == "darwin" || runtime.GOOS == "linux" {
+		capt = newRealCapturer(cfg)
 	} else {
 		capt = newDummyCapturer(cfg.FrameWidth, cfg.FrameHeight)
 	}
+	endpoints := newEndpointList(cfg.ServerAddr)
+	keys := newKeyframeRequests()
 	a := &Agent{
 		ctx:           ctx,
 		cancel:        cancel,
 		agentID:       id,
 		hostname:      host,
+		startedAt:     time.Now(),
 		cfg:           cfg,
+		loaded:        *cfg,
 		logger:        logger,
 		capturer:      capt,
 		captureStopCh: nil,
+		intervalCh:    make(chan time.Duration, 1),
 		capMu:         sync.RWMutex{},
+		rec:           newRecorder(cfg.DataDir),
+		frameQueue:    newFrameQueue(cfg.FrameQueueSize, keys),
+		keyframes:     keys,
+		senderDone:    make(chan struct{}),
+		conn:          &connTracker{status: ConnectionStatus{State: CONN_STATE_IDLE, Server: endpoints.current()}},
+		endpoints:     endpoints,
+		auth:          newTokenAuth(cfg, logger),
+		tracer:        newPipelineTracer(cfg, logger),
+		offline:       newOfflineSpool(cfg, logger),
+		reconnectCh:   make(chan reconnectRequest, 1),
+		lock:          newScreenLockState(),
+		blocker:       newAppBlocker(cfg.BlockedApps, cfg.BlockedAppAction),
+		watermark:     newWatermark(cfg, id, host),
+		filter:        newEventFilter(cfg),
+		durable:       openEventAckQueue(cfg, logger),
+		identity:      identityState{name: loadDeviceName(host), source: idSource},
+		consent:       consentState{rec: ReadConsent()},
+		history:       newEventHistory(cfg.EventHistory),
+		bandwidth:     newBandwidthMeter(),
 	}
+	a.events = newEventBatcher(id, time.Duration(cfg.EventBatchWindowMs)*time.Millisecond, a.deliverEvent)
+	a.scaler = newAdaptiveScaler(cfg.AdaptiveScale, func(from, to int) {
+		a.logger.Infow("전송 해상도 단계 변경", "from_pct", from, "to_pct", to)
+	})
+	a.sendLimit = newSendLimiter(cfg)
+	a.quality = newQualityGovernor(cfg, func(from, to int) {
+		st := a.quality.stats()
+		a.logger.Infow("전송 품질 단계 변경", "from", from, "to", to, "quality_pct", st.QualityPct, "scale_pct", st.ScalePct, "measured_kbps", int(st.MeasuredKbps), "cap_kbps", st.CapKbps)
+	})
+	a.clipboard.consent.Store(cfg.ClipboardConsent)
+	a.loadPrivacyMasks()
+	a.loadSchedule()
+	go func() {
+		defer close(a.senderDone)
+		a.guardLoop(LOOP_FRAME_SENDER, a.runFrameSender)
+	}()
 	return a
 }
 
-func (a *Agent) Init() { // 단일 책임: gRPC 연결 및 스트림 시작
-	if err := a.connectGRPC(); err != nil {
-		a.logger.Errorf("gRPC 연결 실패: %v", err)
-		return
+// Init 메서드는 즉시 반환하고 백그라운드에서 gRPC 연결, 등록(Register) 및 스트림을 시작합니다.
+// 서버에 닿지 않아도 앱 시작이 지연되지 않으며, 준비 상태는 ConnectionStatus/리스너로 확인합니다.
+// 연결 전 발생한 프레임/이벤트는 오프라인 스풀에 보관했다가 연결 후 재전송합니다.
+func (a *Agent) Init() { // 단일 책임: 비동기 연결 시작
+	a.conn.set(CONN_STATE_CONNECTING, "")
+	a.conn.setSpooling(a.offline.pending()) // 이전 실행에서 남은 재전송 대기 기록
+	a.startScreenLockWatch()
+	a.startEventSources() // 잠금/세션/전원/모니터 감시는 캡처 상태도 바꾸므로 발생원이 아닌 전용 감시로 시작
+	a.startSessionWatch()
+	a.startPowerWatch()
+	a.startDisplayWatch()
+	a.startConfigWatch()
+	a.reportConfigProblems(a.cfg.Problems)
+	a.startSchedule()
+	if a.cfg.PprofEnabled {
+		if _, err := a.StartPprof(""); err != nil {
+			a.logger.Warnf("%v", err)
+		}
+	}
+	if a.cfg.HealthEnabled {
+		if _, err := a.StartHealth(); err != nil {
+			a.logger.Warnf("%v", err)
+		}
 	}
-	a.startStream(a.ctx)
+	go func() {
+		if err := a.connectGRPC(); err != nil {
+			a.logger.Errorf("gRPC 연결 실패: %v", err)
+			a.conn.set(CONN_STATE_FAILED, err.Error())
+			return
+		}
+		if err := a.register(); err != nil {
+			a.logger.Errorf("%v", err)
+			a.conn.set(CONN_STATE_FAILED, err.Error())
+			return
+		}
+		a.startStream(a.ctx)
+		a.conn.set(CONN_STATE_READY, "")
+		a.emitEvent(CONNECTED_EVENT, "reconnected=false")
+		go a.replayOffline()
+		go a.retryRecordingUploads()
+		go a.guardLoop(LOOP_HEARTBEAT, a.runHeartbeat)
+		go a.guardLoop(LOOP_REMOTE_CFG, a.runRemoteConfig)
+		a.superviseConnection()
+	}()
 }
 
-func (a *Agent) startStream(ctx context.Context) { // 단일 책임: 두 개 스트림 오픈
+// Connect 메서드는 스트림을 열지 않고 gRPC 연결만 수립합니다 (CLI 등 단발성 작업용).
+func (a *Agent) Connect() error { // 단일 책임: 연결만 수립
+	return a.connectGRPC()
+}
+
+func (a *Agent) startStream(ctx context.Context) { // 단일 책임: 프레임/이벤트 스트림 + 제어 채널 오픈
 	if err := a.openFrameStream(); err != nil {
 		a.logger.Errorf("프레임 스트림 열기 실패: %v", err)
 	}
 	if err := a.openEventStream(); err != nil {
 		a.logger.Errorf("이벤트 스트림 열기 실패: %v", err)
 	}
+	a.openControlStream()
+	a.openAckedEventStream()
 }
 
+// connectGRPC 함수는 서버 주소 목록을 현재 대상부터 차례로 시도하고, 모두 실패하면 백오프 후 다시 시도합니다.
 func (a *Agent) connectGRPC() error { // 단일 책임: gRPC 연결 (재시도 포함)
-	serverAddr := a.cfg.ServerAddr
-	var lastErr error
-	for attempt := 1; attempt <= GRPC_CONNECT_MAX_ATTEMPTS; attempt++ {
-		conn, err := grpcPkg.DialContext(
-			a.ctx,
-			serverAddr,
-			grpcPkg.WithTransportCredentials(insecure.NewCredentials()),
-			grpcPkg.WithBlock(),
-		)
+	creds, err := transportCredentials(a.cfg)
+	if err != nil {
+		return err
+	}
+	opts := []grpcPkg.DialOption{grpcPkg.WithTransportCredentials(creds), grpcPkg.WithBlock(), grpcPkg.WithStatsHandler(a.bandwidth)}
+	if a.auth.enabled() { // 모든 RPC(스트림 포함)에 토큰 첨부
+		opts = append(opts, grpcPkg.WithPerRPCCredentials(a.auth))
+	}
+	if a.cfg.KeepaliveTimeMs > 0 { // 응답 없는 ping 은 연결을 닫아 스트림 오류 → 재연결 경로로 넘김
+		opts = append(opts, grpcPkg.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                time.Duration(a.cfg.KeepaliveTimeMs) * time.Millisecond,
+			Timeout:             time.Duration(a.cfg.KeepaliveTimeoutMs) * time.Millisecond,
+			PermitWithoutStream: a.cfg.KeepaliveIdle,
+		}))
+	}
+	// 송신 상한을 넘는 프레임은 sendFrameData 가 FrameChunk 조각으로 나누므로 여기서 막히지 않음
+	opts = append(opts, grpcPkg.WithDefaultCallOptions(grpcPkg.MaxCallSendMsgSize(a.cfg.MaxMessageBytes), grpcPkg.MaxCallRecvMsgSize(a.cfg.MaxMessageBytes)))
+	// 컨텍스트가 살아 있는 동안 지수 백오프로 계속 재시도
+	bo := newBackoff(a.cfg)
+	for attempt := 1; ; attempt++ {
+		serverAddr := a.endpoints.current()
+		a.conn.setServer(serverAddr)
+		dialCtx, cancel := context.WithTimeout(a.ctx, time.Duration(GRPC_DIAL_TIMEOUT_MS)*time.Millisecond)
+		conn, err := grpcPkg.DialContext(dialCtx, serverAddr, opts...)
+		cancel()
 		if err == nil {
+			if a.ctx.Err() != nil { // 연결 중 종료됨
+				_ = conn.Close()
+				return a.ctx.Err()
+			}
+			a.mu.Lock()
 			a.grpcConn = conn
 			a.agentClient = monitorProto.NewAgentServiceClient(conn)
+			a.mu.Unlock()
 			a.logger.Infof("gRPC 연결 성공 (%s) attempt=%d", serverAddr, attempt)
 			return nil
 		}
-		lastErr = err
-		a.logger.Warnf("gRPC 연결 실패 attempt=%d err=%v", attempt, err)
-		select {
-		case <-time.After(time.Duration(GRPC_RETRY_DELAY_MS) * time.Millisecond):
-		case <-a.ctx.Done():
-			return a.ctx.Err()
+		a.logger.Warnf("gRPC 연결 실패 (%s) attempt=%d err=%v", serverAddr, attempt, err)
+		if !a.endpoints.advance() { // 아직 시도하지 않은 주소가 남음
+			a.conn.note(a.conn.get().State, err.Error())
+			continue
+		}
+		a.conn.note(CONN_STATE_OFFLINE, err.Error()) // 한 바퀴 모두 실패: 성공할 때까지 offline 유지
+		if werr := bo.wait(a.ctx); werr != nil {
+			return werr
 		}
 	}
-	return lastErr
+}
+
+// streamCallOptions 함수는 프레임/이벤트 스트림을 열 때 붙일 호출 옵션(GRPC_COMPRESSION 메시지 압축)을 반환합니다.
+// H.264 영상 스트림은 이미 압축된 데이터라 붙이지 않습니다.
+func (a *Agent) streamCallOptions() []grpcPkg.CallOption { // 단일 책임: 스트림 압축 옵션
+	if a.cfg.Compression == "" || a.cfg.Compression == compression.NONE {
+		return nil
+	}
+	return []grpcPkg.CallOption{grpcPkg.UseCompressor(a.cfg.Compression)}
 }
 
 func (a *Agent) openFrameStream() error { // 단일 책임: 프레임 스트림 오픈
-	if a.agentClient == nil {
+	a.mu.Lock()
+	client := a.agentClient
+	a.mu.Unlock()
+	if client == nil {
 		return nil
 	}
-	stream, err := a.agentClient.StreamFrames(a.ctx)
+	stream, err := client.StreamFrames(a.ctx, a.streamCallOptions()...)
 	if err != nil {
 		return err
 	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	a.frameStream = stream
 	a.logger.Infow("프레임 스트림 생성", "agent_id", a.agentID)
 	if err := a.sendInitialFrame(); err != nil {
@@ -139,13 +325,18 @@ func (a *Agent) openFrameStream() error { // 단일 책임: 프레임 스트림
 }
 
 func (a *Agent) openEventStream() error { // 단일 책임: 이벤트 스트림 오픈
-	if a.agentClient == nil {
+	a.mu.Lock()
+	client := a.agentClient
+	a.mu.Unlock()
+	if client == nil {
 		return nil
 	}
-	stream, err := a.agentClient.StreamEvents(a.ctx)
+	stream, err := client.StreamEvents(a.ctx, a.streamCallOptions()...)
 	if err != nil {
 		return err
 	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	a.eventStream = stream
 	a.logger.Infow("이벤트 스트림 생성", "agent_id", a.agentID)
 	if err := a.sendInitialEvent(); err != nil {
@@ -155,42 +346,108 @@ func (a *Agent) openEventStream() error { // 단일 책임: 이벤트 스트림
 }
 
 func (a *Agent) sendFrameData(frame *monitorProto.FrameData) error { // 단일 책임: 프레임 전송 + 오류 시 재시도
+	msgs := []*monitorProto.FrameData{frame}
+	size := proto.Size(frame)
+	if size > a.cfg.MaxMessageBytes { // GRPC_MAX_MESSAGE_BYTES 초과: 조각으로 나눠 연속 전송
+		chunks, err := framechunk.Split(frame, a.cfg.MaxMessageBytes, a.chunkSeq.Add(1))
+		if err != nil {
+			return err
+		}
+		msgs = chunks
+	}
 	a.mu.Lock()
 	stream := a.frameStream
 	a.mu.Unlock()
 	if stream == nil {
-		return nil
+		return errStreamUnavailable
 	}
-	if err := stream.Send(frame); err != nil {
+	start := time.Now()
+	err := sendFrameMessages(stream, msgs)
+	a.quality.observe(size, time.Since(start), err != nil)
+	if err != nil {
+		err = streamSendError(err, func() error { _, e := stream.CloseAndRecv(); return e })
 		a.logger.Warnf("프레임 전송 실패: %v - 재오픈 시도", err)
-		if a.reopenFrameStream() == nil { // 성공 시 1회 재전송
+		a.refreshAuthIfRejected(err)
+		if a.reopenFrameStream() == nil { // 성공 시 1회 재전송 (조각은 새 스트림에 첫 조각부터, 재전송 성공이면 nil)
 			a.mu.Lock()
+			defer a.mu.Unlock()
 			if a.frameStream != nil {
-				_ = a.frameStream.Send(frame)
+				return sendFrameMessages(a.frameStream, msgs)
 			}
-			a.mu.Unlock()
 		}
 		return err
 	}
 	return nil
 }
 
+// sendFrameMessages 함수는 프레임 또는 한 프레임의 조각들을 stream 에 차례로 보냅니다 (첫 실패에서 중단).
+func sendFrameMessages(stream monitorProto.AgentService_StreamFramesClient, msgs []*monitorProto.FrameData) error { // 단일 책임: 프레임 메시지 연속 전송
+	for _, m := range msgs {
+		if err := stream.Send(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (a *Agent) sendEventData(event *monitorProto.EventData) error { // 단일 책임: 이벤트 전송 + 오류 시 재시도
 	a.mu.Lock()
 	stream := a.eventStream
 	a.mu.Unlock()
 	if stream == nil {
-		return nil
+		return errStreamUnavailable
 	}
 	if err := stream.Send(event); err != nil {
+		err = streamSendError(err, func() error { _, e := stream.CloseAndRecv(); return e })
 		a.logger.Warnf("이벤트 전송 실패: %v - 재오픈 시도", err)
-		if a.reopenEventStream() == nil { // 성공 시 1회 재전송
+		a.refreshAuthIfRejected(err)
+		if a.reopenEventStream() == nil { // 성공 시 1회 재전송 (재전송 성공이면 nil)
 			a.mu.Lock()
+			defer a.mu.Unlock()
 			if a.eventStream != nil {
-				_ = a.eventStream.Send(event)
+				return a.eventStream.Send(event)
 			}
+		}
+		return err
+	}
+	return nil
+}
+
+// sendVideoChunk 함수는 영상 청크를 전송합니다. 스트림이 없으면 새로 열고, 전송에 실패하면 스트림을 버리고 오류를 반환합니다.
+// 영상은 세션 단위로 디코딩되므로 재전송하지 않고 호출자가 새 인코더 세션(키프레임)부터 다시 시작합니다.
+// 스트림 열기와 Send 는 잠금 밖에서 수행해 느린 링크가 다른 송신/연결 관리를 막지 않게 합니다.
+func (a *Agent) sendVideoChunk(chunk *monitorProto.VideoChunk) error { // 단일 책임: 영상 청크 전송
+	a.videoMu.Lock()
+	defer a.videoMu.Unlock()
+	a.mu.Lock()
+	client, stream := a.agentClient, a.videoStream
+	a.mu.Unlock()
+	if stream == nil {
+		if client == nil {
+			return errStreamUnavailable
+		}
+		opened, err := client.StreamVideo(a.ctx)
+		if err != nil {
+			return err
+		}
+		a.mu.Lock()
+		if a.agentClient != client { // 여는 사이 연결 교체됨
 			a.mu.Unlock()
+			_ = opened.CloseSend()
+			return errStreamUnavailable
+		}
+		a.videoStream, stream = opened, opened
+		a.mu.Unlock()
+		a.logger.Infow("영상 스트림 생성", "agent_id", a.agentID)
+	}
+	if err := stream.Send(chunk); err != nil {
+		a.mu.Lock()
+		if a.videoStream == stream {
+			a.videoStream = nil
 		}
+		a.mu.Unlock()
+		err = streamSendError(err, func() error { _, e := stream.CloseAndRecv(); return e })
+		a.logger.Warnf("영상 청크 전송 실패: %v", err)
 		return err
 	}
 	return nil
@@ -200,7 +457,7 @@ func (a *Agent) sendInitialFrame() error { // 단일 책임: 초기 프레임 
 	if a.frameStream == nil {
 		return nil
 	}
-	frame := &monitorProto.FrameData{AgentId: a.agentID, ImageData: nil, Timestamp: time.Now().UnixMilli(), IsPreview: INITIAL_FRAME_IS_PREVIEW}
+	frame := &monitorProto.FrameData{AgentId: a.agentID, ImageData: nil, Timestamp: time.Now().UnixMilli(), IsPreview: INITIAL_FRAME_IS_PREVIEW, MonitorId: MONITOR_ID_NONE}
 	return a.frameStream.Send(frame)
 }
 
@@ -208,73 +465,160 @@ func (a *Agent) sendInitialEvent() error { // 단일 책임: 초기 이벤트 
 	if a.eventStream == nil {
 		return nil
 	}
-	event := &monitorProto.EventData{AgentId: a.agentID, EventType: INITIAL_EVENT_TYPE, EventDetail: INITIAL_EVENT_DETAIL, Timestamp: time.Now().UnixMilli()}
+	class := classifyEvent(INITIAL_EVENT_TYPE)
+	event := &monitorProto.EventData{AgentId: a.agentID, EventType: INITIAL_EVENT_TYPE, EventDetail: INITIAL_EVENT_DETAIL, Timestamp: time.Now().UnixMilli(), Severity: class.severity, Category: class.category}
 	return a.eventStream.Send(event)
 }
 
+// reopenFrameStream 함수는 프레임 스트림을 다시 엽니다. 컨텍스트가 살아 있는 동안 백오프로 계속 시도하며,
+// 대기 중에는 잠금을 풀어 다른 스트림 송신을 막지 않습니다.
 func (a *Agent) reopenFrameStream() error { // 단일 책임: 프레임 스트림 재오픈
-	a.mu.Lock()
-	defer a.mu.Unlock()
-	for i := 1; i <= STREAM_REOPEN_MAX_ATTEMPTS; i++ {
-		stream, err := a.agentClient.StreamFrames(a.ctx)
+	bo := newBackoff(a.cfg)
+	for i := 1; ; i++ {
+		a.mu.Lock()
+		client, conn := a.agentClient, a.grpcConn
+		a.mu.Unlock()
+		if client == nil {
+			return context.Canceled
+		}
+		stream, err := client.StreamFrames(a.ctx, a.streamCallOptions()...)
 		if err == nil {
+			a.mu.Lock()
 			a.frameStream = stream
 			a.logger.Infof("프레임 스트림 재오픈 성공 attempt=%d", i)
 			if errInit := a.sendInitialFrame(); errInit != nil {
 				a.logger.Warnf("재오픈 후 초기 프레임 전송 실패: %v", errInit)
 			}
+			a.mu.Unlock()
 			return nil
 		}
 		a.logger.Warnf("프레임 스트림 재오픈 실패 attempt=%d err=%v", i, err)
-		select {
-		case <-time.After(time.Duration(STREAM_REOPEN_DELAY_MS) * time.Millisecond):
-		case <-a.ctx.Done():
-			return a.ctx.Err()
+		if a.requestReconnect(conn, "frame stream reopen failed: "+err.Error()) { // 연결 자체가 죽음: 연결 재구성에 맡김
+			return err
+		}
+		if werr := bo.wait(a.ctx); werr != nil {
+			a.mu.Lock()
+			a.frameStream = nil
+			a.mu.Unlock()
+			return werr
 		}
 	}
-	a.frameStream = nil
-	return context.Canceled
 }
 
+// reopenEventStream 함수는 이벤트 스트림을 다시 엽니다. 컨텍스트가 살아 있는 동안 백오프로 계속 시도하며,
+// 대기 중에는 잠금을 풀어 다른 스트림 송신을 막지 않습니다.
 func (a *Agent) reopenEventStream() error { // 단일 책임: 이벤트 스트림 재오픈
-	a.mu.Lock()
-	defer a.mu.Unlock()
-	for i := 1; i <= STREAM_REOPEN_MAX_ATTEMPTS; i++ {
-		stream, err := a.agentClient.StreamEvents(a.ctx)
+	bo := newBackoff(a.cfg)
+	for i := 1; ; i++ {
+		a.mu.Lock()
+		client, conn := a.agentClient, a.grpcConn
+		a.mu.Unlock()
+		if client == nil {
+			return context.Canceled
+		}
+		stream, err := client.StreamEvents(a.ctx, a.streamCallOptions()...)
 		if err == nil {
+			a.mu.Lock()
 			a.eventStream = stream
 			a.logger.Infof("이벤트 스트림 재오픈 성공 attempt=%d", i)
 			if errInit := a.sendInitialEvent(); errInit != nil {
 				a.logger.Warnf("재오픈 후 초기 이벤트 전송 실패: %v", errInit)
 			}
+			a.mu.Unlock()
 			return nil
 		}
 		a.logger.Warnf("이벤트 스트림 재오픈 실패 attempt=%d err=%v", i, err)
-		select {
-		case <-time.After(time.Duration(STREAM_REOPEN_DELAY_MS) * time.Millisecond):
-		case <-a.ctx.Done():
-			return a.ctx.Err()
+		if a.requestReconnect(conn, "event stream reopen failed: "+err.Error()) { // 연결 자체가 죽음: 연결 재구성에 맡김
+			return err
+		}
+		if werr := bo.wait(a.ctx); werr != nil {
+			a.mu.Lock()
+			a.eventStream = nil
+			a.mu.Unlock()
+			return werr
 		}
 	}
-	a.eventStream = nil
-	return context.Canceled
 }
 
+// Close 메서드는 캡처 중지 → 인코딩 중 프레임과 송신 큐 비우기 → 대기 이벤트 전송 → 스트림 CloseSend → 연결 종료 순서로
+// 에이전트를 끝냅니다. 비우기는 ShutdownTimeoutMs 까지만 기다리고, 넘기면 진행 중 전송을 취소해 남은 프레임/이벤트를
+// 오프라인 스풀에 보관합니다. 두 번째 호출부터는 무동작입니다.
 func (a *Agent) Close() { // 단일 책임: 자원 정리
-	if a.captureStopCh != nil {
-		close(a.captureStopCh)
+	if a == nil || !a.closing.CompareAndSwap(false, true) {
+		return
+	}
+	deadline := time.Now().Add(time.Duration(a.cfg.ShutdownTimeoutMs) * time.Millisecond)
+	if id, frames, elapsed, _, err := a.rec.end(); id != "" { // 진행 중 녹화는 닫고 종료 마커를 남김 (파일은 다음 실행의 연결 직후 또는 upload_recording 요청 시 업로드)
+		a.reportRecordingStop(id, frames, elapsed, err)
+	}
+	a.stopEventSources()
+	if done, ok := a.haltCapture(); ok { // 루프가 인코딩 중 프레임을 송신 큐에 넘기고 끝날 때까지 대기
+		if !waitDone(done, deadline) {
+			a.logger.Warn("종료 대기 시간 안에 캡처 루프가 끝나지 않음")
+		}
 	}
+	a.frameQueue.close() // 대기 프레임 송신 후 송신 고루틴 종료
+	flushed := make(chan struct{})
+	go func() {
+		defer close(flushed)
+		a.events.flush() // 묶음 대기 중 이벤트 전송 (실패 시 스풀 보관)
+	}()
+	if !waitDone(a.senderDone, deadline) || !waitDone(flushed, deadline) {
+		a.logger.Warnf("종료 대기 %dms 초과 - 진행 중 전송 취소 (남은 프레임 %d, 오프라인 스풀 보관)", a.cfg.ShutdownTimeoutMs, a.frameQueue.stats().Depth)
+		if a.cancel != nil { // 스트림 Send 가 a.ctx 로 풀려나 남은 기록은 스풀로 보냄
+			a.cancel()
+		}
+		abort := time.Now().Add(SHUTDOWN_ABORT_WAIT_MS * time.Millisecond)
+		waitDone(a.senderDone, abort)
+		waitDone(flushed, abort)
+	}
+	if st := a.frameQueue.stats(); st.Dropped > 0 {
+		a.logger.Infof("송신 큐에서 버려진 프레임 수: %d", st.Dropped)
+	}
+	if err := a.tracer.shutdown(); err != nil {
+		a.logger.Debugf("추적 구간 내보내기 실패: %v", err)
+	}
+	a.StopPprof()
+	a.StopHealth()
+	a.offline.close()
+	a.durable.close()
+	a.mu.Lock() // 재오픈 중인 스트림과 겹치지 않게 보유한 채 반쯤 닫기
 	if a.frameStream != nil {
 		_ = a.frameStream.CloseSend()
 	}
 	if a.eventStream != nil {
 		_ = a.eventStream.CloseSend()
 	}
-	if a.grpcConn != nil {
-		_ = a.grpcConn.Close()
+	if a.videoStream != nil {
+		_ = a.videoStream.CloseSend()
+	}
+	conn := a.grpcConn
+	a.mu.Unlock()
+	if conn != nil {
+		_ = conn.Close()
 	}
+	a.capMu.Lock()
+	if c, ok := a.capturer.(closableCapturer); ok { // OS 캡처 세션(DXGI 등) 해제
+		c.close()
+	}
+	a.capMu.Unlock()
 	if a.cancel != nil {
 		a.cancel()
 	}
 	a.logger.Info("에이전트 종료 완료")
 }
+
+// waitDone 함수는 done 이 닫히거나 deadline 이 지날 때까지 기다리고 제때 닫혔는지 반환합니다 (nil 이면 즉시 true).
+func waitDone(done <-chan struct{}, deadline time.Time) bool { // 단일 책임: 기한부 종료 대기
+	if done == nil {
+		return true
+	}
+	t := time.NewTimer(time.Until(deadline))
+	defer t.Stop()
+	select {
+	case <-done:
+		return true
+	case <-t.C:
+		return false
+	}
+}
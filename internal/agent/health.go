@@ -0,0 +1,135 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"agent/internal/config"
+)
+
+const (
+	HEALTH_STATUS_OK        = "ok"        // 정상
+	HEALTH_STATUS_STALLED   = "stalled"   // 캡처 중인데 캡처 루프가 멈춤 (감시가 재시작하는 중)
+	HEALTH_STATUS_NOT_READY = "not_ready" // 동작 중이나 서버 연결/스트림이 준비되지 않음 (/readyz 만 해당)
+
+	HEALTH_SHUTDOWN_TIMEOUT_MS = 2000 // 엔드포인트 종료 대기 상한
+)
+
+// HealthStatus 구조체는 /healthz, /readyz 응답 본문입니다.
+type HealthStatus struct {
+	Status          string `json:"status"`               // HEALTH_STATUS_*
+	Capturing       bool   `json:"capturing"`            // 캡처 루프 실행 여부
+	CaptureStalled  bool   `json:"capture_stalled"`      // 캡처 루프가 CAPTURE_WATCHDOG_FRAMES 기준으로 멈춤
+	LastFrameAgeMs  int64  `json:"last_frame_age_ms"`    // 마지막 캡처 후 경과(ms, 캡처한 적 없으면 -1)
+	Connection      string `json:"connection"`           // 연결 상태 (CONN_STATE_*)
+	FrameStream     bool   `json:"frame_stream"`         // 프레임 스트림 열림
+	EventStream     bool   `json:"event_stream"`         // 이벤트 스트림 열림
+	FrameQueueDepth int    `json:"frame_queue_depth"`    // 송신 대기 프레임 수
+	SpoolPending    bool   `json:"spool_pending"`        // 오프라인 스풀에 재전송 대기 기록 있음
+	SpoolFrameSegs  int    `json:"spool_frame_segments"` // 프레임 스풀 세그먼트 파일 수 (기록 중인 1개 포함)
+	SpoolEventSegs  int    `json:"spool_event_segments"` // 이벤트 스풀 세그먼트 파일 수 (기록 중인 1개 포함)
+}
+
+// healthState 구조체는 로컬 상태 확인 엔드포인트 실행 상태입니다.
+type healthState struct { // 단일 책임: 상태 확인 엔드포인트 상태 보관
+	mu   sync.Mutex
+	srv  *http.Server
+	addr string // 실제 수신 주소
+}
+
+// Health 메서드는 캡처/스트림/스풀 상태를 모아 반환합니다. ready 이면 서버 연결과 스트림 준비까지 봅니다.
+func (a *Agent) Health(ready bool) HealthStatus { // 단일 책임: 상태 확인 결과 계산
+	_, _, stalled := a.watchdog.stalled(a.cfg.WatchdogFrames)
+	cs := a.CaptureStats()
+	sp := a.OfflineSpoolStats()
+	a.mu.Lock()
+	frameOpen, eventOpen := a.frameStream != nil, a.eventStream != nil
+	a.mu.Unlock()
+	h := HealthStatus{
+		Status:          HEALTH_STATUS_OK,
+		Capturing:       cs.Capturing,
+		CaptureStalled:  cs.Capturing && stalled,
+		LastFrameAgeMs:  -1,
+		Connection:      a.conn.get().State,
+		FrameStream:     frameOpen,
+		EventStream:     eventOpen,
+		FrameQueueDepth: a.frameQueue.stats().Depth,
+		SpoolPending:    a.offline.pending(),
+		SpoolFrameSegs:  sp.Frames.Segments,
+		SpoolEventSegs:  sp.Events.Segments,
+	}
+	if cs.LastFrameAt > 0 {
+		h.LastFrameAgeMs = time.Now().UnixMilli() - cs.LastFrameAt
+	}
+	switch {
+	case h.CaptureStalled:
+		h.Status = HEALTH_STATUS_STALLED
+	case ready && (h.Connection != CONN_STATE_READY || !frameOpen || !eventOpen):
+		h.Status = HEALTH_STATUS_NOT_READY
+	}
+	return h
+}
+
+// serveHealth 함수는 Health 결과를 JSON 으로 쓰고, 정상이 아니면 503 으로 응답합니다.
+func (a *Agent) serveHealth(w http.ResponseWriter, ready bool) { // 단일 책임: 상태 확인 응답
+	h := a.Health(ready)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if h.Status != HEALTH_STATUS_OK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(h)
+}
+
+// StartHealth 메서드는 루프백 주소에 /healthz(캡처 루프 동작), /readyz(서버 연결까지 준비) 엔드포인트를 열고 수신 주소를 반환합니다.
+func (a *Agent) StartHealth() (string, error) { // 단일 책임: 상태 확인 엔드포인트 시작
+	addr := a.cfg.HealthAddr
+	if !config.IsLoopbackAddr(addr) {
+		return "", fmt.Errorf("상태 확인 주소는 루프백만 허용: %q", addr)
+	}
+	h := &a.health
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.srv != nil {
+		return h.addr, nil
+	}
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("상태 확인 엔드포인트 열기 실패: %w", err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) { a.serveHealth(w, false) })
+	mux.HandleFunc("GET /readyz", func(w http.ResponseWriter, _ *http.Request) { a.serveHealth(w, true) })
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	h.srv, h.addr = srv, lis.Addr().String()
+	go func() {
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			a.logger.Warnf("상태 확인 엔드포인트 종료: %v", err)
+		}
+	}()
+	a.logger.Infow("상태 확인 엔드포인트 시작", "addr", "http://"+h.addr+"/healthz")
+	return h.addr, nil
+}
+
+// StopHealth 메서드는 상태 확인 엔드포인트를 닫습니다 (열려 있지 않으면 무동작).
+func (a *Agent) StopHealth() { // 단일 책임: 상태 확인 엔드포인트 종료
+	h := &a.health
+	h.mu.Lock()
+	srv := h.srv
+	h.srv, h.addr = nil, ""
+	h.mu.Unlock()
+	if srv == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), HEALTH_SHUTDOWN_TIMEOUT_MS*time.Millisecond)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		a.logger.Debugf("상태 확인 엔드포인트 종료 대기 실패: %v", err)
+	}
+}
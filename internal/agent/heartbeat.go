@@ -0,0 +1,126 @@
+package agent
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	monitorProto "agent/proto"
+
+	grpcPkg "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	HEARTBEAT_MAX_TIMEOUT_MS = 5000 // Heartbeat RPC 1회 타임아웃 상한 (주기보다 길면 주기로 제한)
+)
+
+// procSampler 구조체는 직전 보고 이후 CPU 사용률과 캡처 FPS 를 계산합니다.
+type procSampler struct { // 단일 책임: 구간 사용률 계산
+	lastAt     time.Time
+	lastCPU    time.Duration
+	lastFrames uint64
+}
+
+// sample 함수는 직전 호출 이후 CPU 사용률(전체 코어 대비 %)과 프레임 처리율(FPS)을 반환합니다.
+func (s *procSampler) sample(frames uint64) (cpuPct, fps float64) { // 단일 책임: 구간 값 계산
+	now := time.Now()
+	cpu, ok := processCPUTime()
+	if !s.lastAt.IsZero() {
+		wall := now.Sub(s.lastAt)
+		if wall > 0 {
+			if ok {
+				cpuPct = float64(cpu-s.lastCPU) / float64(wall) / float64(runtime.NumCPU()) * 100
+			}
+			fps = float64(frames-s.lastFrames) / wall.Seconds()
+		}
+	}
+	s.lastAt, s.lastCPU, s.lastFrames = now, cpu, frames
+	return cpuPct, fps
+}
+
+// runHeartbeat 함수는 설정 주기마다 상태를 보고합니다. 연속 실패가 임계값에 닿으면 연결 상태를 서버 응답 없음으로 바꾸고,
+// 다시 성공하면 준비 상태로 되돌립니다. 서버가 Heartbeat 를 지원하지 않거나 컨텍스트가 끝나면 반환합니다.
+func (a *Agent) runHeartbeat() { // 단일 책임: 주기적 상태 보고
+	interval := time.Duration(a.cfg.HeartbeatIntervalMs) * time.Millisecond
+	if interval <= 0 {
+		return
+	}
+	timeout := time.Duration(HEARTBEAT_MAX_TIMEOUT_MS) * time.Millisecond
+	if timeout > interval {
+		timeout = interval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	sampler := &procSampler{}
+	sampler.sample(a.frameQueue.stats().Enqueued + a.videoFrames.Load())
+	var lastConn *grpcPkg.ClientConn
+	fails := 0
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		a.mu.Lock()
+		client, conn := a.agentClient, a.grpcConn
+		a.mu.Unlock()
+		if client == nil { // 재연결 중: 연결 감시 고루틴이 상태를 보고
+			continue
+		}
+		if conn != lastConn { // 새 연결에서는 실패 횟수를 다시 셈
+			lastConn, fails = conn, 0
+		}
+		ctx, cancel := context.WithTimeout(a.ctx, timeout)
+		_, err := client.Heartbeat(ctx, a.heartbeatRequest(sampler))
+		cancel()
+		if status.Code(err) == codes.Unimplemented {
+			a.logger.Info("서버가 Heartbeat RPC 를 지원하지 않음 - 상태 보고 중지")
+			return
+		}
+		if err != nil {
+			fails++
+			a.logger.Debugf("Heartbeat 실패 (%d회 연속): %v", fails, err)
+			if fails == a.cfg.HeartbeatFailThreshold && a.conn.transition(CONN_STATE_READY, CONN_STATE_UNREACHABLE, err.Error()) {
+				a.logger.Warnf("Heartbeat %d회 연속 실패 - 서버 응답 없음", fails)
+				if a.endpoints.count() > 1 { // 연결만 살아 있고 응답 없는 서버는 다음 주소로 장애 조치
+					a.endpoints.advance()
+					a.forceReconnect(conn, "heartbeat unreachable")
+				}
+			}
+			continue
+		}
+		fails = 0
+		if a.conn.transition(CONN_STATE_UNREACHABLE, CONN_STATE_READY, "") {
+			a.logger.Info("Heartbeat 복구 - 서버 응답 재개")
+		}
+	}
+}
+
+// heartbeatRequest 함수는 현재 프로세스/캡처 지표로 Heartbeat 요청을 구성합니다.
+func (a *Agent) heartbeatRequest(sampler *procSampler) *monitorProto.HeartbeatRequest { // 단일 책임: 보고 지표 수집
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	q := a.frameQueue.stats()
+	cpuPct, fps := sampler.sample(q.Enqueued + a.videoFrames.Load())
+	cs := a.stats.snapshot(time.Now())
+	return &monitorProto.HeartbeatRequest{
+		AgentId:            a.agentID,
+		CpuPercent:         cpuPct,
+		MemoryBytes:        ms.Sys,
+		UptimeSec:          int64(time.Since(a.startedAt).Seconds()),
+		CaptureFps:         fps,
+		FramesSent:         q.Sent,
+		FramesDropped:      a.framesDropped(q),
+		Capturing:          a.Capturing(),
+		Timestamp:          time.Now().UnixMilli(),
+		FramesCaptured:     cs.FramesCaptured,
+		AvgEncodeMs:        cs.AvgEncodeMs,
+		AvgFrameBytes:      cs.AvgFrameBytes,
+		ServerAddr:         a.endpoints.current(),
+		Bandwidth:          a.bandwidthReport(),
+		FramesDroppedQueue: q.Dropped,
+		FrameQueueDepth:    int32(q.Depth),
+	}
+}
@@ -0,0 +1,179 @@
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"agent/internal/config"
+	"agent/internal/i18n"
+	"agent/internal/secrets"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	IDENTITY_SOURCE_CONFIG    = "config"    // AGENT_ID 설정으로 지정
+	IDENTITY_SOURCE_KEYCHAIN  = "keychain"  // 비밀 저장소에 저장된 ID
+	IDENTITY_SOURCE_FILE      = "file"      // 사용자 설정 폴더 identity.json 에 저장된 ID
+	IDENTITY_SOURCE_GENERATED = "generated" // 이번 실행에서 새로 만든 ID
+	DEVICE_NAME_MAX_LEN       = 64          // 장치 이름 최대 길이(문자)
+)
+
+// errAgentIDFixed 변수는 AGENT_ID 설정으로 고정된 ID 를 재발급하려 할 때의 오류입니다.
+var errAgentIDFixed = i18n.NewError("error.agent_id_fixed")
+
+// errDeviceNameTooLong 변수는 DEVICE_NAME_MAX_LEN 보다 긴 장치 이름을 거부할 때의 오류입니다.
+var errDeviceNameTooLong = i18n.NewError("error.device_name_too_long")
+
+// Identity 구조체는 UI 에 보일 에이전트 식별 정보입니다.
+type Identity struct {
+	AgentID    string `json:"agentId"`    // 현재 실행 중인 에이전트 ID
+	DeviceName string `json:"deviceName"` // 서버 등록 시 보내는 장치 이름 (기본값 호스트명)
+	Source     string `json:"source"`     // ID 출처 (config | keychain | file | generated)
+	PendingID  string `json:"pendingId"`  // 재발급해 재시작 후 쓸 ID (없으면 빈 값)
+}
+
+// identityFile 구조체는 identity.json 내용입니다. 비밀 저장소를 쓸 수 없어도 ID 가 유지되도록 항상 함께 저장합니다.
+type identityFile struct {
+	AgentID    string `json:"agent_id"`
+	DeviceName string `json:"device_name,omitempty"`
+}
+
+// identityState 구조체는 실행 중 바꿀 수 있는 식별 정보입니다 (ID 자체는 재시작 전까지 고정).
+type identityState struct { // 단일 책임: 식별 정보 보관
+	mu      sync.Mutex
+	name    string
+	source  string
+	pending string
+}
+
+// storedAgentID 함수는 AGENT_ID 미지정 시 사용할 에이전트 ID 와 출처를 비밀 저장소 → identity.json 순서로 찾고,
+// 없으면 새로 만듭니다. 찾거나 만든 ID 는 두 곳 모두에 저장해, 한쪽을 잃어도 재시작 후 새 장치로 보이지 않게 합니다.
+func storedAgentID(logger *zap.SugaredLogger) (string, string) { // 단일 책임: 에이전트 ID 결정
+	file := readIdentityFile()
+	id, err := secrets.Get(secrets.AGENT_ID)
+	source := IDENTITY_SOURCE_KEYCHAIN
+	if err != nil && !errors.Is(err, secrets.ErrNotFound) && !errors.Is(err, secrets.ErrDisabled) {
+		logger.Debugf("저장된 에이전트 ID 조회 실패: %v", err)
+	}
+	if err != nil || id == "" {
+		id, source = file.AgentID, IDENTITY_SOURCE_FILE
+	}
+	if id == "" {
+		id, source = uuid.New().String(), IDENTITY_SOURCE_GENERATED
+	}
+	if source != IDENTITY_SOURCE_KEYCHAIN {
+		if err := secrets.Set(secrets.AGENT_ID, id); err != nil && !errors.Is(err, secrets.ErrDisabled) {
+			logger.Debugf("에이전트 ID 비밀 저장소 저장 실패: %v", err)
+		}
+	}
+	if file.AgentID != id {
+		file.AgentID = id
+		if err := writeIdentityFile(file); err != nil {
+			logger.Warnf("에이전트 ID 파일 저장 실패 (비밀 저장소도 없으면 재시작 시 새 ID): %v", err)
+		}
+	}
+	return id, source
+}
+
+// readIdentityFile 함수는 identity.json 을 읽습니다. 없거나 깨졌으면 빈 값입니다.
+func readIdentityFile() identityFile { // 단일 책임: 식별 파일 로드
+	var f identityFile
+	if data, err := os.ReadFile(config.IdentityPath()); err == nil {
+		_ = json.Unmarshal(data, &f)
+	}
+	return f
+}
+
+// writeIdentityFile 함수는 identity.json 을 임시 파일 교체 방식으로 씁니다.
+func writeIdentityFile(f identityFile) error { // 단일 책임: 식별 파일 기록
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := config.IdentityPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path+".tmp", data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// loadDeviceName 함수는 저장된 장치 이름을 반환합니다 (없으면 호스트명).
+func loadDeviceName(hostname string) string { // 단일 책임: 장치 이름 결정
+	if name := readIdentityFile().DeviceName; name != "" {
+		return name
+	}
+	return hostname
+}
+
+// Identity 메서드는 에이전트 ID, 장치 이름, ID 출처를 반환합니다.
+func (a *Agent) Identity() Identity { // 단일 책임: 식별 정보 조회
+	a.identity.mu.Lock()
+	defer a.identity.mu.Unlock()
+	return Identity{AgentID: a.agentID, DeviceName: a.identity.name, Source: a.identity.source, PendingID: a.identity.pending}
+}
+
+// SetDeviceName 메서드는 장치 이름을 바꿔 저장합니다. 빈 값이면 호스트명으로 되돌리며, 다음 서버 등록부터 반영됩니다.
+func (a *Agent) SetDeviceName(name string) error { // 단일 책임: 장치 이름 변경
+	name = strings.TrimSpace(name)
+	if len([]rune(name)) > DEVICE_NAME_MAX_LEN {
+		return errDeviceNameTooLong
+	}
+	a.identity.mu.Lock()
+	defer a.identity.mu.Unlock()
+	f := readIdentityFile()
+	if f.AgentID == "" && a.identity.source != IDENTITY_SOURCE_CONFIG {
+		f.AgentID = a.agentID
+	}
+	f.DeviceName = name
+	if err := writeIdentityFile(f); err != nil {
+		return err
+	}
+	if name == "" {
+		name = a.hostname
+	}
+	a.identity.name = name
+	a.logger.Infow("장치 이름 변경", "name", name)
+	return nil
+}
+
+// RegenerateAgentID 메서드는 새 에이전트 ID 를 만들어 저장하고 반환합니다. 스트림과 보관 이벤트가 현재 ID 를 쓰고 있어
+// 새 ID 는 재시작 후 적용됩니다. AGENT_ID 설정으로 지정한 ID 는 재발급할 수 없습니다.
+func (a *Agent) RegenerateAgentID() (string, error) { // 단일 책임: 에이전트 ID 재발급
+	a.identity.mu.Lock()
+	defer a.identity.mu.Unlock()
+	if a.identity.source == IDENTITY_SOURCE_CONFIG {
+		return "", errAgentIDFixed
+	}
+	id := uuid.New().String()
+	if err := secrets.Set(secrets.AGENT_ID, id); err != nil && !errors.Is(err, secrets.ErrDisabled) {
+		// 비밀 저장소가 먼저 읽히므로 이전 ID 가 남으면 재발급이 무시됨 - 지워서 파일 값을 쓰게 함
+		if derr := secrets.Delete(secrets.AGENT_ID); derr != nil && !errors.Is(derr, secrets.ErrNotFound) {
+			return "", fmt.Errorf("비밀 저장소의 이전 ID 교체 실패: %w", err)
+		}
+	}
+	f := readIdentityFile()
+	f.AgentID = id
+	if err := writeIdentityFile(f); err != nil {
+		return "", err
+	}
+	a.identity.pending = id
+	a.logger.Infow("에이전트 ID 재발급 (재시작 후 적용)", "current", a.agentID, "next", id)
+	return id, nil
+}
+
+// deviceName 함수는 등록 요청에 넣을 장치 이름을 반환합니다.
+func (a *Agent) deviceName() string { // 단일 책임: 장치 이름 조회
+	a.identity.mu.Lock()
+	defer a.identity.mu.Unlock()
+	return a.identity.name
+}
@@ -0,0 +1,94 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	INPUT_ACTIVITY_EVENT = "input_activity" // 구간별 키 입력/클릭 수와 마우스 이동 거리 (키 내용은 수집하지 않음)
+	INPUT_EVENT_SOURCE   = "input"          // 입력 활동 이벤트 발생원 이름
+	INPUT_POLL_MS        = 20               // 후킹 대신 상태를 조회하는 플랫폼(X11, macOS)의 입력 확인 간격(ms)
+)
+
+// errInputActivityUnsupported 변수는 현재 플랫폼/빌드에서 입력 활동 집계를 지원하지 않음을 나타냅니다.
+var errInputActivityUnsupported = errors.New("이 플랫폼에서는 입력 활동 집계를 지원하지 않음")
+
+// inputCounters 구조체는 보고 구간 동안의 키 입력 수, 클릭 수, 마우스 이동 거리를 모읍니다.
+// OS 훅/조회 스레드가 갱신하고 보고 고루틴이 take 로 읽어 비웁니다.
+type inputCounters struct { // 단일 책임: 입력 활동 집계
+	mu       sync.Mutex
+	keys     uint64  // 키 누름 수 (자동 반복 제외, 가능한 플랫폼에서)
+	clicks   uint64  // 마우스 버튼 누름 수
+	distance float64 // 마우스 이동 거리(px)
+	lastX    int
+	lastY    int
+	hasPos   bool // lastX/lastY 유효 여부
+}
+
+// addKeys 함수는 키 누름 n 회를 셉니다.
+func (c *inputCounters) addKeys(n int) { // 단일 책임: 키 입력 계수
+	c.mu.Lock()
+	c.keys += uint64(n)
+	c.mu.Unlock()
+}
+
+// addClicks 함수는 마우스 버튼 누름 n 회를 셉니다.
+func (c *inputCounters) addClicks(n int) { // 단일 책임: 클릭 계수
+	c.mu.Lock()
+	c.clicks += uint64(n)
+	c.mu.Unlock()
+}
+
+// moveTo 함수는 커서 위치를 받아 직전 위치와의 직선 거리를 더합니다.
+func (c *inputCounters) moveTo(x, y int) { // 단일 책임: 이동 거리 누적
+	c.mu.Lock()
+	if c.hasPos {
+		c.distance += math.Hypot(float64(x-c.lastX), float64(y-c.lastY))
+	}
+	c.lastX, c.lastY, c.hasPos = x, y, true
+	c.mu.Unlock()
+}
+
+// take 함수는 현재 구간 계수를 반환하고 비웁니다 (커서 위치는 유지).
+func (c *inputCounters) take() (keys, clicks uint64, distance float64) { // 단일 책임: 구간 계수 인출
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys, clicks, distance = c.keys, c.clicks, c.distance
+	c.keys, c.clicks, c.distance = 0, 0, 0
+	return keys, clicks, distance
+}
+
+// inputSource 함수는 InputActivity 설정 시 입력을 감시해 InputActivitySec 마다 집계 이벤트를 내는 발생원을 만듭니다 (꺼져 있으면 nil).
+// 입력이 없던 구간도 0 으로 보고해 서버가 자리 비움을 구분할 수 있게 하며, 감시를 시작하지 못하면 보고도 멈춥니다.
+func (a *Agent) inputSource() EventSource { // 단일 책임: 입력 활동 발생원 생성
+	if !a.cfg.InputActivity {
+		return nil
+	}
+	return newWatchSource(INPUT_EVENT_SOURCE, func(ctx context.Context, emit eventEmitter) {
+		counters := &inputCounters{}
+		failed := make(chan error, 1)
+		go func() { failed <- watchInput(ctx, counters) }()
+		interval := time.Duration(a.cfg.InputActivitySec) * time.Second
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-failed:
+				if err != nil && ctx.Err() == nil {
+					a.logger.Infof("입력 활동 집계 비활성: %v", err)
+				}
+				return
+			case <-ticker.C:
+				keys, clicks, distance := counters.take()
+				emit(INPUT_ACTIVITY_EVENT, fmt.Sprintf("keystrokes=%d clicks=%d mouse_px=%d interval_sec=%d", keys, clicks, int64(distance), a.cfg.InputActivitySec))
+			}
+		}
+	})
+}
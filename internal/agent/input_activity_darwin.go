@@ -0,0 +1,58 @@
+//go:build darwin && cgo
+
+package agent
+
+/*
+#cgo LDFLAGS: -framework CoreGraphics -framework CoreFoundation
+#include <CoreGraphics/CoreGraphics.h>
+
+// 세션 전체 누적 키 누름 수를 반환합니다.
+static uint32_t agent_key_count(void) {
+	return CGEventSourceCounterForEventType(kCGEventSourceStateCombinedSessionState, kCGEventKeyDown);
+}
+
+// 세션 전체 누적 마우스 버튼 누름 수를 반환합니다.
+static uint32_t agent_click_count(void) {
+	CGEventSourceStateID s = kCGEventSourceStateCombinedSessionState;
+	return CGEventSourceCounterForEventType(s, kCGEventLeftMouseDown) + CGEventSourceCounterForEventType(s, kCGEventRightMouseDown) + CGEventSourceCounterForEventType(s, kCGEventOtherMouseDown);
+}
+
+// 현재 커서 위치를 반환합니다.
+static CGPoint agent_cursor(void) {
+	CGEventRef e = CGEventCreate(NULL);
+	CGPoint p = CGEventGetLocation(e);
+	CFRelease(e);
+	return p;
+}
+*/
+import "C"
+
+import (
+	"context"
+	"time"
+)
+
+// watchInput 함수는 CGEventSourceCounterForEventType 누적 계수와 커서 위치를 INPUT_POLL_MS 마다 조회해 차이를 셉니다.
+// 이벤트 탭을 쓰지 않으므로 입력 모니터링 권한이 필요 없고, 키 누름 수에는 자동 반복이 포함됩니다.
+func watchInput(ctx context.Context, c *inputCounters) error { // 단일 책임: macOS 입력 계수 조회
+	keys, clicks := C.agent_key_count(), C.agent_click_count()
+	ticker := time.NewTicker(time.Duration(INPUT_POLL_MS) * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		k, cl := C.agent_key_count(), C.agent_click_count()
+		if k != keys {
+			c.addKeys(int(uint32(k - keys)))
+		}
+		if cl != clicks {
+			c.addClicks(int(uint32(cl - clicks)))
+		}
+		keys, clicks = k, cl
+		p := C.agent_cursor()
+		c.moveTo(int(p.x), int(p.y))
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !windows && !linux && !freebsd && !(darwin && cgo)
+
+package agent
+
+import "context"
+
+// watchInput 함수는 입력 활동 집계를 지원하지 않는 플랫폼에서 errInputActivityUnsupported 를 반환합니다.
+func watchInput(context.Context, *inputCounters) error { // 단일 책임: 미지원 플랫폼 처리
+	return errInputActivityUnsupported
+}
@@ -0,0 +1,119 @@
+//go:build windows
+
+package agent
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	WH_KEYBOARD_LL = 13
+	WH_MOUSE_LL    = 14
+	WM_QUIT        = 0x0012
+	WM_KEYDOWN     = 0x0100
+	WM_KEYUP       = 0x0101
+	WM_SYSKEYDOWN  = 0x0104
+	WM_SYSKEYUP    = 0x0105
+	WM_MOUSEMOVE   = 0x0200
+	WM_LBUTTONDOWN = 0x0201
+	WM_RBUTTONDOWN = 0x0204
+	WM_MBUTTONDOWN = 0x0207
+	WM_XBUTTONDOWN = 0x020B
+)
+
+// kbdLLHookStruct 구조체는 KBDLLHOOKSTRUCT 입니다.
+type kbdLLHookStruct struct {
+	VkCode    uint32
+	ScanCode  uint32
+	Flags     uint32
+	Time      uint32
+	ExtraInfo uintptr
+}
+
+// msLLHookStruct 구조체는 MSLLHOOKSTRUCT 입니다.
+type msLLHookStruct struct {
+	Pt        struct{ X, Y int32 }
+	MouseData uint32
+	Flags     uint32
+	Time      uint32
+	ExtraInfo uintptr
+}
+
+var (
+	procSetWindowsHookExW   = user32.NewProc("SetWindowsHookExW")
+	procUnhookWindowsHookEx = user32.NewProc("UnhookWindowsHookEx")
+	procCallNextHookEx      = user32.NewProc("CallNextHookEx")
+	procPostThreadMessageW  = user32.NewProc("PostThreadMessageW")
+	inputTarget             atomic.Pointer[inputCounters] // 훅 콜백이 갱신할 계수
+	inputKeyDown            [256]bool                     // 눌린 가상 키 (자동 반복 제외용, 훅 스레드에서만 접근)
+	keyboardHookProc        = windows.NewCallback(func(code, wparam uintptr, info *kbdLLHookStruct) uintptr {
+		if c := inputTarget.Load(); int32(code) >= 0 && c != nil {
+			vk := info.VkCode & 0xFF
+			switch wparam {
+			case WM_KEYDOWN, WM_SYSKEYDOWN:
+				if !inputKeyDown[vk] {
+					inputKeyDown[vk] = true
+					c.addKeys(1)
+				}
+			case WM_KEYUP, WM_SYSKEYUP:
+				inputKeyDown[vk] = false
+			}
+		}
+		r, _, _ := procCallNextHookEx.Call(0, code, wparam, uintptr(unsafe.Pointer(info)))
+		return r
+	})
+	mouseHookProc = windows.NewCallback(func(code, wparam uintptr, info *msLLHookStruct) uintptr {
+		if c := inputTarget.Load(); int32(code) >= 0 && c != nil {
+			switch wparam {
+			case WM_MOUSEMOVE:
+				c.moveTo(int(info.Pt.X), int(info.Pt.Y))
+			case WM_LBUTTONDOWN, WM_RBUTTONDOWN, WM_MBUTTONDOWN, WM_XBUTTONDOWN:
+				c.addClicks(1)
+			}
+		}
+		r, _, _ := procCallNextHookEx.Call(0, code, wparam, uintptr(unsafe.Pointer(info)))
+		return r
+	})
+)
+
+// watchInput 함수는 저수준 키보드/마우스 훅(WH_KEYBOARD_LL, WH_MOUSE_LL)으로 입력 횟수만 셉니다 (키 코드는 자동 반복 판별에만 사용).
+// 훅 콜백은 설치한 스레드의 메시지 루프에서 호출되므로 고루틴을 스레드에 고정합니다.
+func watchInput(ctx context.Context, c *inputCounters) error { // 단일 책임: Win32 입력 훅
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	inputTarget.Store(c)
+	defer inputTarget.Store(nil)
+	kb, _, err := procSetWindowsHookExW.Call(WH_KEYBOARD_LL, keyboardHookProc, 0, 0)
+	if kb == 0 {
+		return err
+	}
+	defer procUnhookWindowsHookEx.Call(kb)
+	ms, _, err := procSetWindowsHookExW.Call(WH_MOUSE_LL, mouseHookProc, 0, 0)
+	if ms == 0 {
+		return err
+	}
+	defer procUnhookWindowsHookEx.Call(ms)
+	tid := windows.GetCurrentThreadId()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			procPostThreadMessageW.Call(uintptr(tid), WM_QUIT, 0, 0)
+		case <-done:
+		}
+	}()
+	var m winMsg
+	for {
+		r, _, _ := procGetMessageW.Call(uintptr(unsafe.Pointer(&m)), 0, 0, 0)
+		if int32(r) <= 0 { // WM_QUIT 또는 오류
+			return nil
+		}
+		procDispatchMessageW.Call(uintptr(unsafe.Pointer(&m)))
+	}
+}
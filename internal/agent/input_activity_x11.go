@@ -0,0 +1,66 @@
+//go:build linux || freebsd
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"time"
+
+	"github.com/jezek/xgb"
+	"github.com/jezek/xgb/xproto"
+)
+
+// watchInput 함수는 X11 키맵(QueryKeymap)과 포인터(QueryPointer) 상태를 INPUT_POLL_MS 마다 조회해
+// 새로 눌린 키/버튼 수와 커서 이동 거리를 셉니다. 눌린 키 비트만 비교하므로 키 내용은 남지 않습니다.
+// 조회 사이에 눌렀다 뗀 입력은 놓칠 수 있어 근사치입니다.
+func watchInput(ctx context.Context, c *inputCounters) (err error) { // 단일 책임: X11 입력 상태 조회
+	defer func() { // xgb 는 연결 오류 시 panic 할 수 있음
+		if r := recover(); r != nil {
+			err = fmt.Errorf("X11 입력 상태 조회 실패: %v", r)
+		}
+	}()
+	conn, err := xgb.NewConn()
+	if err != nil {
+		return fmt.Errorf("X11 연결 실패: %w", err)
+	}
+	defer conn.Close()
+	root := xproto.Setup(conn).DefaultScreen(conn).Root
+	var prevKeys [32]byte
+	var prevButtons uint16
+	first := true
+	ticker := time.NewTicker(time.Duration(INPUT_POLL_MS) * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		km, err := xproto.QueryKeymap(conn).Reply()
+		if err != nil {
+			return err
+		}
+		ptr, err := xproto.QueryPointer(conn, root).Reply()
+		if err != nil {
+			return err
+		}
+		buttons := ptr.Mask & (xproto.ButtonMask1 | xproto.ButtonMask2 | xproto.ButtonMask3)
+		if !first { // 시작 시 이미 눌려 있던 키/버튼은 세지 않음
+			pressed := 0
+			for i := range prevKeys {
+				pressed += bits.OnesCount8(km.Keys[i] &^ prevKeys[i])
+			}
+			if pressed > 0 {
+				c.addKeys(pressed)
+			}
+			if n := bits.OnesCount16(buttons &^ prevButtons); n > 0 {
+				c.addClicks(n)
+			}
+		}
+		copy(prevKeys[:], km.Keys)
+		prevButtons, first = buttons, false
+		c.moveTo(int(ptr.RootX), int(ptr.RootY))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
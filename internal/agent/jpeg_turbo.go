@@ -0,0 +1,96 @@
+//go:build jpegturbo && cgo
+
+package agent
+
+/*
+#cgo LDFLAGS: -ljpeg
+#include <stdio.h>
+#include <stdlib.h>
+#include <setjmp.h>
+#include <jpeglib.h>
+
+// agent_jpeg_err 구조체는 longjmp 기반 오류 복귀를 위한 libjpeg 오류 관리자입니다.
+struct agent_jpeg_err {
+	struct jpeg_error_mgr pub;
+	jmp_buf jb;
+	char msg[JMSG_LENGTH_MAX];
+};
+
+static void agent_jpeg_error_exit(j_common_ptr cinfo) {
+	struct agent_jpeg_err *err = (struct agent_jpeg_err *)cinfo->err;
+	(*cinfo->err->format_message)(cinfo, err->msg);
+	longjmp(err->jb, 1);
+}
+
+// agent_jpeg_encode 함수는 RGBA 픽셀을 JPEG 으로 압축합니다. 출력 버퍼는 호출자가 free 해야 합니다.
+static int agent_jpeg_encode(const unsigned char *pix, int width, int height, int stride, int quality,
+                             unsigned char **out, unsigned long *out_size, char *err_buf, int err_len) {
+	struct jpeg_compress_struct cinfo;
+	struct agent_jpeg_err jerr;
+	JSAMPROW row;
+	*out = NULL;
+	*out_size = 0;
+	cinfo.err = jpeg_std_error(&jerr.pub);
+	jerr.pub.error_exit = agent_jpeg_error_exit;
+	if (setjmp(jerr.jb)) {
+		snprintf(err_buf, err_len, "%s", jerr.msg);
+		jpeg_destroy_compress(&cinfo);
+		if (*out) {
+			free(*out);
+			*out = NULL;
+		}
+		return -1;
+	}
+	jpeg_create_compress(&cinfo);
+	jpeg_mem_dest(&cinfo, out, out_size);
+	cinfo.image_width = width;
+	cinfo.image_height = height;
+	cinfo.input_components = 4;
+	cinfo.in_color_space = JCS_EXT_RGBA;
+	jpeg_set_defaults(&cinfo);
+	cinfo.dct_method = JDCT_IFAST;
+	jpeg_set_quality(&cinfo, quality, TRUE);
+	jpeg_start_compress(&cinfo, TRUE);
+	while (cinfo.next_scanline < cinfo.image_height) {
+		row = (JSAMPROW)(pix + (size_t)cinfo.next_scanline * stride);
+		jpeg_write_scanlines(&cinfo, &row, 1);
+	}
+	jpeg_finish_compress(&cinfo);
+	jpeg_destroy_compress(&cinfo);
+	return 0;
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"image"
+	"unsafe"
+)
+
+const (
+	TURBO_JPEG_ERR_BUF = 256 // C 오류 메시지 버퍼 크기
+)
+
+// turboJPEGAvailable 상수는 libjpeg-turbo 가속 인코더 포함 여부입니다.
+const turboJPEGAvailable = true
+
+// encodeJPEGTurbo 함수는 libjpeg-turbo(SIMD) 로 이미지를 JPEG 바이트로 인코딩합니다.
+func encodeJPEGTurbo(img image.Image, quality int) ([]byte, error) { // 단일 책임: 가속 JPEG 인코딩
+	rgba := toRGBA(img)
+	b := rgba.Bounds()
+	if b.Empty() {
+		return nil, errors.New("빈 이미지")
+	}
+	var out *C.uchar
+	var outSize C.ulong
+	errBuf := (*C.char)(C.malloc(TURBO_JPEG_ERR_BUF))
+	defer C.free(unsafe.Pointer(errBuf))
+	pix := (*C.uchar)(unsafe.Pointer(&rgba.Pix[rgba.PixOffset(b.Min.X, b.Min.Y)]))
+	rc := C.agent_jpeg_encode(pix, C.int(b.Dx()), C.int(b.Dy()), C.int(rgba.Stride), C.int(quality), &out, &outSize, errBuf, TURBO_JPEG_ERR_BUF)
+	if rc != 0 {
+		return nil, errors.New(C.GoString(errBuf))
+	}
+	defer C.free(unsafe.Pointer(out))
+	return C.GoBytes(unsafe.Pointer(out), C.int(outSize)), nil
+}
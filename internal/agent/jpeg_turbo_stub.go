@@ -0,0 +1,16 @@
+//go:build !jpegturbo || !cgo
+
+package agent
+
+import (
+	"errors"
+	"image"
+)
+
+// turboJPEGAvailable 상수는 libjpeg-turbo 가속 인코더 포함 여부입니다 (jpegturbo 빌드 태그 + cgo 필요).
+const turboJPEGAvailable = false
+
+// encodeJPEGTurbo 함수는 가속 인코더가 빌드에 포함되지 않았음을 알립니다.
+func encodeJPEGTurbo(img image.Image, quality int) ([]byte, error) { // 단일 책임: 미지원 안내
+	return nil, errors.New("jpegturbo 빌드 태그 없이 빌드됨")
+}
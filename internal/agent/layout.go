@@ -0,0 +1,59 @@
+package agent
+
+import (
+	"image"
+	"math"
+)
+
+const (
+	LAYOUT_HORIZONTAL = "horizontal" // 모니터 인덱스 순서로 가로 배치 (상단 정렬)
+	LAYOUT_VERTICAL   = "vertical"   // 모니터 인덱스 순서로 세로 배치 (좌측 정렬)
+	LAYOUT_GRID       = "grid"       // 가장 큰 모니터 크기의 칸으로 된 격자 (열 수 = ceil(sqrt(n)))
+	LAYOUT_PHYSICAL   = "physical"   // OS 가상 화면 좌표 그대로 배치 (모니터 사이 빈 공간은 검은색)
+)
+
+// COMBINED_LAYOUTS 변수는 지원하는 combined 모드 배치 목록입니다.
+var COMBINED_LAYOUTS = []string{LAYOUT_HORIZONTAL, LAYOUT_VERTICAL, LAYOUT_GRID, LAYOUT_PHYSICAL}
+
+// combinedLayout 함수는 모니터 영역(가상 화면 좌표)을 배치 방식에 따라 캔버스 좌표로 옮긴 영역과 캔버스 크기를 반환합니다.
+// 알 수 없는 배치는 horizontal 로 처리합니다.
+func combinedLayout(layout string, bounds []image.Rectangle) ([]image.Rectangle, image.Point) { // 단일 책임: 모니터 배치 계산
+	targets := make([]image.Rectangle, len(bounds))
+	var size image.Point
+	switch layout {
+	case LAYOUT_VERTICAL:
+		for i, b := range bounds {
+			targets[i] = image.Rect(0, size.Y, b.Dx(), size.Y+b.Dy())
+			size.X = max(size.X, b.Dx())
+			size.Y += b.Dy()
+		}
+	case LAYOUT_GRID:
+		var cell image.Point
+		for _, b := range bounds {
+			cell.X, cell.Y = max(cell.X, b.Dx()), max(cell.Y, b.Dy())
+		}
+		cols := max(int(math.Ceil(math.Sqrt(float64(len(bounds))))), 1)
+		rows := (len(bounds) + cols - 1) / cols
+		for i, b := range bounds {
+			origin := image.Pt(i%cols*cell.X, i/cols*cell.Y)
+			targets[i] = image.Rectangle{Min: origin, Max: origin.Add(b.Size())}
+		}
+		size = image.Pt(min(cols, len(bounds))*cell.X, rows*cell.Y)
+	case LAYOUT_PHYSICAL:
+		var union image.Rectangle
+		for _, b := range bounds {
+			union = union.Union(b)
+		}
+		for i, b := range bounds {
+			targets[i] = b.Sub(union.Min)
+		}
+		size = union.Size()
+	default:
+		for i, b := range bounds {
+			targets[i] = image.Rect(size.X, 0, size.X+b.Dx(), b.Dy())
+			size.X += b.Dx()
+			size.Y = max(size.Y, b.Dy())
+		}
+	}
+	return targets, size
+}
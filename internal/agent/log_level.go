@@ -0,0 +1,17 @@
+package agent
+
+import "agent/internal/logging"
+
+// SetLogLevel 메서드는 실행 중 로그 수준을 바꿉니다 (debug | info | warn | error). 현장에서 재시작 없이 상세 로그를 켤 때 사용하며,
+// 설정에 저장하지 않아 재시작하면 LOG_LEVEL 값으로 돌아갑니다.
+func (a *Agent) SetLogLevel(name string) error { // 단일 책임: 로그 수준 변경
+	if err := logging.SetLevel(name); err != nil {
+		return err
+	}
+	a.capMu.Lock()
+	prev := a.cfg.LogLevel
+	a.cfg.LogLevel = name
+	a.capMu.Unlock()
+	a.logger.Infow("로그 수준 변경", "from", prev, "to", name)
+	return nil
+}
@@ -0,0 +1,74 @@
+package agent
+
+import (
+	"encoding/base64"
+	"fmt"
+	"image"
+
+	"github.com/kbinani/screenshot"
+	xdraw "golang.org/x/image/draw"
+)
+
+const (
+	MONITOR_THUMB_WIDTH        = 240 // 모니터 선택 썸네일 폭(px)
+	MONITOR_THUMB_JPEG_QUALITY = 60  // 모니터 선택 썸네일 JPEG 품질
+)
+
+// MonitorInfo 구조체는 모니터 선택 화면에 보일 모니터 정보입니다.
+type MonitorInfo struct {
+	Index     int    `json:"index"`     // 모니터 번호 (SelectMonitor 인자)
+	Name      string `json:"name"`      // 표시 이름 ("모니터 1 (주)")
+	Width     int    `json:"width"`     // 해상도 폭
+	Height    int    `json:"height"`    // 해상도 높이
+	X         int    `json:"x"`         // 가상 화면 상 왼쪽 위 X
+	Y         int    `json:"y"`         // 가상 화면 상 왼쪽 위 Y
+	Primary   bool   `json:"primary"`   // 주 모니터 (가상 화면 원점에 놓인 모니터)
+	Thumbnail string `json:"thumbnail"` // 축소 화면 JPEG data URL (캡처 실패 또는 요청하지 않으면 빈 값)
+}
+
+// Monitors 메서드는 모니터 목록을 구조화해 반환합니다. thumbnails 이면 모니터마다 축소 화면을 캡처하며,
+// 송신 프레임과 같이 가림 영역을 칠하고 캡처 금지 앱이 전면이면 검게 비웁니다.
+func (a *Agent) Monitors(thumbnails bool) []MonitorInfo { // 단일 책임: 모니터 정보 조회
+	bounds := listMonitors()
+	blocked := thumbnails && a.privacyBlock() != ""
+	res := make([]MonitorInfo, 0, len(bounds))
+	for i, b := range bounds {
+		m := MonitorInfo{Index: i, Width: b.Dx(), Height: b.Dy(), X: b.Min.X, Y: b.Min.Y, Primary: b.Min == image.Point{}}
+		m.Name = fmt.Sprintf("모니터 %d", i+1)
+		if m.Primary {
+			m.Name += " (주)"
+		}
+		if thumbnails {
+			thumb, err := a.monitorThumbnail(i, b, blocked)
+			if err != nil {
+				a.logger.Debugf("모니터 %d 썸네일 실패: %v", i, err)
+			}
+			m.Thumbnail = thumb
+		}
+		res = append(res, m)
+	}
+	return res
+}
+
+// monitorThumbnail 함수는 모니터 idx 화면을 MONITOR_THUMB_WIDTH 폭으로 줄여 JPEG data URL 로 반환합니다.
+func (a *Agent) monitorThumbnail(idx int, b image.Rectangle, blank bool) (string, error) { // 단일 책임: 모니터 썸네일 생성
+	img, err := screenshot.CaptureRect(b)
+	if err != nil {
+		return "", err
+	}
+	a.masks.Load().apply(img, int32(idx))
+	if blank {
+		blankImage(img)
+	}
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	if w > MONITOR_THUMB_WIDTH {
+		w, h = MONITOR_THUMB_WIDTH, max(1, h*MONITOR_THUMB_WIDTH/w)
+	}
+	small := image.NewRGBA(image.Rect(0, 0, w, h))
+	xdraw.ApproxBiLinear.Scale(small, small.Bounds(), img, img.Bounds(), xdraw.Src, nil)
+	data, err := encodeJPEG(small, MONITOR_THUMB_JPEG_QUALITY)
+	if err != nil {
+		return "", err
+	}
+	return UI_PREVIEW_DATA_PREFIX + base64.StdEncoding.EncodeToString(data), nil
+}
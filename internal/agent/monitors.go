@@ -0,0 +1,148 @@
+package agent
+
+import (
+	"fmt"
+	"image"
+	"sync"
+	"time"
+
+	"github.com/kbinani/screenshot"
+)
+
+const (
+	MONITOR_MODE_ALL = "all" // 모든 모니터를 동시에 캡처해 모니터별 스트림으로 전송
+	MONITOR_ID_NONE  = -1    // 특정 모니터에 속하지 않는 프레임 (combined/window/더미)
+)
+
+// monitorSetCapturer 구조체는 모든 모니터를 각각 캡처합니다. captureLoop 는 grabAll 로 모니터별 이미지를 받아
+// 모니터마다 별도 파이프라인 상태로 처리하고, 단발 캡처(스크린샷 명령 등)는 combined 화면을 반환합니다.
+type monitorSetCapturer struct { // 단일 책임: 모니터별 동시 캡처
+	*screenshotCapturer // 단발 캡처용 combined 캡처러
+
+	backend  string // 모니터별 캡처 백엔드 (auto | screenshot)
+	mu       sync.Mutex
+	monitors []*screenshotCapturer // 모니터 인덱스별 single 캡처러
+}
+
+// monitorShot 구조체는 모니터 하나의 캡처 결과입니다.
+type monitorShot struct {
+	capt *screenshotCapturer
+	img  image.Image
+	at   time.Time
+	err  error
+}
+
+// newMonitorSetCapturer 함수는 monitorSetCapturer 생성자입니다. layout 은 단발 캡처의 combined 배치입니다.
+func newMonitorSetCapturer(layout, backend string, opts encodeOptions) *monitorSetCapturer { // 단일 책임: 인스턴스 생성
+	return &monitorSetCapturer{screenshotCapturer: newCombinedCapturer(layout, backend, opts), backend: backend}
+}
+
+// grabAll 함수는 현재 연결된 모든 모니터를 동시에 캡처합니다. 모니터 수가 바뀌면 모니터별 캡처러를 다시 만듭니다.
+func (m *monitorSetCapturer) grabAll() []monitorShot { // 단일 책임: 모니터별 동시 캡처
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if count := screenshot.NumActiveDisplays(); count != len(m.monitors) {
+		m.closeMonitorsLocked()
+		m.monitors = make([]*screenshotCapturer, count)
+		for i := range m.monitors {
+			m.monitors[i] = newMonitorCapturer(i, m.backend, m.opts)
+		}
+	}
+	shots := make([]monitorShot, len(m.monitors))
+	var wg sync.WaitGroup
+	for i, c := range m.monitors {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			img, err := c.grab()
+			shots[i] = monitorShot{capt: c, img: img, at: time.Now(), err: err}
+		}()
+	}
+	wg.Wait()
+	return shots
+}
+
+// closeMonitorsLocked 함수는 모니터별 캡처러의 백엔드 세션을 닫습니다 (mu 보유 상태).
+func (m *monitorSetCapturer) closeMonitorsLocked() { // 단일 책임: 모니터별 세션 정리
+	for _, c := range m.monitors {
+		c.close()
+	}
+}
+
+// close 함수는 모니터별 캡처러와 단발 캡처용 combined 캡처러의 백엔드 세션을 닫습니다.
+func (m *monitorSetCapturer) close() { // 단일 책임: closableCapturer 구현
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closeMonitorsLocked()
+	m.screenshotCapturer.close()
+}
+
+// capturerMonitorID 함수는 캡처러가 특정 모니터 하나를 캡처하면 그 인덱스를, 아니면 MONITOR_ID_NONE 을 반환합니다.
+func capturerMonitorID(capt screenCapturer) int32 { // 단일 책임: 프레임 모니터 ID 결정
+	if s, ok := capt.(*screenshotCapturer); ok && s.mode == "single" {
+		return int32(s.monitorIndex)
+	}
+	return MONITOR_ID_NONE
+}
+
+// captureMonitors 함수는 모든 모니터를 동시에 캡처한 뒤 모니터별 파이프라인 상태로 차례로 처리합니다.
+// 모니터 수가 바뀌면 모니터별 상태(delta/tiles 기준 프레임, 변화 감지, 영상 세션)를 새로 시작합니다.
+func (a *Agent) captureMonitors(ms *monitorSetCapturer, st *captureState) error { // 단일 책임: 모니터별 프레임 캡처
+	if !st.pool.hasRoom(1) {
+		a.scaler.notePressure()
+		return nil
+	}
+	shots := ms.grabAll()
+	if st.stopped() { // 캡처 중 중지/교체됨: 잡은 프레임 버림
+		for _, shot := range shots {
+			if shot.err == nil {
+				shot.capt.release(shot.img)
+			}
+		}
+		return nil
+	}
+	if len(shots) != len(st.monitors) {
+		st.resetMonitors()
+		for range shots {
+			st.monitors = append(st.monitors, a.newCaptureState(st.fps))
+		}
+	}
+	preview := a.computePreviewFlag()
+	var firstErr error
+	for i, shot := range shots {
+		if shot.err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("모니터 %d: %w", i, shot.err)
+			}
+			continue
+		}
+		sub := st.monitors[i]
+		sub.pool, sub.fps = st.pool, st.fps
+		if i > 0 && !st.pool.hasRoom(1) { // 앞 모니터 제출로 포화: 나머지 모니터는 이번 틱 생략
+			shot.capt.release(shot.img)
+			a.scaler.notePressure()
+			continue
+		}
+		meta := frameMeta{preview: preview, monitorID: int32(i)}
+		if err := a.processFrame(shot.capt, shot.img, shot.at, sub, meta); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("모니터 %d: %w", i, err)
+		}
+	}
+	return firstErr
+}
+
+// resetMonitors 함수는 모니터별 파이프라인 상태를 정리합니다 (all 모드를 벗어났거나 모니터 수가 바뀐 경우).
+func (st *captureState) resetMonitors() { // 단일 책임: 모니터별 상태 정리
+	for _, sub := range st.monitors {
+		sub.closeVideo()
+	}
+	st.monitors = nil
+}
+
+// SetAllMonitorsMode 메서드는 all 모드로 전환해 모든 모니터를 동시에 캡처하고 모니터별 프레임으로 전송합니다.
+func (a *Agent) SetAllMonitorsMode() { // 단일 책임: all 모드 전환
+	a.capMu.Lock()
+	defer a.capMu.Unlock()
+	a.cfg.MonitorMode = MONITOR_MODE_ALL
+	a.setCapturerLocked(newMonitorSetCapturer(a.cfg.CombinedLayout, a.cfg.CaptureBackend, encodeOptionsFromConfig(a.cfg)))
+}
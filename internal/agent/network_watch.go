@@ -0,0 +1,162 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	NETWORK_UP_EVENT       = "network_interface_up"       // 인터페이스 활성 (name, kind, addrs)
+	NETWORK_DOWN_EVENT     = "network_interface_down"     // 인터페이스 비활성 또는 제거 (name, kind)
+	NETWORK_ADDR_EVENT     = "network_address_changed"    // 활성 인터페이스 주소 변경 (name, kind, addrs)
+	NETWORK_ROUTE_EVENT    = "network_route_changed"      // 기본 경로 인터페이스/출발 주소 변경 (interface 가 비면 연결 없음)
+	NETWORK_EVENT_SOURCE   = "network"                    // 네트워크 이벤트 발생원 이름
+	NETWORK_ROUTE_PROBE    = "192.0.2.1:9"                // 기본 경로 조회용 UDP 목적지 (TEST-NET-1, 패킷은 보내지 않음)
+	NETWORK_KIND_WIFI      = "wifi"                       // 무선 LAN
+	NETWORK_KIND_ETHERNET  = "ethernet"                   // 유선 LAN (macOS en* 은 유/무선 구분 불가로 여기에 포함)
+	NETWORK_KIND_VPN       = "vpn"                        // 터널/VPN
+	NETWORK_KIND_OTHER     = "other"                      // 그 외 (휴대폰 테더링, 가상 브리지 등)
+	NETWORK_SYSFS_WIRELESS = "/sys/class/net/%s/wireless" // Linux 무선 인터페이스 표시 경로
+)
+
+// netInterface 구조체는 비교용 인터페이스 상태입니다.
+type netInterface struct {
+	kind  string
+	up    bool
+	addrs string // 정렬한 주소 목록 (쉼표 구분)
+}
+
+// netRoute 구조체는 기본 경로 요약입니다 (iface 가 비면 기본 경로 없음).
+type netRoute struct {
+	iface   string
+	localIP string
+}
+
+// networkSource 함수는 NetworkEvents 설정 시 NetworkPollMs 마다 인터페이스/기본 경로를 확인해
+// 바뀐 항목마다 이벤트를 내는 발생원을 만듭니다 (꺼져 있으면 nil). 프레임 전송 공백이 네트워크 전환 때문인지 구분하는 용도입니다.
+func (a *Agent) networkSource() EventSource { // 단일 책임: 네트워크 발생원 생성
+	if !a.cfg.NetworkEvents {
+		return nil
+	}
+	return newWatchSource(NETWORK_EVENT_SOURCE, func(ctx context.Context, emit eventEmitter) {
+		ticker := time.NewTicker(time.Duration(a.cfg.NetworkPollMs) * time.Millisecond)
+		defer ticker.Stop()
+		ifaces, route := snapshotInterfaces(), defaultRoute()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			cur := snapshotInterfaces()
+			diffInterfaces(ifaces, cur, emit)
+			ifaces = cur
+			if r := defaultRoute(); r != route {
+				kind := ""
+				if r.iface != "" {
+					kind = cur[r.iface].kind
+				}
+				a.logger.Infow("기본 네트워크 경로 변경", "from", route.iface, "to", r.iface, "local_ip", r.localIP)
+				emit(NETWORK_ROUTE_EVENT, fmt.Sprintf("interface=%s kind=%s local_ip=%s previous=%s", r.iface, kind, r.localIP, route.iface))
+				route = r
+			}
+		}
+	})
+}
+
+// diffInterfaces 함수는 두 상태를 비교해 인터페이스 활성/비활성/주소 변경 이벤트를 발행합니다.
+func diffInterfaces(prev, cur map[string]netInterface, emit eventEmitter) { // 단일 책임: 인터페이스 변화 이벤트
+	for name, c := range cur {
+		p, ok := prev[name]
+		switch {
+		case c.up && (!ok || !p.up):
+			emit(NETWORK_UP_EVENT, fmt.Sprintf("name=%s kind=%s addrs=%s", name, c.kind, c.addrs))
+		case !c.up && ok && p.up:
+			emit(NETWORK_DOWN_EVENT, fmt.Sprintf("name=%s kind=%s", name, c.kind))
+		case c.up && c.addrs != p.addrs:
+			emit(NETWORK_ADDR_EVENT, fmt.Sprintf("name=%s kind=%s addrs=%s", name, c.kind, c.addrs))
+		}
+	}
+	for name, p := range prev {
+		if _, ok := cur[name]; !ok && p.up { // USB/VPN 어댑터 제거
+			emit(NETWORK_DOWN_EVENT, fmt.Sprintf("name=%s kind=%s", name, p.kind))
+		}
+	}
+}
+
+// snapshotInterfaces 함수는 루프백을 제외한 인터페이스의 활성 여부와 주소를 조회합니다.
+func snapshotInterfaces() map[string]netInterface { // 단일 책임: 인터페이스 상태 조회
+	list, err := net.Interfaces()
+	if err != nil {
+		return nil
+	}
+	out := make(map[string]netInterface, len(list))
+	for _, ifc := range list {
+		if ifc.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		var addrs []string
+		if as, err := ifc.Addrs(); err == nil {
+			for _, addr := range as {
+				if ipn, ok := addr.(*net.IPNet); ok && !ipn.IP.IsLinkLocalUnicast() {
+					addrs = append(addrs, ipn.IP.String())
+				}
+			}
+		}
+		slices.Sort(addrs)
+		out[ifc.Name] = netInterface{kind: interfaceKind(ifc), up: ifc.Flags&net.FlagUp != 0 && ifc.Flags&net.FlagRunning != 0, addrs: strings.Join(addrs, ",")}
+	}
+	return out
+}
+
+// interfaceKind 함수는 인터페이스 이름/플래그로 종류를 추정합니다 (Linux 는 sysfs 무선 표시 우선).
+func interfaceKind(ifc net.Interface) string { // 단일 책임: 인터페이스 종류 추정
+	name := strings.ToLower(ifc.Name)
+	if _, err := os.Stat(fmt.Sprintf(NETWORK_SYSFS_WIRELESS, ifc.Name)); err == nil {
+		return NETWORK_KIND_WIFI
+	}
+	switch {
+	case ifc.Flags&net.FlagPointToPoint != 0, hasAnyPrefix(name, "tun", "tap", "wg", "ppp", "utun", "ipsec", "vpn"), strings.Contains(name, "vpn"):
+		return NETWORK_KIND_VPN
+	case hasAnyPrefix(name, "wl", "wi-fi", "wifi", "wireless", "무선"):
+		return NETWORK_KIND_WIFI
+	case hasAnyPrefix(name, "eth", "en", "이더넷"):
+		return NETWORK_KIND_ETHERNET
+	}
+	return NETWORK_KIND_OTHER
+}
+
+// hasAnyPrefix 함수는 s 가 접두사 중 하나로 시작하는지 반환합니다.
+func hasAnyPrefix(s string, prefixes ...string) bool { // 단일 책임: 접두사 비교
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultRoute 함수는 외부 주소로 UDP 소켓을 연결해(패킷 전송 없음) OS 가 고른 출발 주소와 그 인터페이스를 찾습니다.
+func defaultRoute() netRoute { // 단일 책임: 기본 경로 조회
+	conn, err := net.Dial("udp", NETWORK_ROUTE_PROBE)
+	if err != nil { // 기본 경로 없음
+		return netRoute{}
+	}
+	local := conn.LocalAddr().(*net.UDPAddr).IP
+	conn.Close()
+	list, _ := net.Interfaces()
+	for _, ifc := range list {
+		addrs, _ := ifc.Addrs()
+		for _, addr := range addrs {
+			if ipn, ok := addr.(*net.IPNet); ok && ipn.IP.Equal(local) {
+				return netRoute{iface: ifc.Name, localIP: local.String()}
+			}
+		}
+	}
+	return netRoute{localIP: local.String()}
+}
@@ -0,0 +1,368 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"agent/internal/config"
+	"agent/internal/spool"
+	monitorProto "agent/proto"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	SPOOL_DIR_NAME           = "spool"          // 데이터 디렉터리 하위 오프라인 스풀 폴더
+	SPOOL_FRAME_SEGMENT_SIZE = 32 << 20         // 프레임 스풀 세그먼트 크기 (고해상도 PNG 1장 이상 수용)
+	SPOOL_EVENT_SEGMENT_SIZE = 4 << 20          // 이벤트 스풀 세그먼트 크기
+	SPOOL_COMMIT_EVERY       = 32               // 재전송 중 읽기 위치를 확정하는 레코드 간격
+	SPOOL_DROP_OLDEST        = "oldest"         // 가득 차면 가장 오래된 기록 폐기
+	SPOOL_DROP_NEWEST        = "newest"         // 가득 차면 새 기록 거부
+	SPOOL_RECORD_KIND        = byte(1)          // 스풀 레코드 종류 (proto 직렬화 메시지)
+	SPOOL_REPLAY_EVENT       = "spool_replayed" // 재전송 완료 이벤트
+)
+
+// errStreamUnavailable 변수는 스트림이 아직 열리지 않았거나 재구성 중임을 나타냅니다.
+var errStreamUnavailable = errors.New("스트림 사용 불가")
+
+// spoolLane 구조체는 한 종류(프레임 또는 이벤트) 메시지의 오프라인 스풀입니다.
+// 스풀에 재전송 대기 기록이 있는 동안(backlog)에는 새 메시지도 스풀 끝에 붙여 전송 순서를 유지합니다.
+type spoolLane struct { // 단일 책임: 종류별 스풀 + 순서 유지
+	name    string
+	sp      *spool.Spool
+	mu      sync.Mutex
+	backlog bool
+	admit   func(msg proto.Message) bool // 보관 여부 필터 (nil 이면 전부 보관, l.mu 보유 상태에서 호출)
+	spooled atomic.Uint64                // 누적 스풀 기록 수
+	dropped atomic.Uint64                // 용량 초과/직렬화 실패/필터로 버린 수
+}
+
+// openSpoolLane 함수는 maxMB 용량의 스풀을 엽니다. maxMB 가 0 이하이면 nil(비활성)을 반환합니다.
+func openSpoolLane(dir, name string, maxMB, segSize int, policy string, logger *zap.SugaredLogger) *spoolLane { // 단일 책임: 스풀 열기
+	if maxMB <= 0 {
+		return nil
+	}
+	segments := int(int64(maxMB) << 20 / int64(segSize))
+	if segments < 2 {
+		segments = 2
+	}
+	sp, err := spool.Open(filepath.Join(dir, name), spool.Options{SegmentSize: segSize, MaxSegments: segments, DropNewest: policy == SPOOL_DROP_NEWEST})
+	if err != nil {
+		logger.Warnf("%s 오프라인 스풀 열기 실패 (비활성): %v", name, err)
+		return nil
+	}
+	return &spoolLane{name: name, sp: sp, backlog: !sp.Empty()}
+}
+
+// offer 함수는 재전송 대기 중이면 메시지를 스풀 끝에 추가하고 true 를 반환합니다 (false 면 바로 전송).
+func (l *spoolLane) offer(msg proto.Message) bool { // 단일 책임: 순서 유지 스풀링
+	if l == nil {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.backlog {
+		return false
+	}
+	l.appendLocked(msg)
+	return true
+}
+
+// put 함수는 전송 실패한 메시지를 스풀에 넣고 이후 메시지도 스풀로 보내도록 전환합니다.
+func (l *spoolLane) put(msg proto.Message) { // 단일 책임: 실패 메시지 스풀링
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	l.backlog = true
+	l.appendLocked(msg)
+	l.mu.Unlock()
+}
+
+// appendLocked 함수는 메시지를 직렬화해 스풀에 기록합니다 (l.mu 보유 상태).
+func (l *spoolLane) appendLocked(msg proto.Message) { // 단일 책임: 직렬화 + 기록
+	if l.admit != nil && !l.admit(msg) {
+		l.dropped.Add(1)
+		return
+	}
+	b, err := proto.Marshal(msg)
+	if err == nil {
+		err = l.sp.Append(SPOOL_RECORD_KIND, b)
+	}
+	if err != nil {
+		l.dropped.Add(1)
+		return
+	}
+	l.spooled.Add(1)
+}
+
+// pending 함수는 재전송 대기 기록이 있어 새 메시지도 스풀로 보내는 중인지 반환합니다.
+func (l *spoolLane) pending() bool { // 단일 책임: 재전송 대기 조회
+	if l == nil {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.backlog
+}
+
+// drain 함수는 스풀 기록을 순서대로 send 로 재전송합니다. 전송 실패 시 마지막 확정 위치로 되돌리고 오류를 반환하며,
+// 스풀이 비면 backlog 를 해제해 이후 메시지는 바로 전송되게 합니다. 반환값 n 은 재전송한 기록 수입니다.
+func (l *spoolLane) drain(send func(data []byte) error) (n int, err error) { // 단일 책임: 순서대로 재전송
+	if l == nil {
+		return 0, nil
+	}
+	for {
+		l.mu.Lock()
+		rec, ok, err := l.sp.Next()
+		if err != nil {
+			l.mu.Unlock()
+			return n, err
+		}
+		if !ok { // 잠금 보유 중 비었음을 확인해야 새 메시지가 끼어들지 않음
+			l.backlog = false
+			err := l.sp.Commit()
+			l.mu.Unlock()
+			return n, err
+		}
+		l.mu.Unlock()
+		if err := send(rec.Data); err != nil {
+			l.sp.Rewind()
+			return n, err
+		}
+		n++
+		if n%SPOOL_COMMIT_EVERY == 0 {
+			_ = l.sp.Commit()
+		}
+	}
+}
+
+// close 함수는 스풀을 닫습니다.
+func (l *spoolLane) close() { // 단일 책임: 스풀 닫기
+	if l != nil {
+		_ = l.sp.Close()
+	}
+}
+
+// offlineSpool 구조체는 서버에 닿지 않는 동안 프레임/이벤트를 디스크에 보관하고 재연결 시 재전송합니다.
+type offlineSpool struct { // 단일 책임: 오프라인 보관 + 재전송
+	frames    *spoolLane
+	events    *spoolLane
+	maxAge    time.Duration // 이보다 오래된 기록은 재전송하지 않음 (0=제한 없음)
+	replaying atomic.Bool
+	live      *chainGuard // 재전송을 마친 뒤 실시간 delta/tiles 프레임의 기준 프레임 유실 여부
+}
+
+// newOfflineSpool 함수는 설정에 따라 프레임/이벤트 스풀을 엽니다.
+func newOfflineSpool(cfg *config.Config, logger *zap.SugaredLogger) *offlineSpool { // 단일 책임: 인스턴스 생성
+	dir := filepath.Join(cfg.DataDir, SPOOL_DIR_NAME)
+	o := &offlineSpool{
+		frames: openSpoolLane(dir, "frames", cfg.SpoolFrameMaxMB, SPOOL_FRAME_SEGMENT_SIZE, cfg.SpoolFrameDrop, logger),
+		events: openSpoolLane(dir, "events", cfg.SpoolEventMaxMB, SPOOL_EVENT_SEGMENT_SIZE, cfg.SpoolEventDrop, logger),
+		maxAge: time.Duration(cfg.SpoolMaxAgeSec) * time.Second,
+		live:   newChainGuard(),
+	}
+	if o.frames != nil && cfg.SpoolFrameIntervalMs > 0 {
+		o.frames.admit = frameSampler(time.Duration(cfg.SpoolFrameIntervalMs) * time.Millisecond)
+	}
+	return o
+}
+
+// frameSampler 함수는 오프라인 동안 모니터마다 interval 당 한 장만 보관하는 필터를 반환합니다 (디스크 쓰기량 제한).
+// delta/tiles 프레임은 건너뛰면 복원할 수 없으므로 독립 디코딩 가능한 키프레임만 보관합니다 (재전송 후 실시간 프레임은
+// replayOffline 이 키프레임부터 다시 시작시킴).
+func frameSampler(interval time.Duration) func(proto.Message) bool { // 단일 책임: 보관 프레임 표본 추출
+	last := make(map[int32]int64) // 모니터 ID → 마지막 보관 프레임 타임스탬프
+	return func(msg proto.Message) bool {
+		fr, ok := msg.(*monitorProto.FrameData)
+		if !ok {
+			return true
+		}
+		if fr.GetUnchanged() { // 생존 표시는 재전송 의미 없음
+			return false
+		}
+		if chainedEncoding(fr.GetEncoding()) && !fr.GetKeyframe() {
+			return false
+		}
+		if prev := last[fr.GetMonitorId()]; prev != 0 && time.Duration(fr.GetTimestamp()-prev)*time.Millisecond < interval {
+			return false
+		}
+		last[fr.GetMonitorId()] = fr.GetTimestamp()
+		return true
+	}
+}
+
+// chainGuard 구조체는 버려진 기록 때문에 delta/tiles 기준 프레임을 잃은 모니터를 기록해, 다음 키프레임까지
+// 그 모니터의 비키프레임을 걸러냅니다 (서버가 복원할 수 없는 프레임을 보내지 않음).
+type chainGuard struct { // 단일 책임: 끊긴 프레임 체인 차단
+	mu     sync.Mutex
+	all    bool           // 모든 모니터가 끊김 (키프레임을 보낸 모니터만 fixed 로 해제)
+	broken map[int32]bool // 개별로 끊긴 모니터
+	fixed  map[int32]bool // all 이후 키프레임으로 복구된 모니터
+}
+
+// newChainGuard 함수는 chainGuard 생성자입니다.
+func newChainGuard() *chainGuard { // 단일 책임: 인스턴스 생성
+	return &chainGuard{broken: make(map[int32]bool), fixed: make(map[int32]bool)}
+}
+
+// breakAll 함수는 모든 모니터를 다음 키프레임까지 끊긴 것으로 표시합니다.
+func (g *chainGuard) breakAll() { // 단일 책임: 전체 끊김 표시
+	g.mu.Lock()
+	g.all = true
+	clear(g.broken)
+	clear(g.fixed)
+	g.mu.Unlock()
+}
+
+// skip 함수는 frame 이 버려진 delta/tiles 기록이면 그 모니터를 끊긴 것으로 표시합니다.
+func (g *chainGuard) skip(frame *monitorProto.FrameData) { // 단일 책임: 개별 끊김 표시
+	if !chainedEncoding(frame.GetEncoding()) {
+		return
+	}
+	g.mu.Lock()
+	g.broken[frame.GetMonitorId()] = true
+	g.mu.Unlock()
+}
+
+// admit 함수는 frame 을 보내도 되는지 반환합니다. 끊긴 모니터의 delta/tiles 비키프레임은 false 이고, 키프레임은 그 모니터를 복구합니다.
+func (g *chainGuard) admit(frame *monitorProto.FrameData) bool { // 단일 책임: 복원 가능 프레임 판별
+	if !chainedEncoding(frame.GetEncoding()) {
+		return true
+	}
+	id := frame.GetMonitorId()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if frame.GetKeyframe() {
+		delete(g.broken, id)
+		if g.all {
+			g.fixed[id] = true
+		}
+		return true
+	}
+	return !g.broken[id] && (!g.all || g.fixed[id])
+}
+
+// expired 함수는 타임스탬프(ms)가 보관 시간 상한을 넘었는지 반환합니다.
+func (o *offlineSpool) expired(tsMs int64) bool { // 단일 책임: 보관 시간 확인
+	return o.maxAge > 0 && time.Since(time.UnixMilli(tsMs)) > o.maxAge
+}
+
+// pending 함수는 프레임 또는 이벤트 스풀에 재전송 대기 기록이 있는지 반환합니다.
+func (o *offlineSpool) pending() bool { // 단일 책임: 재전송 대기 조회
+	return o.frames.pending() || o.events.pending()
+}
+
+// close 함수는 두 스풀을 닫습니다.
+func (o *offlineSpool) close() { // 단일 책임: 스풀 닫기
+	o.frames.close()
+	o.events.close()
+}
+
+// sendOrSpoolFrame 함수는 재전송 대기 중이거나 전송에 실패한 프레임을 스풀에 보관합니다.
+func (a *Agent) sendOrSpoolFrame(frame *monitorProto.FrameData) { // 단일 책임: 프레임 전송/보관
+	if a.offline.frames.offer(frame) {
+		return
+	}
+	if !a.offline.live.admit(frame) { // 재전송 후 기준 프레임이 없는 delta/tiles: 요청한 키프레임까지 버림
+		return
+	}
+	if !a.throttleFrame(frame, true) { // 송신 예산 소진 (drop 정책)
+		return
+	}
+	if err := a.sendFrameData(frame); err != nil {
+		a.offline.frames.put(frame)
+		a.conn.setSpooling(a.offline.pending())
+	}
+}
+
+// deliverEvent 함수는 재전송 대기 중이거나 전송에 실패한 이벤트를 스풀에 보관합니다 (이벤트 묶음 전송 단계의 출구).
+func (a *Agent) deliverEvent(event *monitorProto.EventData) error { // 단일 책임: 이벤트 전송/보관
+	if a.offline.events.offer(event) {
+		return nil
+	}
+	if err := a.sendEventData(event); err != nil {
+		a.offline.events.put(event)
+		a.conn.setSpooling(a.offline.pending())
+		return err
+	}
+	return nil
+}
+
+// replayOffline 함수는 스트림이 열린 뒤 보관된 이벤트와 프레임을 각각 순서대로 재전송합니다 (동시 실행 1개).
+// 보관 중 표본 추출/보관 시간 초과/용량 초과 회전으로 빠진 기록이 있을 수 있으므로, delta/tiles 는 모니터마다 키프레임부터
+// 보내고, 재전송을 마치면 실시간 프레임도 모든 모니터에 키프레임을 요청해 그 키프레임부터 보냅니다.
+func (a *Agent) replayOffline() { // 단일 책임: 오프라인 기록 재전송
+	if !a.offline.replaying.CompareAndSwap(false, true) {
+		return
+	}
+	defer a.offline.replaying.Store(false)
+	defer func() { a.conn.setSpooling(a.offline.pending()) }()
+	start := time.Now()
+	events, errE := a.offline.events.drain(func(b []byte) error {
+		ev := &monitorProto.EventData{}
+		if proto.Unmarshal(b, ev) != nil || a.offline.expired(ev.GetTimestamp()) {
+			return nil
+		}
+		return a.sendEventData(ev)
+	})
+	backlog := a.offline.frames.pending()
+	if backlog { // 재전송이 끝나 실시간으로 넘어가는 순간부터 적용되도록 미리 끊어 둠
+		a.offline.live.breakAll()
+	}
+	replay := newChainGuard()
+	replay.breakAll() // 스풀 첫 기록의 기준 프레임은 회전 폐기/표본 추출로 사라졌을 수 있음
+	frames, errF := a.offline.frames.drain(func(b []byte) error {
+		fr := &monitorProto.FrameData{}
+		if proto.Unmarshal(b, fr) != nil {
+			return nil
+		}
+		if a.offline.expired(fr.GetTimestamp()) {
+			replay.skip(fr)
+			return nil
+		}
+		if !replay.admit(fr) {
+			return nil
+		}
+		a.throttleFrame(fr, false)
+		return a.sendFrameData(fr)
+	})
+	if backlog && errF == nil { // 실시간 delta/tiles 의 기준 프레임이 스풀에 들어갔거나 빠졌음: 새 키프레임부터
+		a.keyframes.requestAll()
+	}
+	if errE != nil || errF != nil {
+		a.logger.Warnf("오프라인 기록 재전송 중단 events=%d frames=%d err=%v", events, frames, errors.Join(errE, errF))
+		return
+	}
+	if events+frames > 0 {
+		a.logger.Infow("오프라인 기록 재전송 완료", "events", events, "frames", frames, "elapsed_ms", time.Since(start).Milliseconds())
+		a.emitEvent(SPOOL_REPLAY_EVENT, fmt.Sprintf("events=%d frames=%d", events, frames))
+	}
+}
+
+// OfflineSpoolStats 구조체는 오프라인 스풀 계측값입니다.
+type OfflineSpoolStats struct {
+	FramesSpooled uint64      `json:"frames_spooled"` // 누적 보관 프레임 수
+	FramesDropped uint64      `json:"frames_dropped"` // 용량 초과로 버린 프레임 수
+	EventsSpooled uint64      `json:"events_spooled"` // 누적 보관 이벤트 수
+	EventsDropped uint64      `json:"events_dropped"` // 용량 초과로 버린 이벤트 수
+	Frames        spool.Stats `json:"frames"`         // 프레임 스풀 파일 상태
+	Events        spool.Stats `json:"events"`         // 이벤트 스풀 파일 상태
+}
+
+// OfflineSpoolStats 함수는 오프라인 스풀 계측값을 반환합니다.
+func (a *Agent) OfflineSpoolStats() OfflineSpoolStats { // 단일 책임: 스풀 계측 노출
+	var st OfflineSpoolStats
+	if l := a.offline.frames; l != nil {
+		st.FramesSpooled, st.FramesDropped, st.Frames = l.spooled.Load(), l.dropped.Load(), l.sp.Stats()
+	}
+	if l := a.offline.events; l != nil {
+		st.EventsSpooled, st.EventsDropped, st.Events = l.spooled.Load(), l.dropped.Load(), l.sp.Stats()
+	}
+	return st
+}
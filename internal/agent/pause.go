@@ -0,0 +1,132 @@
+package agent
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"agent/internal/i18n"
+)
+
+const (
+	CAPTURE_PAUSED_EVENT  = "capture_paused"  // 사용자 일시 중지 (minutes, until)
+	CAPTURE_RESUMED_EVENT = "capture_resumed" // 일시 중지 종료 (reason: manual | expired)
+)
+
+// errUserPaused 변수는 일시 중지 중 캡처 시작 요청을 거부할 때의 오류입니다 (ResumeCapture 로 해제).
+var errUserPaused = i18n.NewError("error.user_paused")
+
+// pauseState 구조체는 캡처 일시 중지 종료 시각과 자동 재개 타이머입니다.
+type pauseState struct { // 단일 책임: 일시 중지 상태 보관
+	mu     sync.Mutex
+	until  time.Time   // 0 이면 일시 중지 아님
+	timer  *time.Timer // until 에 자동 재개
+	resume bool        // 일시 중지가 실행 중인 캡처를 멈췄음 (끝나면 다시 시작, 그 사이 StopCapture 하면 해제)
+}
+
+// active 함수는 일시 중지 중인지 반환합니다.
+func (p *pauseState) active() bool { // 단일 책임: 일시 중지 여부 조회
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return !p.until.IsZero()
+}
+
+// clear 함수는 일시 중지를 해제하고 타이머를 멈춥니다. 일시 중지 중이었는지와 캡처를 다시 시작해야 하는지를 반환합니다.
+func (p *pauseState) clear() (paused, resume bool) { // 단일 책임: 일시 중지 해제
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+	paused, resume = !p.until.IsZero(), p.resume
+	p.until, p.resume = time.Time{}, false
+	return paused, resume
+}
+
+// cancelResume 함수는 일시 중지가 끝나도 캡처를 다시 시작하지 않게 합니다 (일시 중지 중 사용자/서버가 캡처를 중지).
+func (p *pauseState) cancelResume() { // 단일 책임: 자동 재개 취소
+	p.mu.Lock()
+	p.resume = false
+	p.mu.Unlock()
+}
+
+// PauseCapture 메서드는 캡처를 d 동안 멈추고, 캡처 중이었으면 끝날 때 다시 시작합니다. 일시 중지 중에 다시 부르면 종료 시각만 새로 정합니다.
+// 일시 중지 동안에는 일정/원격 명령의 캡처 시작도 거부합니다.
+func (a *Agent) PauseCapture(d time.Duration) error { // 단일 책임: 캡처 일시 중지
+	if d <= 0 {
+		return fmt.Errorf("일시 중지 시간 오류: %s", d)
+	}
+	p := &a.pause
+	wasCapturing := a.Capturing()
+	p.mu.Lock()
+	if p.until.IsZero() { // 이미 일시 중지 중이면 처음 멈출 때의 재개 여부 유지
+		p.resume = wasCapturing
+	}
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+	until := time.Now().Add(d)
+	p.until = until
+	p.timer = time.AfterFunc(d, func() { a.endPause(until) })
+	p.mu.Unlock()
+	a.stopCapture()
+	a.logger.Infow("캡처 일시 중지", "minutes", d.Minutes(), "until", until.Format(time.RFC3339))
+	a.emitEvent(CAPTURE_PAUSED_EVENT, fmt.Sprintf("minutes=%g until=%s", d.Minutes(), until.Format(time.RFC3339)))
+	return nil
+}
+
+// ResumeCapture 메서드는 일시 중지를 바로 끝내고, 일시 중지가 멈춘 캡처였으면 다시 시작합니다 (일시 중지 중이 아니면 무동작).
+func (a *Agent) ResumeCapture() error { // 단일 책임: 일시 중지 해제
+	paused, resume := a.pause.clear()
+	if !paused {
+		return nil
+	}
+	a.logger.Info("캡처 일시 중지 해제")
+	a.emitEvent(CAPTURE_RESUMED_EVENT, "reason=manual")
+	if !resume {
+		return nil
+	}
+	return a.StartCapture()
+}
+
+// PausedUntil 메서드는 일시 중지 종료 시각을 반환합니다 (일시 중지 중이 아니면 0).
+func (a *Agent) PausedUntil() time.Time { // 단일 책임: 일시 중지 종료 시각 조회
+	a.pause.mu.Lock()
+	defer a.pause.mu.Unlock()
+	return a.pause.until
+}
+
+// PauseRemaining 메서드는 자동 재개까지 남은 시간을 반환합니다 (일시 중지 중이 아니면 0).
+func (a *Agent) PauseRemaining() time.Duration { // 단일 책임: 남은 일시 중지 시간 조회
+	until := a.PausedUntil()
+	if until.IsZero() {
+		return 0
+	}
+	return max(0, time.Until(until))
+}
+
+// endPause 함수는 타이머가 until 에 닿으면 일시 중지를 끝내고, 일시 중지가 멈춘 캡처였으면 다시 시작합니다.
+// 그 사이 다시 일시 중지해 종료 시각이 바뀌었으면 무시합니다.
+func (a *Agent) endPause(until time.Time) { // 단일 책임: 자동 재개
+	p := &a.pause
+	p.mu.Lock()
+	if !p.until.Equal(until) {
+		p.mu.Unlock()
+		return
+	}
+	resume := p.resume
+	p.until, p.timer, p.resume = time.Time{}, nil, false
+	p.mu.Unlock()
+	if a.closing.Load() {
+		return
+	}
+	a.logger.Infow("캡처 일시 중지 종료", "resume", resume)
+	a.emitEvent(CAPTURE_RESUMED_EVENT, "reason=expired")
+	if !resume {
+		return
+	}
+	if err := a.StartCapture(); err != nil {
+		a.logger.Warnf("일시 중지 후 캡처 재개 실패: %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	POWER_AC_EVENT      = "power_ac"      // 배터리 → AC 전원 전환 (percent)
+	POWER_BATTERY_EVENT = "power_battery" // AC 전원 → 배터리 전환 (percent)
+	BATTERY_LOW_EVENT   = "battery_low"   // 배터리 사용 중 잔량이 BatteryLowPct 아래로 떨어짐 (percent, threshold)
+	POWER_POLL_MS       = 5000            // 전원 상태 확인 주기(ms)
+	POWER_SAVER_FORMAT  = "jpeg"          // 배터리 절약 중 전환할 인코딩 (무손실 형식보다 인코딩 CPU 가 적음)
+)
+
+// errPowerStatusUnsupported 변수는 현재 플랫폼/빌드에서 전원 상태 조회를 지원하지 않음을 나타냅니다.
+var errPowerStatusUnsupported = errors.New("이 플랫폼에서는 전원 상태 조회를 지원하지 않음")
+
+// powerStatus 구조체는 OS 전원 상태입니다.
+type powerStatus struct {
+	hasBattery bool
+	onBattery  bool
+	percent    int // 배터리 잔량(%) (-1=알 수 없음)
+}
+
+// powerSaver 구조체는 배터리 절약으로 낮춘 캡처 설정과 복원할 원래 값입니다.
+type powerSaver struct { // 단일 책임: 배터리 절약 설정 전환
+	active     bool
+	savedFPS   int    // 절약 전 TargetFPS
+	savedEnc   string // 절약 전 인코딩 (바꾸지 않았으면 빈 값)
+	appliedFPS int    // 절약으로 적용한 FPS (사용자가 그사이 바꿨으면 복원하지 않음)
+}
+
+// startPowerWatch 함수는 PowerEvents 또는 PowerSaver 설정 시 POWER_POLL_MS 마다 전원 상태를 확인하는 고루틴을 시작합니다.
+// 전원 전환/배터리 부족 이벤트를 발행하고, PowerSaver 이면 배터리 사용 중 FPS 와 인코딩을 낮췄다가 AC 연결 시 되돌립니다.
+func (a *Agent) startPowerWatch() { // 단일 책임: 전원 감시 시작
+	if !a.cfg.PowerEvents && !a.cfg.PowerSaver {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(time.Duration(POWER_POLL_MS) * time.Millisecond)
+		defer ticker.Stop()
+		var saver powerSaver
+		var prev powerStatus
+		first, lowReported := true, false
+		for {
+			st, err := readPowerStatus()
+			if errors.Is(err, errPowerStatusUnsupported) {
+				a.logger.Infof("전원 상태 감시 비활성: %v", err)
+				return
+			}
+			if err == nil && st.hasBattery {
+				if !first && st.onBattery != prev.onBattery && a.cfg.PowerEvents {
+					event := POWER_AC_EVENT
+					if st.onBattery {
+						event = POWER_BATTERY_EVENT
+					}
+					a.logger.Infow("전원 전환", "on_battery", st.onBattery, "percent", st.percent)
+					a.emitEvent(event, fmt.Sprintf("percent=%d", st.percent))
+				}
+				low := st.onBattery && st.percent >= 0 && st.percent < a.cfg.BatteryLowPct
+				if low && !lowReported && a.cfg.PowerEvents {
+					a.emitEvent(BATTERY_LOW_EVENT, fmt.Sprintf("percent=%d threshold=%d", st.percent, a.cfg.BatteryLowPct))
+				}
+				lowReported = low
+				if a.cfg.PowerSaver && st.onBattery != saver.active {
+					a.applyPowerSaver(&saver, st.onBattery)
+				}
+				prev, first = st, false
+			}
+			select {
+			case <-a.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// applyPowerSaver 함수는 배터리 사용 시작 시 TargetFPS 를 BatteryFPS 이하로, 무손실 인코딩을 jpeg 로 낮추고
+// AC 연결 시 원래 값으로 되돌립니다. 절약 중 사용자가 직접 바꾼 값은 그대로 둡니다.
+func (a *Agent) applyPowerSaver(s *powerSaver, onBattery bool) { // 단일 책임: 배터리 절약 적용/해제
+	a.capMu.RLock()
+	fps, enc := a.cfg.TargetFPS, a.cfg.CaptureEncoding
+	a.capMu.RUnlock()
+	s.active = onBattery
+	if onBattery {
+		s.savedFPS, s.savedEnc, s.appliedFPS = fps, "", fps
+		if fps > a.cfg.BatteryFPS {
+			s.appliedFPS = a.cfg.BatteryFPS
+			_ = a.SetTargetFPS(s.appliedFPS)
+		}
+		if a.cfg.BatteryJpeg && enc != POWER_SAVER_FORMAT && enc != ENCODING_H264 {
+			if err := a.setEncoding(POWER_SAVER_FORMAT, 0); err == nil {
+				s.savedEnc = enc
+			}
+		}
+		a.logger.Infow("배터리 절약 적용", "fps", s.appliedFPS, "encoding_from", s.savedEnc)
+		return
+	}
+	if fps == s.appliedFPS && s.savedFPS != fps {
+		_ = a.SetTargetFPS(s.savedFPS)
+	}
+	if s.savedEnc != "" && enc == POWER_SAVER_FORMAT {
+		_ = a.setEncoding(s.savedEnc, 0)
+	}
+	a.logger.Infow("배터리 절약 해제", "fps", s.savedFPS, "encoding", s.savedEnc)
+}
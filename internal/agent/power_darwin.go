@@ -0,0 +1,64 @@
+//go:build darwin && cgo
+
+package agent
+
+/*
+#cgo LDFLAGS: -framework IOKit -framework CoreFoundation
+#include <CoreFoundation/CoreFoundation.h>
+#include <IOKit/ps/IOPowerSources.h>
+#include <IOKit/ps/IOPSKeys.h>
+
+// 전원 상태를 채웁니다. 반환값: -1 조회 실패, 0 배터리 없음, 1 배터리 있음.
+static int agent_power_status(int *onBattery, int *percent) {
+	CFTypeRef info = IOPSCopyPowerSourcesInfo();
+	if (!info) {
+		return -1;
+	}
+	CFStringRef source = IOPSGetProvidingPowerSourceType(info);
+	*onBattery = source && CFStringCompare(source, CFSTR(kIOPMBatteryPowerKey), 0) == kCFCompareEqualTo;
+	*percent = -1;
+	int found = 0;
+	CFArrayRef list = IOPSCopyPowerSourcesList(info);
+	if (list) {
+		for (CFIndex i = 0; i < CFArrayGetCount(list) && !found; i++) {
+			CFDictionaryRef d = IOPSGetPowerSourceDescription(info, CFArrayGetValueAtIndex(list, i));
+			if (!d) {
+				continue;
+			}
+			CFStringRef type = CFDictionaryGetValue(d, CFSTR(kIOPSTypeKey));
+			if (!type || CFStringCompare(type, CFSTR(kIOPSInternalBatteryType), 0) != kCFCompareEqualTo) {
+				continue;
+			}
+			found = 1;
+			int cur = 0, max = 0;
+			CFNumberRef n = CFDictionaryGetValue(d, CFSTR(kIOPSCurrentCapacityKey));
+			if (n) {
+				CFNumberGetValue(n, kCFNumberIntType, &cur);
+			}
+			n = CFDictionaryGetValue(d, CFSTR(kIOPSMaxCapacityKey));
+			if (n) {
+				CFNumberGetValue(n, kCFNumberIntType, &max);
+			}
+			if (max > 0) {
+				*percent = cur * 100 / max;
+			}
+		}
+		CFRelease(list);
+	}
+	CFRelease(info);
+	return found;
+}
+*/
+import "C"
+
+import "errors"
+
+// readPowerStatus 함수는 IOKit 전원 소스 정보로 배터리 사용 여부와 내장 배터리 잔량을 조회합니다.
+func readPowerStatus() (powerStatus, error) { // 단일 책임: IOKit 전원 상태 조회
+	var onBattery, percent C.int
+	r := C.agent_power_status(&onBattery, &percent)
+	if r < 0 {
+		return powerStatus{}, errors.New("IOPSCopyPowerSourcesInfo 실패")
+	}
+	return powerStatus{hasBattery: r == 1, onBattery: r == 1 && onBattery != 0, percent: int(percent)}, nil
+}
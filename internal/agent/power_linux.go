@@ -0,0 +1,62 @@
+//go:build linux
+
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	POWER_SUPPLY_DIR = "/sys/class/power_supply" // Linux 전원 공급 장치 sysfs 경로
+)
+
+// readPowerStatus 함수는 sysfs power_supply 에서 AC(Mains) 연결 여부와 배터리 잔량(여러 개면 평균)을 조회합니다.
+// AC 어댑터 항목이 없는 기기는 배터리 status 가 Discharging 인지로 판단합니다.
+func readPowerStatus() (powerStatus, error) { // 단일 책임: sysfs 전원 상태 조회
+	entries, err := os.ReadDir(POWER_SUPPLY_DIR)
+	if err != nil {
+		return powerStatus{}, err
+	}
+	st := powerStatus{percent: -1}
+	hasMains, mainsOnline, discharging := false, false, false
+	total, batteries := 0, 0
+	for _, e := range entries {
+		dir := filepath.Join(POWER_SUPPLY_DIR, e.Name())
+		switch sysfsValue(dir, "type") {
+		case "Mains":
+			hasMains = true
+			if sysfsValue(dir, "online") == "1" {
+				mainsOnline = true
+			}
+		case "Battery":
+			if sysfsValue(dir, "scope") == "Device" { // 무선 마우스 등 주변기기 배터리 제외
+				continue
+			}
+			st.hasBattery = true
+			if sysfsValue(dir, "status") == "Discharging" {
+				discharging = true
+			}
+			if pct, err := strconv.Atoi(sysfsValue(dir, "capacity")); err == nil {
+				total += pct
+				batteries++
+			}
+		}
+	}
+	if batteries > 0 {
+		st.percent = total / batteries
+	}
+	st.onBattery = st.hasBattery && ((hasMains && !mainsOnline) || (!hasMains && discharging))
+	return st, nil
+}
+
+// sysfsValue 함수는 sysfs 속성 파일 값을 공백을 제거해 읽습니다 (없으면 빈 값).
+func sysfsValue(dir, name string) string { // 단일 책임: sysfs 값 조회
+	b, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
@@ -0,0 +1,8 @@
+//go:build !windows && !linux && !(darwin && cgo)
+
+package agent
+
+// readPowerStatus 함수는 전원 상태 조회를 지원하지 않는 플랫폼에서 errPowerStatusUnsupported 를 반환합니다.
+func readPowerStatus() (powerStatus, error) { // 단일 책임: 미지원 플랫폼 처리
+	return powerStatus{}, errPowerStatusUnsupported
+}
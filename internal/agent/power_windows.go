@@ -0,0 +1,46 @@
+//go:build windows
+
+package agent
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	AC_LINE_OFFLINE         = 0   // SYSTEM_POWER_STATUS.ACLineStatus: 배터리 사용
+	BATTERY_FLAG_NONE       = 128 // SYSTEM_POWER_STATUS.BatteryFlag 비트: 배터리 없음
+	BATTERY_FLAG_UNKNOWN    = 255 // SYSTEM_POWER_STATUS.BatteryFlag: 상태 알 수 없음
+	BATTERY_PERCENT_UNKNOWN = 255 // SYSTEM_POWER_STATUS.BatteryLifePercent: 알 수 없음
+)
+
+var procGetSystemPowerStatus = windows.NewLazySystemDLL("kernel32.dll").NewProc("GetSystemPowerStatus")
+
+// systemPowerStatus 구조체는 SYSTEM_POWER_STATUS 입니다.
+type systemPowerStatus struct {
+	ACLineStatus        byte
+	BatteryFlag         byte
+	BatteryLifePercent  byte
+	SystemStatusFlag    byte
+	BatteryLifeTime     uint32
+	BatteryFullLifeTime uint32
+}
+
+// readPowerStatus 함수는 GetSystemPowerStatus 로 AC 연결 여부와 배터리 잔량을 조회합니다.
+func readPowerStatus() (powerStatus, error) { // 단일 책임: Win32 전원 상태 조회
+	var s systemPowerStatus
+	if r, _, err := procGetSystemPowerStatus.Call(uintptr(unsafe.Pointer(&s))); r == 0 {
+		return powerStatus{}, err
+	}
+	st := powerStatus{onBattery: s.ACLineStatus == AC_LINE_OFFLINE, percent: int(s.BatteryLifePercent)}
+	if s.BatteryLifePercent == BATTERY_PERCENT_UNKNOWN {
+		st.percent = -1
+	}
+	if s.BatteryFlag == BATTERY_FLAG_UNKNOWN { // 플래그를 모르면 잔량 보고 여부로 판단
+		st.hasBattery = st.percent >= 0
+	} else {
+		st.hasBattery = s.BatteryFlag&BATTERY_FLAG_NONE == 0
+	}
+	return st, nil
+}
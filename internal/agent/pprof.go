@@ -0,0 +1,166 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"path/filepath"
+	"runtime"
+	rpprof "runtime/pprof"
+	"sync"
+	"time"
+
+	"agent/internal/config"
+	"agent/internal/i18n"
+)
+
+const (
+	PPROF_DIR_NAME            = "profiles" // DataDir 하위 프로파일 저장 폴더명
+	PPROF_CPU_DEFAULT_SEC     = 30         // CPU 프로파일 기본 수집 시간(초)
+	PPROF_CPU_MAX_SEC         = 120        // CPU 프로파일 수집 시간 상한(초)
+	PPROF_SHUTDOWN_TIMEOUT_MS = 2000       // 엔드포인트 종료 대기 상한
+	PPROF_BLOCK_RATE          = 10000      // 엔드포인트 실행 중 block 프로파일 표본 간격(ns)
+	PPROF_MUTEX_FRACTION      = 100        // 엔드포인트 실행 중 mutex 경합 표본 비율 (1/n)
+
+	PROFILE_CPU = "cpu" // WriteProfile CPU 프로파일 종류
+)
+
+// profileKinds 변수는 WriteProfile 이 지원하는 프로파일 종류입니다 (cpu 외에는 runtime/pprof 이름 그대로).
+var profileKinds = []string{PROFILE_CPU, "heap", "allocs", "goroutine", "block", "mutex", "threadcreate"}
+
+// errProfileBusy 변수는 CPU 프로파일이 이미 수집 중일 때의 오류입니다 (런타임이 동시에 하나만 허용).
+var errProfileBusy = i18n.NewError("error.profile_busy")
+
+// pprofState 구조체는 로컬 pprof 엔드포인트 실행 상태입니다.
+type pprofState struct { // 단일 책임: pprof 엔드포인트 상태 보관
+	mu   sync.Mutex
+	srv  *http.Server
+	addr string // 실제 수신 주소 (포트 0 지정 시 할당된 포트)
+	cpu  sync.Mutex
+}
+
+// StartPprof 메서드는 루프백 주소에 net/http/pprof 엔드포인트를 열고 수신 주소를 반환합니다 (이미 열려 있으면 그 주소).
+// addr 이 비어 있으면 PprofAddr 설정을 쓰며, 루프백이 아닌 주소는 거부합니다. 열려 있는 동안 block/mutex 표본도 수집합니다.
+func (a *Agent) StartPprof(addr string) (string, error) { // 단일 책임: pprof 엔드포인트 시작
+	if addr == "" {
+		addr = a.cfg.PprofAddr
+	}
+	if !config.IsLoopbackAddr(addr) {
+		return "", fmt.Errorf("pprof 주소는 루프백만 허용: %q", addr)
+	}
+	p := &a.pprof
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.srv != nil {
+		return p.addr, nil
+	}
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("pprof 엔드포인트 열기 실패: %w", err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	p.srv, p.addr = srv, lis.Addr().String()
+	runtime.SetBlockProfileRate(PPROF_BLOCK_RATE)
+	runtime.SetMutexProfileFraction(PPROF_MUTEX_FRACTION)
+	go func() {
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			a.logger.Warnf("pprof 엔드포인트 종료: %v", err)
+		}
+	}()
+	a.logger.Infow("pprof 엔드포인트 시작", "addr", "http://"+p.addr+"/debug/pprof/")
+	return p.addr, nil
+}
+
+// StopPprof 메서드는 pprof 엔드포인트를 닫고 block/mutex 표본 수집을 끕니다 (열려 있지 않으면 무동작).
+func (a *Agent) StopPprof() { // 단일 책임: pprof 엔드포인트 종료
+	p := &a.pprof
+	p.mu.Lock()
+	srv := p.srv
+	p.srv, p.addr = nil, ""
+	p.mu.Unlock()
+	if srv == nil {
+		return
+	}
+	runtime.SetBlockProfileRate(0)
+	runtime.SetMutexProfileFraction(0)
+	ctx, cancel := context.WithTimeout(context.Background(), PPROF_SHUTDOWN_TIMEOUT_MS*time.Millisecond)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		a.logger.Debugf("pprof 엔드포인트 종료 대기 실패: %v", err)
+	}
+	a.logger.Info("pprof 엔드포인트 종료")
+}
+
+// PprofAddr 메서드는 pprof 엔드포인트 수신 주소를 반환합니다 (닫혀 있으면 빈 값).
+func (a *Agent) PprofAddr() string { // 단일 책임: pprof 주소 조회
+	a.pprof.mu.Lock()
+	defer a.pprof.mu.Unlock()
+	return a.pprof.addr
+}
+
+// WriteProfile 메서드는 kind 프로파일을 DataDir/profiles 아래 파일로 저장하고 경로를 반환합니다 (엔드포인트 없이 현장 수집용).
+// cpu 는 seconds 초(0 이면 PPROF_CPU_DEFAULT_SEC, 상한 PPROF_CPU_MAX_SEC) 동안 수집하므로 그만큼 반환이 늦습니다.
+func (a *Agent) WriteProfile(kind string, seconds int) (string, error) { // 단일 책임: 프로파일 파일 저장
+	var prof *rpprof.Profile
+	if kind != PROFILE_CPU {
+		if prof = rpprof.Lookup(kind); prof == nil {
+			return "", fmt.Errorf("알 수 없는 프로파일 종류: %q (%v)", kind, profileKinds)
+		}
+	}
+	dir := filepath.Join(a.cfg.DataDir, PPROF_DIR_NAME)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.pprof", kind, time.Now().Format("20060102-150405")))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return "", err
+	}
+	if prof != nil {
+		err = prof.WriteTo(f, 0)
+	} else {
+		err = a.writeCPUProfile(f, seconds)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	a.logger.Infow("프로파일 저장", "kind", kind, "path", path)
+	return path, nil
+}
+
+// writeCPUProfile 함수는 seconds 초 동안 CPU 프로파일을 수집해 f 에 기록합니다. 에이전트가 끝나면 일찍 멈춥니다.
+func (a *Agent) writeCPUProfile(f *os.File, seconds int) error { // 단일 책임: CPU 프로파일 수집
+	if seconds <= 0 {
+		seconds = PPROF_CPU_DEFAULT_SEC
+	}
+	if seconds > PPROF_CPU_MAX_SEC {
+		seconds = PPROF_CPU_MAX_SEC
+	}
+	if !a.pprof.cpu.TryLock() {
+		return errProfileBusy
+	}
+	defer a.pprof.cpu.Unlock()
+	if err := rpprof.StartCPUProfile(f); err != nil { // 엔드포인트 /profile 수집과 겹친 경우
+		return fmt.Errorf("%w: %v", errProfileBusy, err)
+	}
+	select {
+	case <-time.After(time.Duration(seconds) * time.Second):
+	case <-a.ctx.Done():
+	}
+	rpprof.StopCPUProfile()
+	return nil
+}
@@ -0,0 +1,184 @@
+package agent
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"strconv"
+	"strings"
+)
+
+const (
+	MASK_MODE_BLACK   = "black"         // 가림 영역을 검은색으로 채움
+	MASK_MODE_BLUR    = "blur"          // 가림 영역을 큰 블록 평균색 모자이크로 처리 (글자 판독 불가)
+	MASK_BLUR_BLOCK   = 24              // blur 모드 모자이크 블록 크기(px)
+	MASK_ALL_MONITORS = MONITOR_ID_NONE // 모니터를 지정하지 않은 가림 영역 (모든 프레임에 적용)
+)
+
+// PrivacyMask 구조체는 인코딩 전에 가릴 프레임 영역입니다. 좌표는 캡처 원본 프레임 픽셀 기준이며,
+// Monitor 를 지정하면(0 이상) all/single 모드에서 그 모니터 프레임에만 적용합니다.
+type PrivacyMask struct {
+	X       int `json:"x"`
+	Y       int `json:"y"`
+	Width   int `json:"width"`
+	Height  int `json:"height"`
+	Monitor int `json:"monitor"` // -1 이면 모든 프레임
+}
+
+// privacyMasks 구조체는 현재 적용 중인 가림 영역 목록과 방식입니다 (교체 시 통째로 바꿔 캡처 루프와 공유).
+type privacyMasks struct {
+	mode  string
+	masks []PrivacyMask
+}
+
+// parsePrivacyMasks 함수는 "[모니터@]x,y,w,h;..." 형식의 가림 영역 목록을 파싱합니다.
+func parsePrivacyMasks(s string) ([]PrivacyMask, error) { // 단일 책임: 가림 영역 파싱
+	var masks []PrivacyMask
+	for _, part := range strings.Split(s, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		m := PrivacyMask{Monitor: MASK_ALL_MONITORS}
+		if mon, rect, ok := strings.Cut(part, "@"); ok {
+			n, err := strconv.Atoi(strings.TrimSpace(mon))
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("잘못된 가림 영역 모니터: %q", part)
+			}
+			m.Monitor, part = n, rect
+		}
+		fields := strings.Split(part, ",")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("가림 영역은 x,y,w,h 형식이어야 함: %q", part)
+		}
+		vals := make([]int, 4)
+		for i, f := range fields {
+			v, err := strconv.Atoi(strings.TrimSpace(f))
+			if err != nil {
+				return nil, fmt.Errorf("잘못된 가림 영역 값: %q", part)
+			}
+			vals[i] = v
+		}
+		m.X, m.Y, m.Width, m.Height = vals[0], vals[1], vals[2], vals[3]
+		if err := m.validate(); err != nil {
+			return nil, err
+		}
+		masks = append(masks, m)
+	}
+	return masks, nil
+}
+
+// formatPrivacyMasks 함수는 가림 영역 목록을 설정 문자열 형식으로 변환합니다.
+func formatPrivacyMasks(masks []PrivacyMask) string { // 단일 책임: 가림 영역 직렬화
+	parts := make([]string, 0, len(masks))
+	for _, m := range masks {
+		rect := fmt.Sprintf("%d,%d,%d,%d", m.X, m.Y, m.Width, m.Height)
+		if m.Monitor != MASK_ALL_MONITORS {
+			rect = fmt.Sprintf("%d@%s", m.Monitor, rect)
+		}
+		parts = append(parts, rect)
+	}
+	return strings.Join(parts, ";")
+}
+
+// validate 함수는 가림 영역 크기와 모니터 값을 검증합니다.
+func (m PrivacyMask) validate() error { // 단일 책임: 영역 검증
+	if m.Width <= 0 || m.Height <= 0 {
+		return fmt.Errorf("가림 영역 크기 오류: %dx%d", m.Width, m.Height)
+	}
+	if m.Monitor < MASK_ALL_MONITORS {
+		return fmt.Errorf("가림 영역 모니터 오류: %d", m.Monitor)
+	}
+	return nil
+}
+
+// loadPrivacyMasks 함수는 설정의 가림 영역을 적용합니다. 형식 오류가 있으면 경고 후 가림 없이 시작합니다.
+func (a *Agent) loadPrivacyMasks() { // 단일 책임: 설정 가림 영역 적용
+	masks, err := parsePrivacyMasks(a.cfg.PrivacyMasks)
+	if err != nil {
+		a.logger.Warnf("PRIVACY_MASKS 무시: %v", err)
+		return
+	}
+	a.masks.Store(&privacyMasks{mode: a.cfg.PrivacyMaskMode, masks: masks})
+}
+
+// SetPrivacyMasks 메서드는 가림 영역과 방식(black | blur, 빈 값이면 유지)을 바꿉니다. 다음 프레임부터 적용됩니다.
+func (a *Agent) SetPrivacyMasks(masks []PrivacyMask, mode string) error { // 단일 책임: 가림 영역 변경
+	if mode == "" {
+		mode = a.cfg.PrivacyMaskMode
+	}
+	if mode != MASK_MODE_BLACK && mode != MASK_MODE_BLUR {
+		return fmt.Errorf("지원하지 않는 가림 방식: %q (black | blur)", mode)
+	}
+	for _, m := range masks {
+		if err := m.validate(); err != nil {
+			return err
+		}
+	}
+	masks = append([]PrivacyMask(nil), masks...)
+	a.capMu.Lock()
+	a.cfg.PrivacyMasks, a.cfg.PrivacyMaskMode = formatPrivacyMasks(masks), mode
+	a.capMu.Unlock()
+	a.masks.Store(&privacyMasks{mode: mode, masks: masks})
+	a.logger.Infow("개인정보 가림 영역 변경", "count", len(masks), "mode", mode)
+	return nil
+}
+
+// PrivacyMasks 메서드는 현재 가림 영역 목록을 반환합니다.
+func (a *Agent) PrivacyMasks() []PrivacyMask { // 단일 책임: 가림 영역 조회
+	p := a.masks.Load()
+	if p == nil {
+		return []PrivacyMask{}
+	}
+	return append([]PrivacyMask{}, p.masks...)
+}
+
+// apply 함수는 프레임 monitorID 에 해당하는 가림 영역을 img 에 직접 칠합니다 (캡처러가 넘긴 원본 버퍼를 수정).
+func (p *privacyMasks) apply(img image.Image, monitorID int32) { // 단일 책임: 프레임 가림 처리
+	if p == nil || len(p.masks) == 0 {
+		return
+	}
+	dst, ok := img.(draw.Image)
+	if !ok {
+		return
+	}
+	b := img.Bounds()
+	for _, m := range p.masks {
+		if m.Monitor != MASK_ALL_MONITORS && int32(m.Monitor) != monitorID {
+			continue
+		}
+		r := image.Rect(m.X, m.Y, m.X+m.Width, m.Y+m.Height).Add(b.Min).Intersect(b)
+		if r.Empty() {
+			continue
+		}
+		if rgba, ok := img.(*image.RGBA); ok && p.mode == MASK_MODE_BLUR {
+			pixelate(rgba, r, MASK_BLUR_BLOCK)
+			continue
+		}
+		draw.Draw(dst, r, image.Black, image.Point{}, draw.Src)
+	}
+}
+
+// pixelate 함수는 r 영역을 block 크기 셀마다 평균색으로 채웁니다.
+func pixelate(img *image.RGBA, r image.Rectangle, block int) { // 단일 책임: 모자이크 처리
+	for by := r.Min.Y; by < r.Max.Y; by += block {
+		for bx := r.Min.X; bx < r.Max.X; bx += block {
+			cell := image.Rect(bx, by, bx+block, by+block).Intersect(r)
+			var sr, sg, sb int
+			for y := cell.Min.Y; y < cell.Max.Y; y++ {
+				row := img.Pix[img.PixOffset(cell.Min.X, y):img.PixOffset(cell.Max.X, y)]
+				for i := 0; i < len(row); i += 4 {
+					sr, sg, sb = sr+int(row[i]), sg+int(row[i+1]), sb+int(row[i+2])
+				}
+			}
+			n := cell.Dx() * cell.Dy()
+			cr, cg, cb := uint8(sr/n), uint8(sg/n), uint8(sb/n)
+			for y := cell.Min.Y; y < cell.Max.Y; y++ {
+				row := img.Pix[img.PixOffset(cell.Min.X, y):img.PixOffset(cell.Max.X, y)]
+				for i := 0; i < len(row); i += 4 {
+					row[i], row[i+1], row[i+2], row[i+3] = cr, cg, cb, 255
+				}
+			}
+		}
+	}
+}
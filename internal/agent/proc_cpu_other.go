@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !freebsd && !windows
+
+package agent
+
+import "time"
+
+// processCPUTime 함수는 미지원 OS 에서 false 를 반환합니다.
+func processCPUTime() (time.Duration, bool) { // 단일 책임: 누적 CPU 시간 조회 (미지원)
+	return 0, false
+}
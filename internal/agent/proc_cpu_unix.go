@@ -0,0 +1,18 @@
+//go:build linux || darwin || freebsd
+
+package agent
+
+import (
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// processCPUTime 함수는 프로세스가 사용한 누적 CPU 시간(사용자+커널)을 반환합니다.
+func processCPUTime() (time.Duration, bool) { // 단일 책임: 누적 CPU 시간 조회
+	var ru unix.Rusage
+	if err := unix.Getrusage(unix.RUSAGE_SELF, &ru); err != nil {
+		return 0, false
+	}
+	return time.Duration(ru.Utime.Nano() + ru.Stime.Nano()), true
+}
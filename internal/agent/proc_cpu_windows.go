@@ -0,0 +1,20 @@
+//go:build windows
+
+package agent
+
+import (
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// processCPUTime 함수는 프로세스가 사용한 누적 CPU 시간(사용자+커널)을 반환합니다.
+func processCPUTime() (time.Duration, bool) { // 단일 책임: 누적 CPU 시간 조회
+	var creation, exit, kernel, user windows.Filetime
+	if err := windows.GetProcessTimes(windows.CurrentProcess(), &creation, &exit, &kernel, &user); err != nil {
+		return 0, false
+	}
+	k := int64(kernel.HighDateTime)<<32 | int64(kernel.LowDateTime)
+	u := int64(user.HighDateTime)<<32 | int64(user.LowDateTime)
+	return time.Duration((k + u) * 100), true // FILETIME 단위 100ns
+}
@@ -0,0 +1,21 @@
+//go:build darwin
+
+package agent
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// listProcesses 함수는 kern.proc.all sysctl 로 실행 중인 프로세스의 PID → 이름(최대 16자)을 조회합니다.
+func listProcesses() (map[int]string, error) { // 단일 책임: sysctl 프로세스 목록 조회
+	list, err := unix.SysctlKinfoProcSlice("kern.proc.all")
+	if err != nil {
+		return nil, err
+	}
+	procs := make(map[int]string, len(list))
+	for i := range list {
+		p := &list[i].Proc
+		procs[int(p.P_pid)] = unix.ByteSliceToString(p.P_comm[:])
+	}
+	return procs, nil
+}
@@ -0,0 +1,28 @@
+//go:build linux
+
+package agent
+
+import (
+	"os"
+	"strconv"
+)
+
+// listProcesses 함수는 /proc 에서 실행 중인 프로세스의 PID → 실행 파일 이름을 조회합니다.
+// 다른 사용자 프로세스는 실행 파일 링크 대신 comm(최대 15자) 이름을 씁니다.
+func listProcesses() (map[int]string, error) { // 단일 책임: /proc 프로세스 목록 조회
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+	procs := make(map[int]string, len(entries))
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil || !e.IsDir() {
+			continue
+		}
+		if name := processNameByPID(pid); name != "" { // 조회 중 종료한 프로세스 제외
+			procs[pid] = name
+		}
+	}
+	return procs, nil
+}
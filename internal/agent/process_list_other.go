@@ -0,0 +1,8 @@
+//go:build !linux && !darwin && !windows
+
+package agent
+
+// listProcesses 함수는 프로세스 목록 조회를 지원하지 않는 빌드에서 오류를 반환합니다.
+func listProcesses() (map[int]string, error) { // 단일 책임: 미지원 플랫폼 처리
+	return nil, errProcessListUnsupported
+}
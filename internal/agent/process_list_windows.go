@@ -0,0 +1,28 @@
+//go:build windows
+
+package agent
+
+import (
+	"errors"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// listProcesses 함수는 Toolhelp 스냅샷으로 실행 중인 프로세스의 PID → 실행 파일 이름을 조회합니다.
+func listProcesses() (map[int]string, error) { // 단일 책임: Win32 프로세스 목록 조회
+	snap, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer windows.CloseHandle(snap)
+	entry := windows.ProcessEntry32{Size: uint32(unsafe.Sizeof(windows.ProcessEntry32{}))}
+	procs := map[int]string{}
+	for err = windows.Process32First(snap, &entry); err == nil; err = windows.Process32Next(snap, &entry) {
+		procs[int(entry.ProcessID)] = windows.UTF16ToString(entry.ExeFile[:])
+	}
+	if !errors.Is(err, windows.ERROR_NO_MORE_FILES) {
+		return nil, err
+	}
+	return procs, nil
+}
@@ -0,0 +1,103 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	PROCESS_STARTED_EVENT = "process_started" // 감시 목록 프로세스 시작 (process, pid - 감시 시작 시 이미 실행 중이면 initial=true)
+	PROCESS_EXITED_EVENT  = "process_exited"  // 감시 목록 프로세스 종료 (process, pid)
+	PROCESS_EVENT_SOURCE  = "process"         // 프로세스 이벤트 발생원 이름
+)
+
+// errProcessListUnsupported 변수는 현재 플랫폼에서 프로세스 목록 조회를 지원하지 않음을 나타냅니다.
+var errProcessListUnsupported = errors.New("이 플랫폼에서는 프로세스 목록 조회를 지원하지 않음")
+
+// processWatcher 구조체는 감시 목록(프로세스 이름)에 해당하는 실행 중 프로세스를 PID 별로 기억해 시작/종료를 판별합니다.
+type processWatcher struct { // 단일 책임: 감시 프로세스 변화 판별
+	names   map[string]bool // 소문자 확장자 제외 프로세스 이름
+	running map[int]string  // 직전 확인 시 실행 중이던 감시 프로세스 (PID → 이름)
+}
+
+// newProcessWatcher 함수는 쉼표로 구분한 프로세스 이름 목록으로 processWatcher 를 생성합니다. 목록이 비었으면 nil 을 반환합니다.
+func newProcessWatcher(list string) *processWatcher { // 단일 책임: 인스턴스 생성
+	names := map[string]bool{}
+	for _, n := range strings.Split(list, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			names[strings.ToLower(processBaseName(n))] = true
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return &processWatcher{names: names}
+}
+
+// diff 함수는 현재 프로세스 목록(PID → 실행 파일 이름)에서 감시 대상만 골라 직전 확인 대비 시작/종료한 PID 를 반환합니다.
+func (w *processWatcher) diff(procs map[int]string) (started, exited map[int]string) { // 단일 책임: 시작/종료 비교
+	cur := map[int]string{}
+	for pid, name := range procs {
+		if base := processBaseName(name); w.names[strings.ToLower(base)] {
+			cur[pid] = base
+		}
+	}
+	started, exited = map[int]string{}, map[int]string{}
+	for pid, name := range cur {
+		if prev, ok := w.running[pid]; !ok || prev != name { // PID 재사용도 새 프로세스
+			started[pid] = name
+		}
+	}
+	for pid, name := range w.running {
+		if cur[pid] != name {
+			exited[pid] = name
+		}
+	}
+	w.running = cur
+	return started, exited
+}
+
+// processSource 함수는 ProcessWatchlist 설정 시 ProcessWatchMs 마다 프로세스 목록을 확인해
+// 감시 대상 프로세스의 시작/종료 이벤트를 내는 발생원을 만듭니다 (목록이 비면 nil).
+func (a *Agent) processSource() EventSource { // 단일 책임: 프로세스 발생원 생성
+	w := newProcessWatcher(a.cfg.ProcessWatchlist)
+	if w == nil {
+		return nil
+	}
+	return newWatchSource(PROCESS_EVENT_SOURCE, func(ctx context.Context, emit eventEmitter) {
+		ticker := time.NewTicker(time.Duration(a.cfg.ProcessWatchMs) * time.Millisecond)
+		defer ticker.Stop()
+		initial := true
+		for {
+			procs, err := listProcesses()
+			if errors.Is(err, errProcessListUnsupported) {
+				a.logger.Infof("프로세스 감시 비활성: %v", err)
+				return
+			}
+			if err != nil {
+				a.logger.Debugf("프로세스 목록 조회 실패: %v", err)
+			} else {
+				started, exited := w.diff(procs)
+				for pid, name := range exited {
+					emit(PROCESS_EXITED_EVENT, fmt.Sprintf("process=%s pid=%d", name, pid))
+				}
+				for pid, name := range started {
+					detail := fmt.Sprintf("process=%s pid=%d", name, pid)
+					if initial {
+						detail += " initial=true"
+					}
+					emit(PROCESS_STARTED_EVENT, detail)
+				}
+				initial = false
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+}
@@ -0,0 +1,193 @@
+package agent
+
+import (
+	"sync"
+	"time"
+
+	"agent/internal/config"
+)
+
+const (
+	QUALITY_WINDOW_MS       = 2000 // 대역폭 평가 구간 길이(ms)
+	QUALITY_LATENCY_HIGH_MS = 250  // 평균 전송 지연이 이 값을 넘으면 회선 포화로 보고 단계 하향
+	QUALITY_RAISE_RATIO     = 0.6  // 측정 대역폭이 상한의 이 비율 미만인 구간이 연속되면 단계 상향
+	QUALITY_CALM_WINDOWS    = 3    // 한 단계 상향에 필요한 연속 여유 구간 수
+)
+
+// qualityLevel 구조체는 대역폭 단계 하나의 인코딩 품질/해상도 비율입니다.
+type qualityLevel struct {
+	qualityPct int // 설정 품질(JPEG/WebP 손실) 대비 비율(%)
+	scalePct   int // 전송 해상도 비율(%)
+}
+
+// QUALITY_LEVELS 변수는 대역폭 단계 목록입니다 (0번이 설정값 그대로). 품질을 먼저 낮추고 그래도 넘치면 해상도를 낮춥니다.
+var QUALITY_LEVELS = []qualityLevel{{100, 100}, {80, 100}, {60, 100}, {60, 75}, {50, 50}}
+
+// QualityStats 구조체는 대역폭 기반 품질 제어 상태입니다.
+type QualityStats struct {
+	Enabled      bool    `json:"enabled"`       // 대역폭 상한 설정 여부
+	Level        int     `json:"level"`         // 현재 단계 (0=설정값 그대로)
+	MaxLevel     int     `json:"max_level"`     // 가장 낮은 품질 단계
+	QualityPct   int     `json:"quality_pct"`   // 설정 품질 대비 현재 품질 비율(%)
+	ScalePct     int     `json:"scale_pct"`     // 현재 전송 해상도 비율(%)
+	JpegQuality  int     `json:"jpeg_quality"`  // 현재 적용 JPEG 품질
+	WebpQuality  int     `json:"webp_quality"`  // 현재 적용 WebP 손실 압축 품질
+	CapKbps      int     `json:"cap_kbps"`      // 대역폭 상한(kbps)
+	MeasuredKbps float64 `json:"measured_kbps"` // 직전 평가 구간 프레임 전송 대역폭(kbps)
+	AvgSendMs    float64 `json:"avg_send_ms"`   // 직전 평가 구간 평균 전송 지연(ms)
+	SendErrors   uint64  `json:"send_errors"`   // 누적 프레임 스트림 전송 오류 수
+	LevelChanges uint64  `json:"level_changes"` // 누적 단계 변경 횟수
+}
+
+// qualityGovernor 구조체는 sendFrameData 의 전송 바이트/지연/오류를 구간별로 집계해
+// 대역폭 상한을 넘거나 회선이 밀리면 품질 단계를 낮추고, 여유가 이어지면 한 단계씩 되돌립니다.
+type qualityGovernor struct { // 단일 책임: 대역폭 상한 기반 품질/해상도 단계 제어
+	mu          sync.Mutex
+	capKbps     int // 0 이면 비활성
+	minQuality  int // 단계가 낮아져도 유지할 최소 손실 압축 품질
+	jpegQuality int // 설정 JPEG 품질
+	webpQuality int // 설정 WebP 품질
+	level       int // QUALITY_LEVELS 인덱스
+
+	windowStart time.Time     // 현재 구간 시작 시각
+	bytes       int64         // 현재 구간 전송 바이트
+	sends       int           // 현재 구간 전송 횟수
+	sendTotal   time.Duration // 현재 구간 전송 시간 합
+	errors      int           // 현재 구간 전송 오류 수
+	calmWindows int           // 연속 여유 구간 수
+
+	measuredKbps float64
+	avgSendMs    float64
+	totalErrors  uint64
+	changes      uint64
+	onChange     func(from, to int)
+}
+
+// newQualityGovernor 함수는 qualityGovernor 생성자입니다. BandwidthCapKbps 가 0 이면 단계가 항상 0 입니다.
+func newQualityGovernor(cfg *config.Config, onChange func(from, to int)) *qualityGovernor { // 단일 책임: 인스턴스 생성
+	return &qualityGovernor{
+		capKbps:     cfg.BandwidthCapKbps,
+		minQuality:  cfg.MinQuality,
+		jpegQuality: cfg.JpegQuality,
+		webpQuality: cfg.WebpQuality,
+		onChange:    onChange,
+	}
+}
+
+// observe 함수는 프레임 1개의 전송 결과를 기록하고 구간이 지나면 단계를 재평가합니다.
+func (g *qualityGovernor) observe(size int, d time.Duration, failed bool) { // 단일 책임: 전송 결과 기록
+	now := time.Now()
+	g.mu.Lock()
+	if g.capKbps <= 0 {
+		g.mu.Unlock()
+		return
+	}
+	if g.windowStart.IsZero() {
+		g.windowStart = now
+	}
+	g.bytes += int64(size)
+	g.sends++
+	g.sendTotal += d
+	if failed {
+		g.errors++
+		g.totalErrors++
+	}
+	elapsed := now.Sub(g.windowStart)
+	if elapsed < time.Duration(QUALITY_WINDOW_MS)*time.Millisecond {
+		g.mu.Unlock()
+		return
+	}
+	g.measuredKbps = float64(g.bytes*8) / float64(elapsed.Milliseconds()) // bit/ms = kbit/s
+	g.avgSendMs = float64(g.sendTotal) / float64(g.sends) / float64(time.Millisecond)
+	from := g.level
+	switch {
+	case g.measuredKbps > float64(g.capKbps) || g.errors > 0 || g.avgSendMs > QUALITY_LATENCY_HIGH_MS:
+		if g.level < len(QUALITY_LEVELS)-1 {
+			g.level++
+		}
+		g.calmWindows = 0
+	case g.measuredKbps < float64(g.capKbps)*QUALITY_RAISE_RATIO:
+		g.calmWindows++
+		if g.calmWindows >= QUALITY_CALM_WINDOWS && g.level > 0 {
+			g.level--
+			g.calmWindows = 0
+		}
+	default:
+		g.calmWindows = 0
+	}
+	g.windowStart, g.bytes, g.sends, g.sendTotal, g.errors = now, 0, 0, 0, 0
+	to := g.level
+	if from != to {
+		g.changes++
+	}
+	g.mu.Unlock()
+	if from != to && g.onChange != nil {
+		g.onChange(from, to)
+	}
+}
+
+// scale 함수는 현재 단계의 전송 해상도 비율(%)을 반환합니다.
+func (g *qualityGovernor) scale() int { // 단일 책임: 해상도 비율 조회
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return QUALITY_LEVELS[g.level].scalePct
+}
+
+// apply 함수는 현재 단계의 품질을 손실 압축 옵션(JPEG, 손실 WebP)에 반영한 인코딩 옵션을 반환합니다.
+func (g *qualityGovernor) apply(opts encodeOptions) encodeOptions { // 단일 책임: 품질 단계 적용
+	g.mu.Lock()
+	pct := QUALITY_LEVELS[g.level].qualityPct
+	g.mu.Unlock()
+	if pct < 100 {
+		opts.jpegQuality = g.reduce(opts.jpegQuality, pct)
+		opts.webpQuality = g.reduce(opts.webpQuality, pct)
+	}
+	return opts
+}
+
+// setBase 함수는 단계 적용 전 기준 품질(설정값)을 바꿉니다.
+func (g *qualityGovernor) setBase(jpegQuality, webpQuality int) { // 단일 책임: 기준 품질 갱신
+	g.mu.Lock()
+	g.jpegQuality, g.webpQuality = jpegQuality, webpQuality
+	g.mu.Unlock()
+}
+
+// reduce 함수는 품질을 비율만큼 낮추되 minQuality(원래 품질이 더 낮으면 원래 품질) 아래로는 내리지 않습니다.
+func (g *qualityGovernor) reduce(quality, pct int) int { // 단일 책임: 품질 하한 적용
+	q := quality * pct / 100
+	if floor := min(g.minQuality, quality); q < floor {
+		q = floor
+	}
+	return max(q, 1)
+}
+
+// stats 함수는 현재 상태 스냅샷을 반환합니다.
+func (g *qualityGovernor) stats() QualityStats { // 단일 책임: 상태 조회
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	lv := QUALITY_LEVELS[g.level]
+	st := QualityStats{
+		Enabled:      g.capKbps > 0,
+		Level:        g.level,
+		MaxLevel:     len(QUALITY_LEVELS) - 1,
+		QualityPct:   lv.qualityPct,
+		ScalePct:     lv.scalePct,
+		JpegQuality:  g.jpegQuality,
+		WebpQuality:  g.webpQuality,
+		CapKbps:      g.capKbps,
+		MeasuredKbps: g.measuredKbps,
+		AvgSendMs:    g.avgSendMs,
+		SendErrors:   g.totalErrors,
+		LevelChanges: g.changes,
+	}
+	if lv.qualityPct < 100 {
+		st.JpegQuality = g.reduce(g.jpegQuality, lv.qualityPct)
+		st.WebpQuality = g.reduce(g.webpQuality, lv.qualityPct)
+	}
+	return st
+}
+
+// QualityStats 메서드는 대역폭 기반 품질 제어 상태를 반환합니다 (외부 노출용).
+func (a *Agent) QualityStats() QualityStats { // 단일 책임: 품질 단계 노출
+	return a.quality.stats()
+}
@@ -0,0 +1,352 @@
+package agent
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	monitorProto "agent/proto"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	RECORDING_MAX_MINUTES     = 120                  // 단일 녹화 최대 길이(분)
+	RECORDING_DIR_NAME        = "recordings"         // DataDir 하위 녹화 폴더명
+	RECORDING_FILE_EXT        = ".arec"              // 녹화 파일 확장자
+	RECORDING_FILE_MAGIC      = "AREC1\n"            // 녹화 파일 헤더 (길이 프리픽스 FrameData 레코드 연속)
+	RECORDING_UPLOAD_CHUNK    = 1 << 20              // 업로드 청크 크기 (1MiB)
+	RECORDING_START_EVENT     = "recording_start"    // 녹화 시작 마커 이벤트 타입
+	RECORDING_STOP_EVENT      = "recording_stop"     // 녹화 종료 마커 이벤트 타입
+	RECORDING_UPLOADED_EVENT  = "recording_uploaded" // 녹화 업로드 완료 이벤트 타입
+	RECORDING_UPLOAD_TIMEOUT  = 10 * time.Minute     // 녹화 업로드 전체 타임아웃
+	RECORDING_WRITE_BUF_BYTES = 256 << 10            // 파일 쓰기 버퍼 크기
+)
+
+// errRecordingUploading 변수는 같은 녹화 파일을 이미 다른 고루틴이 업로드 중임을 나타냅니다.
+var errRecordingUploading = errors.New("이미 업로드 중인 녹화")
+
+// recorder 구조체는 캡처 프레임을 로컬 파일로 기록하는 녹화 세션을 관리합니다.
+type recorder struct { // 단일 책임: 로컬 녹화 세션 관리
+	mu             sync.Mutex
+	dir            string          // 녹화 저장 디렉터리
+	id             string          // 현재 녹화 ID (비어 있으면 비활성)
+	file           *os.File        // 현재 녹화 파일
+	w              *bufio.Writer   // 버퍼드 writer
+	frames         int             // 기록된 프레임 수
+	startedAt      time.Time       // 녹화 시작 시각
+	timer          *time.Timer     // 자동 종료 타이머
+	startedCapture bool            // 녹화가 캡처 루프를 직접 시작했는지 여부
+	uploading      map[string]bool // 업로드 중인 녹화 ID (종료 직후 업로드와 재연결 재시도 겹침 방지)
+}
+
+// newRecorder 함수는 recorder 생성자입니다.
+func newRecorder(dataDir string) *recorder { // 단일 책임: 인스턴스 생성
+	return &recorder{dir: filepath.Join(dataDir, RECORDING_DIR_NAME), uploading: make(map[string]bool)}
+}
+
+// pathFor 함수는 녹화 ID 에 대응하는 파일 경로를 반환합니다.
+func (r *recorder) pathFor(id string) string { // 단일 책임: 경로 계산
+	return filepath.Join(r.dir, id+RECORDING_FILE_EXT)
+}
+
+// active 함수는 현재 녹화 중인지 반환합니다.
+func (r *recorder) active() bool { // 단일 책임: 상태 조회
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.id != ""
+}
+
+// begin 함수는 새 녹화 파일을 생성하고 세션을 시작합니다.
+func (r *recorder) begin() (string, error) { // 단일 책임: 세션 시작
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.id != "" {
+		return "", fmt.Errorf("이미 녹화 중: %s", r.id)
+	}
+	if err := os.MkdirAll(r.dir, 0o700); err != nil {
+		return "", err
+	}
+	id := uuid.New().String()
+	f, err := os.OpenFile(r.pathFor(id), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return "", err
+	}
+	w := bufio.NewWriterSize(f, RECORDING_WRITE_BUF_BYTES)
+	if _, err := w.WriteString(RECORDING_FILE_MAGIC); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+	r.id, r.file, r.w, r.frames, r.startedAt = id, f, w, 0, time.Now()
+	return id, nil
+}
+
+// write 함수는 활성 녹화가 있으면 프레임을 길이 프리픽스 레코드로 기록합니다.
+func (r *recorder) write(frame *monitorProto.FrameData) error { // 단일 책임: 프레임 기록
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.id == "" {
+		return nil
+	}
+	b, err := proto.Marshal(frame)
+	if err != nil {
+		return err
+	}
+	var lenBuf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lenBuf[:], uint64(len(b)))
+	if _, err := r.w.Write(lenBuf[:n]); err != nil {
+		return err
+	}
+	if _, err := r.w.Write(b); err != nil {
+		return err
+	}
+	r.frames++
+	return nil
+}
+
+// end 함수는 현재 세션을 닫고 (ID, 프레임 수, 녹화 길이, 캡처 직접 시작 여부)를 반환합니다.
+func (r *recorder) end() (string, int, time.Duration, bool, error) { // 단일 책임: 세션 종료
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.id == "" {
+		return "", 0, 0, false, fmt.Errorf("녹화 중이 아님")
+	}
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	flushErr := r.w.Flush()
+	closeErr := r.file.Close()
+	id, frames, elapsed, startedCapture := r.id, r.frames, time.Since(r.startedAt), r.startedCapture
+	r.id, r.file, r.w, r.startedCapture = "", nil, nil, false
+	if flushErr != nil {
+		return id, frames, elapsed, startedCapture, flushErr
+	}
+	return id, frames, elapsed, startedCapture, closeErr
+}
+
+// abort 함수는 시작 직후 취소한 세션을 닫고 빈 녹화 파일을 지웁니다 (업로드 대상으로 남지 않도록).
+func (r *recorder) abort() error { // 단일 책임: 세션 취소
+	id, _, _, _, err := r.end()
+	if id == "" {
+		return err
+	}
+	if rmErr := os.Remove(r.pathFor(id)); rmErr != nil && err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+// claim 함수는 녹화 id 업로드를 시작해도 되는지 확인하고 업로드 중으로 표시합니다 (녹화 중이거나 이미 업로드 중이면 오류).
+func (r *recorder) claim(id string) error { // 단일 책임: 업로드 선점
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id == r.id {
+		return fmt.Errorf("녹화 중인 파일은 업로드할 수 없음: %s", id)
+	}
+	if r.uploading[id] {
+		return errRecordingUploading
+	}
+	r.uploading[id] = true
+	return nil
+}
+
+// unclaim 함수는 녹화 id 의 업로드 중 표시를 지웁니다.
+func (r *recorder) unclaim(id string) { // 단일 책임: 업로드 선점 해제
+	r.mu.Lock()
+	delete(r.uploading, id)
+	r.mu.Unlock()
+}
+
+// pendingIDs 함수는 아직 업로드하지 못해 남아 있는 녹화 파일 ID 목록을 반환합니다 (진행 중 녹화 제외).
+// 업로드가 끝난 파일은 지우므로 폴더에 남은 파일이 곧 업로드 대기 목록입니다.
+func (r *recorder) pendingIDs() ([]string, error) { // 단일 책임: 업로드 대기 목록
+	entries, err := os.ReadDir(r.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	active := r.id
+	r.mu.Unlock()
+	var ids []string
+	for _, e := range entries {
+		id, ok := strings.CutSuffix(e.Name(), RECORDING_FILE_EXT)
+		if !ok || e.IsDir() || id == active || uuid.Validate(id) != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// StartRecording 메서드는 durationMin 분 동안 로컬 녹화를 시작하고 녹화 ID 를 반환합니다.
+// 종료 시 녹화 파일은 자동으로 서버에 업로드되고, 실패하면 다음 연결 때 다시 업로드합니다.
+func (a *Agent) StartRecording(durationMin int) (string, error) { // 단일 책임: 녹화 시작
+	if durationMin < 1 || durationMin > RECORDING_MAX_MINUTES {
+		return "", fmt.Errorf("녹화 시간 범위 오류: %d (1~%d분)", durationMin, RECORDING_MAX_MINUTES)
+	}
+	id, err := a.rec.begin()
+	if err != nil {
+		return "", err
+	}
+	// 캡처 루프가 꺼져 있으면 녹화를 위해 시작 (동의/일시 중지/일정 때문에 캡처할 수 없으면 빈 녹화를 남기지 않음)
+	started, err := a.startCapture()
+	if err != nil {
+		if abortErr := a.rec.abort(); abortErr != nil {
+			a.logger.Warnf("녹화 시작 취소 중 파일 정리 실패: %v", abortErr)
+		}
+		return "", err
+	}
+	if started { // 이미 돌던 캡처는 녹화 종료 때 멈추지 않음
+		a.rec.mu.Lock()
+		a.rec.startedCapture = true
+		a.rec.mu.Unlock()
+	}
+	duration := time.Duration(durationMin) * time.Minute
+	a.rec.mu.Lock()
+	a.rec.timer = time.AfterFunc(duration, func() { // 자동 종료 + 업로드
+		if _, err := a.StopRecording(); err != nil {
+			a.logger.Warnf("녹화 자동 종료 실패: %v", err)
+		}
+	})
+	a.rec.mu.Unlock()
+	a.logger.Infow("녹화 시작", "recording_id", id, "duration_min", durationMin)
+	a.emitEvent(RECORDING_START_EVENT, fmt.Sprintf("recording_id=%s duration_sec=%d", id, int(duration.Seconds())))
+	return id, nil
+}
+
+// StopRecording 메서드는 진행 중인 녹화를 종료하고 백그라운드로 업로드한 뒤 녹화 ID 를 반환합니다.
+func (a *Agent) StopRecording() (string, error) { // 단일 책임: 녹화 종료
+	id, frames, elapsed, startedCapture, err := a.rec.end()
+	if id == "" {
+		return "", err
+	}
+	if startedCapture { // 녹화가 시작한 캡처는 함께 종료
+		a.StopCapture()
+	}
+	a.reportRecordingStop(id, frames, elapsed, err)
+	go func() { // 실패하면 파일이 남아 다음 연결 때 다시 업로드
+		if _, err := a.UploadRecording(id); err != nil {
+			a.logger.Warnf("녹화 업로드 실패 recording_id=%s err=%v", id, err)
+		}
+	}()
+	return id, err
+}
+
+// reportRecordingStop 함수는 녹화 종료를 기록하고 종료 마커 이벤트를 발행합니다 (err 는 파일 마무리 오류).
+func (a *Agent) reportRecordingStop(id string, frames int, elapsed time.Duration, err error) { // 단일 책임: 녹화 종료 보고
+	if err != nil {
+		a.logger.Warnf("녹화 파일 마무리 실패: %v", err)
+	}
+	a.logger.Infow("녹화 종료", "recording_id", id, "frames", frames, "elapsed", elapsed.String())
+	a.emitEvent(RECORDING_STOP_EVENT, fmt.Sprintf("recording_id=%s frames=%d duration_sec=%d", id, frames, int(elapsed.Seconds())))
+}
+
+// UploadRecording 메서드는 저장된 녹화 파일을 서버에 업로드하고 업로드한 파일 수를 반환합니다.
+// id 가 비어 있으면 아직 업로드하지 못한 녹화 파일을 모두 업로드합니다. 서버가 확인한 파일은 지웁니다.
+func (a *Agent) UploadRecording(id string) (int, error) { // 단일 책임: 녹화 업로드
+	if id != "" {
+		if uuid.Validate(id) != nil {
+			return 0, fmt.Errorf("잘못된 녹화 ID: %q", id)
+		}
+		if err := a.uploadRecordingFile(id); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}
+	ids, err := a.rec.pendingIDs()
+	if err != nil {
+		return 0, err
+	}
+	uploaded := 0
+	var errs []error
+	for _, id := range ids {
+		switch err := a.uploadRecordingFile(id); {
+		case err == nil:
+			uploaded++
+		case !errors.Is(err, errRecordingUploading): // 다른 고루틴이 올리는 중이면 그쪽 결과를 따름
+			errs = append(errs, fmt.Errorf("recording_id=%s: %w", id, err))
+		}
+	}
+	return uploaded, errors.Join(errs...)
+}
+
+// retryRecordingUploads 함수는 연결(재연결) 직후 이전에 업로드하지 못한 녹화 파일을 백그라운드로 다시 업로드합니다.
+func (a *Agent) retryRecordingUploads() { // 단일 책임: 녹화 업로드 재시도
+	n, err := a.UploadRecording("")
+	if err != nil {
+		a.logger.Warnf("대기 중 녹화 업로드 실패 (다음 연결 때 재시도): %v", err)
+	}
+	if n > 0 {
+		a.logger.Infow("대기 중 녹화 업로드 완료", "recordings", n)
+	}
+}
+
+// uploadRecordingFile 함수는 녹화 파일 하나를 청크 스트림으로 업로드하고, 서버가 확인하면 파일을 지웁니다.
+func (a *Agent) uploadRecordingFile(id string) error { // 단일 책임: 녹화 파일 업로드
+	if err := a.rec.claim(id); err != nil {
+		return err
+	}
+	defer a.rec.unclaim(id)
+	a.mu.Lock()
+	client := a.agentClient
+	a.mu.Unlock()
+	if client == nil {
+		return fmt.Errorf("gRPC 클라이언트 없음")
+	}
+	path := a.rec.pathFor(id)
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	ctx, cancel := context.WithTimeout(a.ctx, RECORDING_UPLOAD_TIMEOUT)
+	defer cancel()
+	stream, err := client.UploadRecording(ctx)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, RECORDING_UPLOAD_CHUNK)
+	var offset int64
+	for {
+		n, readErr := io.ReadFull(f, buf)
+		last := readErr == io.EOF || readErr == io.ErrUnexpectedEOF
+		if readErr != nil && !last {
+			return readErr
+		}
+		chunk := &monitorProto.RecordingChunk{AgentId: a.agentID, RecordingId: id, Offset: offset, Data: buf[:n], Last: last}
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+		offset += int64(n)
+		if last {
+			break
+		}
+	}
+	ack, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	if !ack.GetSuccess() {
+		return fmt.Errorf("서버 거부: %s", ack.GetMessage())
+	}
+	_ = f.Close()
+	if err := os.Remove(path); err != nil { // 남으면 다음 연결 때 같은 녹화 ID 로 다시 올림
+		a.logger.Warnf("업로드한 녹화 파일 삭제 실패: %v", err)
+	}
+	a.logger.Infow("녹화 업로드 완료", "recording_id", id, "bytes", offset)
+	a.emitEvent(RECORDING_UPLOADED_EVENT, fmt.Sprintf("recording_id=%s bytes=%d", id, offset))
+	return nil
+}
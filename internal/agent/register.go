@@ -0,0 +1,178 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"slices"
+	"time"
+
+	"agent/internal/i18n"
+	monitorProto "agent/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	REGISTER_TIMEOUT_MS    = 5000               // Register RPC 타임아웃
+	ENCODING_CHANGED_EVENT = "encoding_changed" // 프레임 인코딩/품질 변경 이벤트
+)
+
+// Version 변수는 에이전트 빌드 버전입니다 (빌드 시 -ldflags "-X agent/internal/agent.Version=..." 로 지정).
+var Version = "dev"
+
+// supportedEncodings 변수는 등록 시 보고하는 프레임 인코딩 목록입니다.
+var supportedEncodings = []string{"png", "jpeg", ENCODING_DELTA, ENCODING_TILES, ENCODING_WEBP, ENCODING_H264}
+
+// errRegisterRejected 변수는 서버가 등록을 거부했음을 나타냅니다.
+var errRegisterRejected = i18n.NewError("error.register_rejected")
+
+// register 함수는 스트림을 열기 전 Register RPC 로 에이전트 정보와 기능을 보고하고 서버 지정 설정을 적용합니다.
+// 서버가 Register 를 지원하지 않거나 호출이 실패하면 로컬 설정으로 진행하며, 거부 응답일 때만 오류를 반환합니다.
+func (a *Agent) register() error { // 단일 책임: 등록 + 설정 수신
+	a.mu.Lock()
+	client := a.agentClient
+	a.mu.Unlock()
+	if client == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(a.ctx, time.Duration(REGISTER_TIMEOUT_MS)*time.Millisecond)
+	defer cancel()
+	resp, err := client.Register(ctx, a.registerRequest())
+	if status.Code(err) == codes.Unimplemented {
+		a.logger.Info("서버가 등록 RPC 를 지원하지 않음 - 로컬 설정 사용")
+		return nil
+	}
+	if err != nil {
+		a.logger.Warnf("에이전트 등록 실패 (로컬 설정 사용): %v", err)
+		return nil
+	}
+	if !resp.GetAccepted() {
+		return fmt.Errorf("%w: %s", errRegisterRejected, resp.GetMessage())
+	}
+	a.logger.Infow("에이전트 등록 완료", "message", resp.GetMessage())
+	a.applyServerSettings(resp)
+	if err := a.pullRemoteConfig(); status.Code(err) == codes.Unimplemented {
+		a.logger.Info("서버가 설정 조회 RPC 를 지원하지 않음 - 로컬 설정 사용")
+	} else if err != nil {
+		a.logger.Warnf("서버 관리 설정 조회 실패 (로컬 설정 유지): %v", err)
+	}
+	return nil
+}
+
+// registerRequest 함수는 현재 호스트/모니터/기능 정보로 등록 요청을 구성합니다.
+func (a *Agent) registerRequest() *monitorProto.RegisterRequest { // 단일 책임: 등록 요청 구성
+	bounds := listMonitors()
+	monitors := make([]*monitorProto.MonitorInfo, 0, len(bounds))
+	for i, b := range bounds {
+		monitors = append(monitors, &monitorProto.MonitorInfo{
+			Index:  int32(i),
+			X:      int32(b.Min.X),
+			Y:      int32(b.Min.Y),
+			Width:  int32(b.Dx()),
+			Height: int32(b.Dy()),
+		})
+	}
+	return &monitorProto.RegisterRequest{
+		AgentId:    a.agentID,
+		Hostname:   a.hostname,
+		Os:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		Version:    Version,
+		Monitors:   monitors,
+		Encodings:  a.availableEncodings(),
+		Commands:   SupportedCommands(),
+		Timestamp:  time.Now().UnixMilli(),
+		ServerAddr: a.endpoints.current(),
+		DeviceName: a.deviceName(),
+		Consent:    a.consentInfo(),
+	}
+}
+
+// availableEncodings 함수는 이 호스트에서 사용할 수 있는 인코딩 목록을 반환합니다 (ffmpeg 가 없으면 h264 제외).
+func (a *Agent) availableEncodings() []string { // 단일 책임: 사용 가능 인코딩 판별
+	if _, err := exec.LookPath(a.cfg.FFmpegPath); err == nil {
+		return supportedEncodings
+	}
+	return slices.DeleteFunc(slices.Clone(supportedEncodings), func(e string) bool { return e == ENCODING_H264 })
+}
+
+// applyServerSettings 함수는 등록 응답의 FPS/품질/인코딩 지정값을 적용합니다. 0 또는 빈 값은 로컬 설정을 유지합니다.
+func (a *Agent) applyServerSettings(resp *monitorProto.RegisterResponse) { // 단일 책임: 서버 지정 설정 적용
+	if fps := int(resp.GetTargetFps()); fps > 0 {
+		if err := a.SetTargetFPS(fps); err != nil {
+			a.logger.Warnf("서버 지정 FPS 무시: %v", err)
+		}
+	}
+	if err := a.setEncoding(resp.GetEncoding(), int(resp.GetJpegQuality())); err != nil {
+		a.logger.Warnf("서버 지정 인코딩 설정 무시: %v", err)
+	}
+}
+
+// SetEncoding 메서드는 캡처 루프를 멈추지 않고 프레임 인코딩과 품질을 바꿉니다 (다음 프레임부터 적용).
+// quality 는 바꾼 뒤 인코딩이 webp 이면 webp 품질, 그 밖에는 jpeg 품질이며 0 이면 유지합니다.
+func (a *Agent) SetEncoding(encoding string, quality int) error { // 단일 책임: 인코딩 변경 노출
+	if encoding != "" && !slices.Contains(a.availableEncodings(), encoding) {
+		return fmt.Errorf("사용할 수 없는 인코딩: %q", encoding)
+	}
+	a.capMu.RLock()
+	target := a.cfg.CaptureEncoding
+	a.capMu.RUnlock()
+	if encoding != "" {
+		target = encoding
+	}
+	if target == ENCODING_WEBP {
+		return a.setEncodingQuality(encoding, 0, quality)
+	}
+	return a.setEncoding(encoding, quality)
+}
+
+// setEncoding 함수는 프레임 인코딩과 jpeg 품질을 바꾸고 실제 화면 캡처러를 새 옵션으로 교체합니다.
+// encoding 이 빈 값이거나 quality 가 0 이면 해당 항목은 유지합니다.
+func (a *Agent) setEncoding(encoding string, quality int) error { // 단일 책임: 인코딩 설정 변경
+	return a.setEncodingQuality(encoding, quality, 0)
+}
+
+// setEncodingQuality 함수는 setEncoding 에 webp 품질까지 받아 실제 값이 바뀐 경우에만 캡처러를 교체하고 변경 이벤트를 보냅니다.
+func (a *Agent) setEncodingQuality(encoding string, jpegQuality, webpQuality int) error { // 단일 책임: 인코딩/품질 변경 적용
+	if encoding == "" && jpegQuality == 0 && webpQuality == 0 {
+		return nil
+	}
+	if encoding != "" && !slices.Contains(supportedEncodings, encoding) {
+		return fmt.Errorf("지원하지 않는 인코딩: %q", encoding)
+	}
+	if jpegQuality < 0 || jpegQuality > 100 {
+		return fmt.Errorf("jpeg 품질 범위 오류: %d", jpegQuality)
+	}
+	if webpQuality < 0 || webpQuality > 100 {
+		return fmt.Errorf("webp 품질 범위 오류: %d", webpQuality)
+	}
+	a.capMu.Lock()
+	prevEnc, prevJpeg, prevWebp := a.cfg.CaptureEncoding, a.cfg.JpegQuality, a.cfg.WebpQuality
+	if encoding != "" {
+		a.cfg.CaptureEncoding = encoding
+	}
+	if jpegQuality > 0 {
+		a.cfg.JpegQuality = jpegQuality
+	}
+	if webpQuality > 0 {
+		a.cfg.WebpQuality = webpQuality
+	}
+	if a.cfg.CaptureEncoding == prevEnc && a.cfg.JpegQuality == prevJpeg && a.cfg.WebpQuality == prevWebp {
+		a.capMu.Unlock()
+		return nil
+	}
+	switch a.capturer.(type) { // 실제 화면 캡처러만 교체 (더미는 인코딩 옵션 없음)
+	case *screenshotCapturer, *windowCapturer, *monitorSetCapturer, *testPatternCapturer:
+		a.setCapturerLocked(newRealCapturer(a.cfg))
+	}
+	a.quality.setBase(a.cfg.JpegQuality, a.cfg.WebpQuality)
+	enc, jpegQ, webpQ := a.cfg.CaptureEncoding, a.cfg.JpegQuality, a.cfg.WebpQuality
+	a.capMu.Unlock()
+	a.logger.Infow("인코딩 설정 변경", "encoding", enc, "jpeg_quality", jpegQ, "webp_quality", webpQ)
+	detail := fmt.Sprintf("encoding=%s jpeg_quality=%d webp_quality=%d", enc, jpegQ, webpQ)
+	a.emitEvent(ENCODING_CHANGED_EVENT, detail)
+	return nil
+}
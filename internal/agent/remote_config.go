@@ -0,0 +1,161 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"agent/internal/config"
+	monitorProto "agent/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	REMOTE_CONFIG_TIMEOUT_MS = 5000 // GetConfig RPC 타임아웃
+)
+
+// remoteConfigKeys 변수는 서버 설정 항목별로 로컬 지정 여부를 확인할 설정 이름입니다 (local_overrides 판정용).
+var remoteConfigKeys = map[string][]string{
+	CONFIG_ITEM_FPS:      {"CAPTURE_TARGET_FPS"},
+	CONFIG_ITEM_ENCODING: {"CAPTURE_ENCODING"},
+	CONFIG_ITEM_QUALITY:  {"JPEG_QUALITY", "WEBP_QUALITY"},
+	CONFIG_ITEM_PRIVACY:  {"PRIVACY_MASKS", "PRIVACY_MASK_MODE", "CAPTURE_BLOCKED_APPS", "CAPTURE_BLOCKED_ACTION"},
+}
+
+// remoteConfigState 구조체는 마지막으로 적용한 서버 관리 설정 상태입니다.
+type remoteConfigState struct { // 단일 책임: 서버 설정 적용 상태 보관
+	mu       sync.Mutex
+	revision uint64          // 마지막으로 적용한 개정 번호
+	managed  map[string]bool // 서버 값을 적용한 항목 (설정 파일 재적용 시 건너뜀)
+	interval time.Duration   // 서버가 지정한 조회 간격 (0 이면 설정 값)
+}
+
+// manages 함수는 서버가 관리 중인 항목인지 반환합니다.
+func (r *remoteConfigState) manages(item string) bool { // 단일 책임: 관리 항목 조회
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.managed[item]
+}
+
+// pullRemoteConfig 함수는 GetConfig 로 서버 관리 설정을 받아 개정 번호가 바뀌었으면 적용합니다.
+// RemoteConfig 가 꺼져 있거나 연결이 없으면 아무것도 하지 않습니다.
+func (a *Agent) pullRemoteConfig() error { // 단일 책임: 서버 설정 조회
+	if !a.cfg.RemoteConfig {
+		return nil
+	}
+	a.mu.Lock()
+	client := a.agentClient
+	a.mu.Unlock()
+	if client == nil {
+		return nil
+	}
+	r := &a.remote
+	r.mu.Lock()
+	rev := r.revision
+	r.mu.Unlock()
+	ctx, cancel := context.WithTimeout(a.ctx, time.Duration(REMOTE_CONFIG_TIMEOUT_MS)*time.Millisecond)
+	defer cancel()
+	rc, err := client.GetConfig(ctx, &monitorProto.ConfigRequest{AgentId: a.agentID, Revision: rev})
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.interval = time.Duration(rc.GetPollIntervalSec()) * time.Second
+	r.mu.Unlock()
+	if rc.GetRevision() == rev {
+		return nil
+	}
+	a.applyRemoteConfig(rc)
+	return nil
+}
+
+// runRemoteConfig 함수는 서버 지정 간격(없으면 RemoteConfigSec)마다 서버 관리 설정을 다시 조회합니다.
+// 간격이 0 이거나, 서버가 GetConfig 를 지원하지 않거나, 컨텍스트가 끝나면 반환합니다.
+func (a *Agent) runRemoteConfig() { // 단일 책임: 주기적 서버 설정 조회
+	if !a.cfg.RemoteConfig {
+		return
+	}
+	for {
+		a.remote.mu.Lock()
+		interval := a.remote.interval
+		a.remote.mu.Unlock()
+		if interval <= 0 {
+			interval = time.Duration(a.cfg.RemoteConfigSec) * time.Second
+		}
+		if interval <= 0 {
+			return
+		}
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+		err := a.pullRemoteConfig()
+		if status.Code(err) == codes.Unimplemented {
+			return
+		}
+		if err != nil {
+			a.logger.Debugf("서버 관리 설정 조회 실패: %v", err)
+		}
+	}
+}
+
+// applyRemoteConfig 함수는 서버 관리 설정을 적용합니다. 서버가 local_overrides 로 허용한 항목은
+// 로컬(명령행, 환경 변수, UI 변경 설정, 설정 파일)에서 지정했으면 로컬 값을 유지합니다.
+func (a *Agent) applyRemoteConfig(rc *monitorProto.AgentConfig) { // 단일 책임: 서버 설정 적용
+	local := map[string]bool{}
+	for _, item := range rc.GetLocalOverrides() {
+		for _, key := range remoteConfigKeys[item] {
+			if config.IsSet(key) {
+				local[item] = true
+			}
+		}
+	}
+	managed := map[string]bool{}
+	var applied, kept []string
+	apply := func(item string, set bool, fn func() error) {
+		if !set {
+			return
+		}
+		if local[item] {
+			kept = append(kept, item)
+			return
+		}
+		managed[item] = true
+		if err := fn(); err != nil {
+			a.logger.Warnw("서버 관리 설정 무시", "item", item, "error", err)
+			return
+		}
+		applied = append(applied, item)
+	}
+	apply(CONFIG_ITEM_FPS, rc.GetTargetFps() > 0, func() error {
+		return a.SetTargetFPS(int(rc.GetTargetFps()))
+	})
+	apply(CONFIG_ITEM_ENCODING, rc.GetEncoding() != "", func() error {
+		return a.SetEncoding(rc.GetEncoding(), 0)
+	})
+	apply(CONFIG_ITEM_QUALITY, rc.GetJpegQuality() > 0 || rc.GetWebpQuality() > 0, func() error {
+		return a.setEncodingQuality("", int(rc.GetJpegQuality()), int(rc.GetWebpQuality()))
+	})
+	apply(CONFIG_ITEM_PRIVACY, rc.GetPrivacyManaged(), func() error {
+		masks, err := parsePrivacyMasks(rc.GetPrivacyMasks())
+		if err != nil {
+			return err
+		}
+		if err := a.SetPrivacyMasks(masks, rc.GetPrivacyMaskMode()); err != nil {
+			return err
+		}
+		return a.SetBlockedApps(strings.Split(rc.GetBlockedApps(), ","), rc.GetBlockedAppAction())
+	})
+	r := &a.remote
+	r.mu.Lock()
+	r.revision, r.managed = rc.GetRevision(), managed
+	r.mu.Unlock()
+	a.logger.Infow("서버 관리 설정 적용", "revision", rc.GetRevision(), "applied", applied, "local", kept)
+	detail := fmt.Sprintf("source=server revision=%d changed=%s", rc.GetRevision(), strings.Join(applied, ","))
+	a.emitEvent(CONFIG_RELOADED_EVENT, detail)
+}
@@ -0,0 +1,115 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	RESOURCE_HIGH_EVENT   = "resource_high"   // 자원 사용률이 임계값 이상으로 지정 시간 지속 (resource, value, threshold, sustained_sec)
+	RESOURCE_NORMAL_EVENT = "resource_normal" // resource_high 이후 임계값 아래로 회복 (resource, value, threshold)
+	RESOURCE_EVENT_SOURCE = "resource"        // 자원 사용률 이벤트 발생원 이름
+	RESOURCE_POLL_MS      = 5000              // 시스템 자원 사용률 확인 주기(ms)
+	RESOURCE_CPU          = "cpu"             // 시스템 전체 CPU 사용률
+	RESOURCE_MEMORY       = "memory"          // 물리 메모리 사용률
+	RESOURCE_DISK         = "disk"            // 디스크(ResourceDiskPath 볼륨) 사용률
+)
+
+// resourceThreshold 구조체는 자원 하나의 임계값 초과 지속 시간과 보고 상태입니다.
+type resourceThreshold struct { // 단일 책임: 임계값 지속 판정
+	name      string
+	threshold float64   // 임계 사용률(%) (0=비활성)
+	overSince time.Time // 임계값 이상이 시작된 시각 (아래면 zero)
+	reported  bool      // resource_high 보고 후 회복 전
+}
+
+// observe 함수는 사용률을 반영해 이벤트를 낼 차례면 이벤트 종류를, 아니면 빈 값을 반환합니다.
+func (t *resourceThreshold) observe(value float64, now time.Time, sustain time.Duration) string { // 단일 책임: 초과/회복 판정
+	if value < t.threshold {
+		t.overSince = time.Time{}
+		if t.reported {
+			t.reported = false
+			return RESOURCE_NORMAL_EVENT
+		}
+		return ""
+	}
+	if t.overSince.IsZero() {
+		t.overSince = now
+	}
+	if !t.reported && now.Sub(t.overSince) >= sustain {
+		t.reported = true
+		return RESOURCE_HIGH_EVENT
+	}
+	return ""
+}
+
+// cpuSampler 구조체는 시스템 CPU 누적 시간(틱) 직전 값입니다.
+type cpuSampler struct {
+	idle, total uint64
+}
+
+// usage 함수는 직전 호출 이후 시스템 전체 CPU 사용률(%)을 반환합니다. 첫 호출이나 조회 실패면 false 입니다.
+func (s *cpuSampler) usage() (float64, bool) { // 단일 책임: 구간 CPU 사용률 계산
+	idle, total, ok := systemCPUTimes()
+	if !ok {
+		return 0, false
+	}
+	prevIdle, prevTotal := s.idle, s.total
+	s.idle, s.total = idle, total
+	if prevTotal == 0 || total <= prevTotal {
+		return 0, false
+	}
+	return 100 * (1 - float64(idle-prevIdle)/float64(total-prevTotal)), true
+}
+
+// resourceSource 함수는 ResourceEvents 설정 시 RESOURCE_POLL_MS 마다 CPU/메모리/디스크 사용률을 확인해
+// 임계값 이상이 ResourceSustainSec 동안 이어지면 resource_high, 회복하면 resource_normal 이벤트를 내는 발생원을 만듭니다.
+func (a *Agent) resourceSource() EventSource { // 단일 책임: 자원 발생원 생성
+	if !a.cfg.ResourceEvents {
+		return nil
+	}
+	diskPath := a.cfg.ResourceDiskPath
+	if diskPath == "" {
+		diskPath = a.cfg.DataDir
+	}
+	return newWatchSource(RESOURCE_EVENT_SOURCE, func(ctx context.Context, emit eventEmitter) {
+		ticker := time.NewTicker(time.Duration(RESOURCE_POLL_MS) * time.Millisecond)
+		defer ticker.Stop()
+		sustain := time.Duration(a.cfg.ResourceSustainSec) * time.Second
+		cpu := &cpuSampler{}
+		cpu.usage()
+		checks := []struct {
+			t      *resourceThreshold
+			sample func() (float64, bool)
+		}{
+			{&resourceThreshold{name: RESOURCE_CPU, threshold: float64(a.cfg.CPUThresholdPct)}, cpu.usage},
+			{&resourceThreshold{name: RESOURCE_MEMORY, threshold: float64(a.cfg.MemThresholdPct)}, memoryUsedPct},
+			{&resourceThreshold{name: RESOURCE_DISK, threshold: float64(a.cfg.DiskThresholdPct)}, func() (float64, bool) { return diskUsedPct(diskPath) }},
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				for _, c := range checks {
+					if c.t.threshold <= 0 {
+						continue
+					}
+					value, ok := c.sample()
+					if !ok {
+						continue
+					}
+					switch c.t.observe(value, now, sustain) {
+					case RESOURCE_HIGH_EVENT:
+						a.logger.Warnw("시스템 자원 사용률 임계값 초과", "resource", c.t.name, "value", value, "threshold", c.t.threshold)
+						emit(RESOURCE_HIGH_EVENT, fmt.Sprintf("resource=%s value=%.1f threshold=%.0f sustained_sec=%d", c.t.name, value, c.t.threshold, a.cfg.ResourceSustainSec))
+					case RESOURCE_NORMAL_EVENT:
+						a.logger.Infow("시스템 자원 사용률 회복", "resource", c.t.name, "value", value)
+						emit(RESOURCE_NORMAL_EVENT, fmt.Sprintf("resource=%s value=%.1f threshold=%.0f", c.t.name, value, c.t.threshold))
+					}
+				}
+			}
+		}
+	})
+}
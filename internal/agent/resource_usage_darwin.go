@@ -0,0 +1,57 @@
+//go:build darwin && cgo
+
+package agent
+
+/*
+#include <mach/mach.h>
+#include <sys/sysctl.h>
+
+// 누적 CPU 틱을 채웁니다 (0: 성공).
+static int agent_cpu_ticks(unsigned long long *idle, unsigned long long *total) {
+	host_cpu_load_info_data_t info;
+	mach_msg_type_number_t count = HOST_CPU_LOAD_INFO_COUNT;
+	if (host_statistics(mach_host_self(), HOST_CPU_LOAD_INFO, (host_info_t)&info, &count) != KERN_SUCCESS) {
+		return -1;
+	}
+	*idle = info.cpu_ticks[CPU_STATE_IDLE];
+	*total = 0;
+	for (int i = 0; i < CPU_STATE_MAX; i++) {
+		*total += info.cpu_ticks[i];
+	}
+	return 0;
+}
+
+// 활성+wired+압축 페이지 기준 메모리 사용률(%)을 반환합니다 (-1: 실패). 활성 모니터 앱의 "사용된 메모리"와 같은 기준입니다.
+static double agent_memory_used_pct(void) {
+	unsigned long long memsize = 0;
+	size_t len = sizeof(memsize);
+	if (sysctlbyname("hw.memsize", &memsize, &len, NULL, 0) != 0 || memsize == 0) {
+		return -1;
+	}
+	vm_statistics64_data_t vm;
+	mach_msg_type_number_t count = HOST_VM_INFO64_COUNT;
+	if (host_statistics64(mach_host_self(), HOST_VM_INFO64, (host_info64_t)&vm, &count) != KERN_SUCCESS) {
+		return -1;
+	}
+	vm_size_t page = 0;
+	host_page_size(mach_host_self(), &page);
+	unsigned long long used = ((unsigned long long)vm.active_count + vm.wire_count + vm.compressor_page_count) * page;
+	return 100.0 * (double)used / (double)memsize;
+}
+*/
+import "C"
+
+// systemCPUTimes 함수는 host_statistics(HOST_CPU_LOAD_INFO) 로 누적 유휴 틱과 전체 틱을 읽습니다.
+func systemCPUTimes() (idle, total uint64, ok bool) { // 단일 책임: mach CPU 틱 조회
+	var i, t C.ulonglong
+	if C.agent_cpu_ticks(&i, &t) != 0 {
+		return 0, 0, false
+	}
+	return uint64(i), uint64(t), true
+}
+
+// memoryUsedPct 함수는 mach 가상 메모리 통계로 물리 메모리 사용률을 계산합니다.
+func memoryUsedPct() (float64, bool) { // 단일 책임: mach 메모리 사용률 조회
+	pct := float64(C.agent_memory_used_pct())
+	return pct, pct >= 0
+}
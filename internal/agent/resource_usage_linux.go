@@ -0,0 +1,69 @@
+//go:build linux
+
+package agent
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// systemCPUTimes 함수는 /proc/stat 첫 줄에서 누적 유휴(idle+iowait) 틱과 전체 틱을 읽습니다.
+func systemCPUTimes() (idle, total uint64, ok bool) { // 단일 책임: /proc CPU 시간 조회
+	f, err := os.Open("/proc/stat")
+	if err != nil {
+		return 0, 0, false
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	if !sc.Scan() {
+		return 0, 0, false
+	}
+	fields := strings.Fields(sc.Text())
+	if len(fields) < 5 || fields[0] != "cpu" {
+		return 0, 0, false
+	}
+	for i, f := range fields[1:] {
+		v, err := strconv.ParseUint(f, 10, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+		if i >= 8 { // guest/guest_nice 는 user/nice 에 이미 포함
+			break
+		}
+		total += v
+		if i == 3 || i == 4 { // idle, iowait
+			idle += v
+		}
+	}
+	return idle, total, true
+}
+
+// memoryUsedPct 함수는 /proc/meminfo 의 MemTotal 대비 MemAvailable 로 메모리 사용률을 계산합니다.
+func memoryUsedPct() (float64, bool) { // 단일 책임: /proc 메모리 사용률 조회
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, false
+	}
+	defer f.Close()
+	var total, avail uint64
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		v, _ := strconv.ParseUint(fields[1], 10, 64)
+		switch fields[0] {
+		case "MemTotal:":
+			total = v
+		case "MemAvailable:":
+			avail = v
+		}
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return 100 * float64(total-min(avail, total)) / float64(total), true
+}
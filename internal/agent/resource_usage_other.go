@@ -0,0 +1,13 @@
+//go:build !windows && !linux && !(darwin && cgo)
+
+package agent
+
+// systemCPUTimes 함수는 시스템 CPU 시간 조회를 지원하지 않는 플랫폼에서 false 를 반환합니다.
+func systemCPUTimes() (idle, total uint64, ok bool) { // 단일 책임: 미지원 플랫폼 처리
+	return 0, 0, false
+}
+
+// memoryUsedPct 함수는 메모리 사용률 조회를 지원하지 않는 플랫폼에서 false 를 반환합니다.
+func memoryUsedPct() (float64, bool) { // 단일 책임: 미지원 플랫폼 처리
+	return 0, false
+}
@@ -0,0 +1,60 @@
+//go:build windows
+
+package agent
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	procGetSystemTimes       = windows.NewLazySystemDLL("kernel32.dll").NewProc("GetSystemTimes")
+	procGlobalMemoryStatusEx = windows.NewLazySystemDLL("kernel32.dll").NewProc("GlobalMemoryStatusEx")
+)
+
+// memoryStatusEx 구조체는 MEMORYSTATUSEX 입니다.
+type memoryStatusEx struct {
+	Length               uint32
+	MemoryLoad           uint32
+	TotalPhys            uint64
+	AvailPhys            uint64
+	TotalPageFile        uint64
+	AvailPageFile        uint64
+	TotalVirtual         uint64
+	AvailVirtual         uint64
+	AvailExtendedVirtual uint64
+}
+
+// systemCPUTimes 함수는 GetSystemTimes 로 누적 유휴 시간과 전체 시간(커널+사용자, 커널에 유휴 포함)을 읽습니다.
+func systemCPUTimes() (idle, total uint64, ok bool) { // 단일 책임: Win32 CPU 시간 조회
+	var idleFT, kernelFT, userFT windows.Filetime
+	if r, _, _ := procGetSystemTimes.Call(uintptr(unsafe.Pointer(&idleFT)), uintptr(unsafe.Pointer(&kernelFT)), uintptr(unsafe.Pointer(&userFT))); r == 0 {
+		return 0, 0, false
+	}
+	ft := func(f windows.Filetime) uint64 { return uint64(f.HighDateTime)<<32 | uint64(f.LowDateTime) }
+	return ft(idleFT), ft(kernelFT) + ft(userFT), true
+}
+
+// memoryUsedPct 함수는 GlobalMemoryStatusEx 의 물리 메모리 사용률을 반환합니다.
+func memoryUsedPct() (float64, bool) { // 단일 책임: Win32 메모리 사용률 조회
+	m := memoryStatusEx{}
+	m.Length = uint32(unsafe.Sizeof(m))
+	if r, _, _ := procGlobalMemoryStatusEx.Call(uintptr(unsafe.Pointer(&m))); r == 0 || m.TotalPhys == 0 {
+		return 0, false
+	}
+	return 100 * float64(m.TotalPhys-m.AvailPhys) / float64(m.TotalPhys), true
+}
+
+// diskUsedPct 함수는 path 가 속한 볼륨의 사용률을 GetDiskFreeSpaceEx 로 계산합니다 (호출자 가용 공간 기준).
+func diskUsedPct(path string) (float64, bool) { // 단일 책임: Win32 디스크 사용률 조회
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, false
+	}
+	var avail, total, free uint64
+	if err := windows.GetDiskFreeSpaceEx(p, &avail, &total, &free); err != nil || total == 0 {
+		return 0, false
+	}
+	return 100 * float64(total-min(avail, total)) / float64(total), true
+}
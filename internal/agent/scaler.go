@@ -0,0 +1,173 @@
+package agent
+
+import (
+	"image"
+	"math"
+	"sync"
+	"time"
+
+	"agent/internal/config"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+const (
+	ADAPTIVE_SCALE_WINDOW       = 30  // 부하 평가 단위 프레임 수
+	ADAPTIVE_SCALE_HIGH_LOAD    = 0.9 // 전송 시간/프레임 간격 비율이 이 값을 넘으면 축소
+	ADAPTIVE_SCALE_LOW_LOAD     = 0.5 // 이 값 미만 구간이 연속되면 복원
+	ADAPTIVE_SCALE_CALM_WINDOWS = 3   // 한 단계 복원에 필요한 연속 저부하 구간 수
+)
+
+// ADAPTIVE_SCALE_LEVELS 변수는 단계별 전송 해상도 비율(%)입니다 (0번이 원본).
+var ADAPTIVE_SCALE_LEVELS = []int{100, 75, 50}
+
+// adaptiveScaler 구조체는 전송 부하(전송 시간 대비 프레임 간격, 인코딩 단계 포화)를 측정해
+// 전송 해상도 단계를 낮추거나 회선 회복 시 복원합니다.
+type adaptiveScaler struct { // 단일 책임: 대역폭 기반 해상도 단계 제어
+	mu          sync.Mutex
+	enabled     bool
+	level       int           // ADAPTIVE_SCALE_LEVELS 인덱스
+	interval    time.Duration // 목표 프레임 간격
+	frames      int           // 현재 구간 전송 프레임 수
+	sendTotal   time.Duration // 현재 구간 전송 시간 합
+	pressure    int           // 현재 구간 포화(백로그) 발생 횟수
+	calmWindows int           // 연속 저부하 구간 수
+	onChange    func(from, to int)
+}
+
+// newAdaptiveScaler 함수는 adaptiveScaler 생성자입니다.
+func newAdaptiveScaler(enabled bool, onChange func(from, to int)) *adaptiveScaler { // 단일 책임: 인스턴스 생성
+	return &adaptiveScaler{enabled: enabled, onChange: onChange}
+}
+
+// reset 함수는 새 캡처 루프 시작 시 목표 간격을 설정하고 측정 구간을 초기화합니다 (해상도 단계는 유지).
+func (s *adaptiveScaler) reset(interval time.Duration) { // 단일 책임: 구간 초기화
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.interval = interval
+	s.frames, s.sendTotal, s.pressure, s.calmWindows = 0, 0, 0, 0
+}
+
+// current 함수는 현재 전송 해상도 비율(%)을 반환합니다.
+func (s *adaptiveScaler) current() int { // 단일 책임: 현재 단계 조회
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.enabled {
+		return 100
+	}
+	return ADAPTIVE_SCALE_LEVELS[s.level]
+}
+
+// notePressure 함수는 송신 백로그(인코딩/전송 단계 포화)를 기록합니다.
+func (s *adaptiveScaler) notePressure() { // 단일 책임: 포화 기록
+	s.mu.Lock()
+	s.pressure++
+	s.mu.Unlock()
+}
+
+// observeSend 함수는 프레임 1개의 전송 소요 시간을 기록하고 구간이 차면 단계를 재평가합니다.
+func (s *adaptiveScaler) observeSend(d time.Duration) { // 단일 책임: 전송 시간 기록
+	s.mu.Lock()
+	if !s.enabled || s.interval <= 0 {
+		s.mu.Unlock()
+		return
+	}
+	s.frames++
+	s.sendTotal += d
+	if s.frames < ADAPTIVE_SCALE_WINDOW {
+		s.mu.Unlock()
+		return
+	}
+	load := float64(s.sendTotal) / float64(time.Duration(s.frames)*s.interval)
+	from := s.level
+	switch {
+	case load > ADAPTIVE_SCALE_HIGH_LOAD || s.pressure > 0: // 회선이 프레임 비트레이트를 못 따라감
+		if s.level < len(ADAPTIVE_SCALE_LEVELS)-1 {
+			s.level++
+		}
+		s.calmWindows = 0
+	case load < ADAPTIVE_SCALE_LOW_LOAD:
+		s.calmWindows++
+		if s.calmWindows >= ADAPTIVE_SCALE_CALM_WINDOWS && s.level > 0 {
+			s.level--
+			s.calmWindows = 0
+		}
+	default:
+		s.calmWindows = 0
+	}
+	s.frames, s.sendTotal, s.pressure = 0, 0, 0
+	to := s.level
+	s.mu.Unlock()
+	if from != to && s.onChange != nil {
+		s.onChange(ADAPTIVE_SCALE_LEVELS[from], ADAPTIVE_SCALE_LEVELS[to])
+	}
+}
+
+// outputScale 구조체는 설정으로 고정한 출력 축소(배율, 최대 폭/높이)와 적응형 단계를 합쳐 인코딩 전 이미지 크기를 정합니다.
+type outputScale struct { // 단일 책임: 출력 해상도 결정
+	factor    float64            // 고정 배율 (1=원본)
+	maxWidth  int                // 최대 폭 (0=제한 없음)
+	maxHeight int                // 최대 높이 (0=제한 없음)
+	interp    xdraw.Interpolator // 고정 축소 보간 방식
+}
+
+// newOutputScale 함수는 설정에서 outputScale 을 구성합니다.
+func newOutputScale(cfg *config.Config) outputScale { // 단일 책임: 설정 변환
+	o := outputScale{factor: cfg.CaptureScale, maxWidth: cfg.CaptureMaxWidth, maxHeight: cfg.CaptureMaxHeight, interp: xdraw.CatmullRom}
+	switch cfg.CaptureScaleFilter {
+	case "bilinear":
+		o.interp = xdraw.BiLinear
+	case "approx":
+		o.interp = xdraw.ApproxBiLinear
+	}
+	return o
+}
+
+// fixed 함수는 고정 출력 축소가 설정되어 있는지 반환합니다.
+func (o outputScale) fixed() bool { // 단일 책임: 고정 축소 여부
+	return o.factor < 1 || o.maxWidth > 0 || o.maxHeight > 0
+}
+
+// size 함수는 w x h 원본을 고정 축소와 적응형 단계 pct(%)로 줄인 크기를 반환합니다 (확대는 하지 않음).
+func (o outputScale) size(w, h, pct int) (int, int) { // 단일 책임: 출력 크기 계산
+	f := 1.0
+	if o.factor > 0 && o.factor < 1 {
+		f = o.factor
+	}
+	if o.maxWidth > 0 && float64(w)*f > float64(o.maxWidth) {
+		f = float64(o.maxWidth) / float64(w)
+	}
+	if o.maxHeight > 0 && float64(h)*f > float64(o.maxHeight) {
+		f = float64(o.maxHeight) / float64(h)
+	}
+	if pct > 0 && pct < 100 {
+		f = f * float64(pct) / 100
+	}
+	return max(1, int(math.Round(float64(w)*f))), max(1, int(math.Round(float64(h)*f)))
+}
+
+// ratio 함수는 b 크기 원본의 출력 폭 비율(%)을 반환합니다 (축소가 없으면 100).
+func (o outputScale) ratio(b image.Rectangle, pct int) int { // 단일 책임: 출력 비율 계산
+	w, _ := o.size(b.Dx(), b.Dy(), pct)
+	if w >= b.Dx() {
+		return 100
+	}
+	return max(1, int(math.Round(float64(w)*100/float64(b.Dx()))))
+}
+
+// apply 함수는 이미지를 출력 크기로 축소합니다 (축소가 없으면 원본 반환).
+// 고정 축소가 설정되어 있으면 설정한 고품질 보간을, 적응형 단계만 적용되면 저비용 근사 쌍선형 보간을 사용합니다.
+func (o outputScale) apply(img image.Image, pct int) image.Image { // 단일 책임: 인코딩 전 축소
+	b := img.Bounds()
+	w, h := o.size(b.Dx(), b.Dy(), pct)
+	if w >= b.Dx() && h >= b.Dy() {
+		return img
+	}
+	interp := xdraw.Interpolator(xdraw.ApproxBiLinear)
+	if o.fixed() {
+		interp = o.interp
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	interp.Scale(dst, dst.Bounds(), img, b, xdraw.Src, nil)
+	return dst
+}
@@ -0,0 +1,205 @@
+package agent
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"agent/internal/i18n"
+)
+
+const (
+	SCHEDULE_STARTED_EVENT = "schedule_started" // 캡처 허용 시간대 시작 (캡처 자동 시작)
+	SCHEDULE_STOPPED_EVENT = "schedule_stopped" // 캡처 허용 시간대 종료 (캡처 자동 중지)
+	SCHEDULE_CHECK_MAX     = time.Minute        // 시간대 재확인 최대 간격 (절전 복귀/시계 변경 보정)
+	MINUTES_PER_DAY        = 24 * 60
+)
+
+// errOutsideSchedule 변수는 캡처 허용 시간대 밖에서 캡처 시작을 요청했음을 나타냅니다.
+var errOutsideSchedule = i18n.NewError("error.outside_schedule")
+
+// scheduleDays 변수는 요일 이름과 묶음 별칭입니다.
+var scheduleDays = map[string][]time.Weekday{
+	"sun": {time.Sunday}, "mon": {time.Monday}, "tue": {time.Tuesday}, "wed": {time.Wednesday},
+	"thu": {time.Thursday}, "fri": {time.Friday}, "sat": {time.Saturday},
+	"weekdays": {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	"weekend":  {time.Saturday, time.Sunday},
+	"daily":    {time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday},
+}
+
+// scheduleWindow 구조체는 요일별 허용 시간대 하나입니다. end 가 start 보다 작으면 자정을 넘겨 다음 날 end 까지입니다.
+type scheduleWindow struct {
+	days       [7]bool // 시작 요일
+	start, end int     // 자정 기준 분 (end 는 24:00 까지)
+}
+
+// captureSchedule 타입은 캡처 허용 시간대 목록입니다 (하나라도 포함하면 허용).
+type captureSchedule []scheduleWindow
+
+// parseSchedule 함수는 "요일[,요일|-요일] HH:MM-HH:MM; ..." 형식의 시간대 목록을 파싱합니다.
+// 요일은 mon..sun 과 weekdays/weekend/daily 를 쓰며, 요일을 빼면 매일입니다.
+func parseSchedule(s string) (captureSchedule, error) { // 단일 책임: 캡처 일정 파싱
+	var sched captureSchedule
+	for _, part := range strings.Split(s, ";") {
+		fields := strings.Fields(strings.ToLower(part))
+		if len(fields) == 0 {
+			continue
+		}
+		var w scheduleWindow
+		span := fields[len(fields)-1]
+		switch len(fields) {
+		case 1:
+			w.days = [7]bool{true, true, true, true, true, true, true}
+		case 2:
+			days, err := parseScheduleDays(fields[0])
+			if err != nil {
+				return nil, err
+			}
+			w.days = days
+		default:
+			return nil, fmt.Errorf("캡처 일정 형식 오류: %q (요일 HH:MM-HH:MM)", strings.TrimSpace(part))
+		}
+		from, to, ok := strings.Cut(span, "-")
+		var err error
+		if !ok {
+			return nil, fmt.Errorf("캡처 일정 시간 형식 오류: %q (HH:MM-HH:MM)", span)
+		}
+		if w.start, err = parseClock(from); err == nil {
+			w.end, err = parseClock(to)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if w.start == w.end || w.start == MINUTES_PER_DAY {
+			return nil, fmt.Errorf("캡처 일정 시간대 오류: %q", span)
+		}
+		sched = append(sched, w)
+	}
+	return sched, nil
+}
+
+// parseScheduleDays 함수는 "mon-fri", "sat,sun", "weekdays" 같은 요일 지정을 파싱합니다.
+func parseScheduleDays(s string) ([7]bool, error) { // 단일 책임: 요일 파싱
+	var days [7]bool
+	for _, item := range strings.Split(s, ",") {
+		from, to, isRange := strings.Cut(item, "-")
+		first, ok := scheduleDays[from]
+		if !ok {
+			return days, fmt.Errorf("캡처 일정 요일 오류: %q", item)
+		}
+		if !isRange {
+			for _, d := range first {
+				days[d] = true
+			}
+			continue
+		}
+		last, ok := scheduleDays[to]
+		if !ok || len(first) != 1 || len(last) != 1 {
+			return days, fmt.Errorf("캡처 일정 요일 범위 오류: %q", item)
+		}
+		for d := first[0]; ; d = (d + 1) % 7 { // sat-mon 처럼 주를 넘는 범위 허용
+			days[d] = true
+			if d == last[0] {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+// parseClock 함수는 "HH:MM" 을 자정 기준 분으로 바꿉니다 (24:00 허용).
+func parseClock(s string) (int, error) { // 단일 책임: 시각 파싱
+	hh, mm, ok := strings.Cut(s, ":")
+	h, errH := strconv.Atoi(hh)
+	m, errM := strconv.Atoi(mm)
+	if !ok || errH != nil || errM != nil || h < 0 || m < 0 || m > 59 || h*60+m > MINUTES_PER_DAY {
+		return 0, fmt.Errorf("캡처 일정 시각 오류: %q (HH:MM)", s)
+	}
+	return h*60 + m, nil
+}
+
+// contains 함수는 t(로컬 시각)가 시간대에 포함되는지 반환합니다.
+func (w scheduleWindow) contains(t time.Time) bool { // 단일 책임: 시간대 포함 판정
+	m, day := t.Hour()*60+t.Minute(), t.Weekday()
+	if w.start < w.end {
+		return w.days[day] && m >= w.start && m < w.end
+	}
+	return (w.days[day] && m >= w.start) || (w.days[(day+6)%7] && m < w.end)
+}
+
+// active 함수는 t 가 허용 시간대 중 하나에 포함되는지 반환합니다.
+func (s captureSchedule) active(t time.Time) bool { // 단일 책임: 캡처 허용 판정
+	for _, w := range s {
+		if w.contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// scheduleState 구조체는 설정한 캡처 일정과 현재 허용 여부입니다 (windows 가 비어 있으면 제한 없음).
+type scheduleState struct { // 단일 책임: 캡처 일정 상태 보관
+	windows captureSchedule
+	outside atomic.Bool // 허용 시간대 밖 (StartCapture 거부)
+}
+
+// loadSchedule 함수는 설정의 캡처 일정을 적용합니다. 형식 오류가 있으면 경고 후 시간 제한 없이 시작합니다.
+func (a *Agent) loadSchedule() { // 단일 책임: 캡처 일정 적용
+	sched, err := parseSchedule(a.cfg.CaptureSchedule)
+	if err != nil {
+		a.logger.Warnf("CAPTURE_SCHEDULE 무시: %v", err)
+		return
+	}
+	a.schedule.windows = sched
+	a.schedule.outside.Store(len(sched) > 0 && !sched.active(time.Now()))
+}
+
+// startSchedule 함수는 캡처 일정이 있으면 허용 시간대에 맞춰 캡처 루프를 자동 시작/중지하는 고루틴을 시작합니다.
+// 시작 시 이미 허용 시간대 안이면 바로 캡처를 시작합니다.
+func (a *Agent) startSchedule() { // 단일 책임: 캡처 일정 감시 시작
+	if len(a.schedule.windows) == 0 {
+		return
+	}
+	if !a.schedule.outside.Load() {
+		a.onScheduleChange(true)
+	}
+	go a.runSchedule()
+}
+
+// runSchedule 함수는 매 분 경계(최대 SCHEDULE_CHECK_MAX)마다 허용 여부를 다시 계산해 바뀌면 반영합니다.
+func (a *Agent) runSchedule() { // 단일 책임: 캡처 일정 주기 확인
+	for {
+		now := time.Now()
+		wait := now.Truncate(time.Minute).Add(time.Minute).Sub(now)
+		if wait > SCHEDULE_CHECK_MAX {
+			wait = SCHEDULE_CHECK_MAX
+		}
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+		inside := a.schedule.windows.active(time.Now())
+		if a.schedule.outside.Swap(!inside) == inside { // 상태 전환
+			a.onScheduleChange(inside)
+		}
+	}
+}
+
+// onScheduleChange 함수는 허용 시간대 시작/종료에 맞춰 캡처를 시작/중지하고 일정 이벤트를 발행합니다.
+// 시간대 안에서 사용자가 중지한 캡처는 다음 시작 시각까지 다시 시작하지 않습니다.
+func (a *Agent) onScheduleChange(inside bool) { // 단일 책임: 일정 전환 처리
+	if inside {
+		a.logger.Info("캡처 허용 시간대 시작: 캡처 시작")
+		if err := a.StartCapture(); err != nil {
+			a.logger.Warnf("일정 캡처 시작 실패: %v", err)
+		}
+		a.emitEvent(SCHEDULE_STARTED_EVENT, "capture=started")
+		return
+	}
+	a.logger.Info("캡처 허용 시간대 종료: 캡처 중지")
+	a.StopCapture()
+	a.emitEvent(SCHEDULE_STOPPED_EVENT, "capture=stopped")
+}
@@ -0,0 +1,75 @@
+package agent
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+const (
+	SCREEN_LOCKED_EVENT   = "screen_locked"   // 화면 잠김 (캡처 일시 중지)
+	SCREEN_UNLOCKED_EVENT = "screen_unlocked" // 화면 잠금 해제 (캡처 재개)
+)
+
+// errScreenLockUnsupported 변수는 현재 플랫폼/빌드에서 화면 잠금 감지를 지원하지 않음을 나타냅니다.
+var errScreenLockUnsupported = errors.New("이 플랫폼에서는 화면 잠금 감지를 지원하지 않음")
+
+// screenLockHandler 변수는 OS 콜백(Win32 창 프로시저, CoreFoundation 알림)이 호출할 잠금 상태 처리기입니다.
+var screenLockHandler atomic.Pointer[func(locked bool)]
+
+// screenLockState 구조체는 화면 잠금 상태와 캡처 루프를 깨우는 변경 알림입니다.
+type screenLockState struct { // 단일 책임: 잠금 상태 보관
+	locked   atomic.Bool   // 캡처 일시 중지용 잠금 상태 (PauseOnLock 일 때만 갱신)
+	changed  chan struct{} // 상태가 바뀌면 신호 (1 버퍼, 대기 중인 캡처 루프 재개)
+	reported atomic.Bool   // 세션 잠금 이벤트로 마지막 보고한 상태
+}
+
+// newScreenLockState 함수는 screenLockState 생성자입니다.
+func newScreenLockState() *screenLockState { // 단일 책임: 인스턴스 생성
+	return &screenLockState{changed: make(chan struct{}, 1)}
+}
+
+// set 함수는 잠금 상태를 바꾸고, 실제로 바뀌었으면 true 를 반환합니다.
+func (s *screenLockState) set(locked bool) bool { // 단일 책임: 상태 전환
+	if s.locked.Swap(locked) == locked {
+		return false
+	}
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// startScreenLockWatch 함수는 PauseOnLock 또는 SessionEvents 설정 시 OS 화면 잠금 감지 고루틴을 시작합니다.
+// 감지를 지원하지 않거나 실패하면 잠금과 무관하게 계속 캡처합니다.
+func (a *Agent) startScreenLockWatch() { // 단일 책임: 잠금 감지 시작
+	if !a.cfg.PauseOnLock && !a.cfg.SessionEvents {
+		return
+	}
+	go func() {
+		err := watchScreenLock(a.ctx, a.onScreenLock)
+		if err != nil && a.ctx.Err() == nil {
+			a.logger.Infof("화면 잠금 감지 비활성: %v", err)
+		}
+	}()
+}
+
+// onScreenLock 함수는 잠금 상태 변경을 반영하고 잠금/해제 이벤트를 발행합니다.
+func (a *Agent) onScreenLock(locked bool) { // 단일 책임: 잠금 변경 처리
+	a.reportSessionLock(locked)
+	if !a.cfg.PauseOnLock || !a.lock.set(locked) {
+		return
+	}
+	if locked {
+		a.logger.Info("화면 잠김: 캡처 일시 중지")
+		a.emitEvent(SCREEN_LOCKED_EVENT, "capture=paused")
+		return
+	}
+	a.logger.Info("화면 잠금 해제: 캡처 재개")
+	a.emitEvent(SCREEN_UNLOCKED_EVENT, "capture=resumed")
+}
+
+// ScreenLocked 메서드는 화면 잠금으로 캡처가 일시 중지된 상태인지 반환합니다.
+func (a *Agent) ScreenLocked() bool { // 단일 책임: 잠금 상태 조회
+	return a.lock.locked.Load()
+}
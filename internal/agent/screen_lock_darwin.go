@@ -0,0 +1,90 @@
+//go:build darwin && cgo
+
+package agent
+
+/*
+#cgo LDFLAGS: -framework CoreFoundation -framework CoreGraphics
+#include <CoreFoundation/CoreFoundation.h>
+#include <CoreGraphics/CoreGraphics.h>
+
+extern void goScreenLockChanged(int locked);
+
+static CFRunLoopRef screenLockRunLoop;
+
+static void screenLockCallback(CFNotificationCenterRef center, void *observer, CFNotificationName name, const void *object, CFDictionaryRef info) {
+	goScreenLockChanged(CFStringCompare(name, CFSTR("com.apple.screenIsLocked"), 0) == kCFCompareEqualTo);
+}
+
+static void screenLockKeepAlive(CFRunLoopTimerRef timer, void *info) {
+}
+
+// screenLockInitial 은 현재 로그인 세션의 화면 잠금 여부를 반환합니다.
+static int screenLockInitial(void) {
+	CFDictionaryRef session = CGSessionCopyCurrentDictionary();
+	if (session == NULL) {
+		return 0;
+	}
+	CFTypeRef v = CFDictionaryGetValue(session, CFSTR("CGSSessionScreenIsLocked"));
+	int locked = v != NULL && CFGetTypeID(v) == CFBooleanGetTypeID() && CFBooleanGetValue((CFBooleanRef)v);
+	CFRelease(session);
+	return locked;
+}
+
+// screenLockRun 은 분산 알림 관찰자를 현재 스레드 런루프에 등록하고 screenLockStop 까지 런루프를 실행합니다.
+static void screenLockRun(void) {
+	screenLockRunLoop = CFRunLoopGetCurrent();
+	CFNotificationCenterRef center = CFNotificationCenterGetDistributedCenter();
+	CFNotificationCenterAddObserver(center, &screenLockRunLoop, screenLockCallback, CFSTR("com.apple.screenIsLocked"), NULL, CFNotificationSuspensionBehaviorDeliverImmediately);
+	CFNotificationCenterAddObserver(center, &screenLockRunLoop, screenLockCallback, CFSTR("com.apple.screenIsUnlocked"), NULL, CFNotificationSuspensionBehaviorDeliverImmediately);
+	// 입력 소스가 없으면 CFRunLoopRun 이 즉시 반환하므로 긴 주기 타이머로 런루프 유지
+	CFRunLoopTimerRef timer = CFRunLoopTimerCreate(NULL, CFAbsoluteTimeGetCurrent() + 1e9, 1e9, 0, 0, screenLockKeepAlive, NULL);
+	CFRunLoopAddTimer(screenLockRunLoop, timer, kCFRunLoopDefaultMode);
+	CFRunLoopRun();
+	CFRunLoopRemoveTimer(screenLockRunLoop, timer, kCFRunLoopDefaultMode);
+	CFRelease(timer);
+	CFNotificationCenterRemoveEveryObserver(center, &screenLockRunLoop);
+}
+
+static void screenLockStop(void) {
+	if (screenLockRunLoop != NULL) {
+		CFRunLoopStop(screenLockRunLoop);
+	}
+}
+*/
+import "C"
+
+import (
+	"context"
+	"runtime"
+)
+
+// goScreenLockChanged 함수는 CoreFoundation 분산 알림 콜백에서 호출됩니다.
+//
+//export goScreenLockChanged
+func goScreenLockChanged(locked C.int) { // 단일 책임: 알림 전달
+	if h := screenLockHandler.Load(); h != nil {
+		(*h)(locked != 0)
+	}
+}
+
+// watchScreenLock 함수는 com.apple.screenIsLocked/screenIsUnlocked 분산 알림으로 화면 잠금을 감지합니다.
+// 알림은 관찰자를 등록한 스레드의 런루프로 전달되므로 전용 OS 스레드에서 런루프를 돌립니다.
+func watchScreenLock(ctx context.Context, onChange func(locked bool)) error { // 단일 책임: macOS 화면 잠금 감지
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	screenLockHandler.Store(&onChange)
+	if C.screenLockInitial() != 0 {
+		onChange(true)
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			C.screenLockStop()
+		case <-done:
+		}
+	}()
+	C.screenLockRun()
+	return nil
+}
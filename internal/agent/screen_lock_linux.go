@@ -0,0 +1,85 @@
+//go:build linux
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	DBUS_SCREENSAVER_SERVICE = "org.freedesktop.ScreenSaver" // 세션 버스 화면 보호기 (KDE, GNOME 호환 계층 등)
+	DBUS_SCREENSAVER_PATH    = "/org/freedesktop/ScreenSaver"
+	DBUS_GNOME_SCREENSAVER   = "org.gnome.ScreenSaver"  // GNOME Shell 잠금 화면
+	DBUS_LOGIND_SERVICE      = "org.freedesktop.login1" // 시스템 버스 logind (loginctl lock-session, light-locker 등)
+	DBUS_LOGIND_SESSION      = "org.freedesktop.login1.Session"
+)
+
+// watchScreenLock 함수는 D-Bus 로 화면 잠금을 감지합니다. 세션 버스의 화면 보호기 ActiveChanged 신호와
+// 시스템 버스 logind 의 현재 세션 Lock/Unlock 신호를 함께 구독하며, 세션 버스가 없으면(헤드리스) 오류를 반환합니다.
+func watchScreenLock(ctx context.Context, onChange func(locked bool)) error { // 단일 책임: D-Bus 잠금 감지
+	session, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+	for _, iface := range []string{DBUS_SCREENSAVER_SERVICE, DBUS_GNOME_SCREENSAVER} {
+		if err := session.AddMatchSignal(dbus.WithMatchInterface(iface), dbus.WithMatchMember("ActiveChanged")); err != nil {
+			return err
+		}
+	}
+	signals := make(chan *dbus.Signal, 8)
+	session.Signal(signals)
+	var active bool
+	if session.Object(DBUS_SCREENSAVER_SERVICE, DBUS_SCREENSAVER_PATH).Call(DBUS_SCREENSAVER_SERVICE+".GetActive", 0).Store(&active) == nil && active {
+		onChange(true)
+	}
+	if system := watchLogindLock(signals); system != nil { // logind 는 선택 사항 (없어도 화면 보호기 신호로 동작)
+		defer system.Close()
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case sig, ok := <-signals:
+			if !ok {
+				return errors.New("D-Bus 연결 종료")
+			}
+			switch sig.Name {
+			case DBUS_LOGIND_SESSION + ".Lock":
+				onChange(true)
+			case DBUS_LOGIND_SESSION + ".Unlock":
+				onChange(false)
+			default: // ActiveChanged(bool)
+				if len(sig.Body) == 1 {
+					if v, ok := sig.Body[0].(bool); ok {
+						onChange(v)
+					}
+				}
+			}
+		}
+	}
+}
+
+// watchLogindLock 함수는 시스템 버스에서 이 프로세스가 속한 logind 세션의 Lock/Unlock 신호를 signals 로 받도록 구독합니다.
+// 시스템 버스나 logind 가 없으면 nil 을 반환합니다.
+func watchLogindLock(signals chan<- *dbus.Signal) *dbus.Conn { // 단일 책임: logind 신호 구독
+	system, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return nil
+	}
+	var path dbus.ObjectPath
+	err = system.Object(DBUS_LOGIND_SERVICE, "/org/freedesktop/login1").Call(DBUS_LOGIND_SERVICE+".Manager.GetSessionByPID", 0, uint32(os.Getpid())).Store(&path)
+	if err == nil {
+		err = system.AddMatchSignal(dbus.WithMatchObjectPath(path), dbus.WithMatchInterface(DBUS_LOGIND_SESSION))
+	}
+	if err != nil {
+		system.Close()
+		return nil
+	}
+	system.Signal(signals)
+	return system
+}
@@ -0,0 +1,10 @@
+//go:build !windows && !linux && !(darwin && cgo)
+
+package agent
+
+import "context"
+
+// watchScreenLock 함수는 화면 잠금 감지를 지원하지 않는 플랫폼에서 errScreenLockUnsupported 를 반환합니다.
+func watchScreenLock(context.Context, func(bool)) error { // 단일 책임: 미지원 플랫폼 처리
+	return errScreenLockUnsupported
+}
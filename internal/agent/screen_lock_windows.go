@@ -0,0 +1,143 @@
+//go:build windows
+
+package agent
+
+import (
+	"context"
+	"runtime"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	WM_CLOSE                 = 0x0010
+	WM_WTSSESSION_CHANGE     = 0x02B1 // WTSRegisterSessionNotification 세션 변경 알림
+	WTS_SESSION_LOCK         = 0x7
+	WTS_SESSION_UNLOCK       = 0x8
+	NOTIFY_FOR_THIS_SESSION  = 0
+	DESKTOP_SWITCHDESKTOP    = 0x0100
+	SCREEN_LOCK_WINDOW_CLASS = "AgentScreenLockWatcher" // 알림 수신용 메시지 전용 창 클래스
+	HWND_MESSAGE             = ^uintptr(2)              // (HWND)-3: 메시지 전용 창 부모
+)
+
+var (
+	procWTSRegisterSessionNotification   = windows.NewLazySystemDLL("wtsapi32.dll").NewProc("WTSRegisterSessionNotification")
+	procWTSUnRegisterSessionNotification = windows.NewLazySystemDLL("wtsapi32.dll").NewProc("WTSUnRegisterSessionNotification")
+	procRegisterClassExW                 = user32.NewProc("RegisterClassExW")
+	procCreateWindowExW                  = user32.NewProc("CreateWindowExW")
+	procDestroyWindow                    = user32.NewProc("DestroyWindow")
+	procDefWindowProcW                   = user32.NewProc("DefWindowProcW")
+	procGetMessageW                      = user32.NewProc("GetMessageW")
+	procDispatchMessageW                 = user32.NewProc("DispatchMessageW")
+	procPostMessageW                     = user32.NewProc("PostMessageW")
+	procPostQuitMessage                  = user32.NewProc("PostQuitMessage")
+	procOpenInputDesktop                 = user32.NewProc("OpenInputDesktop")
+	procCloseDesktop                     = user32.NewProc("CloseDesktop")
+	screenLockWndProc                    = windows.NewCallback(func(hwnd, msg, wparam, lparam uintptr) uintptr {
+		switch msg {
+		case WM_WTSSESSION_CHANGE:
+			if h := screenLockHandler.Load(); h != nil && (wparam == WTS_SESSION_LOCK || wparam == WTS_SESSION_UNLOCK) {
+				(*h)(wparam == WTS_SESSION_LOCK)
+			}
+			return 0
+		case WM_CLOSE:
+			procPostQuitMessage.Call(0)
+			return 0
+		}
+		r, _, _ := procDefWindowProcW.Call(hwnd, msg, wparam, lparam)
+		return r
+	})
+)
+
+// wndClassEx 구조체는 WNDCLASSEXW 입니다.
+type wndClassEx struct {
+	Size       uint32
+	Style      uint32
+	WndProc    uintptr
+	ClsExtra   int32
+	WndExtra   int32
+	Instance   windows.Handle
+	Icon       windows.Handle
+	Cursor     windows.Handle
+	Background windows.Handle
+	MenuName   *uint16
+	ClassName  *uint16
+	IconSm     windows.Handle
+}
+
+// winMsg 구조체는 MSG 입니다.
+type winMsg struct {
+	Hwnd    uintptr
+	Message uint32
+	WParam  uintptr
+	LParam  uintptr
+	Time    uint32
+	Pt      struct{ X, Y int32 }
+	Private uint32
+}
+
+// watchScreenLock 함수는 메시지 전용 창을 만들어 WTSRegisterSessionNotification 으로 세션 잠금/해제 알림을 받습니다.
+func watchScreenLock(ctx context.Context, onChange func(locked bool)) error { // 단일 책임: Win32 세션 잠금 감지
+	screenLockHandler.Store(&onChange)
+	return runMessageWindow(ctx, SCREEN_LOCK_WINDOW_CLASS, screenLockWndProc, func(hwnd uintptr) (func(), error) {
+		if r, _, err := procWTSRegisterSessionNotification.Call(hwnd, NOTIFY_FOR_THIS_SESSION); r == 0 {
+			return nil, err
+		}
+		if inputDesktopLocked() { // 잠긴 상태에서 시작 (서비스 재시작 등)
+			onChange(true)
+		}
+		return func() { procWTSUnRegisterSessionNotification.Call(hwnd) }, nil
+	})
+}
+
+// runMessageWindow 함수는 class 이름의 메시지 전용 창을 만들고 ctx 취소까지 메시지 루프를 돌립니다.
+// attach 는 창 생성 직후 알림을 등록하고 해제 함수를 반환합니다. 창 프로시저는 WM_CLOSE 에서 PostQuitMessage 를 호출해야 합니다.
+// 창 메시지 루프는 생성한 OS 스레드에서만 돌아야 하므로 고루틴을 스레드에 고정합니다.
+func runMessageWindow(ctx context.Context, className string, wndProc uintptr, attach func(hwnd uintptr) (func(), error)) error { // 단일 책임: 메시지 전용 창 루프
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	var instance windows.Handle
+	_ = windows.GetModuleHandleEx(0, nil, &instance)
+	class, _ := windows.UTF16PtrFromString(className)
+	wc := wndClassEx{WndProc: wndProc, Instance: instance, ClassName: class}
+	wc.Size = uint32(unsafe.Sizeof(wc))
+	procRegisterClassExW.Call(uintptr(unsafe.Pointer(&wc))) // 이미 등록된 경우(재시작) 실패해도 기존 클래스 사용
+	hwnd, _, err := procCreateWindowExW.Call(0, uintptr(unsafe.Pointer(class)), 0, 0, 0, 0, 0, 0, HWND_MESSAGE, 0, uintptr(instance), 0)
+	if hwnd == 0 {
+		return err
+	}
+	defer procDestroyWindow.Call(hwnd)
+	detach, err := attach(hwnd)
+	if err != nil {
+		return err
+	}
+	defer detach()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			procPostMessageW.Call(hwnd, WM_CLOSE, 0, 0)
+		case <-done:
+		}
+	}()
+	var m winMsg
+	for {
+		r, _, _ := procGetMessageW.Call(uintptr(unsafe.Pointer(&m)), 0, 0, 0)
+		if int32(r) <= 0 { // WM_QUIT 또는 오류
+			return nil
+		}
+		procDispatchMessageW.Call(uintptr(unsafe.Pointer(&m)))
+	}
+}
+
+// inputDesktopLocked 함수는 입력 데스크톱을 열 수 없으면(잠금 화면의 Winlogon 데스크톱) true 를 반환합니다.
+func inputDesktopLocked() bool { // 단일 책임: 초기 잠금 상태 판단
+	h, _, _ := procOpenInputDesktop.Call(0, 0, DESKTOP_SWITCHDESKTOP)
+	if h == 0 {
+		return true
+	}
+	procCloseDesktop.Call(h)
+	return false
+}
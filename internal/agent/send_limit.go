@@ -0,0 +1,154 @@
+package agent
+
+import (
+	"sync"
+	"time"
+
+	"agent/internal/config"
+	monitorProto "agent/proto"
+)
+
+const (
+	SEND_LIMIT_DELAY     = "delay" // 예산이 찰 때까지 송신 대기 (밀린 프레임은 송신 큐에서 가장 오래된 것부터 폐기)
+	SEND_LIMIT_DROP      = "drop"  // 예산이 없으면 프레임 폐기 (delta/tiles 는 다음 키프레임까지 함께 폐기)
+	SEND_LIMIT_BURST_SEC = 1       // 버킷 용량 = 초당 예산 × 이 초 (쉬었다 보내는 첫 프레임 허용량)
+)
+
+// SendLimitStats 구조체는 프레임 송신 속도 제한 상태입니다.
+type SendLimitStats struct {
+	Enabled bool    `json:"enabled"`  // FRAME_SEND_LIMIT_KBS 설정 여부
+	RateKBs int     `json:"rate_kbs"` // 초당 예산(KB/s)
+	Policy  string  `json:"policy"`   // delay | drop
+	Dropped uint64  `json:"dropped"`  // 예산 부족으로 버린 누적 프레임 수 (drop 정책)
+	Delayed uint64  `json:"delayed"`  // 예산이 찰 때까지 기다린 누적 프레임 수 (delay 정책)
+	WaitMs  float64 `json:"wait_ms"`  // 누적 대기 시간(ms)
+}
+
+// sendLimiter 구조체는 프레임 송신 바이트에 대한 토큰 버킷입니다. 예산보다 큰 프레임은 버킷이 가득 찼을 때 빚으로 보내
+// 이후 프레임이 그만큼 더 기다리므로, 장기 평균은 설정 속도를 넘지 않습니다.
+type sendLimiter struct { // 단일 책임: 프레임 송신 속도 제한
+	mu      sync.Mutex
+	rate    float64 // 초당 바이트 (0 이면 비활성)
+	burst   float64 // 버킷 용량(바이트)
+	tokens  float64 // 남은 예산(바이트, 빚이면 음수)
+	last    time.Time
+	drop    bool
+	broken  map[int32]bool // delta/tiles 프레임을 버려 다음 키프레임 전까지 복원할 수 없는 모니터
+	dropped uint64
+	delayed uint64
+	waited  time.Duration
+}
+
+// newSendLimiter 함수는 설정값으로 sendLimiter 를 생성합니다 (FRAME_SEND_LIMIT_KBS 가 0 이면 무제한).
+func newSendLimiter(cfg *config.Config) *sendLimiter { // 단일 책임: 인스턴스 생성
+	rate := float64(cfg.FrameSendLimitKBs) * 1024
+	return &sendLimiter{
+		rate:   rate,
+		burst:  rate * SEND_LIMIT_BURST_SEC,
+		tokens: rate * SEND_LIMIT_BURST_SEC,
+		last:   time.Now(),
+		drop:   cfg.FrameSendLimitPolicy == SEND_LIMIT_DROP,
+		broken: make(map[int32]bool),
+	}
+}
+
+// reserve 함수는 n 바이트 송신 예산을 잡고 기다릴 시간을 반환합니다. drop 정책에서 예산이 없으면 잡지 않고 false 를 반환합니다
+// (mayDrop 이 false 이면 정책과 관계없이 기다림).
+func (l *sendLimiter) reserve(n int, mayDrop bool, now time.Time) (time.Duration, bool) { // 단일 책임: 송신 예산 계산
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	need := min(float64(n), l.burst)
+	if l.tokens >= need {
+		l.tokens -= float64(n)
+		return 0, true
+	}
+	if l.drop && mayDrop {
+		l.dropped++
+		return 0, false
+	}
+	wait := time.Duration((need - l.tokens) / l.rate * float64(time.Second))
+	l.tokens -= float64(n)
+	l.delayed++
+	l.waited += wait
+	return wait, true
+}
+
+// admit 함수는 drop 정책에서 frame 을 보낼지 판단합니다. 버린 delta/tiles 뒤의 비키프레임은 복원할 수 없으므로
+// 같은 모니터의 다음 키프레임까지 예산과 관계없이 함께 버립니다.
+func (l *sendLimiter) admit(frame *monitorProto.FrameData, now time.Time) (time.Duration, bool) { // 단일 책임: 프레임 송신 허용 판단
+	chained := frame.GetEncoding() == ENCODING_DELTA || frame.GetEncoding() == ENCODING_TILES
+	id := frame.GetMonitorId()
+	l.mu.Lock()
+	skip := chained && !frame.GetKeyframe() && l.broken[id]
+	if skip {
+		l.dropped++
+	}
+	l.mu.Unlock()
+	if skip {
+		return 0, false
+	}
+	wait, ok := l.reserve(frameBytes(frame), true, now)
+	if chained {
+		l.mu.Lock()
+		if !ok {
+			l.broken[id] = true
+		} else if frame.GetKeyframe() {
+			delete(l.broken, id)
+		}
+		l.mu.Unlock()
+	}
+	return wait, ok
+}
+
+// frameBytes 함수는 송신 예산에 셀 프레임 이미지 크기입니다 (tiles 는 영역 이미지 합).
+func frameBytes(frame *monitorProto.FrameData) int { // 단일 책임: 프레임 크기 계산
+	n := len(frame.GetImageData())
+	for _, r := range frame.GetRegions() {
+		n += len(r.GetData())
+	}
+	return n
+}
+
+// throttleFrame 함수는 FRAME_SEND_LIMIT_KBS 예산에 맞춰 송신을 늦추고, drop 정책에서 예산이 없으면 false 를 반환합니다.
+// 재전송(replay)처럼 버리면 안 되는 프레임은 mayDrop=false 로 불러 항상 기다립니다.
+func (a *Agent) throttleFrame(frame *monitorProto.FrameData, mayDrop bool) bool { // 단일 책임: 프레임 송신 속도 제한
+	l := a.sendLimit
+	if l.rate <= 0 {
+		return true
+	}
+	now := time.Now()
+	var wait time.Duration
+	ok := true
+	if mayDrop {
+		wait, ok = l.admit(frame, now)
+	} else {
+		wait, _ = l.reserve(frameBytes(frame), false, now)
+	}
+	if !ok || wait <= 0 {
+		return ok
+	}
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-a.ctx.Done():
+	}
+	return true
+}
+
+// SendLimitStats 메서드는 프레임 송신 속도 제한 계수를 반환합니다.
+func (a *Agent) SendLimitStats() SendLimitStats { // 단일 책임: 속도 제한 계측 노출
+	l := a.sendLimit
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return SendLimitStats{
+		Enabled: l.rate > 0,
+		RateKBs: a.cfg.FrameSendLimitKBs,
+		Policy:  a.cfg.FrameSendLimitPolicy,
+		Dropped: l.dropped,
+		Delayed: l.delayed,
+		WaitMs:  float64(l.waited) / float64(time.Millisecond),
+	}
+}
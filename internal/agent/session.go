@@ -0,0 +1,62 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"os/user"
+)
+
+const (
+	SESSION_LOGIN_EVENT  = "session_login"  // 사용자 세션 로그인 (user, session)
+	SESSION_LOGOUT_EVENT = "session_logout" // 사용자 세션 로그아웃 (user, session)
+	SESSION_LOCK_EVENT   = "session_lock"   // 에이전트가 실행 중인 세션 잠김 (user)
+	SESSION_UNLOCK_EVENT = "session_unlock" // 에이전트가 실행 중인 세션 잠금 해제 (user)
+)
+
+// errSessionWatchUnsupported 변수는 현재 플랫폼에서 로그인/로그아웃 감지를 지원하지 않음을 나타냅니다.
+var errSessionWatchUnsupported = errors.New("이 플랫폼에서는 세션 로그인/로그아웃 감지를 지원하지 않음")
+
+// startSessionWatch 함수는 SessionEvents 설정 시 OS 세션 로그인/로그아웃 알림 감시 고루틴을 시작합니다.
+// 잠금/해제는 화면 잠금 감지(startScreenLockWatch)가 함께 보고합니다.
+func (a *Agent) startSessionWatch() { // 단일 책임: 세션 감시 시작
+	if !a.cfg.SessionEvents {
+		return
+	}
+	go func() {
+		err := watchSessions(a.ctx, a.onSession)
+		if err != nil && a.ctx.Err() == nil {
+			a.logger.Infof("세션 로그인/로그아웃 감지 비활성: %v", err)
+		}
+	}()
+}
+
+// onSession 함수는 OS 세션 로그인/로그아웃 알림을 이벤트로 발행합니다.
+func (a *Agent) onSession(login bool, username, session string) { // 단일 책임: 세션 변경 처리
+	event := SESSION_LOGOUT_EVENT
+	if login {
+		event = SESSION_LOGIN_EVENT
+	}
+	a.logger.Infow("사용자 세션 변경", "event", event, "user", username, "session", session)
+	a.emitEvent(event, fmt.Sprintf("user=%s session=%s", username, session))
+}
+
+// reportSessionLock 함수는 SessionEvents 설정 시 에이전트 세션의 잠금 상태 변경을 세션 이벤트로 발행합니다.
+func (a *Agent) reportSessionLock(locked bool) { // 단일 책임: 세션 잠금 이벤트
+	if !a.cfg.SessionEvents || a.lock.reported.Swap(locked) == locked {
+		return
+	}
+	event := SESSION_UNLOCK_EVENT
+	if locked {
+		event = SESSION_LOCK_EVENT
+	}
+	a.emitEvent(event, "user="+currentUsername())
+}
+
+// currentUsername 함수는 에이전트를 실행한 사용자 이름을 반환합니다 (알 수 없으면 빈 값).
+func currentUsername() string { // 단일 책임: 현재 사용자 조회
+	u, err := user.Current()
+	if err != nil {
+		return ""
+	}
+	return u.Username
+}
@@ -0,0 +1,74 @@
+//go:build linux
+
+package agent
+
+import (
+	"context"
+	"errors"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	DBUS_LOGIND_PATH    = "/org/freedesktop/login1"
+	DBUS_LOGIND_MANAGER = "org.freedesktop.login1.Manager"
+)
+
+// logindSession 구조체는 logind ListSessions 응답 항목 a(susso) 입니다.
+type logindSession struct {
+	ID   string
+	UID  uint32
+	User string
+	Seat string
+	Path dbus.ObjectPath
+}
+
+// watchSessions 함수는 시스템 버스 logind 의 SessionNew/SessionRemoved 신호로 로그인/로그아웃(원격 SSH 세션 포함)을 감지합니다.
+// 제거된 세션은 속성을 읽을 수 없으므로 시작 시 목록과 새 세션의 사용자 이름을 기억해 둡니다.
+func watchSessions(ctx context.Context, onChange func(login bool, username, session string)) error { // 단일 책임: logind 세션 감지
+	system, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return err
+	}
+	defer system.Close()
+	manager := system.Object(DBUS_LOGIND_SERVICE, DBUS_LOGIND_PATH)
+	var list []logindSession
+	if err := manager.Call(DBUS_LOGIND_MANAGER+".ListSessions", 0).Store(&list); err != nil {
+		return err
+	}
+	users := make(map[string]string, len(list))
+	for _, s := range list {
+		users[s.ID] = s.User
+	}
+	if err := system.AddMatchSignal(dbus.WithMatchObjectPath(DBUS_LOGIND_PATH), dbus.WithMatchInterface(DBUS_LOGIND_MANAGER)); err != nil {
+		return err
+	}
+	signals := make(chan *dbus.Signal, 8)
+	system.Signal(signals)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case sig, ok := <-signals:
+			if !ok {
+				return errors.New("D-Bus 연결 종료")
+			}
+			if len(sig.Body) != 2 {
+				continue
+			}
+			id, _ := sig.Body[0].(string)
+			path, _ := sig.Body[1].(dbus.ObjectPath)
+			switch sig.Name {
+			case DBUS_LOGIND_MANAGER + ".SessionNew":
+				name, err := system.Object(DBUS_LOGIND_SERVICE, path).GetProperty(DBUS_LOGIND_SESSION + ".Name")
+				if err == nil {
+					users[id], _ = name.Value().(string)
+				}
+				onChange(true, users[id], id)
+			case DBUS_LOGIND_MANAGER + ".SessionRemoved":
+				onChange(false, users[id], id)
+				delete(users, id)
+			}
+		}
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !windows && !linux
+
+package agent
+
+import "context"
+
+// watchSessions 함수는 로그인/로그아웃 감지를 지원하지 않는 플랫폼에서 errSessionWatchUnsupported 를 반환합니다.
+// macOS 에서는 잠금/해제만 화면 잠금 감지로 보고합니다.
+func watchSessions(context.Context, func(bool, string, string)) error { // 단일 책임: 미지원 플랫폼 처리
+	return errSessionWatchUnsupported
+}
@@ -0,0 +1,88 @@
+//go:build windows
+
+package agent
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	WTS_SESSION_LOGON       = 0x5
+	WTS_SESSION_LOGOFF      = 0x6
+	NOTIFY_FOR_ALL_SESSIONS = 1
+	WTS_USER_NAME           = 5                     // WTS_INFO_CLASS WTSUserName
+	WTS_DOMAIN_NAME         = 7                     // WTS_INFO_CLASS WTSDomainName
+	SESSION_WINDOW_CLASS    = "AgentSessionWatcher" // 로그인/로그아웃 알림 수신용 메시지 전용 창 클래스
+)
+
+var (
+	procWTSQuerySessionInformationW = windows.NewLazySystemDLL("wtsapi32.dll").NewProc("WTSQuerySessionInformationW")
+	sessionHandler                  atomic.Pointer[func(login bool, session uint32)] // 창 프로시저가 호출할 로그인/로그아웃 처리기
+	sessionWndProc                  = windows.NewCallback(func(hwnd, msg, wparam, lparam uintptr) uintptr {
+		switch msg {
+		case WM_WTSSESSION_CHANGE:
+			if h := sessionHandler.Load(); h != nil && (wparam == WTS_SESSION_LOGON || wparam == WTS_SESSION_LOGOFF) {
+				(*h)(wparam == WTS_SESSION_LOGON, uint32(lparam))
+			}
+			return 0
+		case WM_CLOSE:
+			procPostQuitMessage.Call(0)
+			return 0
+		}
+		r, _, _ := procDefWindowProcW.Call(hwnd, msg, wparam, lparam)
+		return r
+	})
+)
+
+// watchSessions 함수는 모든 세션(콘솔, 원격 데스크톱)의 WTS 로그온/로그오프 알림을 받아 사용자 이름과 함께 전달합니다.
+// 로그오프 시점에는 세션 정보가 지워졌을 수 있어 로그온 때 조회한 이름을 기억해 둡니다.
+func watchSessions(ctx context.Context, onChange func(login bool, username, session string)) error { // 단일 책임: Win32 세션 로그인 감지
+	users := map[uint32]string{} // 창 프로시저는 메시지 루프 스레드에서만 호출됨
+	handler := func(login bool, id uint32) {
+		name := wtsSessionUser(id)
+		if login {
+			users[id] = name
+		} else if name == "" {
+			name = users[id]
+		}
+		if !login {
+			delete(users, id)
+		}
+		onChange(login, name, strconv.FormatUint(uint64(id), 10))
+	}
+	sessionHandler.Store(&handler)
+	return runMessageWindow(ctx, SESSION_WINDOW_CLASS, sessionWndProc, func(hwnd uintptr) (func(), error) {
+		if r, _, err := procWTSRegisterSessionNotification.Call(hwnd, NOTIFY_FOR_ALL_SESSIONS); r == 0 {
+			return nil, err
+		}
+		return func() { procWTSUnRegisterSessionNotification.Call(hwnd) }, nil
+	})
+}
+
+// wtsSessionUser 함수는 세션의 "도메인\사용자" 이름을 조회합니다 (알 수 없으면 빈 값).
+func wtsSessionUser(id uint32) string { // 단일 책임: 세션 사용자 조회
+	name := wtsSessionString(id, WTS_USER_NAME)
+	if name == "" {
+		return ""
+	}
+	if domain := wtsSessionString(id, WTS_DOMAIN_NAME); domain != "" {
+		return domain + `\` + name
+	}
+	return name
+}
+
+// wtsSessionString 함수는 WTSQuerySessionInformationW 문자열 항목을 조회합니다.
+func wtsSessionString(id uint32, class uintptr) string { // 단일 책임: 세션 정보 조회
+	var buf *uint16
+	var size uint32
+	if r, _, _ := procWTSQuerySessionInformationW.Call(0, uintptr(id), class, uintptr(unsafe.Pointer(&buf)), uintptr(unsafe.Pointer(&size))); r == 0 || buf == nil {
+		return ""
+	}
+	defer windows.WTSFreeMemory(uintptr(unsafe.Pointer(buf)))
+	return windows.UTF16PtrToString(buf)
+}
@@ -0,0 +1,182 @@
+package agent
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"agent/internal/config"
+	"agent/internal/i18n"
+)
+
+// errLocaleUnsupported 변수는 카탈로그가 없는 언어로 바꾸려 할 때의 오류입니다.
+var errLocaleUnsupported = i18n.NewError("error.locale_unsupported")
+
+// CaptureSettings 구조체는 설정 화면에서 보고 바꾸는 캡처 항목입니다 (GetSettings/UpdateSettings).
+type CaptureSettings struct {
+	TargetFPS      int           `json:"targetFps"`      // 목표 FPS
+	IntervalMs     int           `json:"intervalMs"`     // 캡처 주기(ms, 목표 FPS 가 없을 때)
+	Encoding       string        `json:"encoding"`       // 프레임 인코딩
+	JpegQuality    int           `json:"jpegQuality"`    // jpeg 품질
+	WebpQuality    int           `json:"webpQuality"`    // webp 품질
+	Scale          float64       `json:"scale"`          // 인코딩 전 출력 배율 (0 초과 1 이하)
+	MaxWidth       int           `json:"maxWidth"`       // 출력 최대 폭 (0=제한 없음)
+	MaxHeight      int           `json:"maxHeight"`      // 출력 최대 높이 (0=제한 없음)
+	MonitorMode    string        `json:"monitorMode"`    // single | combined | all | window
+	MonitorIndex   int           `json:"monitorIndex"`   // single 모드 모니터 번호
+	CombinedLayout string        `json:"combinedLayout"` // combined/all 모드 배치
+	WindowTitle    string        `json:"windowTitle"`    // window 모드 대상 창 제목
+	WindowProcess  string        `json:"windowProcess"`  // window 모드 대상 프로세스
+	PrivacyMasks   []PrivacyMask `json:"privacyMasks"`   // 가림 영역
+	MaskMode       string        `json:"maskMode"`       // 가림 방식 (black | blur)
+	Schedule       string        `json:"schedule"`       // 캡처 허용 시간대 (빈 값이면 제한 없음)
+}
+
+// SettingsUpdate 구조체는 UpdateSettings 결과입니다. Problems 가 있으면 아무것도 저장/적용하지 않은 것입니다.
+type SettingsUpdate struct {
+	Applied  []string         `json:"applied"`  // 바로 적용한 항목 (CONFIG_ITEM_*)
+	Deferred []string         `json:"deferred"` // 저장했지만 다음 캡처 시작(scale, interval) 또는 재시작(schedule)부터 적용할 항목
+	Problems []config.Problem `json:"problems"` // 검증 실패 항목
+}
+
+// PersistSettings 메서드는 현재 설정의 keys 항목(config.MonitorSettings 등)을 UI 변경 설정으로 저장해 재시작 후에도 유지합니다.
+// 저장 실패는 실행 중 설정에 영향이 없어 경고만 남깁니다.
+func (a *Agent) PersistSettings(keys []string) { // 단일 책임: UI 변경 설정 저장
+	a.capMu.RLock()
+	snapshot := *a.cfg
+	a.capMu.RUnlock()
+	if err := config.SaveSettings(&snapshot, keys); err != nil {
+		a.logger.Warnf("UI 설정 저장 실패: %v", err)
+	}
+}
+
+// ExportConfig 메서드는 실행 중인 유효 설정(UI/원격 명령 변경 포함, 에이전트 ID/토큰 제외)을 JSON 으로 반환합니다.
+// 결과는 다른 PC 의 ImportConfig 입력이나 설정 파일로 그대로 쓸 수 있습니다.
+func (a *Agent) ExportConfig() (string, error) { // 단일 책임: 유효 설정 내보내기
+	a.capMu.RLock()
+	snapshot := *a.cfg
+	a.capMu.RUnlock()
+	data, err := json.MarshalIndent(config.Export(&snapshot), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ImportConfig 메서드는 JSON 설정 묶음을 검증해 저장하고, 실행 중 바꿀 수 있는 항목은 ApplyConfig 로 바로 적용합니다.
+// 검증에 실패하면 아무것도 저장/적용하지 않으며, 적용한 항목 밖의 설정은 재시작 후 적용됩니다.
+func (a *Agent) ImportConfig(data string) ([]string, error) { // 단일 책임: 설정 가져오기
+	if _, err := config.Import([]byte(data)); err != nil {
+		return nil, err
+	}
+	changed := a.ApplyConfig(config.Load())
+	a.logger.Infow("설정 가져오기 완료", "applied", changed)
+	return changed, nil
+}
+
+// GetSettings 메서드는 실행 중인 캡처 설정(UI/원격 명령 변경 포함)을 반환합니다.
+func (a *Agent) GetSettings() CaptureSettings { // 단일 책임: 캡처 설정 조회
+	a.capMu.RLock()
+	c := *a.cfg
+	a.capMu.RUnlock()
+	return CaptureSettings{
+		TargetFPS:      c.TargetFPS,
+		IntervalMs:     c.CaptureIntervalMs,
+		Encoding:       c.CaptureEncoding,
+		JpegQuality:    c.JpegQuality,
+		WebpQuality:    c.WebpQuality,
+		Scale:          c.CaptureScale,
+		MaxWidth:       c.CaptureMaxWidth,
+		MaxHeight:      c.CaptureMaxHeight,
+		MonitorMode:    c.MonitorMode,
+		MonitorIndex:   c.MonitorIndex,
+		CombinedLayout: c.CombinedLayout,
+		WindowTitle:    c.WindowTitle,
+		WindowProcess:  c.WindowProcess,
+		PrivacyMasks:   a.PrivacyMasks(),
+		MaskMode:       c.PrivacyMaskMode,
+		Schedule:       c.CaptureSchedule,
+	}
+}
+
+// UpdateSettings 메서드는 s 를 검증해 UI 변경 설정으로 저장하고, 실행 중 바꿀 수 있는 항목은 바로 적용합니다.
+// 검증에 실패한 항목이 하나라도 있으면 아무것도 바꾸지 않고 Problems 로 돌려줍니다 (오류는 저장 실패 등에만 반환).
+func (a *Agent) UpdateSettings(s CaptureSettings) (SettingsUpdate, error) { // 단일 책임: 캡처 설정 변경
+	var res SettingsUpdate
+	for _, m := range s.PrivacyMasks {
+		if err := m.validate(); err != nil {
+			res.Problems = append(res.Problems, config.Problem{Key: "PRIVACY_MASKS", Value: formatPrivacyMasks([]PrivacyMask{m}), Reason: err.Error(), Applied: "변경 안 함"})
+		}
+	}
+	if _, err := parseSchedule(s.Schedule); err != nil {
+		res.Problems = append(res.Problems, config.Problem{Key: "CAPTURE_SCHEDULE", Value: s.Schedule, Reason: err.Error(), Applied: "변경 안 함"})
+	}
+	if len(res.Problems) > 0 {
+		return res, nil
+	}
+	cur := a.GetSettings()
+	values := map[string]string{
+		"CAPTURE_TARGET_FPS":      strconv.Itoa(s.TargetFPS),
+		"CAPTURE_INTERVAL_MS":     strconv.Itoa(s.IntervalMs),
+		"CAPTURE_ENCODING":        s.Encoding,
+		"JPEG_QUALITY":            strconv.Itoa(s.JpegQuality),
+		"WEBP_QUALITY":            strconv.Itoa(s.WebpQuality),
+		"CAPTURE_SCALE":           strconv.FormatFloat(s.Scale, 'g', -1, 64),
+		"CAPTURE_MAX_WIDTH":       strconv.Itoa(s.MaxWidth),
+		"CAPTURE_MAX_HEIGHT":      strconv.Itoa(s.MaxHeight),
+		"CAPTURE_MONITOR_MODE":    s.MonitorMode,
+		"CAPTURE_MONITOR_INDEX":   strconv.Itoa(s.MonitorIndex),
+		"CAPTURE_COMBINED_LAYOUT": s.CombinedLayout,
+		"CAPTURE_WINDOW_TITLE":    s.WindowTitle,
+		"CAPTURE_WINDOW_PROCESS":  s.WindowProcess,
+		"PRIVACY_MASKS":           formatPrivacyMasks(s.PrivacyMasks),
+		"PRIVACY_MASK_MODE":       s.MaskMode,
+		"CAPTURE_SCHEDULE":        s.Schedule,
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return res, err
+	}
+	if problems, err := config.Import(data); len(problems) > 0 {
+		res.Problems = problems
+		return res, nil
+	} else if err != nil {
+		return res, err
+	}
+	next := config.Load() // 명령행/환경 변수로 지정한 항목은 저장 값보다 우선
+	res.Applied = a.ApplyConfig(next)
+	if formatPrivacyMasks(s.PrivacyMasks) != formatPrivacyMasks(cur.PrivacyMasks) || s.MaskMode != cur.MaskMode {
+		if err := a.SetPrivacyMasks(s.PrivacyMasks, s.MaskMode); err != nil {
+			return res, err
+		}
+		res.Applied = append(res.Applied, CONFIG_ITEM_PRIVACY)
+	}
+	a.capMu.Lock()
+	if next.CaptureScale != cur.Scale || next.CaptureMaxWidth != cur.MaxWidth || next.CaptureMaxHeight != cur.MaxHeight {
+		a.cfg.CaptureScale, a.cfg.CaptureMaxWidth, a.cfg.CaptureMaxHeight = next.CaptureScale, next.CaptureMaxWidth, next.CaptureMaxHeight
+		res.Deferred = append(res.Deferred, CONFIG_ITEM_SCALE)
+	}
+	if next.CaptureIntervalMs != cur.IntervalMs {
+		a.cfg.CaptureIntervalMs = next.CaptureIntervalMs
+		res.Deferred = append(res.Deferred, CONFIG_ITEM_INTERVAL)
+	}
+	a.capMu.Unlock()
+	if next.CaptureSchedule != cur.Schedule {
+		res.Deferred = append(res.Deferred, CONFIG_ITEM_SCHEDULE)
+	}
+	a.logger.Infow("캡처 설정 변경", "applied", res.Applied, "deferred", res.Deferred)
+	return res, nil
+}
+
+// SetLocale 메서드는 UI/트레이/알림과 번역 오류 문구의 언어를 바꿔 저장합니다 (빈 값이면 OS 로캘).
+func (a *Agent) SetLocale(tag string) error { // 단일 책임: 표시 언어 변경
+	if tag != "" && !i18n.Supported(tag) {
+		return errLocaleUnsupported
+	}
+	a.capMu.Lock()
+	a.cfg.Locale = tag
+	a.capMu.Unlock()
+	i18n.SetLocale(i18n.Resolve(tag))
+	a.PersistSettings(config.LocaleSettings)
+	a.logger.Infow("표시 언어 변경", "setting", tag, "locale", i18n.Locale())
+	return nil
+}
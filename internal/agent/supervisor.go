@@ -0,0 +1,119 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	grpcPkg "google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+const (
+	CONNECTED_EVENT    = "connected"    // 서버 연결(재연결 포함) 완료 이벤트
+	DISCONNECTED_EVENT = "disconnected" // 전송 계층 장애로 연결 끊김 이벤트
+)
+
+// reconnectRequest 구조체는 송신 경로가 감지한 연결 장애입니다. conn 이 현재 연결과 다르면 이미 처리된 요청입니다.
+type reconnectRequest struct {
+	conn   *grpcPkg.ClientConn
+	reason string
+}
+
+// superviseConnection 함수는 ClientConn 상태를 감시하다 전송 장애(TransientFailure/Shutdown)나 재연결 요청이 오면
+// 연결을 새로 맺고 클라이언트와 두 스트림을 다시 구성합니다. 컨텍스트 종료 또는 Close 시 반환합니다.
+func (a *Agent) superviseConnection() { // 단일 책임: 연결 수준 재접속 감시
+	for {
+		a.mu.Lock()
+		conn := a.grpcConn
+		a.mu.Unlock()
+		if conn == nil || a.closing.Load() {
+			return
+		}
+		state := conn.GetState()
+		if state == connectivity.TransientFailure || state == connectivity.Shutdown {
+			if !a.reconnect(state.String()) {
+				return
+			}
+			continue
+		}
+		waitCtx, cancel := context.WithCancel(a.ctx)
+		changed := make(chan struct{})
+		go func() {
+			conn.WaitForStateChange(waitCtx, state)
+			close(changed)
+		}()
+		select {
+		case <-changed:
+		case req := <-a.reconnectCh: // 송신 경로가 죽은 연결을 감지
+			cancel()
+			if req.conn != conn { // 이전 연결에 대한 늦은 요청
+				continue
+			}
+			if !a.reconnect(req.reason) {
+				return
+			}
+		case <-a.ctx.Done():
+			cancel()
+			return
+		}
+		cancel()
+	}
+}
+
+// reconnect 함수는 기존 연결과 스트림을 버리고 백오프로 다시 연결·등록한 뒤 스트림을 엽니다. 종료 중이거나 등록이 거부되면 false 를 반환합니다.
+func (a *Agent) reconnect(reason string) bool { // 단일 책임: 연결 재구성
+	if a.closing.Load() || a.ctx.Err() != nil {
+		return false
+	}
+	lostAt := time.Now()
+	a.logger.Warnf("서버 연결 끊김 (%s) - 재연결 시도", reason)
+	a.conn.reconnecting(reason)
+	a.mu.Lock()
+	old := a.grpcConn
+	a.grpcConn, a.agentClient, a.frameStream, a.eventStream, a.videoStream = nil, nil, nil, nil, nil
+	a.mu.Unlock()
+	if old != nil {
+		_ = old.Close()
+	}
+	if err := a.connectGRPC(); err != nil { // 컨텍스트 종료 전까지 반환하지 않음
+		return false
+	}
+	if err := a.register(); err != nil { // 재연결 중 거부되면 감시 종료
+		a.logger.Errorf("%v", err)
+		a.conn.set(CONN_STATE_FAILED, err.Error())
+		return false
+	}
+	a.startStream(a.ctx)
+	a.conn.set(CONN_STATE_READY, "")
+	// 끊긴 동안에는 이벤트 스트림이 없으므로 끊김 이벤트는 재연결 후 발생 시각으로 보고
+	a.emitEventAt(DISCONNECTED_EVENT, "reason="+reason, lostAt)
+	a.emitEvent(CONNECTED_EVENT, fmt.Sprintf("reconnected=true downtime_ms=%d", time.Since(lostAt).Milliseconds()))
+	go a.replayOffline()
+	go a.retryRecordingUploads()
+	return true
+}
+
+// forceReconnect 함수는 conn 의 전송 상태와 관계없이 감시 고루틴에 연결 재구성을 요청합니다 (서버 장애 조치용).
+func (a *Agent) forceReconnect(conn *grpcPkg.ClientConn, reason string) { // 단일 책임: 강제 재연결 요청
+	select {
+	case a.reconnectCh <- reconnectRequest{conn: conn, reason: reason}:
+	default: // 이미 요청 대기 중
+	}
+}
+
+// requestReconnect 함수는 conn 이 전송 장애 상태이면 감시 고루틴에 연결 재구성을 요청하고 true 를 반환합니다.
+// 재구성 중(conn 이 nil)이면 요청 없이 true 를 반환합니다.
+func (a *Agent) requestReconnect(conn *grpcPkg.ClientConn, reason string) bool { // 단일 책임: 재연결 요청
+	if conn == nil {
+		return true
+	}
+	if s := conn.GetState(); s != connectivity.TransientFailure && s != connectivity.Shutdown {
+		return false
+	}
+	select {
+	case a.reconnectCh <- reconnectRequest{conn: conn, reason: reason}:
+	default: // 이미 요청 대기 중
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package agent
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+	"time"
+
+	"agent/internal/config"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+const (
+	PATTERN_SWEEP_PERIOD_MS  = 4000           // 세로 막대가 화면을 한 번 가로지르는 주기(ms)
+	PATTERN_BOUNCE_SPEED_PCT = 25             // 튕기는 상자의 초당 이동 거리 (프레임 폭/높이 대비 %)
+	PATTERN_TEXT_SCALE       = 3              // 시각/프레임 번호 문구 확대 배율
+	PATTERN_TIME_LAYOUT      = "15:04:05.000" // 테스트 패턴 시각 형식 (ms 단위)
+)
+
+// testPatternCapturer 구조체는 실제 화면 대신 움직이는 합성 패턴을 만드는 캡처러입니다.
+// 정적 그라디언트 배경 위에 이동 막대, 튕기는 상자, 시각/프레임 번호를 그려 변화 감지·인코딩·전송 경로를 모두 거치게 합니다.
+// 움직임은 경과 시간 기준이라 FPS 를 바꿔도 같은 속도로 보입니다.
+type testPatternCapturer struct { // 단일 책임: 테스트 패턴 프레임 생성
+	opts    encodeOptions
+	base    *image.RGBA // 정적 배경
+	started time.Time   // 패턴 시작 시각 (움직임 기준)
+
+	mu    sync.Mutex
+	frame uint64   // 생성한 프레임 번호
+	pool  rgbaPool // 프레임 버퍼 재사용
+}
+
+// newTestPatternCapturer 함수는 설정 해상도의 testPatternCapturer 를 생성합니다.
+func newTestPatternCapturer(cfg *config.Config) *testPatternCapturer { // 단일 책임: 인스턴스 생성
+	w, h := cfg.TestPatternWidth, cfg.TestPatternHeight
+	base := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ { // 대각 그라디언트 + 8칸 격자
+		for x := 0; x < w; x++ {
+			off := base.PixOffset(x, y)
+			base.Pix[off+0] = uint8(x * 255 / w)
+			base.Pix[off+1] = uint8(y * 255 / h)
+			base.Pix[off+2] = uint8((x + y) * 255 / (w + h))
+			base.Pix[off+3] = 255
+			if x%(max(1, w/8)) == 0 || y%(max(1, h/8)) == 0 {
+				base.Pix[off+0], base.Pix[off+1], base.Pix[off+2] = 255, 255, 255
+			}
+		}
+	}
+	return &testPatternCapturer{opts: encodeOptionsFromConfig(cfg), base: base, started: time.Now()}
+}
+
+// Capture 함수는 패턴 한 장을 만들어 인코딩합니다.
+func (p *testPatternCapturer) Capture() ([]byte, error) { // 단일 책임: 생성 + 인코딩
+	img, err := p.grab()
+	if err != nil {
+		return nil, err
+	}
+	defer p.release(img)
+	return p.encode(img)
+}
+
+// grab 함수는 현재 시각 기준 패턴 프레임을 반환합니다.
+func (p *testPatternCapturer) grab() (image.Image, error) { // 단일 책임: 패턴 프레임 생성
+	p.mu.Lock()
+	p.frame++
+	n := p.frame
+	p.mu.Unlock()
+	now := time.Now()
+	elapsed := now.Sub(p.started)
+	b := p.base.Bounds()
+	w, h := b.Dx(), b.Dy()
+	img := p.pool.get(w, h)
+	copy(img.Pix, p.base.Pix)
+
+	barW := max(1, w/20)
+	period := time.Duration(PATTERN_SWEEP_PERIOD_MS) * time.Millisecond
+	barX := int(int64(w+barW) * int64(elapsed%period) / int64(period))
+	draw.Draw(img, image.Rect(barX-barW, 0, barX, h), image.NewUniform(color.RGBA{R: 255, G: 255, B: 255, A: 255}), image.Point{}, draw.Src)
+
+	box := max(1, min(w, h)/6)
+	ms := elapsed.Milliseconds()
+	bx := bounce(ms*int64(w)*PATTERN_BOUNCE_SPEED_PCT/100/1000, w-box)
+	by := bounce(ms*int64(h)*PATTERN_BOUNCE_SPEED_PCT/100/1000*3/4, h-box) // 가로와 다른 속도로 대각 궤적
+	draw.Draw(img, image.Rect(bx, by, bx+box, by+box), image.NewUniform(color.RGBA{R: 230, G: 40, B: 40, A: 255}), image.Point{}, draw.Src)
+
+	text := renderWatermark(fmt.Sprintf("%s  #%d", now.Format(PATTERN_TIME_LAYOUT), n))
+	tb := text.Bounds()
+	dst := image.Rect(0, 0, tb.Dx()*PATTERN_TEXT_SCALE, tb.Dy()*PATTERN_TEXT_SCALE).Add(image.Pt(WATERMARK_MARGIN, WATERMARK_MARGIN)).Intersect(b)
+	xdraw.NearestNeighbor.Scale(img, dst, text, tb, xdraw.Src, nil)
+	return img, nil
+}
+
+// bounce 함수는 0~limit 사이를 왕복하는 위치를 이동 거리 d 로부터 계산합니다.
+func bounce(d int64, limit int) int { // 단일 책임: 왕복 위치 계산
+	if limit <= 0 {
+		return 0
+	}
+	pos := int(d % int64(2*limit))
+	if pos > limit {
+		pos = 2*limit - pos
+	}
+	return pos
+}
+
+// release 함수는 인코딩이 끝난 프레임 버퍼를 재사용 풀에 반환합니다.
+func (p *testPatternCapturer) release(img image.Image) { // 단일 책임: 버퍼 반환
+	if rgba, ok := img.(*image.RGBA); ok {
+		p.pool.put(rgba)
+	}
+}
+
+// options 함수는 캡처러의 인코딩 옵션을 반환합니다.
+func (p *testPatternCapturer) options() encodeOptions { // 단일 책임: 옵션 조회
+	return p.opts
+}
+
+// encode 함수는 캡처러의 인코딩 옵션으로 이미지를 인코딩합니다.
+func (p *testPatternCapturer) encode(img image.Image) ([]byte, error) { // 단일 책임: 선택 인코딩 처리
+	return p.opts.encode(img)
+}
@@ -0,0 +1,128 @@
+package agent
+
+import (
+	"hash/maphash"
+	"image"
+
+	monitorProto "agent/proto"
+)
+
+const (
+	ENCODING_TILES = "tiles" // 타일 해시 비교로 변경된 영역만 전송 (주기적으로 전체 키프레임)
+	TILE_SIZE      = 64      // 변경 감지 타일 한 변 픽셀 수
+)
+
+// tileEncoder 구조체는 직전 프레임의 타일별 해시를 보관하고 변경된 영역을 판별합니다.
+// 상태를 가지므로 캡처 순서대로(captureLoop 고루틴에서) 호출되어야 합니다.
+type tileEncoder struct { // 단일 책임: 타일 단위 변경 영역 판별
+	keyframeInterval int // 키프레임 주기 (프레임 수)
+	seed             maphash.Seed
+	hashes           []uint64 // 직전 프레임 타일 해시 (행 우선)
+	prevW, prevH     int
+	sinceKey         int // 마지막 키프레임 이후 프레임 수
+}
+
+// newTileEncoder 함수는 tileEncoder 생성자입니다.
+func newTileEncoder(keyframeInterval int) *tileEncoder { // 단일 책임: 인스턴스 생성
+	return &tileEncoder{keyframeInterval: keyframeInterval, seed: maphash.MakeSeed()}
+}
+
+// prepare 함수는 직전 프레임 대비 변경된 영역(이미지 좌표)과 키프레임 여부를 반환합니다.
+// 해상도가 바뀌거나 키프레임 주기에 도달하면 전체 화면 1개 영역을 키프레임으로 반환합니다.
+func (t *tileEncoder) prepare(img *image.RGBA) ([]image.Rectangle, bool) { // 단일 책임: 변경 영역 계산
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	cols, rows := (w+TILE_SIZE-1)/TILE_SIZE, (h+TILE_SIZE-1)/TILE_SIZE
+	keyframe := t.prevW != w || t.prevH != h || t.sinceKey >= t.keyframeInterval
+	if len(t.hashes) != cols*rows {
+		t.hashes = make([]uint64, cols*rows)
+	}
+	changed := make([]bool, cols*rows)
+	var hash maphash.Hash
+	hash.SetSeed(t.seed)
+	for ty := 0; ty < rows; ty++ {
+		for tx := 0; tx < cols; tx++ {
+			r := tileRect(b, tx, ty)
+			hash.Reset()
+			for y := r.Min.Y; y < r.Max.Y; y++ {
+				hash.Write(img.Pix[img.PixOffset(r.Min.X, y):img.PixOffset(r.Max.X, y)])
+			}
+			i := ty*cols + tx
+			sum := hash.Sum64()
+			changed[i] = sum != t.hashes[i]
+			t.hashes[i] = sum
+		}
+	}
+	t.prevW, t.prevH = w, h
+	if keyframe {
+		t.sinceKey = 1
+		return []image.Rectangle{b}, true
+	}
+	t.sinceKey++
+	return mergeTiles(changed, cols, rows, b), false
+}
+
+// tileRect 함수는 (tx, ty) 타일의 이미지 좌표 영역을 반환합니다 (가장자리 타일은 이미지 경계로 잘림).
+func tileRect(b image.Rectangle, tx, ty int) image.Rectangle { // 단일 책임: 타일 좌표 변환
+	min := b.Min.Add(image.Pt(tx*TILE_SIZE, ty*TILE_SIZE))
+	return image.Rectangle{Min: min, Max: min.Add(image.Pt(TILE_SIZE, TILE_SIZE))}.Intersect(b)
+}
+
+// mergeTiles 함수는 변경 타일을 사각형으로 묶습니다. 행마다 가로로 연속된 타일을 하나로 합치고,
+// 바로 윗행에 같은 열 범위의 사각형이 있으면 아래로 늘려 영역 수를 줄입니다.
+func mergeTiles(changed []bool, cols, rows int, b image.Rectangle) []image.Rectangle { // 단일 책임: 변경 타일 병합
+	var rects []image.Rectangle
+	open := map[[2]int]int{} // 윗행 열 범위 → rects 인덱스
+	for ty := 0; ty < rows; ty++ {
+		next := map[[2]int]int{}
+		for tx := 0; tx < cols; {
+			if !changed[ty*cols+tx] {
+				tx++
+				continue
+			}
+			x0 := tx
+			for tx < cols && changed[ty*cols+tx] {
+				tx++
+			}
+			span := [2]int{x0, tx}
+			r := tileRect(b, x0, ty).Union(tileRect(b, tx-1, ty))
+			if i, ok := open[span]; ok {
+				rects[i].Max.Y = r.Max.Y
+				next[span] = i
+				continue
+			}
+			next[span] = len(rects)
+			rects = append(rects, r)
+		}
+		open = next
+	}
+	return rects
+}
+
+// frameRegions 함수는 변경 영역을 프레임 좌상단 기준 오프셋의 FrameRegion 목록으로 변환합니다 (data 는 인코딩 단계에서 채움).
+func frameRegions(rects []image.Rectangle, b image.Rectangle) []*monitorProto.FrameRegion { // 단일 책임: 영역 메타 구성
+	regions := make([]*monitorProto.FrameRegion, 0, len(rects))
+	for _, r := range rects {
+		regions = append(regions, &monitorProto.FrameRegion{
+			X:      int32(r.Min.X - b.Min.X),
+			Y:      int32(r.Min.Y - b.Min.Y),
+			Width:  int32(r.Dx()),
+			Height: int32(r.Dy()),
+		})
+	}
+	return regions
+}
+
+// encodeRegions 함수는 각 영역을 잘라 opts 인코딩으로 압축해 data 를 채웁니다.
+func encodeRegions(img *image.RGBA, regions []*monitorProto.FrameRegion, opts encodeOptions) error { // 단일 책임: 영역 인코딩
+	origin := img.Bounds().Min
+	for _, region := range regions {
+		r := image.Rect(int(region.X), int(region.Y), int(region.X+region.Width), int(region.Y+region.Height)).Add(origin)
+		data, err := opts.encode(img.SubImage(r))
+		if err != nil {
+			return err
+		}
+		region.Data = data
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package agent
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"agent/internal/config"
+	"agent/internal/secrets"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// certReloader 구조체는 클라이언트 인증서/키 파일을 보관하고 파일이 바뀌면 다음 핸드셰이크에서 다시 읽습니다.
+// keyPath 가 빈 값이면 개인키는 비밀 저장소(tls_client_key)에서 읽으며 인증서 파일이 바뀔 때 함께 다시 읽습니다.
+type certReloader struct { // 단일 책임: 클라이언트 인증서 자동 재적재
+	certPath, keyPath string
+	mu                sync.Mutex
+	cert              *tls.Certificate
+	certMod, keyMod   time.Time // 마지막으로 읽은 파일 수정 시각
+}
+
+// newCertReloader 함수는 인증서를 즉시 한 번 읽어 유효성을 확인한 certReloader 를 반환합니다.
+func newCertReloader(certPath, keyPath string) (*certReloader, error) { // 단일 책임: 인스턴스 생성
+	r := &certReloader{certPath: certPath, keyPath: keyPath}
+	if _, err := r.current(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// current 함수는 파일 수정 시각이 바뀌었으면 다시 읽고 현재 인증서를 반환합니다.
+// 재적재에 실패하면 (교체 중 일부만 기록된 경우 등) 이전 인증서를 계속 사용합니다.
+func (r *certReloader) current() (*tls.Certificate, error) { // 단일 책임: 인증서 조회/재적재
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	certSt, errC := os.Stat(r.certPath)
+	keyMod, errK := r.keyModTime()
+	if errC == nil && errK == nil && r.cert != nil && certSt.ModTime().Equal(r.certMod) && keyMod.Equal(r.keyMod) {
+		return r.cert, nil
+	}
+	cert, err := r.load()
+	if err != nil {
+		if r.cert != nil {
+			return r.cert, nil
+		}
+		return nil, fmt.Errorf("클라이언트 인증서 로드 실패: %w", err)
+	}
+	r.cert = &cert
+	if errC == nil && errK == nil {
+		r.certMod, r.keyMod = certSt.ModTime(), keyMod
+	}
+	return r.cert, nil
+}
+
+// keyModTime 함수는 개인키 파일 수정 시각을 반환합니다 (비밀 저장소 키는 항상 0 시각).
+func (r *certReloader) keyModTime() (time.Time, error) { // 단일 책임: 키 변경 시각 조회
+	if r.keyPath == "" {
+		return time.Time{}, nil
+	}
+	st, err := os.Stat(r.keyPath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return st.ModTime(), nil
+}
+
+// load 함수는 인증서 파일과 개인키(파일 또는 비밀 저장소)를 읽어 키 쌍을 만듭니다.
+func (r *certReloader) load() (tls.Certificate, error) { // 단일 책임: 키 쌍 읽기
+	if r.keyPath != "" {
+		return tls.LoadX509KeyPair(r.certPath, r.keyPath)
+	}
+	certPEM, err := os.ReadFile(r.certPath)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	keyPEM, err := secrets.Get(secrets.TLS_CLIENT_KEY)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("비밀 저장소 클라이언트 키: %w", err)
+	}
+	return tls.X509KeyPair(certPEM, []byte(keyPEM))
+}
+
+// getClientCertificate 함수는 tls.Config.GetClientCertificate 콜백입니다.
+func (r *certReloader) getClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) { // 단일 책임: 핸드셰이크용 인증서 제공
+	return r.current()
+}
+
+// transportCredentials 함수는 설정에 맞는 gRPC 전송 자격 증명을 만듭니다.
+// TLS 설정(CA 또는 클라이언트 인증서)이 없으면 평문 연결을 사용합니다.
+func transportCredentials(cfg *config.Config) (credentials.TransportCredentials, error) { // 단일 책임: 전송 자격 증명 구성
+	if !cfg.TLSEnabled && cfg.TLSCAFile == "" && cfg.TLSCertFile == "" {
+		return insecure.NewCredentials(), nil
+	}
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: cfg.TLSServerName}
+	if cfg.TLSCAFile != "" { // 사설 CA 로 서버 인증서 검증 (미설정 시 시스템 루트)
+		pem, err := os.ReadFile(cfg.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("CA 파일 읽기 실패: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA 파일에 유효한 인증서 없음: %s", cfg.TLSCAFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+	if cfg.TLSCertFile != "" || cfg.TLSKeyFile != "" { // 상호 TLS: 클라이언트 인증서 제시
+		if cfg.TLSCertFile == "" || (cfg.TLSKeyFile == "" && !cfg.SecretStore) {
+			return nil, fmt.Errorf("클라이언트 인증서와 키 경로는 함께 지정해야 합니다 (키는 비밀 저장소 tls_client_key 로 대신 가능)")
+		}
+		r, err := newCertReloader(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsCfg.GetClientCertificate = r.getClientCertificate
+	}
+	return credentials.NewTLS(tlsCfg), nil
+}
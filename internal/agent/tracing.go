@@ -0,0 +1,144 @@
+package agent
+
+import (
+	"context"
+	"time"
+
+	"agent/internal/config"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	TRACER_NAME               = "agent/pipeline" // 파이프라인 추적기 이름
+	TRACE_SERVICE_NAME        = "agent"          // 추적 리소스 service.name
+	TRACE_SHUTDOWN_TIMEOUT_MS = 3000             // 종료 시 남은 구간 내보내기 대기 상한
+
+	SPAN_FRAME   = "frame"   // 프레임 하나의 캡처부터 송신 완료까지
+	SPAN_CAPTURE = "capture" // 화면 획득 (인코딩까지 하는 캡처러는 인코딩 포함)
+	SPAN_ENCODE  = "encode"  // 인코딩 워커 처리
+	SPAN_QUEUE   = "queue"   // 송신 큐 대기
+	SPAN_SEND    = "send"    // 스트림 송신 (미연결이면 오프라인 스풀 보관)
+)
+
+// frameTrace 구조체는 프레임이 파이프라인 단계를 지난 시각입니다. 단계가 서로 다른 고루틴이라
+// 구간을 그때그때 열지 않고 시각만 모았다가 송신이 끝나면 한 번에 기록합니다.
+type frameTrace struct {
+	grabStart   time.Time     // 화면 획득 시작 (0 이면 모름)
+	grabDur     time.Duration // 화면 획득 소요 시간
+	encodeStart time.Time     // 인코딩 시작 (0 이면 인코딩 단계 없음)
+	encodeDur   time.Duration // 인코딩 소요 시간
+	enqueuedAt  time.Time     // 송신 큐 투입 시각 (큐가 채움)
+}
+
+// pipelineTracer 구조체는 파이프라인 추적기와 종료 함수입니다 (비활성이면 nil).
+type pipelineTracer struct { // 단일 책임: 파이프라인 추적 기록
+	tracer   trace.Tracer
+	provider *sdktrace.TracerProvider
+}
+
+// newPipelineTracer 함수는 TraceEnabled 설정 시 추적기를 만듭니다. 수집기 주소가 있으면 OTLP/gRPC 로 내보내고,
+// TraceSlowMs 이상 걸린 구간은 수집기 유무와 관계없이 로그로 남깁니다. 설정이 꺼져 있거나 실패하면 nil 입니다.
+func newPipelineTracer(cfg *config.Config, logger *zap.SugaredLogger) *pipelineTracer { // 단일 책임: 인스턴스 생성
+	if !cfg.TraceEnabled {
+		return nil
+	}
+	res := resource.NewSchemaless(
+		attribute.String("service.name", TRACE_SERVICE_NAME),
+		attribute.String("service.version", Version),
+	)
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TraceSampleRatio))),
+	}
+	if cfg.TraceOTLPEndpoint != "" {
+		expOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.TraceOTLPEndpoint)}
+		if cfg.TraceOTLPInsecure {
+			expOpts = append(expOpts, otlptracegrpc.WithInsecure())
+		}
+		exp, err := otlptracegrpc.New(context.Background(), expOpts...) // 연결은 첫 내보내기 때 수립
+		if err != nil {
+			logger.Warnf("추적 내보내기 비활성: %v", err)
+		} else {
+			opts = append(opts, sdktrace.WithBatcher(exp))
+		}
+	}
+	if cfg.TraceSlowMs > 0 {
+		opts = append(opts, sdktrace.WithSpanProcessor(&slowSpanLogger{threshold: time.Duration(cfg.TraceSlowMs) * time.Millisecond, logger: logger}))
+	}
+	provider := sdktrace.NewTracerProvider(opts...)
+	logger.Infow("파이프라인 추적 활성", "endpoint", cfg.TraceOTLPEndpoint, "sample_ratio", cfg.TraceSampleRatio, "slow_ms", cfg.TraceSlowMs)
+	return &pipelineTracer{tracer: provider.Tracer(TRACER_NAME), provider: provider}
+}
+
+// recordFrame 함수는 송신을 마친 프레임의 단계별 시각으로 frame 구간과 하위 구간을 기록합니다.
+func (t *pipelineTracer) recordFrame(frame *frameTrace, encoding string, monitorID int32, bytes int, sendStart, sendEnd time.Time) { // 단일 책임: 프레임 구간 기록
+	if t == nil {
+		return
+	}
+	start := frame.enqueuedAt
+	for _, ts := range []time.Time{frame.grabStart, frame.encodeStart} { // all 모드는 획득 시작 시각이 없음
+		if !ts.IsZero() && ts.Before(start) {
+			start = ts
+		}
+	}
+	ctx, root := t.tracer.Start(context.Background(), SPAN_FRAME, trace.WithTimestamp(start), trace.WithAttributes(
+		attribute.String("encoding", encoding),
+		attribute.Int("monitor", int(monitorID)),
+		attribute.Int("bytes", bytes),
+	))
+	if !root.IsRecording() { // 표본에서 빠진 프레임
+		return
+	}
+	child := func(name string, from time.Time, d time.Duration) {
+		if from.IsZero() {
+			return
+		}
+		_, span := t.tracer.Start(ctx, name, trace.WithTimestamp(from))
+		span.End(trace.WithTimestamp(from.Add(d)))
+	}
+	child(SPAN_CAPTURE, frame.grabStart, frame.grabDur)
+	child(SPAN_ENCODE, frame.encodeStart, frame.encodeDur)
+	child(SPAN_QUEUE, frame.enqueuedAt, sendStart.Sub(frame.enqueuedAt))
+	child(SPAN_SEND, sendStart, sendEnd.Sub(sendStart))
+	root.End(trace.WithTimestamp(sendEnd))
+}
+
+// shutdown 함수는 남은 구간을 내보내고 추적기를 닫습니다.
+func (t *pipelineTracer) shutdown() error { // 단일 책임: 추적기 종료
+	if t == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), TRACE_SHUTDOWN_TIMEOUT_MS*time.Millisecond)
+	defer cancel()
+	return t.provider.Shutdown(ctx)
+}
+
+// slowSpanLogger 구조체는 기준 시간 이상 걸린 구간을 로그로 남기는 SpanProcessor 입니다 (수집기 없이 현장 진단용).
+type slowSpanLogger struct { // 단일 책임: 느린 구간 로그
+	threshold time.Duration
+	logger    *zap.SugaredLogger
+}
+
+// OnStart 함수는 시작 시 할 일이 없습니다.
+func (s *slowSpanLogger) OnStart(context.Context, sdktrace.ReadWriteSpan) {} // 단일 책임: 시작 처리 (무동작)
+
+// OnEnd 함수는 프레임 하위 구간이 기준 시간 이상이면 로그로 남깁니다 (frame 전체 구간은 하위 구간 합이라 제외).
+func (s *slowSpanLogger) OnEnd(span sdktrace.ReadOnlySpan) { // 단일 책임: 느린 구간 판정
+	d := span.EndTime().Sub(span.StartTime())
+	if span.Name() == SPAN_FRAME || d < s.threshold {
+		return
+	}
+	s.logger.Infow("느린 파이프라인 구간", "span", span.Name(), "ms", d.Milliseconds(), "trace_id", span.SpanContext().TraceID().String())
+}
+
+// Shutdown 함수는 정리할 자원이 없습니다.
+func (s *slowSpanLogger) Shutdown(context.Context) error { return nil } // 단일 책임: 종료 (무동작)
+
+// ForceFlush 함수는 보관 중인 구간이 없습니다.
+func (s *slowSpanLogger) ForceFlush(context.Context) error { return nil } // 단일 책임: 비우기 (무동작)
@@ -0,0 +1,121 @@
+package agent
+
+import (
+	"encoding/base64"
+	"image"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+const (
+	UI_PREVIEW_JPEG_QUALITY = 70                        // UI 미리보기 JPEG 품질
+	UI_PREVIEW_DATA_PREFIX  = "data:image/jpeg;base64," // UI 미리보기 data URL 접두사
+)
+
+// UIPreview 구조체는 UI 에 보여줄 송신 화면 축소본입니다 (가림 영역/금지 앱/워터마크 적용 후).
+type UIPreview struct {
+	DataURL   string `json:"dataUrl"`   // JPEG data URL (img src 에 그대로 사용)
+	Width     int    `json:"width"`     // 축소본 폭
+	Height    int    `json:"height"`    // 축소본 높이
+	MonitorID int32  `json:"monitorId"` // all 모드 모니터 번호 (그 밖의 모드는 0)
+	Timestamp int64  `json:"timestamp"` // 캡처 시각 (unix ms)
+}
+
+// uiPreview 구조체는 UI 미리보기 생성 상태입니다. UI 가 켠 동안에만 UIPreviewMs 간격으로 축소본을 만듭니다.
+type uiPreview struct { // 단일 책임: UI 미리보기 생성
+	enabled  atomic.Bool
+	encoding atomic.Bool // 이전 축소본 인코딩 중 (겹치면 이번 차례 생략)
+	mu       sync.Mutex
+	last     map[int32]time.Time // 모니터별 마지막 생성 시각
+	latest   UIPreview
+	listener func(UIPreview)
+}
+
+// SetPreviewListener 함수는 UI 미리보기가 새로 만들어질 때 호출될 함수를 등록합니다.
+func (a *Agent) SetPreviewListener(fn func(UIPreview)) { // 단일 책임: 리스너 등록
+	a.uiPreview.mu.Lock()
+	a.uiPreview.listener = fn
+	a.uiPreview.mu.Unlock()
+}
+
+// SetPreviewEnabled 메서드는 UI 미리보기 생성을 켜고 끕니다 (미리보기 화면이 보일 때만 켜서 축소/인코딩 비용을 아낌).
+func (a *Agent) SetPreviewEnabled(on bool) { // 단일 책임: 미리보기 사용 전환
+	p := &a.uiPreview
+	p.mu.Lock()
+	p.last = nil
+	if !on {
+		p.latest = UIPreview{}
+	}
+	p.mu.Unlock()
+	p.enabled.Store(on)
+}
+
+// Preview 메서드는 마지막 UI 미리보기를 반환합니다 (꺼져 있거나 아직 없으면 DataURL 이 빈 값).
+func (a *Agent) Preview() UIPreview { // 단일 책임: 미리보기 조회
+	a.uiPreview.mu.Lock()
+	defer a.uiPreview.mu.Unlock()
+	return a.uiPreview.latest
+}
+
+// offerPreview 함수는 송신할 화면 img 로 UI 미리보기 차례이면 축소본을 만듭니다. img 는 호출 뒤 재사용되므로
+// 축소는 여기서 하고, JPEG 인코딩과 전달은 캡처 루프를 막지 않도록 별도 고루틴에서 합니다.
+func (a *Agent) offerPreview(img image.Image, now time.Time, monitorID int32) { // 단일 책임: 미리보기 차례 판단
+	p := &a.uiPreview
+	if !p.enabled.Load() {
+		return
+	}
+	p.mu.Lock()
+	due := now.Sub(p.last[monitorID]) >= time.Duration(a.cfg.UIPreviewMs)*time.Millisecond
+	if due && p.encoding.CompareAndSwap(false, true) {
+		if p.last == nil {
+			p.last = map[int32]time.Time{}
+		}
+		p.last[monitorID] = now
+	} else {
+		due = false
+	}
+	p.mu.Unlock()
+	if !due {
+		return
+	}
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w > a.cfg.UIPreviewWidth {
+		w, h = a.cfg.UIPreviewWidth, max(1, h*a.cfg.UIPreviewWidth/w)
+	}
+	small := image.NewRGBA(image.Rect(0, 0, w, h))
+	xdraw.ApproxBiLinear.Scale(small, small.Bounds(), img, b, xdraw.Src, nil)
+	go a.publishPreview(small, now, monitorID)
+}
+
+// publishPreview 함수는 축소본을 JPEG data URL 로 만들어 보관하고 리스너에 전달합니다.
+func (a *Agent) publishPreview(small *image.RGBA, at time.Time, monitorID int32) { // 단일 책임: 미리보기 인코딩/전달
+	p := &a.uiPreview
+	defer p.encoding.Store(false)
+	data, err := encodeJPEG(small, UI_PREVIEW_JPEG_QUALITY)
+	if err != nil {
+		a.logger.Debugf("UI 미리보기 인코딩 실패: %v", err)
+		return
+	}
+	pv := UIPreview{
+		DataURL:   UI_PREVIEW_DATA_PREFIX + base64.StdEncoding.EncodeToString(data),
+		Width:     small.Bounds().Dx(),
+		Height:    small.Bounds().Dy(),
+		MonitorID: monitorID,
+		Timestamp: at.UnixMilli(),
+	}
+	p.mu.Lock()
+	if !p.enabled.Load() { // 인코딩 중 꺼짐
+		p.mu.Unlock()
+		return
+	}
+	p.latest = pv
+	fn := p.listener
+	p.mu.Unlock()
+	if fn != nil {
+		fn(pv)
+	}
+}
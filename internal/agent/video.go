@@ -0,0 +1,277 @@
+package agent
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	monitorProto "agent/proto"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ENCODING_H264          = "h264"   // ffmpeg 기반 H.264 영상 스트리밍 (프레임 단위 인코딩 대신 사용)
+	VIDEO_CODEC            = "h264"   // VideoChunk.codec 값
+	VIDEO_CHUNK_SIZE       = 64 << 10 // 인코더 출력 1회 읽기 최대 크기 (청크 상한)
+	VIDEO_PROBE_TIMEOUT_MS = 5000     // 인코더 사용 가능 여부 확인 타임아웃
+	VIDEO_STOP_TIMEOUT_MS  = 2000     // 인코더 프로세스 종료 대기 시간
+	VIDEO_RETRY_MS         = 5000     // 인코더 시작 실패 후 재시도 간격 (그동안 프레임은 버림)
+)
+
+// videoEncoderArgs 변수는 인코더 별칭별 ffmpeg 코덱과 저지연 옵션입니다.
+var videoEncoderArgs = map[string][]string{
+	"nvenc":        {"-c:v", "h264_nvenc", "-preset", "p1", "-tune", "ll", "-zerolatency", "1"},
+	"qsv":          {"-c:v", "h264_qsv", "-preset", "veryfast", "-low_power", "1"},
+	"videotoolbox": {"-c:v", "h264_videotoolbox", "-realtime", "1"},
+	"x264":         {"-c:v", "libx264", "-preset", "ultrafast", "-tune", "zerolatency"},
+}
+
+// videoEncoderCandidates 함수는 auto 선택 시 OS 별로 시도할 인코더 순서(하드웨어 우선, x264 마지막)를 반환합니다.
+func videoEncoderCandidates() []string { // 단일 책임: 후보 순서 결정
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"videotoolbox", "x264"}
+	case "windows", "linux":
+		return []string{"nvenc", "qsv", "x264"}
+	}
+	return []string{"x264"}
+}
+
+// videoProbeResult 구조체는 인코더 선택 결과입니다.
+type videoProbeResult struct {
+	name string
+	err  error
+}
+
+// videoProbeCache 변수는 ffmpeg 경로/설정별 인코더 선택 결과입니다 (장치 확인은 실패 포함 프로세스당 1회).
+var videoProbeCache = struct {
+	mu  sync.Mutex
+	res map[string]videoProbeResult
+}{res: map[string]videoProbeResult{}}
+
+// resolveVideoEncoder 함수는 설정 인코더를 확인합니다. auto 이면 후보를 순서대로 1프레임 인코딩해 보고 처음 성공한 것을 고릅니다.
+func resolveVideoEncoder(ffmpeg, pref string) (string, error) { // 단일 책임: 인코더 선택
+	if _, err := exec.LookPath(ffmpeg); err != nil {
+		return "", fmt.Errorf("ffmpeg 없음 (%s): %w", ffmpeg, err)
+	}
+	videoProbeCache.mu.Lock()
+	defer videoProbeCache.mu.Unlock()
+	key := ffmpeg + "|" + pref
+	if r, ok := videoProbeCache.res[key]; ok {
+		return r.name, r.err
+	}
+	candidates := videoEncoderCandidates()
+	if pref != "auto" {
+		candidates = []string{pref}
+	}
+	var errs []error
+	for _, name := range candidates {
+		if err := probeVideoEncoder(ffmpeg, name); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		videoProbeCache.res[key] = videoProbeResult{name: name}
+		return name, nil
+	}
+	err := fmt.Errorf("사용 가능한 H.264 인코더 없음: %w", errors.Join(errs...))
+	videoProbeCache.res[key] = videoProbeResult{err: err}
+	return "", err
+}
+
+// probeVideoEncoder 함수는 합성 1프레임을 인코딩해 장치/드라이버가 실제로 동작하는지 확인합니다.
+func probeVideoEncoder(ffmpeg, name string) error { // 단일 책임: 인코더 동작 확인
+	args, ok := videoEncoderArgs[name]
+	if !ok {
+		return fmt.Errorf("알 수 없는 인코더")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(VIDEO_PROBE_TIMEOUT_MS)*time.Millisecond)
+	defer cancel()
+	cmdArgs := append([]string{"-hide_banner", "-loglevel", "error", "-f", "lavfi", "-i", "color=c=black:s=256x256:r=1", "-frames:v", "1"}, args...)
+	cmdArgs = append(cmdArgs, "-f", "null", "-")
+	cmd := exec.CommandContext(ctx, ffmpeg, cmdArgs...)
+	hideProcessWindow(cmd)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%v %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// videoEncoder 구조체는 RGBA 원본 프레임을 ffmpeg 표준 입력으로 보내고 H.264 출력을 청크로 전달하는 인코더 세션입니다.
+type videoEncoder struct { // 단일 책임: 인코더 프로세스 세션
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	sessionID string
+	encoder   string
+	width     int
+	height    int
+	fps       int
+	monitorID int32         // 캡처 대상 모니터 (청크에 그대로 표시)
+	done      chan struct{} // 출력 읽기 고루틴 종료
+	failed    atomic.Bool   // 프로세스/전송 오류 (다음 프레임에서 세션 재시작)
+}
+
+// startVideoEncoder 함수는 w x h, fps 입력을 받는 ffmpeg 인코더를 시작합니다. 출력은 pumpVideo 가 순서대로 전송하며
+// 전송에 실패하면 세션은 실패로 표시됩니다 (수신 측 디코더 상태가 깨졌으므로 새 세션의 키프레임부터 다시 보냄).
+func (a *Agent) startVideoEncoder(w, h, fps int, monitorID int32) (*videoEncoder, error) { // 단일 책임: 인코더 세션 시작
+	name, err := resolveVideoEncoder(a.cfg.FFmpegPath, a.cfg.H264Encoder)
+	if err != nil {
+		return nil, err
+	}
+	gop := fps * a.cfg.H264KeyframeSec
+	if gop < 1 {
+		gop = 1
+	}
+	bitrate := strconv.Itoa(a.cfg.H264BitrateKbps) + "k"
+	args := []string{"-hide_banner", "-loglevel", "error",
+		"-f", "rawvideo", "-pix_fmt", "rgba", "-s", fmt.Sprintf("%dx%d", w, h), "-r", strconv.Itoa(fps), "-i", "pipe:0",
+		"-an", "-vf", "crop=trunc(iw/2)*2:trunc(ih/2)*2", "-pix_fmt", "yuv420p"} // yuv420p 는 짝수 해상도 필요
+	args = append(args, videoEncoderArgs[name]...)
+	args = append(args, "-b:v", bitrate, "-maxrate", bitrate, "-bufsize", bitrate, "-g", strconv.Itoa(gop), "-bf", "0",
+		"-flush_packets", "1", "-f", "h264", "pipe:1")
+	cmd := exec.Command(a.cfg.FFmpegPath, args...)
+	hideProcessWindow(cmd)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	v := &videoEncoder{cmd: cmd, stdin: stdin, sessionID: uuid.New().String(), encoder: name, width: w, height: h, fps: fps, monitorID: monitorID, done: make(chan struct{})}
+	go func() { // ffmpeg 오류 출력은 경고 로그로
+		sc := bufio.NewScanner(stderr)
+		for sc.Scan() {
+			a.logger.Warnf("ffmpeg(%s): %s", name, sc.Text())
+		}
+	}()
+	go a.pumpVideo(v, stdout)
+	a.logger.Infow("H.264 인코더 시작", "encoder", name, "session_id", v.sessionID, "width", w, "height", h, "fps", fps, "bitrate_kbps", a.cfg.H264BitrateKbps)
+	return v, nil
+}
+
+// pumpVideo 함수는 인코더 출력을 읽어 VideoChunk 로 전송합니다. 출력이 끝나거나 전송에 실패하면 세션을 실패로 표시합니다.
+func (a *Agent) pumpVideo(v *videoEncoder, stdout io.Reader) { // 단일 책임: 인코더 출력 전송
+	defer close(v.done)
+	buf := make([]byte, VIDEO_CHUNK_SIZE)
+	var seq int64
+	for {
+		n, err := stdout.Read(buf)
+		if n > 0 && !v.failed.Load() {
+			chunk := &monitorProto.VideoChunk{
+				AgentId:   a.agentID,
+				SessionId: v.sessionID,
+				Seq:       seq,
+				Data:      append([]byte(nil), buf[:n]...),
+				Codec:     VIDEO_CODEC,
+				Encoder:   v.encoder,
+				Width:     int32(v.width &^ 1),
+				Height:    int32(v.height &^ 1),
+				Fps:       int32(v.fps),
+				Timestamp: time.Now().UnixMilli(),
+				MonitorId: v.monitorID,
+			}
+			seq++
+			if serr := a.sendVideoChunk(chunk); serr != nil {
+				v.failed.Store(true) // 나머지 출력은 버리고 다음 프레임에서 새 세션 시작
+			}
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				a.logger.Warnf("H.264 인코더 출력 읽기 실패: %v", err)
+			}
+			v.failed.Store(true)
+			return
+		}
+	}
+}
+
+// writeFrame 함수는 원본 프레임을 인코더 입력으로 보냅니다. 인코더가 밀리면 캡처 루프가 대기합니다 (역압).
+func (v *videoEncoder) writeFrame(img *image.RGBA) error { // 단일 책임: 원본 프레임 입력
+	b := img.Bounds()
+	rowLen := b.Dx() * 4
+	if img.Stride == rowLen && b.Min == (image.Point{}) {
+		_, err := v.stdin.Write(img.Pix[:rowLen*b.Dy()])
+		return err
+	}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		off := img.PixOffset(b.Min.X, y)
+		if _, err := v.stdin.Write(img.Pix[off : off+rowLen]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// close 함수는 입력을 닫아 남은 출력을 내보내게 한 뒤 프로세스를 정리합니다. 제한 시간이 지나면 강제 종료합니다.
+func (v *videoEncoder) close() { // 단일 책임: 세션 종료
+	_ = v.stdin.Close()
+	select {
+	case <-v.done:
+	case <-time.After(time.Duration(VIDEO_STOP_TIMEOUT_MS) * time.Millisecond):
+		_ = v.cmd.Process.Kill()
+		<-v.done
+	}
+	_ = v.cmd.Wait()
+}
+
+// captureVideo 함수는 원본 프레임을 H.264 인코더 세션에 입력합니다. 해상도/FPS/모니터가 바뀌었거나 세션이 실패했으면
+// 세션을 새로 시작합니다 (새 세션은 키프레임부터 시작). 서버 연결이 없으면 세션을 닫고 프레임을 버립니다.
+func (a *Agent) captureVideo(img image.Image, st *captureState, pct int, monitorID int32) error { // 단일 책임: 영상 인코더 입력
+	a.mu.Lock()
+	connected := a.agentClient != nil
+	a.mu.Unlock()
+	if !connected { // 영상은 실시간 전용: 오프라인 보관 없음
+		st.closeVideo()
+		return nil
+	}
+	src := toRGBA(st.output.apply(img, pct))
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	if v := st.video; v != nil && (v.failed.Load() || v.width != w || v.height != h || v.fps != st.fps || v.monitorID != monitorID) {
+		st.closeVideo()
+	}
+	if st.video == nil {
+		if time.Now().Before(st.videoRetryAt) { // 직전 시작 실패: 재시도 간격 동안 프레임 버림
+			return nil
+		}
+		v, err := a.startVideoEncoder(w, h, st.fps, monitorID)
+		if err != nil {
+			st.videoRetryAt = time.Now().Add(time.Duration(VIDEO_RETRY_MS) * time.Millisecond)
+			return err
+		}
+		st.video = v
+	}
+	if err := st.video.writeFrame(src); err != nil {
+		st.video.failed.Store(true)
+		return fmt.Errorf("H.264 인코더 입력 실패: %w", err)
+	}
+	a.videoFrames.Add(1)
+	a.stats.noteDelivered(-1, time.Now())
+	return nil
+}
+
+// closeVideo 함수는 진행 중 인코더 세션을 닫습니다.
+func (st *captureState) closeVideo() { // 단일 책임: 세션 정리
+	if st.video != nil {
+		st.video.close()
+		st.video = nil
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package agent
+
+import "os/exec"
+
+// hideProcessWindow 함수는 콘솔 창 개념이 없는 OS 에서 아무것도 하지 않습니다.
+func hideProcessWindow(cmd *exec.Cmd) {} // 단일 책임: 콘솔 창 숨김 (해당 없음)
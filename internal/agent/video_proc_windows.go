@@ -0,0 +1,17 @@
+//go:build windows
+
+package agent
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+const (
+	CREATE_NO_WINDOW = 0x08000000 // 콘솔 창 없이 자식 프로세스 생성
+)
+
+// hideProcessWindow 함수는 GUI 앱에서 ffmpeg 실행 시 콘솔 창이 뜨지 않게 합니다.
+func hideProcessWindow(cmd *exec.Cmd) { // 단일 책임: 콘솔 창 숨김
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true, CreationFlags: CREATE_NO_WINDOW}
+}
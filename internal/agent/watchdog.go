@@ -0,0 +1,174 @@
+package agent
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+const (
+	LOOP_PANIC_EVENT      = "loop_panic"      // 캡처/송신/스트림 고루틴 panic 복구 후 재시작 (loop, panic)
+	CAPTURE_STALLED_EVENT = "capture_stalled" // 캡처 루프가 멈춰 새 루프로 교체 (idle_ms, limit_ms)
+
+	LOOP_CAPTURE      = "capture"       // captureLoop
+	LOOP_FRAME_SENDER = "frame_sender"  // runFrameSender
+	LOOP_CONTROL      = "control"       // runControl
+	LOOP_ACKED_EVENTS = "acked_events"  // runAckedEvents
+	LOOP_HEARTBEAT    = "heartbeat"     // runHeartbeat
+	LOOP_REMOTE_CFG   = "remote_config" // runRemoteConfig
+
+	LOOP_RESTART_BASE  = 500 * time.Millisecond // panic/멈춤 후 첫 재시작 대기
+	LOOP_RESTART_MAX   = 30 * time.Second       // 재시작 대기 상한 (반복 panic 시 로그/이벤트 폭주 방지)
+	LOOP_STABLE_AFTER  = time.Minute            // 이만큼 정상 동작한 뒤의 panic/멈춤은 대기를 처음 값부터
+	WATCHDOG_MIN_STALL = 5 * time.Second        // 프레임 간격이 짧아도 이보다 짧은 정지는 멈춤으로 보지 않음
+	WATCHDOG_CHECK     = time.Second            // 멈춤 확인 주기
+)
+
+// loopPanic 구조체는 복구한 panic 값과 당시 고루틴 스택입니다.
+type loopPanic struct {
+	value any
+	stack []byte
+}
+
+// catchPanic 함수는 fn 을 실행하고 panic 이 나면 복구해 값과 스택을 반환합니다 (정상 반환이면 nil).
+func catchPanic(fn func()) (p *loopPanic) { // 단일 책임: panic 복구
+	defer func() {
+		if r := recover(); r != nil {
+			p = &loopPanic{value: r, stack: debug.Stack()}
+		}
+	}()
+	fn()
+	return nil
+}
+
+// newLoopBackoff 함수는 고루틴 재시작용 backoff 를 생성합니다 (연결 재시도 설정과 무관한 고정 간격).
+func newLoopBackoff() *backoff { // 단일 책임: 인스턴스 생성
+	return &backoff{base: LOOP_RESTART_BASE, max: LOOP_RESTART_MAX}
+}
+
+// reportPanic 함수는 복구한 panic 을 스택과 함께 기록하고 loop_panic 이벤트를 발행합니다.
+func (a *Agent) reportPanic(loop string, p *loopPanic) { // 단일 책임: panic 보고
+	a.logger.Errorw("고루틴 panic 복구 - 재시작", "loop", loop, "panic", fmt.Sprint(p.value), "stack", string(p.stack))
+	a.emitEvent(LOOP_PANIC_EVENT, fmt.Sprintf("loop=%s panic=%v", loop, p.value))
+}
+
+// guardLoop 함수는 fn 을 실행하고 panic 으로 끝나면 보고 후 백오프로 다시 실행합니다.
+// fn 이 정상 반환하거나 컨텍스트가 끝나면 반환합니다.
+func (a *Agent) guardLoop(loop string, fn func()) { // 단일 책임: 고루틴 panic 재시작
+	bo := newLoopBackoff()
+	for {
+		started := time.Now()
+		p := catchPanic(fn)
+		if p == nil {
+			return
+		}
+		a.reportPanic(loop, p)
+		if time.Since(started) > LOOP_STABLE_AFTER {
+			bo.reset()
+		}
+		if err := bo.wait(a.ctx); err != nil {
+			return
+		}
+	}
+}
+
+// captureWatchdog 구조체는 캡처 루프 진행 표시입니다. 루프가 매 반복 beat 로 남기고 superviseCapture 가 확인합니다.
+type captureWatchdog struct { // 단일 책임: 캡처 진행 기록
+	mu       sync.Mutex
+	at       time.Time     // 마지막 진행 시각 (단조 시계 포함, 절전 중 경과는 멈춤으로 보지 않음)
+	interval time.Duration // 다음 진행까지 예상 간격
+	parked   bool          // 화면 잠금 해제 대기처럼 진행이 없어도 정상인 상태
+}
+
+// beat 함수는 진행을 기록하고 다음 진행까지 예상 간격을 남깁니다.
+func (w *captureWatchdog) beat(interval time.Duration) { // 단일 책임: 진행 기록
+	w.mu.Lock()
+	w.at, w.interval, w.parked = time.Now(), interval, false
+	w.mu.Unlock()
+}
+
+// park 함수는 다음 beat 까지 멈춤 검사를 쉬게 합니다.
+func (w *captureWatchdog) park() { // 단일 책임: 정상 대기 표시
+	w.mu.Lock()
+	w.parked = true
+	w.mu.Unlock()
+}
+
+// stalled 함수는 마지막 진행 후 경과와 멈춤 기준(예상 간격 × frames, 최소 WATCHDOG_MIN_STALL), 멈춤 여부를 반환합니다.
+func (w *captureWatchdog) stalled(frames int) (time.Duration, time.Duration, bool) { // 단일 책임: 멈춤 판정
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if frames <= 0 || w.parked || w.at.IsZero() {
+		return 0, 0, false
+	}
+	limit := max(time.Duration(frames)*w.interval, WATCHDOG_MIN_STALL)
+	idle := time.Since(w.at)
+	return idle, limit, idle > limit
+}
+
+// superviseCapture 함수는 stopCh 가 닫힐 때까지 captureLoop 를 실행하고, 마지막 루프가 끝나면 done 을 닫습니다.
+// panic 으로 끝나거나 CAPTURE_WATCHDOG_FRAMES 기준으로 멈추면 백오프 후 새 루프를 시작합니다. 멈춘 루프는 전용 중지 채널을
+// 닫아 두고 버리므로 막힌 호출에서 풀려나면 잡은 프레임을 버리고 스스로 종료합니다 (새 루프와 겹쳐 제출하지 않음).
+func (a *Agent) superviseCapture(stopCh, done chan struct{}) { // 단일 책임: 캡처 루프 감시/재시작
+	defer close(done)
+	bo := newLoopBackoff()
+	check := time.NewTicker(WATCHDOG_CHECK)
+	defer check.Stop()
+	for {
+		runStop := make(chan struct{})
+		result := make(chan *loopPanic, 1)
+		started := time.Now()
+		a.watchdog.beat(0)
+		go func() {
+			result <- catchPanic(func() { a.captureLoop(runStop) })
+		}()
+		if !a.superviseCaptureRun(stopCh, runStop, result, check.C) {
+			return
+		}
+		if time.Since(started) > LOOP_STABLE_AFTER {
+			bo.reset()
+		}
+		t := time.NewTimer(bo.next())
+		select {
+		case <-t.C:
+		case <-stopCh:
+			t.Stop()
+			return
+		case <-a.ctx.Done():
+			t.Stop()
+			return
+		}
+	}
+}
+
+// superviseCaptureRun 함수는 captureLoop 한 번의 실행을 지켜보다가 panic 또는 멈춤으로 다시 시작해야 하면 true 를 반환합니다.
+// 중지 요청이나 컨텍스트 종료로 끝날 때는 루프가 인코딩 중 프레임까지 전달하고 반환할 때까지 기다립니다.
+func (a *Agent) superviseCaptureRun(stopCh, runStop chan struct{}, result chan *loopPanic, tick <-chan time.Time) bool { // 단일 책임: 캡처 루프 1회 감시
+	for {
+		select {
+		case p := <-result:
+			if p == nil { // 중지 또는 컨텍스트 종료로 정상 반환
+				return false
+			}
+			a.reportPanic(LOOP_CAPTURE, p)
+			return true
+		case <-tick:
+			idle, limit, stalled := a.watchdog.stalled(a.cfg.WatchdogFrames)
+			if !stalled {
+				continue
+			}
+			close(runStop)
+			a.logger.Errorw("캡처 루프 멈춤 감지 - 새 루프로 교체", "idle_ms", idle.Milliseconds(), "limit_ms", limit.Milliseconds())
+			a.emitEvent(CAPTURE_STALLED_EVENT, fmt.Sprintf("idle_ms=%d limit_ms=%d", idle.Milliseconds(), limit.Milliseconds()))
+			return true
+		case <-stopCh:
+			close(runStop)
+			<-result
+			return false
+		case <-a.ctx.Done():
+			<-result
+			return false
+		}
+	}
+}
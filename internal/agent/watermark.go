@@ -0,0 +1,87 @@
+package agent
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+	"time"
+
+	"agent/internal/config"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	WATERMARK_MARGIN      = 8                         // 프레임 가장자리와 워터마크 사이 간격(px)
+	WATERMARK_PADDING     = 4                         // 워터마크 배경 상자 안쪽 여백(px)
+	WATERMARK_TIME_LAYOUT = "2006-01-02 15:04:05 MST" // 워터마크 시각 형식 (초 단위, 로컬 시간대)
+)
+
+// watermark 구조체는 프레임에 시각/에이전트 ID/호스트명 오버레이를 그립니다.
+// 문구는 초 단위로만 바뀌므로 마지막으로 그린 오버레이를 재사용합니다.
+type watermark struct { // 단일 책임: 감사용 워터마크 삽입
+	position string         // top-left | top-right | bottom-left | bottom-right
+	mask     *image.Uniform // 불투명도 마스크
+	identity string         // "agentID@hostname"
+
+	mu      sync.Mutex
+	text    string      // overlay 에 그린 문구
+	overlay *image.RGBA // 검은 배경 + 흰 글자 오버레이
+}
+
+// newWatermark 함수는 설정에서 watermark 를 생성합니다. 비활성이면 nil 을 반환합니다.
+func newWatermark(cfg *config.Config, agentID, hostname string) *watermark { // 단일 책임: 인스턴스 생성
+	if !cfg.Watermark {
+		return nil
+	}
+	return &watermark{
+		position: cfg.WatermarkPosition,
+		mask:     image.NewUniform(color.Alpha{A: uint8(cfg.WatermarkOpacity * 255 / 100)}),
+		identity: agentID + "@" + hostname,
+	}
+}
+
+// apply 함수는 at 시각 워터마크를 img 의 설정 위치에 합성합니다 (all 모드에서는 모니터 번호를 덧붙임).
+func (w *watermark) apply(img image.Image, at time.Time, monitorID int32) { // 단일 책임: 워터마크 합성
+	if w == nil {
+		return
+	}
+	dst, ok := img.(draw.Image)
+	if !ok {
+		return
+	}
+	text := at.Format(WATERMARK_TIME_LAYOUT) + "  " + w.identity
+	if monitorID != MONITOR_ID_NONE {
+		text += fmt.Sprintf("  #%d", monitorID)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if text != w.text {
+		w.text, w.overlay = text, renderWatermark(text)
+	}
+	b, o := dst.Bounds(), w.overlay.Bounds().Size()
+	pos := image.Pt(b.Min.X+WATERMARK_MARGIN, b.Min.Y+WATERMARK_MARGIN)
+	if w.position == "top-right" || w.position == "bottom-right" {
+		pos.X = b.Max.X - WATERMARK_MARGIN - o.X
+	}
+	if w.position == "bottom-left" || w.position == "bottom-right" {
+		pos.Y = b.Max.Y - WATERMARK_MARGIN - o.Y
+	}
+	draw.DrawMask(dst, image.Rectangle{Min: pos, Max: pos.Add(o)}.Intersect(b), w.overlay, image.Point{}, w.mask, image.Point{}, draw.Over)
+}
+
+// renderWatermark 함수는 문구를 검은 배경 상자 위 흰 글자(basicfont 7x13)로 그립니다.
+func renderWatermark(text string) *image.RGBA { // 단일 책임: 오버레이 렌더링
+	face := basicfont.Face7x13
+	width := font.MeasureString(face, text).Ceil()
+	height := face.Metrics().Height.Ceil()
+	img := image.NewRGBA(image.Rect(0, 0, width+2*WATERMARK_PADDING, height+2*WATERMARK_PADDING))
+	draw.Draw(img, img.Bounds(), image.Black, image.Point{}, draw.Src)
+	d := font.Drawer{Dst: img, Src: image.White, Face: face, Dot: fixed.P(WATERMARK_PADDING, WATERMARK_PADDING+face.Metrics().Ascent.Ceil())}
+	d.DrawString(text)
+	return img
+}
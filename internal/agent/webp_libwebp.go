@@ -0,0 +1,41 @@
+//go:build libwebp && cgo
+
+package agent
+
+/*
+#cgo LDFLAGS: -lwebp
+#include <stdlib.h>
+#include <webp/encode.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"image"
+	"unsafe"
+)
+
+// libwebpAvailable 상수는 libwebp 인코더(손실/무손실) 포함 여부입니다.
+const libwebpAvailable = true
+
+// encodeWebPLib 함수는 libwebp 로 이미지를 WebP 바이트로 인코딩합니다.
+func encodeWebPLib(img image.Image, quality int, lossless bool) ([]byte, error) { // 단일 책임: libwebp 인코딩
+	rgba := toRGBA(img)
+	b := rgba.Bounds()
+	if b.Empty() {
+		return nil, errors.New("빈 이미지")
+	}
+	pix := (*C.uint8_t)(unsafe.Pointer(&rgba.Pix[rgba.PixOffset(b.Min.X, b.Min.Y)]))
+	var out *C.uint8_t
+	var size C.size_t
+	if lossless {
+		size = C.WebPEncodeLosslessRGBA(pix, C.int(b.Dx()), C.int(b.Dy()), C.int(rgba.Stride), &out)
+	} else {
+		size = C.WebPEncodeRGBA(pix, C.int(b.Dx()), C.int(b.Dy()), C.int(rgba.Stride), C.float(quality), &out)
+	}
+	if size == 0 || out == nil {
+		return nil, errors.New("libwebp 인코딩 실패")
+	}
+	defer C.WebPFree(unsafe.Pointer(out))
+	return C.GoBytes(unsafe.Pointer(out), C.int(size)), nil
+}
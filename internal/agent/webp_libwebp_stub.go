@@ -0,0 +1,16 @@
+//go:build !libwebp || !cgo
+
+package agent
+
+import (
+	"errors"
+	"image"
+)
+
+// libwebpAvailable 상수는 libwebp 인코더 포함 여부입니다 (libwebp 빌드 태그 + cgo 필요).
+const libwebpAvailable = false
+
+// encodeWebPLib 함수는 libwebp 인코더가 빌드에 포함되지 않았음을 알립니다.
+func encodeWebPLib(img image.Image, quality int, lossless bool) ([]byte, error) { // 단일 책임: 미지원 안내
+	return nil, errors.New("libwebp 빌드 태그 없이 빌드됨")
+}
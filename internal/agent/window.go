@@ -0,0 +1,184 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"agent/internal/config"
+	"agent/internal/i18n"
+)
+
+const (
+	MONITOR_MODE_WINDOW     = "window" // 단일 애플리케이션 창 캡처 모드
+	WINDOW_LOOKUP_RETRY_MS  = 1000     // 대상 창을 찾지 못했을 때 다시 찾는 최소 간격
+	WINDOW_TITLE_MAX_LENGTH = 512      // 창 제목 조회 상한 (Win32: UTF-16 문자 수, X11: 32비트 단위)
+)
+
+// WindowInfo 구조체는 캡처 가능한 최상위 창 정보입니다. 좌표는 가상 화면 기준입니다.
+type WindowInfo struct {
+	ID      uint64 `json:"id"`      // OS 창 식별자 (HWND / CGWindowID / X11 Window)
+	Title   string `json:"title"`   // 창 제목
+	Process string `json:"process"` // 소유 프로세스 실행 파일 이름
+	PID     int    `json:"pid"`     // 소유 프로세스 ID
+	X       int    `json:"x"`
+	Y       int    `json:"y"`
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
+}
+
+// errWindowNotFound 변수는 캡처 대상 창이 닫혔거나 아직 없음을 나타냅니다.
+var errWindowNotFound = i18n.NewError("error.window_not_found")
+
+// errWindowHidden 변수는 창이 최소화되는 등 지금은 화면에 그려지지 않아 캡처할 수 없음을 나타냅니다.
+var errWindowHidden = i18n.NewError("error.window_hidden")
+
+// errWindowCaptureUnsupported 변수는 현재 플랫폼/빌드에서 창 캡처를 지원하지 않음을 나타냅니다.
+var errWindowCaptureUnsupported = i18n.NewError("error.window_unsupported")
+
+// windowCapturer 구조체는 제목/프로세스 이름으로 지정한 창 하나를 캡처합니다.
+// 창이 닫히면 WINDOW_LOOKUP_RETRY_MS 마다 다시 찾으며, 그동안이나 창이 최소화된 동안은 1x1 빈 프레임을 반환합니다.
+type windowCapturer struct { // 단일 책임: 단일 창 캡처
+	title   string        // 창 제목 부분 일치 (대소문자 무시, 빈 값이면 조건 없음)
+	process string        // 프로세스 이름 일치 (대소문자/확장자 무시, 빈 값이면 조건 없음)
+	opts    encodeOptions // 인코딩 옵션
+
+	mu       sync.Mutex
+	id       uint64    // 마지막으로 찾은 창 ID (0 이면 미확정)
+	lookupAt time.Time // 다음 창 검색 가능 시각
+}
+
+// newWindowCapturer 함수는 windowCapturer 생성자입니다.
+func newWindowCapturer(title, process string, opts encodeOptions) *windowCapturer { // 단일 책임: 인스턴스 생성
+	return &windowCapturer{title: title, process: process, opts: opts}
+}
+
+// newRealCapturer 함수는 설정의 모니터 모드에 맞는 실제 화면 캡처러를 생성합니다 (테스트 패턴 설정 시 합성 패턴 캡처러).
+func newRealCapturer(cfg *config.Config) screenCapturer { // 단일 책임: 모드별 캡처러 선택
+	if cfg.TestPattern { // 디스플레이 없이 파이프라인 점검
+		return newTestPatternCapturer(cfg)
+	}
+	opts := encodeOptionsFromConfig(cfg)
+	switch cfg.MonitorMode {
+	case MONITOR_MODE_WINDOW:
+		return newWindowCapturer(cfg.WindowTitle, cfg.WindowProcess, opts)
+	case MONITOR_MODE_ALL:
+		return newMonitorSetCapturer(cfg.CombinedLayout, cfg.CaptureBackend, opts)
+	case "combined":
+		return newCombinedCapturer(cfg.CombinedLayout, cfg.CaptureBackend, opts)
+	}
+	return newMonitorCapturer(cfg.MonitorIndex, cfg.CaptureBackend, opts)
+}
+
+// matches 함수는 창이 제목/프로세스 조건을 만족하는지 반환합니다.
+func (w *windowCapturer) matches(info WindowInfo) bool { // 단일 책임: 창 조건 비교
+	if w.title != "" && !strings.Contains(strings.ToLower(info.Title), strings.ToLower(w.title)) {
+		return false
+	}
+	return w.process == "" || strings.EqualFold(processBaseName(info.Process), processBaseName(w.process))
+}
+
+// processBaseName 함수는 경로와 확장자를 뗀 프로세스 이름을 반환합니다 ("C:\\App\\Foo.exe" → "Foo").
+func processBaseName(name string) string { // 단일 책임: 프로세스 이름 정규화
+	base := filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// resolve 함수는 조건에 맞는 첫 창(전면 우선)을 찾아 ID 를 반환합니다.
+func (w *windowCapturer) resolve() (uint64, error) { // 단일 책임: 대상 창 검색
+	windows, err := listWindows()
+	if err != nil {
+		return 0, err
+	}
+	for _, info := range windows {
+		if w.matches(info) {
+			return info.ID, nil
+		}
+	}
+	return 0, errWindowNotFound
+}
+
+// Capture 함수는 대상 창을 캡처해 인코딩된 바이트를 반환합니다.
+func (w *windowCapturer) Capture() ([]byte, error) { // 단일 책임: 캡처 + 인코딩
+	img, err := w.grab()
+	if err != nil {
+		return nil, err
+	}
+	defer w.release(img)
+	return w.encode(img)
+}
+
+// grab 함수는 대상 창의 원본 이미지를 반환합니다. 창을 찾지 못했거나 보이지 않으면 1x1 빈 프레임을 반환합니다.
+func (w *windowCapturer) grab() (image.Image, error) { // 단일 책임: 창 이미지 획득
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for attempt := 0; attempt < 2; attempt++ {
+		if w.id == 0 {
+			if time.Now().Before(w.lookupAt) {
+				break
+			}
+			id, err := w.resolve()
+			if errors.Is(err, errWindowNotFound) {
+				w.lookupAt = time.Now().Add(time.Duration(WINDOW_LOOKUP_RETRY_MS) * time.Millisecond)
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			w.id = id
+		}
+		img, err := captureWindow(w.id)
+		if errors.Is(err, errWindowNotFound) { // 창이 닫힘: 같은 조건으로 다시 찾음
+			w.id = 0
+			continue
+		}
+		if errors.Is(err, errWindowHidden) {
+			break
+		}
+		return img, err
+	}
+	return image.NewRGBA(image.Rect(0, 0, 1, 1)), nil
+}
+
+// release 함수는 창 이미지가 매 프레임 새로 할당되므로 아무 일도 하지 않습니다.
+func (w *windowCapturer) release(image.Image) {} // 단일 책임: rawCapturer 구현
+
+// options 함수는 캡처러의 인코딩 옵션을 반환합니다.
+func (w *windowCapturer) options() encodeOptions { // 단일 책임: 옵션 조회
+	return w.opts
+}
+
+// encode 함수는 캡처러의 인코딩 옵션으로 이미지를 인코딩합니다.
+func (w *windowCapturer) encode(img image.Image) ([]byte, error) { // 단일 책임: 선택 인코딩 처리
+	return w.opts.encode(img)
+}
+
+// ListWindows 메서드는 캡처 가능한 최상위 창 목록을 전면 창부터 반환합니다 (외부 노출용).
+func (a *Agent) ListWindows() ([]WindowInfo, error) { // 단일 책임: 창 목록 조회
+	return listWindows()
+}
+
+// SelectWindow 메서드는 window 모드로 전환해 제목(부분 일치)/프로세스 이름으로 지정한 창을 캡처합니다.
+// 둘 중 하나 이상 지정해야 하며, 현재 일치하는 창이 없으면 모드를 바꾸지 않고 오류를 반환합니다.
+func (a *Agent) SelectWindow(title, process string) error { // 단일 책임: 대상 창 선택 적용
+	if title == "" && process == "" {
+		return errors.New("창 제목 또는 프로세스 이름을 지정해야 함")
+	}
+	a.capMu.Lock()
+	defer a.capMu.Unlock()
+	capt := newWindowCapturer(title, process, encodeOptionsFromConfig(a.cfg))
+	id, err := capt.resolve()
+	if err != nil {
+		return fmt.Errorf("창 선택 실패 (title=%q process=%q): %w", title, process, err)
+	}
+	capt.id = id
+	a.cfg.MonitorMode = MONITOR_MODE_WINDOW
+	a.cfg.WindowTitle, a.cfg.WindowProcess = title, process
+	a.setCapturerLocked(capt)
+	a.logger.Infow("창 캡처 대상 선택", "title", title, "process", process, "window_id", id)
+	return nil
+}
@@ -0,0 +1,195 @@
+//go:build darwin && cgo
+
+package agent
+
+/*
+#if __ENVIRONMENT_MAC_OS_X_VERSION_MIN_REQUIRED__ > MAC_OS_VERSION_14_4
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework CoreGraphics -framework CoreFoundation -framework ScreenCaptureKit
+#include <ScreenCaptureKit/ScreenCaptureKit.h>
+#else
+#cgo LDFLAGS: -framework CoreGraphics -framework CoreFoundation
+#endif
+#include <CoreGraphics/CoreGraphics.h>
+#include <CoreFoundation/CoreFoundation.h>
+#include <string.h>
+
+typedef struct {
+	uint32_t id;
+	int32_t pid;
+	CGRect bounds;
+	char title[1024];
+	char owner[256];
+} agent_window;
+
+// 화면에 보이는 일반 창(layer 0)을 전면부터 out 에 채우고 개수를 반환합니다 (-1: 조회 실패).
+// 화면 기록 권한이 없으면 macOS 10.15+ 에서 다른 앱 창 제목(kCGWindowName)이 비어 있습니다.
+static int agent_list_windows(agent_window *out, int max) {
+	CFArrayRef list = CGWindowListCopyWindowInfo(kCGWindowListOptionOnScreenOnly | kCGWindowListExcludeDesktopElements, kCGNullWindowID);
+	if (!list) {
+		return -1;
+	}
+	int n = 0;
+	CFIndex count = CFArrayGetCount(list);
+	for (CFIndex i = 0; i < count && n < max; i++) {
+		CFDictionaryRef d = (CFDictionaryRef)CFArrayGetValueAtIndex(list, i);
+		int layer = -1;
+		CFNumberRef num = (CFNumberRef)CFDictionaryGetValue(d, kCGWindowLayer);
+		if (num) {
+			CFNumberGetValue(num, kCFNumberIntType, &layer);
+		}
+		if (layer != 0) { // 메뉴 막대, Dock, 오버레이 제외
+			continue;
+		}
+		agent_window *w = &out[n];
+		memset(w, 0, sizeof(*w));
+		num = (CFNumberRef)CFDictionaryGetValue(d, kCGWindowNumber);
+		if (!num || !CFNumberGetValue(num, kCFNumberSInt32Type, &w->id)) {
+			continue;
+		}
+		num = (CFNumberRef)CFDictionaryGetValue(d, kCGWindowOwnerPID);
+		if (num) {
+			CFNumberGetValue(num, kCFNumberSInt32Type, &w->pid);
+		}
+		CFDictionaryRef b = (CFDictionaryRef)CFDictionaryGetValue(d, kCGWindowBounds);
+		if (!b || !CGRectMakeWithDictionaryRepresentation(b, &w->bounds)) {
+			continue;
+		}
+		CFStringRef s = (CFStringRef)CFDictionaryGetValue(d, kCGWindowName);
+		if (s) {
+			CFStringGetCString(s, w->title, sizeof(w->title), kCFStringEncodingUTF8);
+		}
+		s = (CFStringRef)CFDictionaryGetValue(d, kCGWindowOwnerName);
+		if (s) {
+			CFStringGetCString(s, w->owner, sizeof(w->owner), kCFStringEncodingUTF8);
+		}
+		n++;
+	}
+	CFRelease(list);
+	return n;
+}
+
+// 창 하나의 이미지를 반환합니다 (창이 없으면 NULL). 호출자가 CGImageRelease 해야 합니다.
+static CGImageRef agent_capture_window(uint32_t id) {
+#if __ENVIRONMENT_MAC_OS_X_VERSION_MIN_REQUIRED__ > MAC_OS_VERSION_14_4
+	dispatch_semaphore_t semaphore = dispatch_semaphore_create(0);
+	__block CGImageRef result = nil;
+	[SCShareableContent getShareableContentWithCompletionHandler:^(SCShareableContent* content, NSError* error) {
+		@autoreleasepool {
+			SCWindow* target = nil;
+			for (SCWindow *window in content.windows) {
+				if (window.windowID == id) {
+					target = window;
+					break;
+				}
+			}
+			if (error || !target) {
+				dispatch_semaphore_signal(semaphore);
+				return;
+			}
+			SCContentFilter* filter = [[SCContentFilter alloc] initWithDesktopIndependentWindow:target];
+			SCStreamConfiguration* config = [[SCStreamConfiguration alloc] init];
+			config.width = target.frame.size.width;
+			config.height = target.frame.size.height;
+			config.showsCursor = NO;
+			[SCScreenshotManager captureImageWithFilter:filter
+			                              configuration:config
+			                          completionHandler:^(CGImageRef img, NSError* error) {
+				if (!error && img) {
+					result = CGImageRetain(img);
+				}
+				dispatch_semaphore_signal(semaphore);
+			}];
+		}
+	}];
+	dispatch_semaphore_wait(semaphore, DISPATCH_TIME_FOREVER);
+	dispatch_release(semaphore);
+	return result;
+#else
+	return CGWindowListCreateImage(CGRectNull, kCGWindowListOptionIncludingWindow, id, kCGWindowImageBoundsIgnoreFraming | kCGWindowImageNominalResolution);
+#endif
+}
+
+// CGImage 를 RGBA 바이트 버퍼(stride = width*4)에 그립니다.
+static int agent_draw_rgba(CGImageRef img, void *pix, size_t width, size_t height) {
+	CGColorSpaceRef cs = CGColorSpaceCreateDeviceRGB();
+	if (!cs) {
+		return 0;
+	}
+	CGContextRef ctx = CGBitmapContextCreate(pix, width, height, 8, width * 4, cs, kCGImageAlphaPremultipliedLast | kCGBitmapByteOrder32Big);
+	CGColorSpaceRelease(cs);
+	if (!ctx) {
+		return 0;
+	}
+	CGContextDrawImage(ctx, CGRectMake(0, 0, width, height), img);
+	CGContextRelease(ctx);
+	return 1;
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"image"
+	"unsafe"
+)
+
+const (
+	DARWIN_WINDOW_LIST_MAX = 512 // 한 번에 조회하는 최대 창 수
+)
+
+// listWindows 함수는 CGWindowListCopyWindowInfo 로 화면에 보이는 일반 창 목록을 전면 창부터 반환합니다.
+func listWindows() ([]WindowInfo, error) { // 단일 책임: macOS 창 목록 조회
+	buf := make([]C.agent_window, DARWIN_WINDOW_LIST_MAX)
+	n := int(C.agent_list_windows(&buf[0], C.int(len(buf))))
+	if n < 0 {
+		return nil, errors.New("CGWindowListCopyWindowInfo 실패")
+	}
+	list := make([]WindowInfo, 0, n)
+	for _, w := range buf[:n] {
+		list = append(list, WindowInfo{
+			ID:      uint64(w.id),
+			Title:   C.GoString(&w.title[0]),
+			Process: C.GoString(&w.owner[0]),
+			PID:     int(w.pid),
+			X:       int(w.bounds.origin.x),
+			Y:       int(w.bounds.origin.y),
+			Width:   int(w.bounds.size.width),
+			Height:  int(w.bounds.size.height),
+		})
+	}
+	return list, nil
+}
+
+// foregroundWindow 함수는 가장 앞에 있는 일반 창(CGWindowList 의 첫 layer 0 창)을 반환합니다.
+func foregroundWindow() (WindowInfo, error) { // 단일 책임: 전면 창 조회
+	list, err := listWindows()
+	if err != nil {
+		return WindowInfo{}, err
+	}
+	if len(list) == 0 {
+		return WindowInfo{}, errWindowNotFound
+	}
+	return list[0], nil
+}
+
+// captureWindow 함수는 창 하나를 캡처해 RGBA 이미지로 반환합니다 (macOS 14.4 초과 빌드는 ScreenCaptureKit 사용).
+func captureWindow(id uint64) (image.Image, error) { // 단일 책임: macOS 창 캡처
+	cg := C.agent_capture_window(C.uint32_t(id))
+	if unsafe.Pointer(cg) == nil {
+		return nil, errWindowNotFound
+	}
+	defer C.CGImageRelease(cg)
+	w, h := int(C.CGImageGetWidth(cg)), int(C.CGImageGetHeight(cg))
+	if w == 0 || h == 0 {
+		return nil, errWindowHidden
+	}
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	if C.agent_draw_rgba(cg, unsafe.Pointer(&img.Pix[0]), C.size_t(w), C.size_t(h)) == 0 {
+		return nil, errors.New("창 이미지 비트맵 변환 실패")
+	}
+	for i := 3; i < len(img.Pix); i += 4 { // 창 그림자/투명 영역도 불투명 처리
+		img.Pix[i] = 255
+	}
+	return img, nil
+}
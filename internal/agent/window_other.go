@@ -0,0 +1,20 @@
+//go:build !windows && !linux && !freebsd && !(darwin && cgo)
+
+package agent
+
+import "image"
+
+// listWindows 함수는 창 캡처를 지원하지 않는 빌드에서 오류를 반환합니다.
+func listWindows() ([]WindowInfo, error) { // 단일 책임: 미지원 플랫폼 처리
+	return nil, errWindowCaptureUnsupported
+}
+
+// foregroundWindow 함수는 창 조회를 지원하지 않는 빌드에서 오류를 반환합니다.
+func foregroundWindow() (WindowInfo, error) { // 단일 책임: 미지원 플랫폼 처리
+	return WindowInfo{}, errWindowCaptureUnsupported
+}
+
+// captureWindow 함수는 창 캡처를 지원하지 않는 빌드에서 오류를 반환합니다.
+func captureWindow(uint64) (image.Image, error) { // 단일 책임: 미지원 플랫폼 처리
+	return nil, errWindowCaptureUnsupported
+}
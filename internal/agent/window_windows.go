@@ -0,0 +1,199 @@
+//go:build windows
+
+package agent
+
+import (
+	"image"
+	"path/filepath"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	PW_RENDERFULLCONTENT = 0x2 // PrintWindow: DirectComposition/하드웨어 가속 창 내용까지 렌더링 (Windows 8.1+)
+	DWMWA_CLOAKED        = 14  // DwmGetWindowAttribute: 가상 데스크톱/UWP 로 가려진(cloaked) 창 여부
+	DIB_RGB_COLORS       = 0   // CreateDIBSection 색상 테이블 형식
+)
+
+var (
+	user32                    = windows.NewLazySystemDLL("user32.dll")
+	gdi32                     = windows.NewLazySystemDLL("gdi32.dll")
+	procGetWindowTextW        = user32.NewProc("GetWindowTextW")
+	procGetWindowRect         = user32.NewProc("GetWindowRect")
+	procIsIconic              = user32.NewProc("IsIconic")
+	procIsWindow              = user32.NewProc("IsWindow")
+	procPrintWindow           = user32.NewProc("PrintWindow")
+	procGetForegroundWindow   = user32.NewProc("GetForegroundWindow")
+	procGetDC                 = user32.NewProc("GetDC")
+	procReleaseDC             = user32.NewProc("ReleaseDC")
+	procCreateCompatibleDC    = gdi32.NewProc("CreateCompatibleDC")
+	procCreateDIBSection      = gdi32.NewProc("CreateDIBSection")
+	procSelectObject          = gdi32.NewProc("SelectObject")
+	procDeleteObject          = gdi32.NewProc("DeleteObject")
+	procDeleteDC              = gdi32.NewProc("DeleteDC")
+	procDwmGetWindowAttribute = windows.NewLazySystemDLL("dwmapi.dll").NewProc("DwmGetWindowAttribute")
+	enumWindowsMu             sync.Mutex     // enumWindowsResult 보호 (콜백은 프로세스당 한 번만 생성)
+	enumWindowsResult         []windows.HWND // EnumWindows 콜백 수집 버퍼
+	enumWindowsCallback       = windows.NewCallback(func(hwnd windows.HWND, _ uintptr) uintptr {
+		enumWindowsResult = append(enumWindowsResult, hwnd)
+		return 1 // 계속 열거
+	})
+)
+
+// bitmapInfoHeader 구조체는 BITMAPINFOHEADER 입니다.
+type bitmapInfoHeader struct {
+	Size          uint32
+	Width         int32
+	Height        int32
+	Planes        uint16
+	BitCount      uint16
+	Compression   uint32
+	SizeImage     uint32
+	XPelsPerMeter int32
+	YPelsPerMeter int32
+	ClrUsed       uint32
+	ClrImportant  uint32
+}
+
+// listWindows 함수는 EnumWindows 로 제목이 있고 보이는 최상위 창 목록을 Z 순서(전면 우선)로 반환합니다.
+func listWindows() ([]WindowInfo, error) { // 단일 책임: Win32 창 목록 조회
+	enumWindowsMu.Lock()
+	enumWindowsResult = enumWindowsResult[:0]
+	err := windows.EnumWindows(enumWindowsCallback, nil)
+	hwnds := append([]windows.HWND(nil), enumWindowsResult...)
+	enumWindowsMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]WindowInfo, 0, len(hwnds))
+	for _, hwnd := range hwnds {
+		if !windows.IsWindowVisible(hwnd) || windowCloaked(hwnd) {
+			continue
+		}
+		title := windowTitle(hwnd)
+		if title == "" {
+			continue
+		}
+		r, ok := windowRect(hwnd)
+		if !ok || r.Empty() {
+			continue
+		}
+		var pid uint32
+		windows.GetWindowThreadProcessId(hwnd, &pid)
+		list = append(list, WindowInfo{
+			ID:      uint64(hwnd),
+			Title:   title,
+			Process: processImageName(pid),
+			PID:     int(pid),
+			X:       r.Min.X,
+			Y:       r.Min.Y,
+			Width:   r.Dx(),
+			Height:  r.Dy(),
+		})
+	}
+	return list, nil
+}
+
+// foregroundWindow 함수는 GetForegroundWindow 로 현재 입력을 받는 전면 창을 반환합니다.
+func foregroundWindow() (WindowInfo, error) { // 단일 책임: Win32 전면 창 조회
+	hwnd, _, _ := procGetForegroundWindow.Call()
+	if hwnd == 0 { // 잠금 화면/UAC 등 전면 창 없음
+		return WindowInfo{}, errWindowNotFound
+	}
+	var pid uint32
+	windows.GetWindowThreadProcessId(windows.HWND(hwnd), &pid)
+	info := WindowInfo{ID: uint64(hwnd), Title: windowTitle(windows.HWND(hwnd)), Process: processImageName(pid), PID: int(pid)}
+	if r, ok := windowRect(windows.HWND(hwnd)); ok {
+		info.X, info.Y, info.Width, info.Height = r.Min.X, r.Min.Y, r.Dx(), r.Dy()
+	}
+	return info, nil
+}
+
+// captureWindow 함수는 PrintWindow(PW_RENDERFULLCONTENT)로 창 내용을 DIB 에 그려 RGBA 이미지로 반환합니다.
+// 다른 창에 가려져 있어도 창 자체의 내용을 캡처합니다.
+func captureWindow(id uint64) (image.Image, error) { // 단일 책임: Win32 창 캡처
+	hwnd := uintptr(id)
+	if r, _, _ := procIsWindow.Call(hwnd); r == 0 {
+		return nil, errWindowNotFound
+	}
+	if r, _, _ := procIsIconic.Call(hwnd); r != 0 { // 최소화된 창은 그릴 내용이 없음
+		return nil, errWindowHidden
+	}
+	rect, ok := windowRect(windows.HWND(hwnd))
+	if !ok || rect.Empty() {
+		return nil, errWindowHidden
+	}
+	w, h := rect.Dx(), rect.Dy()
+	screenDC, _, _ := procGetDC.Call(0)
+	if screenDC == 0 {
+		return nil, windows.GetLastError()
+	}
+	defer procReleaseDC.Call(0, screenDC)
+	memDC, _, _ := procCreateCompatibleDC.Call(screenDC)
+	if memDC == 0 {
+		return nil, windows.GetLastError()
+	}
+	defer procDeleteDC.Call(memDC)
+	header := bitmapInfoHeader{Width: int32(w), Height: -int32(h), Planes: 1, BitCount: 32} // 음수 높이: 위→아래 행 순서
+	header.Size = uint32(unsafe.Sizeof(header))
+	var bits unsafe.Pointer
+	bitmap, _, err := procCreateDIBSection.Call(screenDC, uintptr(unsafe.Pointer(&header)), DIB_RGB_COLORS, uintptr(unsafe.Pointer(&bits)), 0, 0)
+	if bitmap == 0 || bits == nil {
+		return nil, err
+	}
+	defer procDeleteObject.Call(bitmap)
+	old, _, _ := procSelectObject.Call(memDC, bitmap)
+	defer procSelectObject.Call(memDC, old)
+	if r, _, err := procPrintWindow.Call(hwnd, memDC, PW_RENDERFULLCONTENT); r == 0 {
+		return nil, err
+	}
+	src := unsafe.Slice((*byte)(bits), w*h*4)
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for i := 0; i < len(src); i += 4 { // BGRA → RGBA (PrintWindow 알파는 신뢰할 수 없어 불투명 처리)
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = src[i+2], src[i+1], src[i], 255
+	}
+	return img, nil
+}
+
+// windowTitle 함수는 창 제목을 반환합니다.
+func windowTitle(hwnd windows.HWND) string { // 단일 책임: 창 제목 조회
+	buf := make([]uint16, WINDOW_TITLE_MAX_LENGTH)
+	n, _, _ := procGetWindowTextW.Call(uintptr(hwnd), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+	return windows.UTF16ToString(buf[:n])
+}
+
+// windowRect 함수는 창의 화면 좌표 영역을 반환합니다.
+func windowRect(hwnd windows.HWND) (image.Rectangle, bool) { // 단일 책임: 창 위치 조회
+	var r windows.Rect
+	if ok, _, _ := procGetWindowRect.Call(uintptr(hwnd), uintptr(unsafe.Pointer(&r))); ok == 0 {
+		return image.Rectangle{}, false
+	}
+	return image.Rect(int(r.Left), int(r.Top), int(r.Right), int(r.Bottom)), true
+}
+
+// windowCloaked 함수는 DWM 이 창을 숨긴(cloaked) 상태인지 반환합니다 (다른 가상 데스크톱, 백그라운드 UWP 등).
+func windowCloaked(hwnd windows.HWND) bool { // 단일 책임: cloaked 여부 조회
+	if procDwmGetWindowAttribute.Find() != nil {
+		return false
+	}
+	var cloaked uint32
+	r, _, _ := procDwmGetWindowAttribute.Call(uintptr(hwnd), DWMWA_CLOAKED, uintptr(unsafe.Pointer(&cloaked)), unsafe.Sizeof(cloaked))
+	return r == 0 && cloaked != 0
+}
+
+// processImageName 함수는 프로세스 실행 파일 이름을 반환합니다 (권한이 없으면 빈 값).
+func processImageName(pid uint32) string { // 단일 책임: 프로세스 이름 조회
+	h, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		return ""
+	}
+	defer windows.CloseHandle(h)
+	buf := make([]uint16, windows.MAX_PATH)
+	size := uint32(len(buf))
+	if err := windows.QueryFullProcessImageName(h, 0, &buf[0], &size); err != nil {
+		return ""
+	}
+	return filepath.Base(windows.UTF16ToString(buf[:size]))
+}
@@ -0,0 +1,210 @@
+//go:build linux || freebsd
+
+package agent
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jezek/xgb"
+	"github.com/jezek/xgb/xproto"
+)
+
+// listWindows 함수는 EWMH _NET_CLIENT_LIST_STACKING(없으면 _NET_CLIENT_LIST)에서 화면에 보이는 창 목록을 전면 창부터 반환합니다.
+func listWindows() (list []WindowInfo, err error) { // 단일 책임: X11 창 목록 조회
+	defer func() { // xgb 는 연결 오류 시 panic 할 수 있음
+		if r := recover(); r != nil {
+			list, err = nil, fmt.Errorf("X11 창 목록 조회 실패: %v", r)
+		}
+	}()
+	c, err := xgb.NewConn()
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+	root := xproto.Setup(c).DefaultScreen(c).Root
+	ids := x11WindowList(c, root, "_NET_CLIENT_LIST_STACKING")
+	if len(ids) == 0 {
+		ids = x11WindowList(c, root, "_NET_CLIENT_LIST")
+	}
+	list = make([]WindowInfo, 0, len(ids))
+	for i := len(ids) - 1; i >= 0; i-- { // 스택 순서는 아래→위
+		win := ids[i]
+		attrs, err := xproto.GetWindowAttributes(c, win).Reply()
+		if err != nil || attrs.MapState != xproto.MapStateViewable {
+			continue
+		}
+		b, err := x11WindowBounds(c, root, win)
+		if err != nil || b.Empty() {
+			continue
+		}
+		pid := x11Cardinal(c, win, "_NET_WM_PID")
+		list = append(list, WindowInfo{
+			ID:      uint64(win),
+			Title:   x11WindowTitle(c, win),
+			Process: processNameByPID(pid),
+			PID:     pid,
+			X:       b.Min.X,
+			Y:       b.Min.Y,
+			Width:   b.Dx(),
+			Height:  b.Dy(),
+		})
+	}
+	return list, nil
+}
+
+// foregroundWindow 함수는 EWMH _NET_ACTIVE_WINDOW 가 가리키는 활성 창을 반환합니다.
+func foregroundWindow() (info WindowInfo, err error) { // 단일 책임: X11 활성 창 조회
+	defer func() {
+		if r := recover(); r != nil {
+			info, err = WindowInfo{}, fmt.Errorf("X11 활성 창 조회 실패: %v", r)
+		}
+	}()
+	c, err := xgb.NewConn()
+	if err != nil {
+		return WindowInfo{}, err
+	}
+	defer c.Close()
+	root := xproto.Setup(c).DefaultScreen(c).Root
+	ids := x11WindowList(c, root, "_NET_ACTIVE_WINDOW")
+	if len(ids) == 0 || ids[0] == 0 {
+		return WindowInfo{}, errWindowNotFound
+	}
+	win := ids[0]
+	pid := x11Cardinal(c, win, "_NET_WM_PID")
+	info = WindowInfo{ID: uint64(win), Title: x11WindowTitle(c, win), Process: processNameByPID(pid), PID: pid}
+	if b, err := x11WindowBounds(c, root, win); err == nil {
+		info.X, info.Y, info.Width, info.Height = b.Min.X, b.Min.Y, b.Dx(), b.Dy()
+	}
+	return info, nil
+}
+
+// captureWindow 함수는 창 드로어블을 GetImage 로 읽어 RGBA 이미지로 반환합니다.
+// 컴포지터가 없는 환경에서는 다른 창에 가려진 부분이 함께 찍힐 수 있습니다.
+func captureWindow(id uint64) (img image.Image, err error) { // 단일 책임: X11 창 캡처
+	defer func() {
+		if r := recover(); r != nil {
+			img, err = nil, fmt.Errorf("X11 창 캡처 실패: %v", r)
+		}
+	}()
+	c, err := xgb.NewConn()
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+	win := xproto.Window(id)
+	geo, err := xproto.GetGeometry(c, xproto.Drawable(win)).Reply()
+	if err != nil {
+		return nil, x11WindowError(err)
+	}
+	reply, err := xproto.GetImage(c, xproto.ImageFormatZPixmap, xproto.Drawable(win), 0, 0, geo.Width, geo.Height, 0xffffffff).Reply()
+	if err != nil {
+		return nil, x11WindowError(err)
+	}
+	w, h := int(geo.Width), int(geo.Height)
+	if len(reply.Data) < w*h*4 { // 32bpp 가 아닌 비주얼은 지원하지 않음
+		return nil, fmt.Errorf("지원하지 않는 창 픽셀 형식 (depth=%d)", geo.Depth)
+	}
+	rgba := image.NewRGBA(image.Rect(0, 0, w, h))
+	for i := 0; i < w*h*4; i += 4 { // BGRX → RGBA
+		rgba.Pix[i], rgba.Pix[i+1], rgba.Pix[i+2], rgba.Pix[i+3] = reply.Data[i+2], reply.Data[i+1], reply.Data[i], 255
+	}
+	return rgba, nil
+}
+
+// x11WindowError 함수는 창이 사라졌거나(BadWindow/BadDrawable) 보이지 않는(BadMatch) 오류를 공통 오류로 변환합니다.
+func x11WindowError(err error) error { // 단일 책임: X11 오류 분류
+	switch err.(type) {
+	case xproto.WindowError, xproto.DrawableError:
+		return errWindowNotFound
+	case xproto.MatchError:
+		return errWindowHidden
+	}
+	return err
+}
+
+// x11Atom 함수는 이름에 해당하는 아톰을 반환합니다 (없으면 0).
+func x11Atom(c *xgb.Conn, name string) xproto.Atom { // 단일 책임: 아톰 조회
+	reply, err := xproto.InternAtom(c, true, uint16(len(name)), name).Reply()
+	if err != nil {
+		return 0
+	}
+	return reply.Atom
+}
+
+// x11WindowList 함수는 루트 창의 WINDOW 배열 속성을 읽습니다.
+func x11WindowList(c *xgb.Conn, root xproto.Window, prop string) []xproto.Window { // 단일 책임: 창 배열 속성 조회
+	atom := x11Atom(c, prop)
+	if atom == 0 {
+		return nil
+	}
+	reply, err := xproto.GetProperty(c, false, root, atom, xproto.AtomWindow, 0, 1<<16).Reply()
+	if err != nil || reply.Format != 32 {
+		return nil
+	}
+	ids := make([]xproto.Window, 0, len(reply.Value)/4)
+	for i := 0; i+4 <= len(reply.Value); i += 4 {
+		ids = append(ids, xproto.Window(binary.LittleEndian.Uint32(reply.Value[i:])))
+	}
+	return ids
+}
+
+// x11WindowTitle 함수는 _NET_WM_NAME(UTF-8), 없으면 WM_NAME 으로 창 제목을 반환합니다.
+func x11WindowTitle(c *xgb.Conn, win xproto.Window) string { // 단일 책임: 창 제목 조회
+	if atom := x11Atom(c, "_NET_WM_NAME"); atom != 0 {
+		reply, err := xproto.GetProperty(c, false, win, atom, xproto.GetPropertyTypeAny, 0, WINDOW_TITLE_MAX_LENGTH).Reply()
+		if err == nil && len(reply.Value) > 0 {
+			return string(reply.Value)
+		}
+	}
+	reply, err := xproto.GetProperty(c, false, win, xproto.AtomWmName, xproto.GetPropertyTypeAny, 0, WINDOW_TITLE_MAX_LENGTH).Reply()
+	if err != nil {
+		return ""
+	}
+	return string(reply.Value)
+}
+
+// x11Cardinal 함수는 창의 CARDINAL 속성 첫 값을 반환합니다 (없으면 0).
+func x11Cardinal(c *xgb.Conn, win xproto.Window, prop string) int { // 단일 책임: 정수 속성 조회
+	atom := x11Atom(c, prop)
+	if atom == 0 {
+		return 0
+	}
+	reply, err := xproto.GetProperty(c, false, win, atom, xproto.AtomCardinal, 0, 1).Reply()
+	if err != nil || len(reply.Value) < 4 {
+		return 0
+	}
+	return int(binary.LittleEndian.Uint32(reply.Value))
+}
+
+// x11WindowBounds 함수는 창의 루트 좌표 기준 영역을 반환합니다.
+func x11WindowBounds(c *xgb.Conn, root, win xproto.Window) (image.Rectangle, error) { // 단일 책임: 창 위치 조회
+	geo, err := xproto.GetGeometry(c, xproto.Drawable(win)).Reply()
+	if err != nil {
+		return image.Rectangle{}, err
+	}
+	pos, err := xproto.TranslateCoordinates(c, win, root, 0, 0).Reply()
+	if err != nil {
+		return image.Rectangle{}, err
+	}
+	return image.Rect(int(pos.DstX), int(pos.DstY), int(pos.DstX)+int(geo.Width), int(pos.DstY)+int(geo.Height)), nil
+}
+
+// processNameByPID 함수는 /proc 에서 프로세스 실행 파일 이름을 조회합니다 (알 수 없으면 빈 값).
+func processNameByPID(pid int) string { // 단일 책임: 프로세스 이름 조회
+	if pid <= 0 {
+		return ""
+	}
+	if exe, err := os.Readlink(fmt.Sprintf("/proc/%d/exe", pid)); err == nil {
+		return filepath.Base(exe)
+	}
+	comm, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(comm))
+}
@@ -0,0 +1,28 @@
+package autostart
+
+import "os"
+
+const (
+	APP_NAME   = "agent"            // Run 키 값 이름 / .desktop 파일 이름
+	APP_LABEL  = "com.mos-mo.agent" // macOS LaunchAgent 레이블
+	LAUNCH_ARG = "--minimized"      // 로그인 시 창 없이 트레이로 시작
+)
+
+// Enable 함수는 현재 실행 파일을 로그인 시 시작하도록 등록합니다 (사용자 단위, 관리자 권한 불필요). 이미 있으면 경로를 갱신합니다.
+func Enable() error { // 단일 책임: 자동 시작 등록
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	return enable(exe, []string{LAUNCH_ARG})
+}
+
+// Disable 함수는 로그인 자동 시작 등록을 지웁니다 (등록되어 있지 않으면 무동작).
+func Disable() error { // 단일 책임: 자동 시작 해제
+	return disable()
+}
+
+// Enabled 함수는 로그인 자동 시작이 등록되어 있는지 반환합니다.
+func Enabled() (bool, error) { // 단일 책임: 자동 시작 등록 조회
+	return enabled()
+}
@@ -0,0 +1,69 @@
+//go:build darwin
+
+package autostart
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// plistPath 함수는 사용자 LaunchAgent plist 경로를 반환합니다.
+func plistPath() (string, error) { // 단일 책임: plist 경로 계산
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "Library", "LaunchAgents", APP_LABEL+".plist"), nil
+}
+
+// enable 함수는 로그인 시 실행(RunAtLoad)하는 LaunchAgent plist 를 씁니다 (다음 로그인부터 적용).
+func enable(exe string, args []string) error { // 단일 책임: LaunchAgent 등록
+	path, err := plistPath()
+	if err != nil {
+		return err
+	}
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	b.WriteString("<plist version=\"1.0\">\n<dict>\n\t<key>Label</key>\n\t<string>")
+	xml.EscapeText(&b, []byte(APP_LABEL))
+	b.WriteString("</string>\n\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, arg := range append([]string{exe}, args...) {
+		b.WriteString("\t\t<string>")
+		xml.EscapeText(&b, []byte(arg))
+		b.WriteString("</string>\n")
+	}
+	b.WriteString("\t</array>\n\t<key>RunAtLoad</key>\n\t<true/>\n\t<key>ProcessType</key>\n\t<string>Interactive</string>\n</dict>\n</plist>\n")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b.Bytes(), 0o644)
+}
+
+// disable 함수는 LaunchAgent plist 를 지웁니다.
+func disable() error { // 단일 책임: LaunchAgent 해제
+	path, err := plistPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// enabled 함수는 LaunchAgent plist 가 있는지 확인합니다.
+func enabled() (bool, error) { // 단일 책임: LaunchAgent 조회
+	path, err := plistPath()
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
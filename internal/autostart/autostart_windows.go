@@ -0,0 +1,56 @@
+//go:build windows
+
+package autostart
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// RUN_KEY_PATH 상수는 사용자 로그인 시 실행 목록 레지스트리 키입니다.
+const RUN_KEY_PATH = `Software\Microsoft\Windows\CurrentVersion\Run`
+
+// enable 함수는 HKCU Run 키에 실행 명령을 기록합니다.
+func enable(exe string, args []string) error { // 단일 책임: Run 키 등록
+	k, _, err := registry.CreateKey(registry.CURRENT_USER, RUN_KEY_PATH, registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+	defer k.Close()
+	return k.SetStringValue(APP_NAME, `"`+exe+`" `+strings.Join(args, " "))
+}
+
+// disable 함수는 Run 키 값을 지웁니다.
+func disable() error { // 단일 책임: Run 키 해제
+	k, err := registry.OpenKey(registry.CURRENT_USER, RUN_KEY_PATH, registry.SET_VALUE)
+	if errors.Is(err, registry.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer k.Close()
+	if err := k.DeleteValue(APP_NAME); err != nil && !errors.Is(err, registry.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// enabled 함수는 Run 키 값이 있는지 확인합니다.
+func enabled() (bool, error) { // 단일 책임: Run 키 조회
+	k, err := registry.OpenKey(registry.CURRENT_USER, RUN_KEY_PATH, registry.QUERY_VALUE)
+	if errors.Is(err, registry.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer k.Close()
+	_, _, err = k.GetStringValue(APP_NAME)
+	if errors.Is(err, registry.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
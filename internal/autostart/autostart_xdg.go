@@ -0,0 +1,79 @@
+//go:build !windows && !darwin
+
+package autostart
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// desktopPath 함수는 XDG autostart 항목 경로를 반환합니다 ($XDG_CONFIG_HOME/autostart, 기본 ~/.config/autostart).
+func desktopPath() (string, error) { // 단일 책임: .desktop 경로 계산
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "autostart", APP_NAME+".desktop"), nil
+}
+
+// enable 함수는 로그인 시 실행할 XDG autostart .desktop 항목을 씁니다.
+func enable(exe string, args []string) error { // 단일 책임: autostart 항목 등록
+	path, err := desktopPath()
+	if err != nil {
+		return err
+	}
+	cmd := make([]string, 0, len(args)+1)
+	for _, arg := range append([]string{exe}, args...) {
+		cmd = append(cmd, desktopQuote(arg))
+	}
+	entry := strings.Join([]string{
+		"[Desktop Entry]",
+		"Type=Application",
+		"Name=" + APP_NAME,
+		"Exec=" + strings.Join(cmd, " "),
+		"Terminal=false",
+		"X-GNOME-Autostart-enabled=true",
+		"",
+	}, "\n")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(entry), 0o644)
+}
+
+// desktopQuote 함수는 Desktop Entry Exec 규칙에 맞게 인자를 따옴표로 감쌉니다 (공백/특수 문자가 있을 때만, % 는 항상 %%).
+func desktopQuote(arg string) string { // 단일 책임: Exec 인자 인용
+	arg = strings.ReplaceAll(arg, "%", "%%")
+	if !strings.ContainsAny(arg, " \t\"'\\$`<>|&;*?#()") {
+		return arg
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`", `$`, `\$`)
+	return `"` + r.Replace(arg) + `"`
+}
+
+// disable 함수는 autostart 항목을 지웁니다.
+func disable() error { // 단일 책임: autostart 항목 해제
+	path, err := desktopPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// enabled 함수는 autostart 항목이 있는지 확인합니다.
+func enabled() (bool, error) { // 단일 책임: autostart 항목 조회
+	path, err := desktopPath()
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
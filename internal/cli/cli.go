@@ -0,0 +1,397 @@
+package cli
+
+import (
+	"agent/internal/agent"
+	"agent/internal/autostart"
+	"agent/internal/config"
+	"agent/internal/logging"
+	"agent/internal/loopback"
+	"agent/internal/secrets"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	goruntime "runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	CLI_DIAG_TIMEOUT_SEC   = 30 // diag 서브커맨드 전체 타임아웃(초)
+	CLI_DOCTOR_TIMEOUT_SEC = 10 // doctor 서브커맨드 서버 연결 확인 타임아웃(초)
+)
+
+// Options 구조체는 서브커맨드 앞에 오는 공통 플래그입니다. 값이 있으면 환경 변수와 설정 파일보다 우선합니다.
+type Options struct {
+	Config    string // 설정 파일 경로
+	Server    string // gRPC 서버 주소 (AGENT_SERVER_ADDR)
+	FPS       int    // 목표 FPS (CAPTURE_TARGET_FPS)
+	Headless  bool   // UI 없이 실행 (run 서브커맨드와 같음)
+	Minimized bool   // 창을 띄우지 않고 시작 (UI_START_MINIMIZED)
+}
+
+// ParseGlobalFlags 함수는 공통 플래그를 해석해 설정에 반영하고 서브커맨드와 그 인자를 반환합니다.
+// Wails 실행과 서브커맨드가 같은 설정을 쓰도록 서브커맨드 분기 전에 처리합니다.
+func ParseGlobalFlags(args []string) (Options, []string, error) { // 단일 책임: 공통 플래그 처리
+	var o Options
+	fs := flag.NewFlagSet("agent", flag.ContinueOnError)
+	fs.StringVar(&o.Config, "config", "", "설정 파일 경로 (YAML 또는 .toml)")
+	fs.StringVar(&o.Server, "server", "", "gRPC 서버 주소 (host:port)")
+	fs.IntVar(&o.FPS, "fps", 0, "목표 캡처 FPS")
+	fs.BoolVar(&o.Headless, "headless", false, "UI 없이 에이전트만 실행")
+	fs.BoolVar(&o.Minimized, "minimized", false, "창을 띄우지 않고 트레이로 시작")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "사용법: agent [플래그] [run | version | doctor | diag | bench] [서브커맨드 플래그]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return o, nil, err
+	}
+	if o.Config != "" {
+		config.SetFilePath(o.Config)
+	}
+	if o.Server != "" {
+		config.SetOverride("AGENT_SERVER_ADDR", o.Server)
+	}
+	if o.FPS > 0 {
+		config.SetOverride("CAPTURE_TARGET_FPS", strconv.Itoa(o.FPS))
+	}
+	if o.Minimized {
+		config.SetOverride("UI_START_MINIMIZED", "true")
+	}
+	return o, fs.Args(), nil
+}
+
+// Run 함수는 서브커맨드를 처리합니다. 처리했다면 종료 코드와 true 를 반환합니다.
+// 서브커맨드 없이 headless 이면 run 과 같이 동작하며, Wails 를 쓰지 않아 UI 없는 agentd 도 그대로 씁니다.
+func Run(args []string, headless bool) (int, bool) { // 단일 책임: 서브커맨드 분기
+	if len(args) == 0 {
+		if headless {
+			return runHeadless(nil), true
+		}
+		return 0, false
+	}
+	switch args[0] {
+	case "run":
+		return runHeadless(args[1:]), true
+	case "version":
+		return runVersion(), true
+	case "doctor":
+		return runDoctor(args[1:]), true
+	case "diag":
+		return runDiag(args[1:]), true
+	case "bench":
+		return runBench(args[1:]), true
+	case "secret":
+		return runSecret(args[1:]), true
+	case "autostart":
+		return runAutostart(args[1:]), true
+	case "consent":
+		return runConsent(args[1:]), true
+	}
+	fmt.Fprintf(os.Stderr, "알 수 없는 서브커맨드: %s (run | version | doctor | diag | bench | secret | autostart | consent)\n", args[0])
+	return 2, true
+}
+
+// runHeadless 함수는 UI 없이 에이전트를 시작해 바로 캡처하고 SIGINT/SIGTERM 을 받을 때까지 실행합니다.
+func runHeadless(args []string) int { // 단일 책임: run 서브커맨드 실행
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	noCapture := fs.Bool("no-capture", false, "캡처 없이 연결/이벤트만 실행")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	cfg := config.Load()
+	logger, err := logging.NewLogger()
+	if err != nil {
+		logger = nil
+	}
+	if cfg.LoopbackMode {
+		srv, err := loopback.Start(cfg.DataDir, logger)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "루프백 서버 시작 실패: %v\n", err)
+			return 1
+		}
+		defer srv.Stop()
+		cfg.ServerAddr = srv.Addr()
+	}
+	// 신호 컨텍스트의 자식이면 SIGTERM 이 Close 전에 스트림을 끊어 비우기/CloseSend 가 서버에 닿지 않으므로 분리 (Close 가 직접 취소)
+	agentCtx, cancel := context.WithCancel(context.Background())
+	ag := agent.New(agentCtx, cancel, cfg, logger)
+	ag.Init()
+	if !*noCapture {
+		if err := ag.StartCapture(); err != nil {
+			fmt.Fprintf(os.Stderr, "캡처 시작 실패: %v\n", err)
+		}
+	}
+	select {
+	case <-ctx.Done(): // SIGINT/SIGTERM
+	case <-agentCtx.Done(): // 에이전트가 스스로 종료
+	}
+	stop() // 종료 중 두 번째 신호는 기본 동작(즉시 종료)
+	ag.Close()
+	return 0
+}
+
+// runVersion 함수는 에이전트 빌드 버전과 실행 환경을 출력합니다.
+func runVersion() int { // 단일 책임: version 서브커맨드 실행
+	fmt.Printf("agent %s (%s, %s/%s)\n", agent.Version, goruntime.Version(), goruntime.GOOS, goruntime.GOARCH)
+	return 0
+}
+
+// runDoctor 함수는 설정 파일, 데이터 디렉터리, 화면 캡처, 서버 연결을 차례로 점검해 결과를 출력합니다.
+// 하나라도 실패하면 종료 코드 1 을 반환합니다.
+func runDoctor(args []string) int { // 단일 책임: doctor 서브커맨드 실행
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	offline := fs.Bool("offline", false, "서버 연결 점검 생략")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	cfg := config.Load()
+	failed := false
+	report := func(name string, err error, detail string) {
+		if err != nil {
+			failed = true
+			fmt.Printf("[FAIL] %-8s %v\n", name, err)
+			return
+		}
+		fmt.Printf("[ OK ] %-8s %s\n", name, detail)
+	}
+
+	path, _ := config.FilePath()
+	switch {
+	case cfg.ConfigFileError != "":
+		report("config", errors.New(cfg.ConfigFileError), "")
+	case cfg.ConfigFile != "":
+		report("config", nil, cfg.ConfigFile)
+	default:
+		report("config", nil, path+" 없음 (환경 변수/기본값 사용)")
+	}
+	for _, p := range cfg.Problems { // 대체 값으로 실행은 되므로 실패로 보지 않음
+		fmt.Printf("[WARN] %-8s %s\n", "config", p)
+	}
+
+	err := os.MkdirAll(cfg.DataDir, 0o755)
+	if err == nil {
+		var f *os.File
+		if f, err = os.CreateTemp(cfg.DataDir, "doctor-*"); err == nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}
+	report("data_dir", err, cfg.DataDir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), CLI_DOCTOR_TIMEOUT_SEC*time.Second)
+	defer cancel()
+	ag := agent.New(ctx, cancel, cfg, zap.NewNop().Sugar())
+	defer ag.Close()
+	monitors := ag.ListMonitors()
+	shot, err := ag.CaptureNow()
+	report("capture", err, fmt.Sprintf("%dx%d %s, 모니터 %d개", shot.Width, shot.Height, shot.Encoding, len(monitors)))
+
+	if *offline || cfg.LoopbackMode {
+		fmt.Printf("[SKIP] %-8s %s\n", "server", cfg.ServerAddr)
+	} else {
+		report("server", ag.Connect(), cfg.ServerAddr)
+	}
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// runDiag 함수는 진단 번들을 생성하고 (선택적으로) 서버에 업로드합니다.
+func runDiag(args []string) int { // 단일 책임: diag 서브커맨드 실행
+	fs := flag.NewFlagSet("diag", flag.ContinueOnError)
+	outDir := fs.String("o", ".", "진단 번들 저장 디렉터리")
+	upload := fs.Bool("upload", true, "서버로 번들 업로드 여부")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), CLI_DIAG_TIMEOUT_SEC*time.Second)
+	defer cancel()
+	cfg := config.Load()
+	logger, err := logging.NewLogger()
+	if err != nil {
+		logger = nil
+	}
+	ag := agent.New(ctx, cancel, cfg, logger)
+	defer ag.Close()
+	if *upload {
+		if err := ag.Connect(); err != nil { // 연결 실패 시 로컬 저장만 수행
+			fmt.Fprintf(os.Stderr, "서버 연결 실패, 로컬에만 저장합니다: %v\n", err)
+			*upload = false
+		}
+	}
+	path, err := ag.CollectDiagnostics(*outDir, *upload)
+	if path != "" {
+		fmt.Println(path)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "진단 번들 처리 실패: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runBench 함수는 서버 없이 합성 프레임으로 캡처/인코딩 파이프라인 성능을 측정해 출력합니다.
+// 인코딩 관련 기본값은 환경 설정을 따르고 플래그로 덮어씁니다.
+func runBench(args []string) int { // 단일 책임: bench 서브커맨드 실행
+	cfg := config.Load()
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	opts := agent.BenchOptions{Encoder: cfg.JpegEncoder, Keyframe: cfg.DeltaKeyframeInterval, TileEncoding: cfg.TileEncoding}
+	fs.IntVar(&opts.Width, "width", agent.BENCH_DEFAULT_WIDTH, "합성 프레임 폭")
+	fs.IntVar(&opts.Height, "height", agent.BENCH_DEFAULT_HEIGHT, "합성 프레임 높이")
+	fs.IntVar(&opts.Frames, "frames", agent.BENCH_DEFAULT_FRAMES, "처리할 프레임 수")
+	fs.IntVar(&opts.ChangePct, "change", agent.BENCH_DEFAULT_CHANGE, "프레임당 변경 면적 비율(%)")
+	fs.StringVar(&opts.Encoding, "encoding", cfg.CaptureEncoding, "png | jpeg | delta | tiles | webp")
+	fs.IntVar(&opts.Quality, "quality", cfg.JpegQuality, "jpeg/webp 품질")
+	fs.BoolVar(&opts.Lossless, "lossless", cfg.WebpLossless, "webp 무손실 압축")
+	fs.BoolVar(&opts.Grayscale, "gray", cfg.Grayscale, "png/jpeg 회색조 변환")
+	fs.StringVar(&opts.PngLevel, "png-level", cfg.PngCompression, "png 압축 수준 (default | speed | best | none)")
+	fs.IntVar(&opts.Workers, "workers", cfg.EncodeWorkers, "인코딩 워커 수 (0=자동)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	res, err := agent.RunBenchmark(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "벤치마크 실패: %v\n", err)
+		return 1
+	}
+	fmt.Printf("%dx%d encoding=%s change=%d%%\n", opts.Width, opts.Height, opts.Encoding, opts.ChangePct)
+	fmt.Println(res.String())
+	return 0
+}
+
+// runSecret 함수는 OS 비밀 저장소의 토큰/TLS 키/에이전트 ID 를 관리합니다.
+// set 은 값을 -file 또는 표준 입력에서 읽어 명령행 기록에 비밀이 남지 않게 하고, list 는 저장 여부만 출력합니다.
+func runSecret(args []string) int { // 단일 책임: secret 서브커맨드 실행
+	usage := "사용법: secret list | set [-file 경로] <이름> | delete <이름> (이름: " + strings.Join(secrets.Names, ", ") + ")"
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	secrets.SetEnabled(config.Load().SecretStore)
+	fs := flag.NewFlagSet("secret "+args[0], flag.ContinueOnError)
+	file := fs.String("file", "", "값을 읽을 파일 (미지정 시 표준 입력)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if args[0] == "list" {
+		for _, name := range secrets.Names {
+			_, err := secrets.Get(name)
+			switch {
+			case err == nil:
+				fmt.Printf("%-16s 저장됨\n", name)
+			case errors.Is(err, secrets.ErrNotFound):
+				fmt.Printf("%-16s 없음\n", name)
+			default:
+				fmt.Printf("%-16s %v\n", name, err)
+			}
+		}
+		return 0
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	name := fs.Arg(0)
+	var err error
+	switch args[0] {
+	case "set":
+		var data []byte
+		if *file != "" {
+			data, err = os.ReadFile(*file)
+		} else {
+			data, err = io.ReadAll(os.Stdin)
+		}
+		if err == nil {
+			err = secrets.Set(name, strings.TrimSpace(string(data)))
+		}
+	case "delete":
+		err = secrets.Delete(name)
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "secret %s 실패: %v\n", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+// runAutostart 함수는 로그인 자동 시작을 등록/해제/조회합니다 (설치/제거 프로그램 훅용, 사용자 단위).
+func runAutostart(args []string) int { // 단일 책임: autostart 서브커맨드 실행
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "사용법: autostart enable | disable | status")
+		return 2
+	}
+	var err error
+	switch args[0] {
+	case "enable":
+		err = autostart.Enable()
+	case "disable":
+		err = autostart.Disable()
+	case "status":
+		var on bool
+		if on, err = autostart.Enabled(); err == nil {
+			fmt.Println(map[bool]string{true: "등록됨", false: "등록 안 됨"}[on])
+		}
+	default:
+		fmt.Fprintln(os.Stderr, "사용법: autostart enable | disable | status")
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "autostart %s 실패: %v\n", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+// runConsent 함수는 캡처 동의를 기록/철회/조회합니다 (UI 없이 run 으로 쓰는 배포에서 첫 캡처 전에 동의).
+// 실행 중인 에이전트는 다음 시작 때 바뀐 기록을 읽습니다.
+func runConsent(args []string) int { // 단일 책임: consent 서브커맨드 실행
+	usage := "사용법: consent accept | revoke | status"
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	policy := config.Load().ConsentPolicyVersion
+	var err error
+	switch args[0] {
+	case "accept":
+		var rec agent.ConsentRecord
+		if rec, err = agent.RecordConsent(policy); err == nil {
+			fmt.Printf("동의 기록됨 (사용자 %s, 정책 %s)\n", rec.User, rec.PolicyVersion)
+		}
+	case "revoke":
+		err = agent.ClearConsent()
+	case "status":
+		rec := agent.ReadConsent()
+		switch {
+		case rec.AcceptedAt == 0:
+			fmt.Println("동의 없음")
+		case rec.PolicyVersion != policy:
+			fmt.Printf("이전 정책 %s 동의 (현재 정책 %s 동의 필요)\n", rec.PolicyVersion, policy)
+		default:
+			fmt.Printf("동의함 (사용자 %s, %s, 정책 %s)\n", rec.User, time.UnixMilli(rec.AcceptedAt).Format(time.RFC3339), rec.PolicyVersion)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "consent %s 실패: %v\n", args[0], err)
+		return 1
+	}
+	return 0
+}
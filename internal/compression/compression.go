@@ -0,0 +1,92 @@
+package compression
+
+import (
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/klauspost/compress/snappy"
+	"google.golang.org/grpc/encoding"
+	_ "google.golang.org/grpc/encoding/gzip" // gzip 압축기 등록 (grpc-go 기본 제공)
+)
+
+const (
+	NONE   = "none"   // 압축 안 함
+	GZIP   = "gzip"   // grpc-go 기본 gzip (압축률 우선)
+	SNAPPY = "snappy" // snappy 프레이밍 형식 (CPU 부담이 작아 큰 PNG/delta 프레임에 적합)
+)
+
+// Names 변수는 GRPC_COMPRESSION 에 쓸 수 있는 값 목록입니다.
+var Names = []string{NONE, GZIP, SNAPPY}
+
+// init 함수는 snappy 압축기를 gRPC 에 등록합니다 (클라이언트 송신과 루프백 서버 수신 공용).
+func init() {
+	encoding.RegisterCompressor(&snappyCompressor{})
+}
+
+// snappyCompressor 구조체는 gRPC encoding.Compressor 로 등록하는 snappy 압축기입니다.
+// 서버도 같은 이름("snappy")의 압축기를 등록해야 메시지를 풀 수 있습니다.
+type snappyCompressor struct { // 단일 책임: snappy 압축/해제
+	writers sync.Pool
+	readers sync.Pool
+}
+
+// snappyWriter 구조체는 Close 시 압축기를 풀에 돌려놓는 snappy 쓰기 래퍼입니다.
+type snappyWriter struct {
+	*snappy.Writer
+	pool *sync.Pool
+}
+
+// pooledReader 구조체는 끝까지 읽으면 해제기를 풀에 돌려놓는 snappy 읽기 래퍼입니다.
+type pooledReader struct {
+	*snappy.Reader
+	pool *sync.Pool
+}
+
+// Supported 함수는 name 이 지원하는 압축 방식인지 반환합니다.
+func Supported(name string) bool { // 단일 책임: 압축 이름 확인
+	return slices.Contains(Names, name)
+}
+
+// Name 메서드는 grpc-encoding 헤더에 쓰일 압축 이름입니다.
+func (c *snappyCompressor) Name() string { // 단일 책임: 압축 이름
+	return SNAPPY
+}
+
+// Compress 메서드는 w 에 snappy 로 압축해 쓰는 WriteCloser 를 반환합니다.
+func (c *snappyCompressor) Compress(w io.Writer) (io.WriteCloser, error) { // 단일 책임: 압축 스트림 생성
+	if sw, ok := c.writers.Get().(*snappyWriter); ok {
+		sw.Reset(w)
+		return sw, nil
+	}
+	return &snappyWriter{Writer: snappy.NewBufferedWriter(w), pool: &c.writers}, nil
+}
+
+// Decompress 메서드는 r 의 snappy 압축을 푸는 Reader 를 반환합니다.
+func (c *snappyCompressor) Decompress(r io.Reader) (io.Reader, error) { // 단일 책임: 해제 스트림 생성
+	if sr, ok := c.readers.Get().(*snappy.Reader); ok {
+		sr.Reset(r)
+		return &pooledReader{Reader: sr, pool: &c.readers}, nil
+	}
+	return &pooledReader{Reader: snappy.NewReader(r), pool: &c.readers}, nil
+}
+
+// Close 메서드는 남은 데이터를 내보내고 압축기를 풀에 돌려놓습니다.
+func (w *snappyWriter) Close() error { // 단일 책임: 압축 마무리
+	err := w.Writer.Close()
+	w.pool.Put(w)
+	return err
+}
+
+// Read 메서드는 압축을 풀어 읽고, EOF 에 닿으면 해제기를 풀에 돌려놓습니다.
+func (r *pooledReader) Read(p []byte) (int, error) { // 단일 책임: 해제 읽기
+	if r.Reader == nil {
+		return 0, io.EOF
+	}
+	n, err := r.Reader.Read(p)
+	if err == io.EOF {
+		r.pool.Put(r.Reader)
+		r.Reader = nil
+	}
+	return n, err
+}
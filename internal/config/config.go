@@ -1,8 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
+	"sync"
 )
 
 // 설정 기본값 상수 정의
@@ -10,86 +13,505 @@ const (
 	DEFAULT_SERVER_ADDR      = "localhost:50051" // 기본 gRPC 서버 주소
 	DEFAULT_CAPTURE_INTERVAL = 1000              // 기본 캡처 주기(ms)
 	DEFAULT_TARGET_FPS       = 60                // 기본 목표 FPS
+	MIN_TARGET_FPS           = 1                 // 목표 FPS 하한
+	MAX_TARGET_FPS           = 240               // 목표 FPS 상한
 	DEFAULT_FRAME_WIDTH      = 200               // 더미 프레임 폭
 	DEFAULT_FRAME_HEIGHT     = 150               // 더미 프레임 높이
-	DEFAULT_MONITOR_MODE     = "single"          // single | combined
+	DEFAULT_PATTERN_WIDTH    = 1920              // 테스트 패턴 프레임 폭
+	DEFAULT_PATTERN_HEIGHT   = 1080              // 테스트 패턴 프레임 높이
+	DEFAULT_MONITOR_MODE     = "single"          // single | combined | all | window
 	DEFAULT_MONITOR_INDEX    = 0                 // 기본 모니터 인덱스
-	DEFAULT_CAPTURE_ENCODING = "png"             // png | jpeg
+	DEFAULT_COMBINED_LAYOUT  = "horizontal"      // combined 모드 배치 (horizontal | vertical | grid | physical)
+	DEFAULT_CAPTURE_BACKEND  = "auto"            // auto(OS 전용 백엔드 우선) | screenshot
+	DEFAULT_CAPTURE_ENCODING = "png"             // png | jpeg | delta | tiles | webp | h264
 	DEFAULT_JPEG_QUALITY     = 80                // JPEG 품질 기본값
 	DEFAULT_PREVIEW_FLAG     = false             // 기본적으로 실제 캡처는 preview 아님
+	DEFAULT_DATA_DIR_NAME    = "agent"           // 사용자 캐시 디렉터리 하위 데이터 폴더명
+	DEFAULT_ENCODE_WORKERS   = 0                 // 인코딩 워커 수 (0=CPU 수 기반 자동)
+	DEFAULT_WATCHDOG_FRAMES  = 10                // 캡처 루프가 이 프레임 간격 수만큼 멈추면 재시작
+	DEFAULT_JPEG_ENCODER     = "auto"            // auto | stdlib | turbo
+	DEFAULT_WEBP_QUALITY     = 75                // WebP 손실 압축 품질 기본값
+	DEFAULT_TILE_ENCODING    = "png"             // tiles 인코딩 변경 영역 이미지 형식 (png | jpeg | webp)
+	DEFAULT_PNG_COMPRESSION  = "default"         // PNG 압축 수준 (default | speed | best | none)
+	DEFAULT_MAX_FRAME_BYTES  = 0                 // 인코딩 프레임 최대 크기(바이트) - 0 이면 제한 없음
+	DEFAULT_H264_ENCODER     = "auto"            // auto | nvenc | qsv | videotoolbox | x264
+	DEFAULT_H264_BITRATE     = 4000              // H.264 목표 비트레이트(kbps)
+	DEFAULT_H264_KEYFRAME    = 2                 // H.264 키프레임 간격(초)
+	DEFAULT_FFMPEG_PATH      = "ffmpeg"          // H.264 인코딩에 사용할 ffmpeg 실행 파일
+	DEFAULT_CHANGE_THRESHOLD = 0                 // 변경 셀 비율 임계값(%) - 0 이면 프레임 생략 비활성
+	DEFAULT_KEEPALIVE_MS     = 5000              // 정적 화면 keepalive 프레임 주기(ms)
+	DEFAULT_SKIP_IDENTICAL   = false             // 직전 전송 프레임과 픽셀이 같으면 인코딩/전송 생략
+	DEFAULT_ADAPTIVE_SCALE   = false             // 대역폭 압박 시 해상도 자동 축소
+	DEFAULT_BANDWIDTH_CAP    = 0                 // 프레임 전송 대역폭 상한(kbps) - 0 이면 품질 자동 조절 비활성
+	DEFAULT_SEND_LIMIT_KBS   = 0                 // 프레임 송신 속도 제한(KB/s) - 0 이면 제한 없음
+	DEFAULT_SEND_LIMIT_MODE  = "delay"           // 송신 예산 소진 시 프레임 처리 (delay | drop)
+	DEFAULT_MIN_QUALITY      = 30                // 대역폭 상한 적용 중 최소 JPEG/WebP 품질
+	DEFAULT_ADAPTIVE_FPS     = false             // 화면 정지 시 유휴 FPS 로 캡처 빈도 낮춤
+	DEFAULT_IDLE_FPS         = 1                 // 화면 정지 중 캡처 FPS
+	DEFAULT_IDLE_AFTER       = 30                // 유휴 FPS 로 전환하기까지의 연속 무변화 프레임 수
+	DEFAULT_PAUSE_ON_LOCK    = true              // 화면 잠금 중 캡처 일시 중지
+	DEFAULT_MASK_MODE        = "black"           // 개인정보 가림 영역 처리 (black | blur)
+	DEFAULT_BLOCKED_ACTION   = "blank"           // 캡처 금지 앱 전면 시 처리 (blank | pause)
+	DEFAULT_CONSENT_POLICY   = "1"               // 캡처 동의 정책 버전 (바꾸면 이전 동의는 무효)
+	DEFAULT_FOCUS_POLL_MS    = 1000              // 전면 창 변경 확인 주기(ms)
+	DEFAULT_FOCUS_TITLE      = "full"            // 전면 창 이벤트 제목 기록 방식 (full | none)
+	DEFAULT_PROCESS_WATCH_MS = 2000              // 감시 프로세스 시작/종료 확인 주기(ms)
+	DEFAULT_INPUT_REPORT_SEC = 60                // 입력 활동 집계 보고 주기(초)
+	DEFAULT_CLIPBOARD_DETAIL = "none"            // 클립보드 이벤트 내용 기록 (none | hash | text)
+	DEFAULT_CLIPBOARD_TEXT   = 64                // 클립보드 text 기록 시 최대 글자 수
+	DEFAULT_NETWORK_POLL_MS  = 3000              // 네트워크 인터페이스/기본 경로 확인 주기(ms)
+	DEFAULT_BATTERY_LOW_PCT  = 20                // battery_low 이벤트 기준 배터리 잔량(%)
+	DEFAULT_BATTERY_FPS      = 5                 // 배터리 절약 중 TargetFPS 상한
+	DEFAULT_RESOURCE_PCT     = 90                // CPU/메모리/디스크 사용률 이벤트 임계값(%)
+	DEFAULT_RESOURCE_SUSTAIN = 60                // 임계값 초과가 이어져야 보고하는 시간(초)
+	DEFAULT_WATERMARK_POS    = "bottom-right"    // 워터마크 위치 (top-left | top-right | bottom-left | bottom-right)
+	DEFAULT_INDICATOR_POS    = "top-right"       // 캡처 중 표시 위치 (top-left | top-right | bottom-left | bottom-right)
+	DEFAULT_WATERMARK_ALPHA  = 70                // 워터마크 불투명도(%)
+	DEFAULT_PREVIEW_SCALE    = 25                // 이중 스트림 preview 프레임 해상도 비율(%)
+	DEFAULT_FULL_STREAM_FPS  = 2                 // 이중 스트림 원본 해상도 프레임 FPS
+	DEFAULT_UI_PREVIEW_MS    = 500               // UI 실시간 미리보기 갱신 간격(ms)
+	DEFAULT_UI_PREVIEW_WIDTH = 480               // UI 실시간 미리보기 최대 폭(px)
+	DEFAULT_NOTIFY_DOWN_SEC  = 30                // 이 시간(초) 넘게 연결이 끊기면 데스크톱 알림
+	DEFAULT_EVENT_HISTORY    = 200               // UI 에서 볼 수 있게 보관하는 최근 보고 이벤트 수
+	DEFAULT_CAPTURE_SCALE    = 1.0               // 인코딩 전 고정 출력 배율 (1=원본)
+	DEFAULT_SCALE_FILTER     = "catmullrom"      // 고정 출력 축소 보간 (catmullrom | bilinear | approx)
+	DEFAULT_EVENT_BATCH_MS   = 100               // 이벤트 묶음 시간 창(ms) - 0 이면 즉시 전송
+	DEFAULT_EVENT_SEVERITY   = "debug"           // 발행할 최소 이벤트 심각도 (debug 이면 전체)
+	DEFAULT_EVENT_ACK_MB     = 16                // 확인 대기 이벤트 큐 디스크 용량(MB)
+	DEFAULT_ACK_TIMEOUT_SEC  = 30                // 이벤트 수신 확인 대기 제한(초)
+	DEFAULT_LOG_LEVEL        = "info"            // debug | info | warn | error
+	DEFAULT_TRACE_SAMPLE     = 0.1               // 파이프라인 추적 프레임 표본 비율 (0 초과 1 이하)
+	DEFAULT_TRACE_SLOW_MS    = 500               // 이 시간 이상 걸린 추적 구간은 로그로 남김(ms, 0=끔)
+	DEFAULT_PPROF_ADDR       = "127.0.0.1:6060"  // pprof 진단 엔드포인트 주소 (루프백만 허용)
+	DEFAULT_HEALTH_ADDR      = "127.0.0.1:6061"  // 상태 확인 엔드포인트 주소 (루프백만 허용)
+	DEFAULT_SHUTDOWN_MS      = 5000              // 종료 시 대기 프레임/이벤트 전송을 기다리는 상한(ms)
+	DEFAULT_REMOTE_CONFIG    = 300               // 서버 관리 설정 조회 주기(초) - 0 이면 등록 때만
+	DEFAULT_DELTA_KEYFRAME   = 60                // delta/tiles 인코딩 키프레임 주기(프레임 수)
+	DEFAULT_CPU_MAX_PROCS    = 0                 // GOMAXPROCS 상한 (0=변경 없음)
+	DEFAULT_KEYCHAIN_USER    = "agent"           // OS 키체인 토큰 계정명 기본값
+	DEFAULT_BACKOFF_BASE_MS  = 500               // 재연결 백오프 첫 간격(ms)
+	DEFAULT_BACKOFF_MAX_MS   = 30000             // 재연결 백오프 간격 상한(ms)
+	DEFAULT_BACKOFF_JITTER   = 20                // 재연결 백오프 지터(±%)
+	DEFAULT_KEEPALIVE_PING   = 15000             // 연결 keepalive ping 간격(ms) - 끊긴 NAT/VPN 경로를 ping+timeout 안에 감지
+	DEFAULT_KEEPALIVE_WAIT   = 5000              // keepalive ping 응답 대기(ms) - 넘으면 연결을 닫고 재연결
+	DEFAULT_GRPC_COMPRESSION = "none"            // 스트림 메시지 압축 (서버가 같은 압축기를 지원해야 함)
+	MIN_KEEPALIVE_PING       = 10000             // gRPC 클라이언트가 허용하는 최소 ping 간격(ms)
+	DEFAULT_MAX_MESSAGE      = 4 << 20           // gRPC 메시지 크기 상한(바이트) - gRPC 서버 기본 수신 한도와 같음
+	MIN_MAX_MESSAGE          = 64 << 10          // 메시지 크기 상한 최솟값 (조각 머리말을 빼고도 쓸 만한 조각이 남도록)
+	DEFAULT_HEARTBEAT_MS     = 10000             // Heartbeat 보고 주기(ms) - 0 이면 비활성
+	DEFAULT_HEARTBEAT_FAILS  = 3                 // 서버 응답 없음으로 표시할 Heartbeat 연속 실패 횟수
+	DEFAULT_SPOOL_FRAME_MB   = 512               // 오프라인 프레임 스풀 용량(MB) - 0 이면 비활성
+	DEFAULT_SPOOL_EVENT_MB   = 64                // 오프라인 이벤트 스풀 용량(MB) - 0 이면 비활성
+	DEFAULT_SPOOL_MAX_AGE    = 86400             // 스풀 기록 최대 보관 시간(초) - 0 이면 제한 없음
+	DEFAULT_SPOOL_FRAME_GAP  = 1000              // 오프라인 중 프레임 보관 최소 간격(ms) - 0 이면 전부 보관
+	DEFAULT_SPOOL_FRAME_DROP = "oldest"          // 프레임 스풀 가득 참: 가장 오래된 것부터 폐기 (최신 화면 우선)
+	DEFAULT_SPOOL_EVENT_DROP = "newest"          // 이벤트 스풀 가득 참: 새 이벤트 거부 (기존 이력 연속성 우선)
+	DEFAULT_FRAME_QUEUE_SIZE = 8                 // 프레임 송신 큐 용량 (가득 차면 가장 오래된 프레임 폐기)
 )
 
 // Config 구조체는 에이전트 실행에 필요한 환경 설정 값을 보관합니다.
 type Config struct { // 단일 책임: 환경 설정 보관
-	ServerAddr        string // gRPC 서버 주소
-	CaptureIntervalMs int    // 캡처 주기(ms)
-	TargetFPS         int    // 목표 FPS (설정 시 CaptureIntervalMs 무시)
-	FrameWidth        int    // 프레임 폭 (더미 모드)
-	FrameHeight       int    // 프레임 높이 (더미 모드)
-	MonitorMode       string // single | combined
-	MonitorIndex      int    // single 모드일 때 사용
-	CaptureEncoding   string // png | jpeg
-	JpegQuality       int    // jpeg 품질 (1~100)
-	ForcePreview      bool   // 강제 preview 플래그
+	ServerAddr             string    // gRPC 서버 주소 (쉼표로 여러 개 지정 시 앞에서부터 시도하고 장애 시 다음 주소로 전환)
+	AgentID                string    // 에이전트 ID (빈 값이면 처음 만든 UUID 를 비밀 저장소와 사용자 설정 폴더에 저장해 재사용)
+	CaptureIntervalMs      int       // 캡처 주기(ms)
+	TargetFPS              int       // 목표 FPS (설정 시 CaptureIntervalMs 무시)
+	FrameWidth             int       // 프레임 폭 (더미 모드)
+	FrameHeight            int       // 프레임 높이 (더미 모드)
+	MonitorMode            string    // single | combined | all | window
+	CombinedLayout         string    // combined 모드 모니터 배치 (horizontal | vertical | grid | physical: 실제 화면 배치)
+	MonitorIndex           int       // single 모드일 때 사용
+	CaptureBackend         string    // 모니터 캡처 백엔드 (auto: Windows DXGI Desktop Duplication / macOS 12.3+ ScreenCaptureKit 우선 | screenshot)
+	WindowTitle            string    // window 모드 대상 창 제목 (부분 일치, 대소문자 무시)
+	WindowProcess          string    // window 모드 대상 프로세스 이름 (확장자 무시)
+	CaptureEncoding        string    // png | jpeg | delta | tiles | webp | h264 (h264 는 ffmpeg 필요)
+	JpegQuality            int       // jpeg 품질 (1~100)
+	ForcePreview           bool      // 강제 preview 플래그
+	DataDir                string    // 로컬 데이터(녹화 등) 저장 디렉터리
+	EncodeWorkers          int       // 비동기 인코딩 단계 워커 수 (0=자동)
+	WatchdogFrames         int       // 캡처 루프가 이 프레임 간격 수(최소 5초) 동안 진행하지 않으면 멈춘 것으로 보고 재시작 (0=감시 안 함)
+	JpegEncoder            string    // auto | stdlib | turbo (turbo 는 jpegturbo 빌드 태그 필요)
+	WebpQuality            int       // webp 손실 압축 품질 (1~100)
+	WebpLossless           bool      // webp 무손실 압축 (손실 압축은 libwebp 빌드 태그 필요)
+	TileEncoding           string    // tiles 인코딩에서 변경 영역을 담는 이미지 형식 (png | jpeg | webp)
+	Grayscale              bool      // png/jpeg 인코딩 전에 8비트 회색조로 변환 (저대역폭 회선용)
+	PngCompression         string    // PNG 압축 수준 (default | speed | best | none)
+	MaxFrameBytes          int       // png/jpeg/webp 프레임 최대 크기(바이트) - 넘으면 품질→해상도 순으로 낮춰 다시 인코딩 (0=제한 없음)
+	H264Encoder            string    // auto | nvenc | qsv | videotoolbox | x264 (auto: 하드웨어 우선 시도)
+	H264BitrateKbps        int       // H.264 목표 비트레이트(kbps)
+	H264KeyframeSec        int       // H.264 키프레임 간격(초)
+	FFmpegPath             string    // ffmpeg 실행 파일 경로 (PATH 검색)
+	ChangeThresholdPct     int       // 이 비율(%) 미만으로 변한 프레임은 생략 (0=비활성)
+	KeepaliveFrameMs       int       // 정적 화면에서 프레임을 보내는 최소 주기(ms)
+	SkipIdenticalFrames    bool      // 원본 픽셀 해시가 직전 전송 프레임과 같으면 인코딩/전송 생략
+	UnchangedMarker        bool      // 동일 프레임 생략 중 KeepaliveFrameMs 마다 이미지 없는 unchanged 표시 프레임 전송
+	AdaptiveScale          bool      // 전송 지연/백로그 시 100→75→50% 단계적 축소 및 회복 시 복원
+	BandwidthCapKbps       int       // 프레임 전송 대역폭 상한(kbps) - 넘으면 품질→해상도 순으로 낮추고 여유 시 복원 (0=비활성)
+	FrameSendLimitKBs      int       // 프레임 송신 토큰 버킷 속도(KB/s) - 품질 조절과 달리 넘지 않도록 강제 (0=제한 없음)
+	FrameSendLimitPolicy   string    // 송신 예산 소진 시: delay(기다려 보냄) | drop(버림)
+	MinQuality             int       // 대역폭 상한 적용 중에도 유지할 최소 JPEG/WebP 품질 (1~100)
+	AdaptiveFPS            bool      // 연속 무변화 프레임이 이어지면 IdleFPS 로 낮추고 움직임 감지 시 TargetFPS 로 복귀
+	IdleFPS                int       // 화면 정지 중 캡처 FPS (TargetFPS 이상이면 효과 없음)
+	IdleAfterFrames        int       // 유휴 전환 기준 연속 무변화 프레임 수
+	PauseOnLock            bool      // 화면 잠금(Windows 세션 잠금, macOS 화면 잠금, Linux 화면 보호기/logind) 중 캡처 일시 중지
+	CaptureSchedule        string    // 캡처 허용 시간대 (로컬 시간, 예: "mon-fri 09:00-18:00; sat 10:00-13:00", 빈 값이면 제한 없음)
+	CaptureScale           float64   // 인코딩 전 출력 배율 (0 초과 1 이하, 1=원본)
+	CaptureMaxWidth        int       // 출력 최대 폭(px) - 넘으면 비율 유지 축소 (0=제한 없음)
+	CaptureMaxHeight       int       // 출력 최대 높이(px) - 넘으면 비율 유지 축소 (0=제한 없음)
+	CaptureScaleFilter     string    // 고정 출력 축소 보간 방식 (catmullrom | bilinear | approx)
+	PrivacyMasks           string    // 인코딩 전 가릴 영역 목록 "[모니터@]x,y,w,h;..." (프레임 픽셀 좌표, 모니터 생략 시 모든 프레임)
+	PrivacyMaskMode        string    // 가림 방식 (black: 검은 사각형 | blur: 모자이크)
+	BlockedApps            string    // 캡처 금지 앱 목록 (쉼표 구분, 프로세스 이름 또는 "title:" 접두 창 제목 부분 일치)
+	BlockedAppAction       string    // 캡처 금지 앱이 전면일 때 처리 (blank: 검은 프레임 전송 | pause: 캡처 중지)
+	ConsentRequired        bool      // 기록된 사용자 동의(현재 정책 버전)가 있어야 캡처 시작
+	ConsentPolicyVersion   string    // 동의 대상 정책 버전 (기록된 동의의 버전과 다르면 다시 동의 필요)
+	FocusEvents            bool      // 전면 앱/창 제목이 바뀔 때마다 foreground_changed 이벤트 발행 (프레임과 사용자 작업 대조용)
+	FocusPollMs            int       // 전면 창 변경 확인 주기(ms)
+	FocusTitleMode         string    // 전면 창 이벤트 제목 기록 (full: 그대로 | none: 프로세스만)
+	FocusRedactApps        string    // 전면 창 이벤트에서 제목을 가릴 앱 목록 (BlockedApps 와 같은 형식, 캡처 금지 앱은 항상 가림)
+	ProcessWatchlist       string    // 시작/종료 이벤트를 발행할 프로세스 이름 목록 (쉼표 구분, 확장자/대소문자 무시, 빈 값이면 비활성)
+	ProcessWatchMs         int       // 감시 프로세스 확인 주기(ms)
+	InputActivity          bool      // 키 입력/클릭 수와 마우스 이동 거리를 구간별로 집계해 input_activity 이벤트 발행 (키 내용은 수집하지 않음)
+	InputActivitySec       int       // 입력 활동 집계 보고 주기(초)
+	ClipboardEvents        bool      // 클립보드 변경 시 clipboard_changed 이벤트 발행 (명시적 opt-in, 사용자 동의가 있어야 동작)
+	ClipboardConsent       bool      // 클립보드 감시 사용자 동의 초기값 (별도 절차로 동의를 받은 배포용, 실행 중 SetClipboardConsent 로 변경)
+	ClipboardDetail        string    // 클립보드 이벤트 내용 기록 (none: 종류/크기만 | hash: SHA-256 | text: 텍스트 앞부분, 그 외 SHA-256)
+	ClipboardTextMax       int       // ClipboardDetail=text 일 때 기록할 최대 글자 수
+	NetworkEvents          bool      // 인터페이스 활성/비활성, 주소, 기본 경로(Wi-Fi/유선/VPN 전환) 변경 이벤트 발행
+	NetworkPollMs          int       // 네트워크 상태 확인 주기(ms)
+	SessionEvents          bool      // OS 세션 로그인/로그아웃과 에이전트 세션 잠금/해제를 사용자 이름과 함께 이벤트로 발행
+	PowerEvents            bool      // AC/배터리 전환과 배터리 부족 이벤트 발행
+	BatteryLowPct          int       // battery_low 이벤트 기준 잔량(%, 1~99)
+	PowerSaver             bool      // 배터리 사용 중 TargetFPS 를 BatteryFPS 로 낮추고 (BatteryJpeg 시) 무손실 인코딩을 jpeg 로 전환, AC 연결 시 복원
+	BatteryFPS             int       // 배터리 절약 중 TargetFPS 상한
+	BatteryJpeg            bool      // 배터리 절약 중 png/webp/delta/tiles 인코딩을 jpeg 로 전환 (h264 는 유지)
+	DisplayWatch           bool      // 모니터 연결/해제와 해상도 변경 시 캡처러 재구성 + display_changed 이벤트 발행
+	ResourceEvents         bool      // 시스템 CPU/메모리/디스크 사용률이 임계값 이상으로 지속되면 resource_high, 회복 시 resource_normal 이벤트 발행
+	CPUThresholdPct        int       // 시스템 CPU 사용률 임계값(%, 0=감시 안 함)
+	MemThresholdPct        int       // 물리 메모리 사용률 임계값(%, 0=감시 안 함)
+	DiskThresholdPct       int       // 디스크 사용률 임계값(%, 0=감시 안 함)
+	ResourceDiskPath       string    // 디스크 사용률을 확인할 경로 (빈 값이면 DataDir 볼륨)
+	ResourceSustainSec     int       // 임계값 이상이 이 시간(초) 이어져야 보고
+	FileWatchPaths         string    // 파일 생성/변경/삭제 이벤트를 발행할 디렉터리 목록 (쉼표 구분, 빈 값이면 비활성)
+	FileWatchRecursive     bool      // FileWatchPaths 하위 디렉터리까지 감시
+	Watermark              bool      // 인코딩 전 프레임에 시각/에이전트 ID/호스트명 워터마크 삽입 (감사/증적용)
+	WatermarkPosition      string    // 워터마크 위치 (top-left | top-right | bottom-left | bottom-right)
+	WatermarkOpacity       int       // 워터마크 불투명도(%, 1~100)
+	DualStream             bool      // TargetFPS 의 저해상도 preview(IsPreview) 스트림과 FullStreamFPS 의 원본 해상도 스트림을 함께 전송 (h264 제외)
+	PreviewScalePct        int       // 이중 스트림 preview 프레임 해상도 비율(%, 1~99)
+	UIPreviewMs            int       // UI 실시간 미리보기 갱신 간격(ms, 100 이상, 미리보기를 켠 동안만 생성)
+	UIPreviewWidth         int       // UI 실시간 미리보기 최대 폭(px, 64~1920)
+	TrayEnabled            bool      // 시스템 트레이(메뉴 막대) 아이콘과 빠른 제어 메뉴 표시
+	CloseToTray            bool      // 창을 닫으면 종료하지 않고 트레이로 숨김 (트레이 표시 중일 때만)
+	StartMinimized         bool      // 창을 띄우지 않고 시작 (트레이에서 열기, 로그인 자동 시작용)
+	CaptureIndicator       bool      // 캡처 중 항상 위 작은 표시 창을 띄움 (화면 전송 사실 고지)
+	IndicatorPosition      string    // 캡처 중 표시 위치 (top-left | top-right | bottom-left | bottom-right, 주 모니터 기준)
+	Notifications          bool      // 연결 끊김/원격 명령/원격 캡처 시작·중지 데스크톱 알림
+	NotifyDisconnectSec    int       // 연결 끊김이 이 시간(초)을 넘으면 알림 (0=연결 끊김 알림 안 함)
+	Locale                 string    // UI/트레이/알림 문구 언어 (ko | en, 빈 값이면 OS 로캘)
+	EventHistory           int       // UI 보고 이벤트 목록에 보관할 최근 이벤트 수 (0=보관 안 함)
+	FullStreamFPS          int       // 이중 스트림 원본 해상도 프레임 FPS (TargetFPS 이상이면 매 프레임)
+	EventBatchWindowMs     int       // 이 시간 창 안에 발생한 이벤트를 하나의 메시지로 묶음 (0=비활성)
+	EventAllow             string    // 발행할 이벤트 타입/분류 목록 (쉼표 구분, 빈 값이면 전체)
+	EventDeny              string    // 발행하지 않을 이벤트 타입/분류 목록 (쉼표 구분, EventAllow 보다 우선)
+	EventMinSeverity       string    // 발행할 최소 심각도 (debug | info | warning | critical)
+	DisabledSources        string    // 시작하지 않을 이벤트 발생원 이름 목록 (쉼표 구분, focus | process | input | clipboard | network | resource | file 또는 등록한 발생원)
+	EventAckQueue          bool      // 이벤트를 서버 확인(StreamAckedEvents) 전까지 디스크에 보관하고 재시작/재연결 후 재전송 (서버 지원 필요)
+	EventAckTypes          string    // 확인 대기 큐로 보낼 이벤트 타입/분류 목록 (쉼표 구분, 빈 값이면 전체 - 나머지는 일반 전송)
+	EventAckMaxMB          int       // 확인 대기 큐 디스크 용량(MB) - 가득 차면 새 이벤트는 일반 전송
+	EventAckTimeoutSec     int       // 보낸 이벤트 확인 대기 제한(초) - 넘으면 스트림을 다시 열어 재전송
+	DeltaKeyframeInterval  int       // delta/tiles 인코딩에서 키프레임(전체 화면)을 보내는 프레임 주기
+	CPUMaxProcs            int       // 에이전트가 사용할 최대 OS 스레드(GOMAXPROCS) 수 (0=제한 없음)
+	CPULowPriority         bool      // 프로세스 우선순위 낮춤 (nice / BELOW_NORMAL)
+	CPUEfficiencyMode      bool      // 효율 코어 우선 실행 (Windows EcoQoS, macOS 백그라운드 밴드)
+	CPUAffinity            string    // CPU 고정 목록 (예: "4-7" 또는 "0,2") - 빈 값이면 미적용
+	LoopbackMode           bool      // 프로세스 내 모의 서버로 송신 (외부 수집 서버 없이 로컬 점검)
+	TestPattern            bool      // 실제 화면 대신 움직이는 테스트 패턴/시각을 TargetFPS 로 캡처 (디스플레이 없는 벤치마크/시연용)
+	TestPatternWidth       int       // 테스트 패턴 프레임 폭
+	TestPatternHeight      int       // 테스트 패턴 프레임 높이
+	FrameQueueSize         int       // 프레임 송신 큐 용량
+	TLSEnabled             bool      // TLS 사용 (CA/인증서 경로 지정 시 자동 활성)
+	TLSCAFile              string    // 서버 인증서 검증용 CA PEM 경로 (빈 값이면 시스템 루트)
+	TLSCertFile            string    // 상호 TLS 클라이언트 인증서 PEM 경로 (변경 시 자동 재적재)
+	TLSKeyFile             string    // 상호 TLS 클라이언트 개인키 PEM 경로 (빈 값이면 비밀 저장소의 tls_client_key)
+	TLSServerName          string    // 서버 인증서 검증 이름 재지정 (빈 값이면 주소의 호스트)
+	AuthToken              string    // 스트림 메타데이터로 보낼 API 키/JWT
+	AuthTokenFile          string    // 토큰 파일 경로 (AuthToken 미설정 시, 재획득 때마다 다시 읽음)
+	AuthKeychainService    string    // OS 키체인 서비스명 (위 두 값 미설정 시 키체인에서 토큰 조회)
+	AuthKeychainUser       string    // OS 키체인 계정명
+	SecretStore            bool      // OS 비밀 저장소(internal/secrets)에 저장한 토큰/TLS 키/에이전트 ID 사용 (끄면 조회/저장 안 함)
+	BackoffBaseMs          int       // 연결/스트림 재시도 첫 간격(ms), 실패마다 2배
+	BackoffMaxMs           int       // 재시도 간격 상한(ms)
+	BackoffJitterPct       int       // 재시도 간격 무작위 편차(±%)
+	KeepaliveTimeMs        int       // 연결 keepalive ping 간격(ms, 0=끔) - 서버 EnforcementPolicy.MinTime 이 이보다 길면 GOAWAY 로 간격이 늘어남
+	KeepaliveTimeoutMs     int       // keepalive ping 응답 대기(ms) - 응답이 없으면 연결을 끊어 재연결 경로로 넘김
+	KeepaliveIdle          bool      // 열린 스트림이 없어도 keepalive ping 전송 (PermitWithoutStream)
+	Compression            string    // 프레임/이벤트 스트림 메시지 압축 (none | gzip | snappy) - png/delta/tiles 에 효과, jpeg/webp 는 이미 압축됨
+	MaxMessageBytes        int       // gRPC 송수신 메시지 크기 상한(바이트) - 이보다 큰 프레임은 FrameChunk 조각으로 나눠 보냄 (서버 수신 한도 이하로)
+	HeartbeatIntervalMs    int       // 상태 보고(Heartbeat) 주기(ms)
+	HeartbeatFailThreshold int       // 이 횟수만큼 연속 실패하면 서버 응답 없음으로 표시
+	SpoolFrameMaxMB        int       // 서버 미연결 중 프레임 디스크 보관 용량(MB)
+	SpoolEventMaxMB        int       // 서버 미연결 중 이벤트 디스크 보관 용량(MB)
+	SpoolMaxAgeSec         int       // 이보다 오래된 보관 기록은 재전송하지 않음(초)
+	SpoolFrameIntervalMs   int       // 미연결 중 프레임 보관 최소 간격(ms)
+	SpoolFrameDrop         string    // oldest | newest - 프레임 스풀 가득 참 시 폐기 정책
+	SpoolEventDrop         string    // oldest | newest - 이벤트 스풀 가득 참 시 폐기 정책
+	LogLevel               string    // 로그 수준 (debug | info | warn | error)
+	TraceEnabled           bool      // 캡처→인코딩→송신 파이프라인 OpenTelemetry 추적
+	TraceOTLPEndpoint      string    // 추적 OTLP/gRPC 수집기 주소 (host:port, 빈 값이면 내보내지 않고 느린 구간 로그만)
+	TraceOTLPInsecure      bool      // 추적 수집기에 평문 연결 (로컬 수집기용)
+	TraceSampleRatio       float64   // 추적할 프레임 비율 (0 초과 1 이하)
+	TraceSlowMs            int       // 표본 프레임 중 느린 구간 로그 기준(ms, 0=끔)
+	PprofEnabled           bool      // 시작 시 로컬 pprof 진단 엔드포인트 열기 (UI/원격 명령으로도 켜고 끔)
+	PprofAddr              string    // pprof 엔드포인트 주소 (127.0.0.1/::1/localhost 만 허용)
+	HealthEnabled          bool      // 로컬 상태 확인 엔드포인트(/healthz, /readyz) 열기 (장치 관리 도구 점검용)
+	HealthAddr             string    // 상태 확인 엔드포인트 주소 (127.0.0.1/::1/localhost 만 허용)
+	ShutdownTimeoutMs      int       // 종료 시 캡처 중 프레임/송신 큐/대기 이벤트 전송을 기다리는 상한(ms) - 넘으면 남은 기록은 오프라인 스풀 보관
+	ConfigReload           bool      // 설정 파일이 바뀌면 FPS/품질/인코딩/모니터 모드/로그 수준을 재시작 없이 적용
+	RemoteConfig           bool      // 등록 때와 RemoteConfigSec 주기로 서버 관리 설정(GetConfig)을 받아 적용
+	RemoteConfigSec        int       // 서버 관리 설정 조회 주기(초, 0 이면 등록 때만, 서버가 간격을 지정하면 그 값)
+	ConfigFile             string    // 적용한 설정 파일 경로 (읽지 않았으면 빈 값)
+	ConfigFileError        string    // 설정 파일 읽기/해석 실패 사유 (환경 변수와 기본값으로 계속 진행)
+	Problems               []Problem // 해석/검증에 실패해 대체 값을 사용한 항목 (Validate 결과 포함)
 }
 
-// Load 함수는 환경 변수에서 설정을 읽어 Config 를 반환합니다.
-func Load() *Config { // 단일 책임: 환경 변수 파싱
+// loadMu 변수는 Load 동시 호출을 직렬화합니다 (fileValues/parseProblems 는 Load 중에만 채움).
+var loadMu sync.Mutex
+
+// parseProblems 변수는 Load 중 숫자/불리언으로 해석하지 못한 설정 값입니다.
+var parseProblems []Problem
+
+// Load 함수는 설정 파일, UI 변경 설정, 환경 변수에서 설정을 읽어 Config 를 반환합니다. 같은 항목은 환경 변수가 우선합니다.
+func Load() *Config { // 단일 책임: 설정 파싱
+	loadMu.Lock()
+	defer loadMu.Unlock()
+	loadSettings()
+	return build()
+}
+
+// build 함수는 읽어 둔 UI 변경 설정과 설정 파일, 환경 변수로 Config 를 만들어 검증합니다 (loadMu 보유 상태에서 호출).
+func build() *Config { // 단일 책임: 설정 구성
+	parseProblems = nil
+	path, explicit := FilePath()
+	values, ferr := loadFile(path)
+	fileValues = values
 	cfg := &Config{
-		ServerAddr:        getEnvString("AGENT_SERVER_ADDR", DEFAULT_SERVER_ADDR),
-		CaptureIntervalMs: getEnvInt("CAPTURE_INTERVAL_MS", DEFAULT_CAPTURE_INTERVAL),
-		TargetFPS:         getEnvInt("CAPTURE_TARGET_FPS", DEFAULT_TARGET_FPS),
-		FrameWidth:        getEnvInt("FRAME_WIDTH", DEFAULT_FRAME_WIDTH),
-		FrameHeight:       getEnvInt("FRAME_HEIGHT", DEFAULT_FRAME_HEIGHT),
-		MonitorMode:       getEnvString("CAPTURE_MONITOR_MODE", DEFAULT_MONITOR_MODE),
-		MonitorIndex:      getEnvInt("CAPTURE_MONITOR_INDEX", DEFAULT_MONITOR_INDEX),
-		CaptureEncoding:   getEnvString("CAPTURE_ENCODING", DEFAULT_CAPTURE_ENCODING),
-		JpegQuality:       getEnvInt("JPEG_QUALITY", DEFAULT_JPEG_QUALITY),
-		ForcePreview:      getEnvBool("CAPTURE_FORCE_PREVIEW", DEFAULT_PREVIEW_FLAG),
-	}
-	if cfg.MonitorMode != "single" && cfg.MonitorMode != "combined" { // 값 검증
-		cfg.MonitorMode = DEFAULT_MONITOR_MODE
-	}
-	if cfg.TargetFPS < 1 || cfg.TargetFPS > 240 { // FPS 범위 검증 (1~240)
-		cfg.TargetFPS = DEFAULT_TARGET_FPS
+		ServerAddr:             getEnvString("AGENT_SERVER_ADDR", DEFAULT_SERVER_ADDR),
+		AgentID:                getEnvString("AGENT_ID", ""),
+		CaptureIntervalMs:      getEnvInt("CAPTURE_INTERVAL_MS", DEFAULT_CAPTURE_INTERVAL),
+		TargetFPS:              getEnvInt("CAPTURE_TARGET_FPS", DEFAULT_TARGET_FPS),
+		FrameWidth:             getEnvInt("FRAME_WIDTH", DEFAULT_FRAME_WIDTH),
+		FrameHeight:            getEnvInt("FRAME_HEIGHT", DEFAULT_FRAME_HEIGHT),
+		MonitorMode:            getEnvString("CAPTURE_MONITOR_MODE", DEFAULT_MONITOR_MODE),
+		CombinedLayout:         getEnvString("CAPTURE_COMBINED_LAYOUT", DEFAULT_COMBINED_LAYOUT),
+		CaptureBackend:         getEnvString("CAPTURE_BACKEND", DEFAULT_CAPTURE_BACKEND),
+		WindowTitle:            getEnvString("CAPTURE_WINDOW_TITLE", ""),
+		WindowProcess:          getEnvString("CAPTURE_WINDOW_PROCESS", ""),
+		MonitorIndex:           getEnvInt("CAPTURE_MONITOR_INDEX", DEFAULT_MONITOR_INDEX),
+		CaptureEncoding:        getEnvString("CAPTURE_ENCODING", DEFAULT_CAPTURE_ENCODING),
+		JpegQuality:            getEnvInt("JPEG_QUALITY", DEFAULT_JPEG_QUALITY),
+		ForcePreview:           getEnvBool("CAPTURE_FORCE_PREVIEW", DEFAULT_PREVIEW_FLAG),
+		DataDir:                getEnvString("AGENT_DATA_DIR", defaultDataDir()),
+		EncodeWorkers:          getEnvInt("CAPTURE_ENCODE_WORKERS", DEFAULT_ENCODE_WORKERS),
+		WatchdogFrames:         getEnvInt("CAPTURE_WATCHDOG_FRAMES", DEFAULT_WATCHDOG_FRAMES),
+		JpegEncoder:            getEnvString("JPEG_ENCODER", DEFAULT_JPEG_ENCODER),
+		WebpQuality:            getEnvInt("WEBP_QUALITY", DEFAULT_WEBP_QUALITY),
+		WebpLossless:           getEnvBool("WEBP_LOSSLESS", false),
+		TileEncoding:           getEnvString("TILE_ENCODING", DEFAULT_TILE_ENCODING),
+		Grayscale:              getEnvBool("CAPTURE_GRAYSCALE", false),
+		PngCompression:         getEnvString("PNG_COMPRESSION", DEFAULT_PNG_COMPRESSION),
+		MaxFrameBytes:          getEnvInt("MAX_FRAME_BYTES", DEFAULT_MAX_FRAME_BYTES),
+		H264Encoder:            getEnvString("H264_ENCODER", DEFAULT_H264_ENCODER),
+		H264BitrateKbps:        getEnvInt("H264_BITRATE_KBPS", DEFAULT_H264_BITRATE),
+		H264KeyframeSec:        getEnvInt("H264_KEYFRAME_SEC", DEFAULT_H264_KEYFRAME),
+		FFmpegPath:             getEnvString("FFMPEG_PATH", DEFAULT_FFMPEG_PATH),
+		ChangeThresholdPct:     getEnvInt("CAPTURE_CHANGE_THRESHOLD_PCT", DEFAULT_CHANGE_THRESHOLD),
+		KeepaliveFrameMs:       getEnvInt("CAPTURE_KEEPALIVE_MS", DEFAULT_KEEPALIVE_MS),
+		SkipIdenticalFrames:    getEnvBool("CAPTURE_SKIP_IDENTICAL", DEFAULT_SKIP_IDENTICAL),
+		UnchangedMarker:        getEnvBool("CAPTURE_UNCHANGED_MARKER", false),
+		AdaptiveScale:          getEnvBool("CAPTURE_ADAPTIVE_SCALE", DEFAULT_ADAPTIVE_SCALE),
+		BandwidthCapKbps:       getEnvInt("BANDWIDTH_CAP_KBPS", DEFAULT_BANDWIDTH_CAP),
+		FrameSendLimitKBs:      getEnvInt("FRAME_SEND_LIMIT_KBS", DEFAULT_SEND_LIMIT_KBS),
+		FrameSendLimitPolicy:   getEnvString("FRAME_SEND_LIMIT_POLICY", DEFAULT_SEND_LIMIT_MODE),
+		MinQuality:             getEnvInt("ADAPTIVE_QUALITY_MIN", DEFAULT_MIN_QUALITY),
+		AdaptiveFPS:            getEnvBool("CAPTURE_ADAPTIVE_FPS", DEFAULT_ADAPTIVE_FPS),
+		IdleFPS:                getEnvInt("CAPTURE_IDLE_FPS", DEFAULT_IDLE_FPS),
+		IdleAfterFrames:        getEnvInt("CAPTURE_IDLE_AFTER_FRAMES", DEFAULT_IDLE_AFTER),
+		PauseOnLock:            getEnvBool("CAPTURE_PAUSE_ON_LOCK", DEFAULT_PAUSE_ON_LOCK),
+		CaptureSchedule:        getEnvString("CAPTURE_SCHEDULE", ""),
+		CaptureScale:           getEnvFloat("CAPTURE_SCALE", DEFAULT_CAPTURE_SCALE),
+		CaptureMaxWidth:        getEnvInt("CAPTURE_MAX_WIDTH", 0),
+		CaptureMaxHeight:       getEnvInt("CAPTURE_MAX_HEIGHT", 0),
+		CaptureScaleFilter:     getEnvString("CAPTURE_SCALE_FILTER", DEFAULT_SCALE_FILTER),
+		PrivacyMasks:           getEnvString("PRIVACY_MASKS", ""),
+		PrivacyMaskMode:        getEnvString("PRIVACY_MASK_MODE", DEFAULT_MASK_MODE),
+		BlockedApps:            getEnvString("CAPTURE_BLOCKED_APPS", ""),
+		BlockedAppAction:       getEnvString("CAPTURE_BLOCKED_ACTION", DEFAULT_BLOCKED_ACTION),
+		ConsentRequired:        getEnvBool("CONSENT_REQUIRED", true),
+		ConsentPolicyVersion:   getEnvString("CONSENT_POLICY_VERSION", DEFAULT_CONSENT_POLICY),
+		FocusEvents:            getEnvBool("FOCUS_EVENTS", false),
+		FocusPollMs:            getEnvInt("FOCUS_POLL_MS", DEFAULT_FOCUS_POLL_MS),
+		FocusTitleMode:         getEnvString("FOCUS_TITLE_MODE", DEFAULT_FOCUS_TITLE),
+		FocusRedactApps:        getEnvString("FOCUS_REDACT_APPS", ""),
+		ProcessWatchlist:       getEnvString("PROCESS_WATCHLIST", ""),
+		ProcessWatchMs:         getEnvInt("PROCESS_WATCH_INTERVAL_MS", DEFAULT_PROCESS_WATCH_MS),
+		InputActivity:          getEnvBool("INPUT_ACTIVITY", false),
+		InputActivitySec:       getEnvInt("INPUT_ACTIVITY_INTERVAL_SEC", DEFAULT_INPUT_REPORT_SEC),
+		ClipboardEvents:        getEnvBool("CLIPBOARD_EVENTS", false),
+		ClipboardConsent:       getEnvBool("CLIPBOARD_CONSENT", false),
+		ClipboardDetail:        getEnvString("CLIPBOARD_DETAIL", DEFAULT_CLIPBOARD_DETAIL),
+		ClipboardTextMax:       getEnvInt("CLIPBOARD_TEXT_MAX", DEFAULT_CLIPBOARD_TEXT),
+		NetworkEvents:          getEnvBool("NETWORK_EVENTS", false),
+		NetworkPollMs:          getEnvInt("NETWORK_POLL_MS", DEFAULT_NETWORK_POLL_MS),
+		SessionEvents:          getEnvBool("SESSION_EVENTS", false),
+		PowerEvents:            getEnvBool("POWER_EVENTS", false),
+		BatteryLowPct:          getEnvInt("BATTERY_LOW_PCT", DEFAULT_BATTERY_LOW_PCT),
+		PowerSaver:             getEnvBool("POWER_SAVER", false),
+		BatteryFPS:             getEnvInt("BATTERY_TARGET_FPS", DEFAULT_BATTERY_FPS),
+		BatteryJpeg:            getEnvBool("BATTERY_JPEG", true),
+		DisplayWatch:           getEnvBool("DISPLAY_WATCH", true),
+		ResourceEvents:         getEnvBool("RESOURCE_EVENTS", false),
+		CPUThresholdPct:        getEnvInt("RESOURCE_CPU_PCT", DEFAULT_RESOURCE_PCT),
+		MemThresholdPct:        getEnvInt("RESOURCE_MEMORY_PCT", DEFAULT_RESOURCE_PCT),
+		DiskThresholdPct:       getEnvInt("RESOURCE_DISK_PCT", DEFAULT_RESOURCE_PCT),
+		ResourceDiskPath:       getEnvString("RESOURCE_DISK_PATH", ""),
+		ResourceSustainSec:     getEnvInt("RESOURCE_SUSTAIN_SEC", DEFAULT_RESOURCE_SUSTAIN),
+		FileWatchPaths:         getEnvString("FILE_WATCH_PATHS", ""),
+		FileWatchRecursive:     getEnvBool("FILE_WATCH_RECURSIVE", false),
+		Watermark:              getEnvBool("CAPTURE_WATERMARK", false),
+		WatermarkPosition:      getEnvString("CAPTURE_WATERMARK_POSITION", DEFAULT_WATERMARK_POS),
+		WatermarkOpacity:       getEnvInt("CAPTURE_WATERMARK_OPACITY", DEFAULT_WATERMARK_ALPHA),
+		DualStream:             getEnvBool("CAPTURE_DUAL_STREAM", false),
+		PreviewScalePct:        getEnvInt("CAPTURE_PREVIEW_SCALE_PCT", DEFAULT_PREVIEW_SCALE),
+		UIPreviewMs:            getEnvInt("UI_PREVIEW_INTERVAL_MS", DEFAULT_UI_PREVIEW_MS),
+		UIPreviewWidth:         getEnvInt("UI_PREVIEW_WIDTH", DEFAULT_UI_PREVIEW_WIDTH),
+		TrayEnabled:            getEnvBool("UI_TRAY", true),
+		CloseToTray:            getEnvBool("UI_CLOSE_TO_TRAY", true),
+		StartMinimized:         getEnvBool("UI_START_MINIMIZED", false),
+		CaptureIndicator:       getEnvBool("UI_CAPTURE_INDICATOR", true),
+		IndicatorPosition:      getEnvString("UI_INDICATOR_POSITION", DEFAULT_INDICATOR_POS),
+		Notifications:          getEnvBool("UI_NOTIFICATIONS", true),
+		NotifyDisconnectSec:    getEnvInt("UI_NOTIFY_DISCONNECT_SEC", DEFAULT_NOTIFY_DOWN_SEC),
+		Locale:                 getEnvString("UI_LOCALE", ""),
+		EventHistory:           getEnvInt("UI_EVENT_HISTORY", DEFAULT_EVENT_HISTORY),
+		FullStreamFPS:          getEnvInt("CAPTURE_FULL_STREAM_FPS", DEFAULT_FULL_STREAM_FPS),
+		EventBatchWindowMs:     getEnvInt("EVENT_BATCH_WINDOW_MS", DEFAULT_EVENT_BATCH_MS),
+		EventAllow:             getEnvString("EVENT_ALLOW", ""),
+		EventDeny:              getEnvString("EVENT_DENY", ""),
+		EventMinSeverity:       getEnvString("EVENT_MIN_SEVERITY", DEFAULT_EVENT_SEVERITY),
+		DisabledSources:        getEnvString("DISABLED_EVENT_SOURCES", ""),
+		EventAckQueue:          getEnvBool("EVENT_ACK_QUEUE", false),
+		EventAckTypes:          getEnvString("EVENT_ACK_TYPES", ""),
+		EventAckMaxMB:          getEnvInt("EVENT_ACK_MAX_MB", DEFAULT_EVENT_ACK_MB),
+		EventAckTimeoutSec:     getEnvInt("EVENT_ACK_TIMEOUT_SEC", DEFAULT_ACK_TIMEOUT_SEC),
+		DeltaKeyframeInterval:  getEnvInt("DELTA_KEYFRAME_INTERVAL", DEFAULT_DELTA_KEYFRAME),
+		CPUMaxProcs:            getEnvInt("CPU_MAX_PROCS", DEFAULT_CPU_MAX_PROCS),
+		CPULowPriority:         getEnvBool("CPU_LOW_PRIORITY", false),
+		CPUEfficiencyMode:      getEnvBool("CPU_EFFICIENCY_MODE", false),
+		CPUAffinity:            getEnvString("CPU_AFFINITY", ""),
+		LoopbackMode:           getEnvBool("AGENT_LOOPBACK", false),
+		TestPattern:            getEnvBool("CAPTURE_TEST_PATTERN", false),
+		TestPatternWidth:       getEnvInt("CAPTURE_TEST_PATTERN_WIDTH", DEFAULT_PATTERN_WIDTH),
+		TestPatternHeight:      getEnvInt("CAPTURE_TEST_PATTERN_HEIGHT", DEFAULT_PATTERN_HEIGHT),
+		FrameQueueSize:         getEnvInt("FRAME_QUEUE_SIZE", DEFAULT_FRAME_QUEUE_SIZE),
+		TLSEnabled:             getEnvBool("AGENT_TLS", false),
+		TLSCAFile:              getEnvString("AGENT_TLS_CA_FILE", ""),
+		TLSCertFile:            getEnvString("AGENT_TLS_CERT_FILE", ""),
+		TLSKeyFile:             getEnvString("AGENT_TLS_KEY_FILE", ""),
+		TLSServerName:          getEnvString("AGENT_TLS_SERVER_NAME", ""),
+		AuthToken:              getEnvString("AGENT_AUTH_TOKEN", ""),
+		AuthTokenFile:          getEnvString("AGENT_AUTH_TOKEN_FILE", ""),
+		AuthKeychainService:    getEnvString("AGENT_AUTH_KEYCHAIN_SERVICE", ""),
+		AuthKeychainUser:       getEnvString("AGENT_AUTH_KEYCHAIN_USER", DEFAULT_KEYCHAIN_USER),
+		SecretStore:            getEnvBool("AGENT_SECRET_STORE", true),
+		BackoffBaseMs:          getEnvInt("RECONNECT_BACKOFF_BASE_MS", DEFAULT_BACKOFF_BASE_MS),
+		BackoffMaxMs:           getEnvInt("RECONNECT_BACKOFF_MAX_MS", DEFAULT_BACKOFF_MAX_MS),
+		BackoffJitterPct:       getEnvInt("RECONNECT_BACKOFF_JITTER_PCT", DEFAULT_BACKOFF_JITTER),
+		KeepaliveTimeMs:        getEnvInt("GRPC_KEEPALIVE_TIME_MS", DEFAULT_KEEPALIVE_PING),
+		KeepaliveTimeoutMs:     getEnvInt("GRPC_KEEPALIVE_TIMEOUT_MS", DEFAULT_KEEPALIVE_WAIT),
+		KeepaliveIdle:          getEnvBool("GRPC_KEEPALIVE_WITHOUT_STREAM", true),
+		Compression:            getEnvString("GRPC_COMPRESSION", DEFAULT_GRPC_COMPRESSION),
+		MaxMessageBytes:        getEnvInt("GRPC_MAX_MESSAGE_BYTES", DEFAULT_MAX_MESSAGE),
+		HeartbeatIntervalMs:    getEnvInt("HEARTBEAT_INTERVAL_MS", DEFAULT_HEARTBEAT_MS),
+		HeartbeatFailThreshold: getEnvInt("HEARTBEAT_FAIL_THRESHOLD", DEFAULT_HEARTBEAT_FAILS),
+		SpoolFrameMaxMB:        getEnvInt("SPOOL_FRAME_MAX_MB", DEFAULT_SPOOL_FRAME_MB),
+		SpoolEventMaxMB:        getEnvInt("SPOOL_EVENT_MAX_MB", DEFAULT_SPOOL_EVENT_MB),
+		SpoolMaxAgeSec:         getEnvInt("SPOOL_MAX_AGE_SEC", DEFAULT_SPOOL_MAX_AGE),
+		SpoolFrameIntervalMs:   getEnvInt("SPOOL_FRAME_INTERVAL_MS", DEFAULT_SPOOL_FRAME_GAP),
+		SpoolFrameDrop:         getEnvString("SPOOL_FRAME_DROP", DEFAULT_SPOOL_FRAME_DROP),
+		SpoolEventDrop:         getEnvString("SPOOL_EVENT_DROP", DEFAULT_SPOOL_EVENT_DROP),
+		LogLevel:               getEnvString("LOG_LEVEL", DEFAULT_LOG_LEVEL),
+		TraceEnabled:           getEnvBool("TRACE_ENABLED", false),
+		TraceOTLPEndpoint:      getEnvString("TRACE_OTLP_ENDPOINT", ""),
+		TraceOTLPInsecure:      getEnvBool("TRACE_OTLP_INSECURE", false),
+		TraceSampleRatio:       getEnvFloat("TRACE_SAMPLE_RATIO", DEFAULT_TRACE_SAMPLE),
+		TraceSlowMs:            getEnvInt("TRACE_SLOW_MS", DEFAULT_TRACE_SLOW_MS),
+		PprofEnabled:           getEnvBool("AGENT_PPROF", false),
+		PprofAddr:              getEnvString("AGENT_PPROF_ADDR", DEFAULT_PPROF_ADDR),
+		HealthEnabled:          getEnvBool("AGENT_HEALTH", false),
+		HealthAddr:             getEnvString("AGENT_HEALTH_ADDR", DEFAULT_HEALTH_ADDR),
+		ShutdownTimeoutMs:      getEnvInt("AGENT_SHUTDOWN_TIMEOUT_MS", DEFAULT_SHUTDOWN_MS),
+		ConfigReload:           getEnvBool("CONFIG_RELOAD", true),
+		RemoteConfig:           getEnvBool("REMOTE_CONFIG", true),
+		RemoteConfigSec:        getEnvInt("REMOTE_CONFIG_INTERVAL_SEC", DEFAULT_REMOTE_CONFIG),
 	}
-	if cfg.MonitorIndex < 0 {
-		cfg.MonitorIndex = 0
-	}
-	if cfg.CaptureEncoding != "png" && cfg.CaptureEncoding != "jpeg" {
-		cfg.CaptureEncoding = DEFAULT_CAPTURE_ENCODING
-	}
-	if cfg.JpegQuality < 1 || cfg.JpegQuality > 100 {
-		cfg.JpegQuality = DEFAULT_JPEG_QUALITY
+	cfg.Problems = append(parseProblems, cfg.Validate()...)
+	if ferr == nil {
+		cfg.ConfigFile = path
+	} else if explicit || !os.IsNotExist(ferr) { // 기본 경로에 파일이 없는 것은 정상
+		cfg.ConfigFileError = ferr.Error()
 	}
 	return cfg
 }
 
-// getEnvString 함수는 문자열 환경 변수 값을 반환합니다.
+// defaultDataDir 함수는 OS 별 사용자 캐시 디렉터리 기반 기본 데이터 경로를 반환합니다.
+func defaultDataDir() string { // 단일 책임: 기본 데이터 경로 계산
+	base, err := os.UserCacheDir()
+	if err != nil {
+		base = os.TempDir()
+	}
+	return filepath.Join(base, DEFAULT_DATA_DIR_NAME)
+}
+
+// getEnvString 함수는 문자열 설정 값(환경 변수 우선, 없으면 설정 파일)을 반환합니다.
 func getEnvString(key, def string) string { // 단일 책임: 문자열 환경 조회
-	v := os.Getenv(key)
+	v := lookup(key)
 	if v == "" {
 		return def
 	}
 	return v
 }
 
-// getEnvInt 함수는 정수 환경 변수 값을 반환합니다.
+// getEnvInt 함수는 정수 설정 값을 반환합니다.
 func getEnvInt(key string, def int) int { // 단일 책임: 정수 환경 조회
-	v := os.Getenv(key)
+	v := lookup(key)
 	if v == "" {
 		return def
 	}
 	n, err := strconv.Atoi(v)
 	if err != nil {
+		noteParseProblem(key, v, "정수", def)
 		return def
 	}
 	return n
 }
 
-// getEnvBool 함수는 불리언 환경 변수 값을 반환합니다.
+// getEnvFloat 함수는 실수 설정 값을 반환합니다.
+func getEnvFloat(key string, def float64) float64 { // 단일 책임: 실수 환경 조회
+	v := lookup(key)
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		noteParseProblem(key, v, "실수", def)
+		return def
+	}
+	return f
+}
+
+// getEnvBool 함수는 불리언 설정 값을 반환합니다.
 func getEnvBool(key string, def bool) bool { // 단일 책임: 불리언 환경 조회
-	v := os.Getenv(key)
+	v := lookup(key)
 	if v == "" {
 		return def
 	}
@@ -99,5 +521,11 @@ func getEnvBool(key string, def bool) bool { // 단일 책임: 불리언 환경
 	if v == "0" || v == "false" || v == "FALSE" || v == "False" || v == "no" || v == "N" {
 		return false
 	}
+	noteParseProblem(key, v, "true | false", def)
 	return def
 }
+
+// noteParseProblem 함수는 해석하지 못한 설정 값을 기록합니다.
+func noteParseProblem(key, value, want string, def any) { // 단일 책임: 해석 실패 기록
+	parseProblems = append(parseProblems, Problem{Key: key, Value: value, Reason: want + " 형식", Applied: fmt.Sprint(def)})
+}
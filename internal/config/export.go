@@ -0,0 +1,253 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// configField 구조체는 설정 이름 하나와 Config 에서 그 값을 문자열로 읽는 함수입니다.
+type configField struct {
+	key string
+	get func(c *Config) string
+}
+
+// configFields 변수는 Load 가 읽는 모든 설정 항목입니다 (Load 와 같은 순서, 내보내기/저장용).
+var configFields = []configField{
+	{"AGENT_SERVER_ADDR", func(c *Config) string { return c.ServerAddr }},
+	{"AGENT_ID", func(c *Config) string { return c.AgentID }},
+	{"CAPTURE_INTERVAL_MS", func(c *Config) string { return strconv.Itoa(c.CaptureIntervalMs) }},
+	{"CAPTURE_TARGET_FPS", func(c *Config) string { return strconv.Itoa(c.TargetFPS) }},
+	{"FRAME_WIDTH", func(c *Config) string { return strconv.Itoa(c.FrameWidth) }},
+	{"FRAME_HEIGHT", func(c *Config) string { return strconv.Itoa(c.FrameHeight) }},
+	{"CAPTURE_MONITOR_MODE", func(c *Config) string { return c.MonitorMode }},
+	{"CAPTURE_COMBINED_LAYOUT", func(c *Config) string { return c.CombinedLayout }},
+	{"CAPTURE_BACKEND", func(c *Config) string { return c.CaptureBackend }},
+	{"CAPTURE_WINDOW_TITLE", func(c *Config) string { return c.WindowTitle }},
+	{"CAPTURE_WINDOW_PROCESS", func(c *Config) string { return c.WindowProcess }},
+	{"CAPTURE_MONITOR_INDEX", func(c *Config) string { return strconv.Itoa(c.MonitorIndex) }},
+	{"CAPTURE_ENCODING", func(c *Config) string { return c.CaptureEncoding }},
+	{"JPEG_QUALITY", func(c *Config) string { return strconv.Itoa(c.JpegQuality) }},
+	{"CAPTURE_FORCE_PREVIEW", func(c *Config) string { return strconv.FormatBool(c.ForcePreview) }},
+	{"AGENT_DATA_DIR", func(c *Config) string { return c.DataDir }},
+	{"CAPTURE_ENCODE_WORKERS", func(c *Config) string { return strconv.Itoa(c.EncodeWorkers) }},
+	{"CAPTURE_WATCHDOG_FRAMES", func(c *Config) string { return strconv.Itoa(c.WatchdogFrames) }},
+	{"JPEG_ENCODER", func(c *Config) string { return c.JpegEncoder }},
+	{"WEBP_QUALITY", func(c *Config) string { return strconv.Itoa(c.WebpQuality) }},
+	{"WEBP_LOSSLESS", func(c *Config) string { return strconv.FormatBool(c.WebpLossless) }},
+	{"TILE_ENCODING", func(c *Config) string { return c.TileEncoding }},
+	{"CAPTURE_GRAYSCALE", func(c *Config) string { return strconv.FormatBool(c.Grayscale) }},
+	{"PNG_COMPRESSION", func(c *Config) string { return c.PngCompression }},
+	{"MAX_FRAME_BYTES", func(c *Config) string { return strconv.Itoa(c.MaxFrameBytes) }},
+	{"H264_ENCODER", func(c *Config) string { return c.H264Encoder }},
+	{"H264_BITRATE_KBPS", func(c *Config) string { return strconv.Itoa(c.H264BitrateKbps) }},
+	{"H264_KEYFRAME_SEC", func(c *Config) string { return strconv.Itoa(c.H264KeyframeSec) }},
+	{"FFMPEG_PATH", func(c *Config) string { return c.FFmpegPath }},
+	{"CAPTURE_CHANGE_THRESHOLD_PCT", func(c *Config) string { return strconv.Itoa(c.ChangeThresholdPct) }},
+	{"CAPTURE_KEEPALIVE_MS", func(c *Config) string { return strconv.Itoa(c.KeepaliveFrameMs) }},
+	{"CAPTURE_SKIP_IDENTICAL", func(c *Config) string { return strconv.FormatBool(c.SkipIdenticalFrames) }},
+	{"CAPTURE_UNCHANGED_MARKER", func(c *Config) string { return strconv.FormatBool(c.UnchangedMarker) }},
+	{"CAPTURE_ADAPTIVE_SCALE", func(c *Config) string { return strconv.FormatBool(c.AdaptiveScale) }},
+	{"BANDWIDTH_CAP_KBPS", func(c *Config) string { return strconv.Itoa(c.BandwidthCapKbps) }},
+	{"FRAME_SEND_LIMIT_KBS", func(c *Config) string { return strconv.Itoa(c.FrameSendLimitKBs) }},
+	{"FRAME_SEND_LIMIT_POLICY", func(c *Config) string { return c.FrameSendLimitPolicy }},
+	{"ADAPTIVE_QUALITY_MIN", func(c *Config) string { return strconv.Itoa(c.MinQuality) }},
+	{"CAPTURE_ADAPTIVE_FPS", func(c *Config) string { return strconv.FormatBool(c.AdaptiveFPS) }},
+	{"CAPTURE_IDLE_FPS", func(c *Config) string { return strconv.Itoa(c.IdleFPS) }},
+	{"CAPTURE_IDLE_AFTER_FRAMES", func(c *Config) string { return strconv.Itoa(c.IdleAfterFrames) }},
+	{"CAPTURE_PAUSE_ON_LOCK", func(c *Config) string { return strconv.FormatBool(c.PauseOnLock) }},
+	{"CAPTURE_SCHEDULE", func(c *Config) string { return c.CaptureSchedule }},
+	{"CAPTURE_SCALE", func(c *Config) string { return strconv.FormatFloat(c.CaptureScale, 'g', -1, 64) }},
+	{"CAPTURE_MAX_WIDTH", func(c *Config) string { return strconv.Itoa(c.CaptureMaxWidth) }},
+	{"CAPTURE_MAX_HEIGHT", func(c *Config) string { return strconv.Itoa(c.CaptureMaxHeight) }},
+	{"CAPTURE_SCALE_FILTER", func(c *Config) string { return c.CaptureScaleFilter }},
+	{"PRIVACY_MASKS", func(c *Config) string { return c.PrivacyMasks }},
+	{"PRIVACY_MASK_MODE", func(c *Config) string { return c.PrivacyMaskMode }},
+	{"CAPTURE_BLOCKED_APPS", func(c *Config) string { return c.BlockedApps }},
+	{"CAPTURE_BLOCKED_ACTION", func(c *Config) string { return c.BlockedAppAction }},
+	{"CONSENT_REQUIRED", func(c *Config) string { return strconv.FormatBool(c.ConsentRequired) }},
+	{"CONSENT_POLICY_VERSION", func(c *Config) string { return c.ConsentPolicyVersion }},
+	{"FOCUS_EVENTS", func(c *Config) string { return strconv.FormatBool(c.FocusEvents) }},
+	{"FOCUS_POLL_MS", func(c *Config) string { return strconv.Itoa(c.FocusPollMs) }},
+	{"FOCUS_TITLE_MODE", func(c *Config) string { return c.FocusTitleMode }},
+	{"FOCUS_REDACT_APPS", func(c *Config) string { return c.FocusRedactApps }},
+	{"PROCESS_WATCHLIST", func(c *Config) string { return c.ProcessWatchlist }},
+	{"PROCESS_WATCH_INTERVAL_MS", func(c *Config) string { return strconv.Itoa(c.ProcessWatchMs) }},
+	{"INPUT_ACTIVITY", func(c *Config) string { return strconv.FormatBool(c.InputActivity) }},
+	{"INPUT_ACTIVITY_INTERVAL_SEC", func(c *Config) string { return strconv.Itoa(c.InputActivitySec) }},
+	{"CLIPBOARD_EVENTS", func(c *Config) string { return strconv.FormatBool(c.ClipboardEvents) }},
+	{"CLIPBOARD_CONSENT", func(c *Config) string { return strconv.FormatBool(c.ClipboardConsent) }},
+	{"CLIPBOARD_DETAIL", func(c *Config) string { return c.ClipboardDetail }},
+	{"CLIPBOARD_TEXT_MAX", func(c *Config) string { return strconv.Itoa(c.ClipboardTextMax) }},
+	{"NETWORK_EVENTS", func(c *Config) string { return strconv.FormatBool(c.NetworkEvents) }},
+	{"NETWORK_POLL_MS", func(c *Config) string { return strconv.Itoa(c.NetworkPollMs) }},
+	{"SESSION_EVENTS", func(c *Config) string { return strconv.FormatBool(c.SessionEvents) }},
+	{"POWER_EVENTS", func(c *Config) string { return strconv.FormatBool(c.PowerEvents) }},
+	{"BATTERY_LOW_PCT", func(c *Config) string { return strconv.Itoa(c.BatteryLowPct) }},
+	{"POWER_SAVER", func(c *Config) string { return strconv.FormatBool(c.PowerSaver) }},
+	{"BATTERY_TARGET_FPS", func(c *Config) string { return strconv.Itoa(c.BatteryFPS) }},
+	{"BATTERY_JPEG", func(c *Config) string { return strconv.FormatBool(c.BatteryJpeg) }},
+	{"DISPLAY_WATCH", func(c *Config) string { return strconv.FormatBool(c.DisplayWatch) }},
+	{"RESOURCE_EVENTS", func(c *Config) string { return strconv.FormatBool(c.ResourceEvents) }},
+	{"RESOURCE_CPU_PCT", func(c *Config) string { return strconv.Itoa(c.CPUThresholdPct) }},
+	{"RESOURCE_MEMORY_PCT", func(c *Config) string { return strconv.Itoa(c.MemThresholdPct) }},
+	{"RESOURCE_DISK_PCT", func(c *Config) string { return strconv.Itoa(c.DiskThresholdPct) }},
+	{"RESOURCE_DISK_PATH", func(c *Config) string { return c.ResourceDiskPath }},
+	{"RESOURCE_SUSTAIN_SEC", func(c *Config) string { return strconv.Itoa(c.ResourceSustainSec) }},
+	{"FILE_WATCH_PATHS", func(c *Config) string { return c.FileWatchPaths }},
+	{"FILE_WATCH_RECURSIVE", func(c *Config) string { return strconv.FormatBool(c.FileWatchRecursive) }},
+	{"CAPTURE_WATERMARK", func(c *Config) string { return strconv.FormatBool(c.Watermark) }},
+	{"CAPTURE_WATERMARK_POSITION", func(c *Config) string { return c.WatermarkPosition }},
+	{"CAPTURE_WATERMARK_OPACITY", func(c *Config) string { return strconv.Itoa(c.WatermarkOpacity) }},
+	{"CAPTURE_DUAL_STREAM", func(c *Config) string { return strconv.FormatBool(c.DualStream) }},
+	{"CAPTURE_PREVIEW_SCALE_PCT", func(c *Config) string { return strconv.Itoa(c.PreviewScalePct) }},
+	{"UI_PREVIEW_INTERVAL_MS", func(c *Config) string { return strconv.Itoa(c.UIPreviewMs) }},
+	{"UI_PREVIEW_WIDTH", func(c *Config) string { return strconv.Itoa(c.UIPreviewWidth) }},
+	{"UI_TRAY", func(c *Config) string { return strconv.FormatBool(c.TrayEnabled) }},
+	{"UI_CLOSE_TO_TRAY", func(c *Config) string { return strconv.FormatBool(c.CloseToTray) }},
+	{"UI_START_MINIMIZED", func(c *Config) string { return strconv.FormatBool(c.StartMinimized) }},
+	{"UI_CAPTURE_INDICATOR", func(c *Config) string { return strconv.FormatBool(c.CaptureIndicator) }},
+	{"UI_INDICATOR_POSITION", func(c *Config) string { return c.IndicatorPosition }},
+	{"UI_NOTIFICATIONS", func(c *Config) string { return strconv.FormatBool(c.Notifications) }},
+	{"UI_NOTIFY_DISCONNECT_SEC", func(c *Config) string { return strconv.Itoa(c.NotifyDisconnectSec) }},
+	{"UI_LOCALE", func(c *Config) string { return c.Locale }},
+	{"UI_EVENT_HISTORY", func(c *Config) string { return strconv.Itoa(c.EventHistory) }},
+	{"CAPTURE_FULL_STREAM_FPS", func(c *Config) string { return strconv.Itoa(c.FullStreamFPS) }},
+	{"EVENT_BATCH_WINDOW_MS", func(c *Config) string { return strconv.Itoa(c.EventBatchWindowMs) }},
+	{"EVENT_ALLOW", func(c *Config) string { return c.EventAllow }},
+	{"EVENT_DENY", func(c *Config) string { return c.EventDeny }},
+	{"EVENT_MIN_SEVERITY", func(c *Config) string { return c.EventMinSeverity }},
+	{"DISABLED_EVENT_SOURCES", func(c *Config) string { return c.DisabledSources }},
+	{"EVENT_ACK_QUEUE", func(c *Config) string { return strconv.FormatBool(c.EventAckQueue) }},
+	{"EVENT_ACK_TYPES", func(c *Config) string { return c.EventAckTypes }},
+	{"EVENT_ACK_MAX_MB", func(c *Config) string { return strconv.Itoa(c.EventAckMaxMB) }},
+	{"EVENT_ACK_TIMEOUT_SEC", func(c *Config) string { return strconv.Itoa(c.EventAckTimeoutSec) }},
+	{"DELTA_KEYFRAME_INTERVAL", func(c *Config) string { return strconv.Itoa(c.DeltaKeyframeInterval) }},
+	{"CPU_MAX_PROCS", func(c *Config) string { return strconv.Itoa(c.CPUMaxProcs) }},
+	{"CPU_LOW_PRIORITY", func(c *Config) string { return strconv.FormatBool(c.CPULowPriority) }},
+	{"CPU_EFFICIENCY_MODE", func(c *Config) string { return strconv.FormatBool(c.CPUEfficiencyMode) }},
+	{"CPU_AFFINITY", func(c *Config) string { return c.CPUAffinity }},
+	{"AGENT_LOOPBACK", func(c *Config) string { return strconv.FormatBool(c.LoopbackMode) }},
+	{"CAPTURE_TEST_PATTERN", func(c *Config) string { return strconv.FormatBool(c.TestPattern) }},
+	{"CAPTURE_TEST_PATTERN_WIDTH", func(c *Config) string { return strconv.Itoa(c.TestPatternWidth) }},
+	{"CAPTURE_TEST_PATTERN_HEIGHT", func(c *Config) string { return strconv.Itoa(c.TestPatternHeight) }},
+	{"FRAME_QUEUE_SIZE", func(c *Config) string { return strconv.Itoa(c.FrameQueueSize) }},
+	{"AGENT_TLS", func(c *Config) string { return strconv.FormatBool(c.TLSEnabled) }},
+	{"AGENT_TLS_CA_FILE", func(c *Config) string { return c.TLSCAFile }},
+	{"AGENT_TLS_CERT_FILE", func(c *Config) string { return c.TLSCertFile }},
+	{"AGENT_TLS_KEY_FILE", func(c *Config) string { return c.TLSKeyFile }},
+	{"AGENT_TLS_SERVER_NAME", func(c *Config) string { return c.TLSServerName }},
+	{"AGENT_AUTH_TOKEN", func(c *Config) string { return c.AuthToken }},
+	{"AGENT_AUTH_TOKEN_FILE", func(c *Config) string { return c.AuthTokenFile }},
+	{"AGENT_AUTH_KEYCHAIN_SERVICE", func(c *Config) string { return c.AuthKeychainService }},
+	{"AGENT_AUTH_KEYCHAIN_USER", func(c *Config) string { return c.AuthKeychainUser }},
+	{"AGENT_SECRET_STORE", func(c *Config) string { return strconv.FormatBool(c.SecretStore) }},
+	{"RECONNECT_BACKOFF_BASE_MS", func(c *Config) string { return strconv.Itoa(c.BackoffBaseMs) }},
+	{"RECONNECT_BACKOFF_MAX_MS", func(c *Config) string { return strconv.Itoa(c.BackoffMaxMs) }},
+	{"RECONNECT_BACKOFF_JITTER_PCT", func(c *Config) string { return strconv.Itoa(c.BackoffJitterPct) }},
+	{"GRPC_KEEPALIVE_TIME_MS", func(c *Config) string { return strconv.Itoa(c.KeepaliveTimeMs) }},
+	{"GRPC_KEEPALIVE_TIMEOUT_MS", func(c *Config) string { return strconv.Itoa(c.KeepaliveTimeoutMs) }},
+	{"GRPC_KEEPALIVE_WITHOUT_STREAM", func(c *Config) string { return strconv.FormatBool(c.KeepaliveIdle) }},
+	{"GRPC_COMPRESSION", func(c *Config) string { return c.Compression }},
+	{"GRPC_MAX_MESSAGE_BYTES", func(c *Config) string { return strconv.Itoa(c.MaxMessageBytes) }},
+	{"HEARTBEAT_INTERVAL_MS", func(c *Config) string { return strconv.Itoa(c.HeartbeatIntervalMs) }},
+	{"HEARTBEAT_FAIL_THRESHOLD", func(c *Config) string { return strconv.Itoa(c.HeartbeatFailThreshold) }},
+	{"SPOOL_FRAME_MAX_MB", func(c *Config) string { return strconv.Itoa(c.SpoolFrameMaxMB) }},
+	{"SPOOL_EVENT_MAX_MB", func(c *Config) string { return strconv.Itoa(c.SpoolEventMaxMB) }},
+	{"SPOOL_MAX_AGE_SEC", func(c *Config) string { return strconv.Itoa(c.SpoolMaxAgeSec) }},
+	{"SPOOL_FRAME_INTERVAL_MS", func(c *Config) string { return strconv.Itoa(c.SpoolFrameIntervalMs) }},
+	{"SPOOL_FRAME_DROP", func(c *Config) string { return c.SpoolFrameDrop }},
+	{"SPOOL_EVENT_DROP", func(c *Config) string { return c.SpoolEventDrop }},
+	{"LOG_LEVEL", func(c *Config) string { return c.LogLevel }},
+	{"TRACE_ENABLED", func(c *Config) string { return strconv.FormatBool(c.TraceEnabled) }},
+	{"TRACE_OTLP_ENDPOINT", func(c *Config) string { return c.TraceOTLPEndpoint }},
+	{"TRACE_OTLP_INSECURE", func(c *Config) string { return strconv.FormatBool(c.TraceOTLPInsecure) }},
+	{"TRACE_SAMPLE_RATIO", func(c *Config) string { return strconv.FormatFloat(c.TraceSampleRatio, 'g', -1, 64) }},
+	{"TRACE_SLOW_MS", func(c *Config) string { return strconv.Itoa(c.TraceSlowMs) }},
+	{"AGENT_PPROF", func(c *Config) string { return strconv.FormatBool(c.PprofEnabled) }},
+	{"AGENT_PPROF_ADDR", func(c *Config) string { return c.PprofAddr }},
+	{"AGENT_HEALTH", func(c *Config) string { return strconv.FormatBool(c.HealthEnabled) }},
+	{"AGENT_HEALTH_ADDR", func(c *Config) string { return c.HealthAddr }},
+	{"AGENT_SHUTDOWN_TIMEOUT_MS", func(c *Config) string { return strconv.Itoa(c.ShutdownTimeoutMs) }},
+	{"CONFIG_RELOAD", func(c *Config) string { return strconv.FormatBool(c.ConfigReload) }},
+	{"REMOTE_CONFIG", func(c *Config) string { return strconv.FormatBool(c.RemoteConfig) }},
+	{"REMOTE_CONFIG_INTERVAL_SEC", func(c *Config) string { return strconv.Itoa(c.RemoteConfigSec) }},
+}
+
+// exportExcluded 변수는 내보내기/가져오기에서 빼는 항목입니다 (다른 PC 로 옮기면 안 되는 식별자와 비밀).
+var exportExcluded = map[string]bool{"AGENT_ID": true, "AGENT_AUTH_TOKEN": true}
+
+// fieldGetter 함수는 설정 이름의 값 조회 함수를 반환합니다.
+func fieldGetter(key string) (func(c *Config) string, bool) { // 단일 책임: 설정 항목 조회
+	for _, f := range configFields {
+		if f.key == key {
+			return f.get, true
+		}
+	}
+	return nil, false
+}
+
+// Export 함수는 c 의 유효 설정을 설정 이름 → 문자열 값으로 반환합니다 (식별자/토큰 제외).
+// JSON 으로 저장하면 그대로 설정 파일(YAML 은 JSON 을 포함)이나 Import 입력으로 쓸 수 있습니다.
+func Export(c *Config) map[string]string { // 단일 책임: 유효 설정 내보내기
+	out := make(map[string]string, len(configFields))
+	for _, f := range configFields {
+		if !exportExcluded[f.key] {
+			out[f.key] = f.get(c)
+		}
+	}
+	return out
+}
+
+// Import 함수는 JSON 설정 묶음(설정 이름 키, 설정 파일처럼 중첩 가능)을 검증해 UI 변경 설정 파일에 저장합니다.
+// 알 수 없는 항목이나 해석/검증에 실패한 값이 하나라도 있으면 아무것도 저장하지 않고 문제 목록과 오류를 반환합니다.
+// 저장한 값은 다음 Load 부터 설정 파일보다 우선하며, 명령행/환경 변수로 지정한 항목은 여전히 그 값이 이깁니다.
+func Import(data []byte) ([]Problem, error) { // 단일 책임: 설정 가져오기
+	raw := map[string]any{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("설정 JSON 해석 실패: %w", err)
+	}
+	values := map[string]string{}
+	flattenInto(values, "", raw)
+	var problems []Problem
+	for key, v := range values {
+		if _, ok := fieldGetter(key); !ok || exportExcluded[key] {
+			problems = append(problems, Problem{Key: key, Value: v, Reason: "가져올 수 없는 설정 항목", Applied: "무시"})
+		}
+	}
+	loadMu.Lock()
+	defer loadMu.Unlock()
+	loadSettings()
+	settingsMu.Lock()
+	prev := settingsValues
+	merged := make(map[string]string, len(prev)+len(values))
+	for k, v := range prev {
+		merged[k] = v
+	}
+	for k, v := range values {
+		merged[k] = v
+	}
+	settingsValues = merged
+	settingsMu.Unlock()
+	for _, p := range build().Problems {
+		if _, ok := values[p.Key]; ok {
+			problems = append(problems, p)
+		}
+	}
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+	if len(problems) > 0 {
+		settingsValues = prev
+		msgs := make([]string, 0, len(problems))
+		for _, p := range problems {
+			msgs = append(msgs, p.String())
+		}
+		return problems, fmt.Errorf("설정 가져오기 거부: %s", strings.Join(msgs, "; "))
+	}
+	if err := writeSettings(merged); err != nil {
+		settingsValues = prev
+		return nil, err
+	}
+	return nil, nil
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	CONFIG_FILE_ENV  = "AGENT_CONFIG_FILE" // 설정 파일 경로 지정 환경 변수 (--config 플래그가 우선)
+	CONFIG_FILE_NAME = "agent.yaml"        // OS 기본 설정 폴더의 설정 파일명
+	CONFIG_TOML_EXT  = ".toml"             // 이 확장자면 TOML, 그 외는 YAML 로 해석
+)
+
+// filePath 변수는 --config 플래그로 지정한 설정 파일 경로입니다 (빈 값이면 환경 변수 또는 OS 기본 경로).
+var filePath string
+
+// overrides 변수는 명령행 플래그로 지정한 값입니다 (환경 변수 이름 → 값, 환경 변수와 설정 파일보다 우선).
+var overrides = map[string]string{}
+
+// fileValues 변수는 Load 가 읽은 설정 파일 값입니다 (환경 변수 이름 → 값, 환경 변수가 있으면 무시).
+var fileValues map[string]string
+
+// SetFilePath 함수는 설정 파일 경로를 지정합니다. Load 전에 호출해야 합니다.
+func SetFilePath(path string) { // 단일 책임: 설정 파일 경로 지정
+	filePath = path
+}
+
+// SetOverride 함수는 key(환경 변수 이름) 설정 값을 명령행 값으로 고정합니다. Load 전에 호출해야 합니다.
+func SetOverride(key, value string) { // 단일 책임: 명령행 값 지정
+	overrides[key] = value
+}
+
+// FilePath 함수는 Load 가 읽을 설정 파일 경로와 명시 지정 여부를 반환합니다.
+// 플래그, AGENT_CONFIG_FILE, OS 기본 경로 순이며 기본 경로는 파일이 없어도 오류가 아닙니다.
+func FilePath() (path string, explicit bool) { // 단일 책임: 설정 파일 경로 결정
+	if filePath != "" {
+		return filePath, true
+	}
+	if p := os.Getenv(CONFIG_FILE_ENV); p != "" {
+		return p, true
+	}
+	return defaultFilePath(), false
+}
+
+// defaultFilePath 함수는 OS 별 시스템 설정 폴더의 기본 설정 파일 경로를 반환합니다.
+// 여러 사용자가 같은 배포 설정을 쓰도록 사용자 폴더가 아닌 시스템 폴더를 사용합니다.
+func defaultFilePath() string { // 단일 책임: 기본 설정 파일 경로 계산
+	switch runtime.GOOS {
+	case "windows":
+		base := os.Getenv("ProgramData")
+		if base == "" {
+			base = `C:\ProgramData`
+		}
+		return filepath.Join(base, DEFAULT_DATA_DIR_NAME, CONFIG_FILE_NAME)
+	case "darwin":
+		return filepath.Join("/Library/Application Support", DEFAULT_DATA_DIR_NAME, CONFIG_FILE_NAME)
+	}
+	return filepath.Join("/etc", DEFAULT_DATA_DIR_NAME, CONFIG_FILE_NAME)
+}
+
+// loadFile 함수는 설정 파일을 읽어 환경 변수 이름 기준 값으로 펼칩니다.
+// 키는 환경 변수 이름(대소문자, '-' 와 '_' 구분 없음)이며, 하위 표는 "상위_하위" 로 이어 붙입니다
+// (예: capture: {target_fps: 10} → CAPTURE_TARGET_FPS). 목록 값은 쉼표로 이어 붙입니다.
+func loadFile(path string) (map[string]string, error) { // 단일 책임: 설정 파일 해석
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	raw := map[string]any{}
+	if strings.EqualFold(filepath.Ext(path), CONFIG_TOML_EXT) {
+		err = toml.Unmarshal(data, &raw)
+	} else {
+		err = yaml.Unmarshal(data, &raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("설정 파일 해석 실패 (%s): %w", path, err)
+	}
+	values := map[string]string{}
+	flattenInto(values, "", raw)
+	return values, nil
+}
+
+// flattenInto 함수는 중첩 표를 환경 변수 이름 키로 펼쳐 out 에 넣습니다.
+func flattenInto(out map[string]string, prefix string, m map[string]any) { // 단일 책임: 중첩 키 평탄화
+	for k, v := range m {
+		key := strings.ToUpper(strings.ReplaceAll(k, "-", "_"))
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+		switch val := v.(type) {
+		case map[string]any:
+			flattenInto(out, key, val)
+		case []any:
+			items := make([]string, 0, len(val))
+			for _, item := range val {
+				items = append(items, fmt.Sprint(item))
+			}
+			out[key] = strings.Join(items, ",")
+		case nil:
+		default:
+			out[key] = fmt.Sprint(val)
+		}
+	}
+}
+
+// IsSet 함수는 key(환경 변수 이름) 설정을 명령행, 환경 변수, UI 변경 설정, 설정 파일 중 하나로 지정했는지 반환합니다.
+func IsSet(key string) bool { // 단일 책임: 명시 설정 여부 조회
+	return lookup(key) != ""
+}
+
+// lookup 함수는 명령행 값, 환경 변수, UI 변경 설정, 설정 파일 순으로 설정 값을 찾습니다 (모두 없으면 빈 문자열).
+func lookup(key string) string { // 단일 책임: 설정 값 조회
+	if v, ok := overrides[key]; ok {
+		return v
+	}
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	if v, ok := storedSetting(key); ok {
+		return v
+	}
+	return fileValues[key]
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	SETTINGS_FILE_NAME = "settings.json" // 사용자 설정 폴더에 저장하는 UI 변경 설정 파일명
+	IDENTITY_FILE_NAME = "identity.json" // 사용자 설정 폴더에 저장하는 에이전트 ID/장치 이름 파일명
+	CONSENT_FILE_NAME  = "consent.json"  // 사용자 설정 폴더에 저장하는 캡처 동의 기록 파일명
+)
+
+// 아래 변수는 UI 에서 바꾸는 설정 묶음입니다 (환경 변수 이름). 바인딩은 자신이 바꾼 묶음만 저장합니다.
+var (
+	MonitorSettings    = []string{"CAPTURE_MONITOR_MODE", "CAPTURE_MONITOR_INDEX", "CAPTURE_COMBINED_LAYOUT", "CAPTURE_WINDOW_TITLE", "CAPTURE_WINDOW_PROCESS"}
+	EncodingSettings   = []string{"CAPTURE_ENCODING", "JPEG_QUALITY", "WEBP_QUALITY"}
+	PrivacySettings    = []string{"PRIVACY_MASKS", "PRIVACY_MASK_MODE"}
+	BlockedAppSettings = []string{"CAPTURE_BLOCKED_APPS", "CAPTURE_BLOCKED_ACTION"}
+	ClipboardSettings  = []string{"CLIPBOARD_CONSENT"}
+	LocaleSettings     = []string{"UI_LOCALE"}
+)
+
+// settingsMu 변수는 settingsValues 와 설정 파일 쓰기를 보호합니다 (UI 바인딩은 여러 고루틴에서 호출).
+var settingsMu sync.Mutex
+
+// settingsValues 변수는 저장된 UI 변경 설정과 가져온 설정입니다 (환경 변수 이름 → 값).
+var settingsValues map[string]string
+
+// SettingsPath 함수는 UI 변경 설정 파일 경로를 반환합니다 (사용자 설정 폴더, 없으면 데이터 경로).
+func SettingsPath() string { // 단일 책임: 설정 저장 경로 계산
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return filepath.Join(defaultDataDir(), SETTINGS_FILE_NAME)
+	}
+	return filepath.Join(base, DEFAULT_DATA_DIR_NAME, SETTINGS_FILE_NAME)
+}
+
+// IdentityPath 함수는 에이전트 ID/장치 이름 파일 경로를 반환합니다 (UI 변경 설정 파일과 같은 폴더).
+func IdentityPath() string { // 단일 책임: 식별 정보 저장 경로 계산
+	return filepath.Join(filepath.Dir(SettingsPath()), IDENTITY_FILE_NAME)
+}
+
+// ConsentPath 함수는 캡처 동의 기록 파일 경로를 반환합니다 (UI 변경 설정 파일과 같은 폴더).
+func ConsentPath() string { // 단일 책임: 동의 기록 저장 경로 계산
+	return filepath.Join(filepath.Dir(SettingsPath()), CONSENT_FILE_NAME)
+}
+
+// loadSettings 함수는 저장된 UI 변경 설정을 읽어 둡니다. 파일이 없거나 깨졌으면 저장 값 없이 진행합니다.
+func loadSettings() { // 단일 책임: 저장 설정 로드
+	values := map[string]string{}
+	if data, err := os.ReadFile(SettingsPath()); err == nil {
+		if json.Unmarshal(data, &values) != nil {
+			values = map[string]string{}
+		}
+	}
+	settingsMu.Lock()
+	settingsValues = values
+	settingsMu.Unlock()
+}
+
+// SaveSettings 함수는 cfg 의 keys 항목 값을 UI 변경 설정 파일에 저장합니다. 다음 Load 부터 기본값과 설정 파일보다 우선합니다.
+// 알 수 없는 항목 이름은 무시하며, 쓰기는 임시 파일 교체로 해 중간에 끊겨도 이전 파일이 남습니다.
+func SaveSettings(cfg *Config, keys []string) error { // 단일 책임: UI 변경 설정 저장
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+	values := make(map[string]string, len(settingsValues)+len(keys))
+	for k, v := range settingsValues {
+		values[k] = v
+	}
+	for _, k := range keys {
+		if get, ok := fieldGetter(k); ok {
+			values[k] = get(cfg)
+		}
+	}
+	if err := writeSettings(values); err != nil {
+		return err
+	}
+	settingsValues = values
+	return nil
+}
+
+// writeSettings 함수는 values 를 UI 변경 설정 파일에 임시 파일 교체로 기록합니다 (settingsMu 보유 상태에서 호출).
+func writeSettings(values map[string]string) error { // 단일 책임: 설정 파일 기록
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := SettingsPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path+".tmp", data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// storedSetting 함수는 저장된 UI 변경 설정 값을 반환합니다.
+func storedSetting(key string) (string, bool) { // 단일 책임: 저장 설정 조회
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+	v, ok := settingsValues[key]
+	return v, ok
+}
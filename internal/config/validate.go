@@ -0,0 +1,436 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"agent/internal/compression"
+	"agent/internal/i18n"
+)
+
+// Problem 구조체는 설정 검증에서 발견한 문제 하나입니다. 잘못된 값은 Applied 값으로 대체해 계속 실행합니다.
+type Problem struct {
+	Key     string `json:"key"`     // 설정 이름 (환경 변수 이름, 설정 파일은 같은 이름의 소문자/중첩 키)
+	Value   string `json:"value"`   // 지정한 값
+	Reason  string `json:"reason"`  // 허용 범위 또는 형식
+	Applied string `json:"applied"` // 대신 사용하는 값
+}
+
+// String 함수는 로그/이벤트용 한 줄 요약을 반환합니다.
+func (p Problem) String() string { // 단일 책임: 문제 요약
+	return fmt.Sprintf("%s=%q: %s (사용 값: %s)", p.Key, p.Value, p.Reason, p.Applied)
+}
+
+// fpsRange 변수는 FPS 설정 허용 범위 안내 문구입니다.
+var fpsRange = fmt.Sprintf("%d~%d 범위", MIN_TARGET_FPS, MAX_TARGET_FPS)
+
+// validator 구조체는 Validate 가 발견한 문제를 모읍니다.
+type validator struct {
+	problems []Problem
+}
+
+// reject 함수는 잘못된 설정 값 하나를 기록합니다.
+func (v *validator) reject(key string, value any, reason string, applied any) { // 단일 책임: 문제 기록
+	v.problems = append(v.problems, Problem{Key: key, Value: fmt.Sprint(value), Reason: reason, Applied: fmt.Sprint(applied)})
+}
+
+// Validate 메서드는 설정 값을 모두 검사해 잘못된 항목을 기본값(또는 안전한 값)으로 바꾸고, 발견한 문제를 빠짐없이 반환합니다.
+// Load 가 호출해 Problems 에 담으며, 문제가 있어도 에이전트는 대체 값으로 실행을 계속합니다.
+func (c *Config) Validate() []Problem { // 단일 책임: 설정 검증
+	v := &validator{}
+	c.validateServerAddr(v)
+	if c.MonitorMode == "window" && c.WindowTitle == "" && c.WindowProcess == "" { // 대상 창 미지정
+		v.reject("CAPTURE_MONITOR_MODE", c.MonitorMode, "window 모드는 CAPTURE_WINDOW_TITLE 또는 CAPTURE_WINDOW_PROCESS 필요", DEFAULT_MONITOR_MODE)
+		c.MonitorMode = DEFAULT_MONITOR_MODE
+	}
+	if c.MonitorMode != "single" && c.MonitorMode != "combined" && c.MonitorMode != "all" && c.MonitorMode != "window" { // 값 검증
+		v.reject("CAPTURE_MONITOR_MODE", c.MonitorMode, "single | combined | all | window 중 하나", DEFAULT_MONITOR_MODE)
+		c.MonitorMode = DEFAULT_MONITOR_MODE
+	}
+	switch c.CombinedLayout {
+	case "horizontal", "vertical", "grid", "physical":
+	default:
+		v.reject("CAPTURE_COMBINED_LAYOUT", c.CombinedLayout, "horizontal | vertical | grid | physical 중 하나", DEFAULT_COMBINED_LAYOUT)
+		c.CombinedLayout = DEFAULT_COMBINED_LAYOUT
+	}
+	if c.CaptureBackend != "auto" && c.CaptureBackend != "screenshot" {
+		v.reject("CAPTURE_BACKEND", c.CaptureBackend, "auto | screenshot 중 하나", DEFAULT_CAPTURE_BACKEND)
+		c.CaptureBackend = DEFAULT_CAPTURE_BACKEND
+	}
+	if c.PrivacyMaskMode != "black" && c.PrivacyMaskMode != "blur" {
+		v.reject("PRIVACY_MASK_MODE", c.PrivacyMaskMode, "black | blur 중 하나", DEFAULT_MASK_MODE)
+		c.PrivacyMaskMode = DEFAULT_MASK_MODE
+	}
+	if c.BlockedAppAction != "blank" && c.BlockedAppAction != "pause" {
+		v.reject("CAPTURE_BLOCKED_ACTION", c.BlockedAppAction, "blank | pause 중 하나", DEFAULT_BLOCKED_ACTION)
+		c.BlockedAppAction = DEFAULT_BLOCKED_ACTION
+	}
+	if strings.TrimSpace(c.ConsentPolicyVersion) == "" {
+		v.reject("CONSENT_POLICY_VERSION", c.ConsentPolicyVersion, "빈 값이 아닌 버전", DEFAULT_CONSENT_POLICY)
+		c.ConsentPolicyVersion = DEFAULT_CONSENT_POLICY
+	}
+	if c.FocusPollMs < 1 {
+		v.reject("FOCUS_POLL_MS", c.FocusPollMs, "1 이상", DEFAULT_FOCUS_POLL_MS)
+		c.FocusPollMs = DEFAULT_FOCUS_POLL_MS
+	}
+	if c.FocusTitleMode != "full" && c.FocusTitleMode != "none" {
+		v.reject("FOCUS_TITLE_MODE", c.FocusTitleMode, "full | none 중 하나", DEFAULT_FOCUS_TITLE)
+		c.FocusTitleMode = DEFAULT_FOCUS_TITLE
+	}
+	if c.ProcessWatchMs < 1 {
+		v.reject("PROCESS_WATCH_INTERVAL_MS", c.ProcessWatchMs, "1 이상", DEFAULT_PROCESS_WATCH_MS)
+		c.ProcessWatchMs = DEFAULT_PROCESS_WATCH_MS
+	}
+	if c.InputActivitySec < 1 {
+		v.reject("INPUT_ACTIVITY_INTERVAL_SEC", c.InputActivitySec, "1 이상", DEFAULT_INPUT_REPORT_SEC)
+		c.InputActivitySec = DEFAULT_INPUT_REPORT_SEC
+	}
+	if c.ClipboardDetail != "none" && c.ClipboardDetail != "hash" && c.ClipboardDetail != "text" {
+		v.reject("CLIPBOARD_DETAIL", c.ClipboardDetail, "none | hash | text 중 하나", DEFAULT_CLIPBOARD_DETAIL)
+		c.ClipboardDetail = DEFAULT_CLIPBOARD_DETAIL
+	}
+	if c.ClipboardTextMax < 1 {
+		v.reject("CLIPBOARD_TEXT_MAX", c.ClipboardTextMax, "1 이상", DEFAULT_CLIPBOARD_TEXT)
+		c.ClipboardTextMax = DEFAULT_CLIPBOARD_TEXT
+	}
+	if c.NetworkPollMs < 1 {
+		v.reject("NETWORK_POLL_MS", c.NetworkPollMs, "1 이상", DEFAULT_NETWORK_POLL_MS)
+		c.NetworkPollMs = DEFAULT_NETWORK_POLL_MS
+	}
+	if c.BatteryLowPct < 1 || c.BatteryLowPct > 99 {
+		v.reject("BATTERY_LOW_PCT", c.BatteryLowPct, "1~99 범위", DEFAULT_BATTERY_LOW_PCT)
+		c.BatteryLowPct = DEFAULT_BATTERY_LOW_PCT
+	}
+	if c.BatteryFPS < MIN_TARGET_FPS || c.BatteryFPS > MAX_TARGET_FPS {
+		v.reject("BATTERY_TARGET_FPS", c.BatteryFPS, fpsRange, DEFAULT_BATTERY_FPS)
+		c.BatteryFPS = DEFAULT_BATTERY_FPS
+	}
+	if c.CPUThresholdPct < 0 || c.CPUThresholdPct > 100 {
+		v.reject("RESOURCE_CPU_PCT", c.CPUThresholdPct, "0~100 범위", DEFAULT_RESOURCE_PCT)
+		c.CPUThresholdPct = DEFAULT_RESOURCE_PCT
+	}
+	if c.MemThresholdPct < 0 || c.MemThresholdPct > 100 {
+		v.reject("RESOURCE_MEMORY_PCT", c.MemThresholdPct, "0~100 범위", DEFAULT_RESOURCE_PCT)
+		c.MemThresholdPct = DEFAULT_RESOURCE_PCT
+	}
+	if c.DiskThresholdPct < 0 || c.DiskThresholdPct > 100 {
+		v.reject("RESOURCE_DISK_PCT", c.DiskThresholdPct, "0~100 범위", DEFAULT_RESOURCE_PCT)
+		c.DiskThresholdPct = DEFAULT_RESOURCE_PCT
+	}
+	if c.ResourceSustainSec < 0 {
+		v.reject("RESOURCE_SUSTAIN_SEC", c.ResourceSustainSec, "0 이상", DEFAULT_RESOURCE_SUSTAIN)
+		c.ResourceSustainSec = DEFAULT_RESOURCE_SUSTAIN
+	}
+	switch c.WatermarkPosition {
+	case "top-left", "top-right", "bottom-left", "bottom-right":
+	default:
+		v.reject("CAPTURE_WATERMARK_POSITION", c.WatermarkPosition, "top-left | top-right | bottom-left | bottom-right 중 하나", DEFAULT_WATERMARK_POS)
+		c.WatermarkPosition = DEFAULT_WATERMARK_POS
+	}
+	if c.WatermarkOpacity < 1 || c.WatermarkOpacity > 100 {
+		v.reject("CAPTURE_WATERMARK_OPACITY", c.WatermarkOpacity, "1~100 범위", DEFAULT_WATERMARK_ALPHA)
+		c.WatermarkOpacity = DEFAULT_WATERMARK_ALPHA
+	}
+	if c.PreviewScalePct < 1 || c.PreviewScalePct > 99 {
+		v.reject("CAPTURE_PREVIEW_SCALE_PCT", c.PreviewScalePct, "1~99 범위", DEFAULT_PREVIEW_SCALE)
+		c.PreviewScalePct = DEFAULT_PREVIEW_SCALE
+	}
+	if c.UIPreviewMs < 100 {
+		v.reject("UI_PREVIEW_INTERVAL_MS", c.UIPreviewMs, "100 이상", DEFAULT_UI_PREVIEW_MS)
+		c.UIPreviewMs = DEFAULT_UI_PREVIEW_MS
+	}
+	if c.UIPreviewWidth < 64 || c.UIPreviewWidth > 1920 {
+		v.reject("UI_PREVIEW_WIDTH", c.UIPreviewWidth, "64~1920 범위", DEFAULT_UI_PREVIEW_WIDTH)
+		c.UIPreviewWidth = DEFAULT_UI_PREVIEW_WIDTH
+	}
+	switch c.IndicatorPosition {
+	case "top-left", "top-right", "bottom-left", "bottom-right":
+	default:
+		v.reject("UI_INDICATOR_POSITION", c.IndicatorPosition, "top-left | top-right | bottom-left | bottom-right 중 하나", DEFAULT_INDICATOR_POS)
+		c.IndicatorPosition = DEFAULT_INDICATOR_POS
+	}
+	if c.NotifyDisconnectSec < 0 {
+		v.reject("UI_NOTIFY_DISCONNECT_SEC", c.NotifyDisconnectSec, "0 이상", DEFAULT_NOTIFY_DOWN_SEC)
+		c.NotifyDisconnectSec = DEFAULT_NOTIFY_DOWN_SEC
+	}
+	if c.Locale != "" && !i18n.Supported(c.Locale) {
+		v.reject("UI_LOCALE", c.Locale, "빈 값(OS 로캘) 또는 "+strings.Join(i18n.Locales, " | "), "")
+		c.Locale = ""
+	}
+	if c.EventHistory < 0 {
+		v.reject("UI_EVENT_HISTORY", c.EventHistory, "0 이상", DEFAULT_EVENT_HISTORY)
+		c.EventHistory = DEFAULT_EVENT_HISTORY
+	}
+	if c.FullStreamFPS < MIN_TARGET_FPS || c.FullStreamFPS > MAX_TARGET_FPS {
+		v.reject("CAPTURE_FULL_STREAM_FPS", c.FullStreamFPS, fpsRange, DEFAULT_FULL_STREAM_FPS)
+		c.FullStreamFPS = DEFAULT_FULL_STREAM_FPS
+	}
+	if c.TargetFPS < MIN_TARGET_FPS || c.TargetFPS > MAX_TARGET_FPS { // FPS 범위 검증 (1~240)
+		v.reject("CAPTURE_TARGET_FPS", c.TargetFPS, fpsRange, DEFAULT_TARGET_FPS)
+		c.TargetFPS = DEFAULT_TARGET_FPS
+	}
+	if c.MonitorIndex < 0 {
+		v.reject("CAPTURE_MONITOR_INDEX", c.MonitorIndex, "0 이상", 0)
+		c.MonitorIndex = 0
+	}
+	if c.CaptureEncoding != "png" && c.CaptureEncoding != "jpeg" && c.CaptureEncoding != "delta" && c.CaptureEncoding != "tiles" && c.CaptureEncoding != "webp" && c.CaptureEncoding != "h264" {
+		v.reject("CAPTURE_ENCODING", c.CaptureEncoding, "png | jpeg | delta | tiles | webp | h264 중 하나", DEFAULT_CAPTURE_ENCODING)
+		c.CaptureEncoding = DEFAULT_CAPTURE_ENCODING
+	}
+	if c.JpegQuality < 1 || c.JpegQuality > 100 {
+		v.reject("JPEG_QUALITY", c.JpegQuality, "1~100 범위", DEFAULT_JPEG_QUALITY)
+		c.JpegQuality = DEFAULT_JPEG_QUALITY
+	}
+	switch c.H264Encoder {
+	case "auto", "nvenc", "qsv", "videotoolbox", "x264":
+	default:
+		v.reject("H264_ENCODER", c.H264Encoder, "auto | nvenc | qsv | videotoolbox | x264 중 하나", DEFAULT_H264_ENCODER)
+		c.H264Encoder = DEFAULT_H264_ENCODER
+	}
+	if c.H264BitrateKbps < 1 {
+		v.reject("H264_BITRATE_KBPS", c.H264BitrateKbps, "1 이상", DEFAULT_H264_BITRATE)
+		c.H264BitrateKbps = DEFAULT_H264_BITRATE
+	}
+	if c.H264KeyframeSec < 1 {
+		v.reject("H264_KEYFRAME_SEC", c.H264KeyframeSec, "1 이상", DEFAULT_H264_KEYFRAME)
+		c.H264KeyframeSec = DEFAULT_H264_KEYFRAME
+	}
+	if c.WebpQuality < 1 || c.WebpQuality > 100 {
+		v.reject("WEBP_QUALITY", c.WebpQuality, "1~100 범위", DEFAULT_WEBP_QUALITY)
+		c.WebpQuality = DEFAULT_WEBP_QUALITY
+	}
+	if c.TileEncoding != "png" && c.TileEncoding != "jpeg" && c.TileEncoding != "webp" {
+		v.reject("TILE_ENCODING", c.TileEncoding, "png | jpeg | webp 중 하나", DEFAULT_TILE_ENCODING)
+		c.TileEncoding = DEFAULT_TILE_ENCODING
+	}
+	if c.JpegEncoder != "auto" && c.JpegEncoder != "stdlib" && c.JpegEncoder != "turbo" {
+		v.reject("JPEG_ENCODER", c.JpegEncoder, "auto | stdlib | turbo 중 하나", DEFAULT_JPEG_ENCODER)
+		c.JpegEncoder = DEFAULT_JPEG_ENCODER
+	}
+	if c.PngCompression != "default" && c.PngCompression != "speed" && c.PngCompression != "best" && c.PngCompression != "none" {
+		v.reject("PNG_COMPRESSION", c.PngCompression, "default | speed | best | none 중 하나", DEFAULT_PNG_COMPRESSION)
+		c.PngCompression = DEFAULT_PNG_COMPRESSION
+	}
+	if c.MaxFrameBytes < 0 {
+		v.reject("MAX_FRAME_BYTES", c.MaxFrameBytes, "0 이상", DEFAULT_MAX_FRAME_BYTES)
+		c.MaxFrameBytes = DEFAULT_MAX_FRAME_BYTES
+	}
+	if c.ChangeThresholdPct < 0 || c.ChangeThresholdPct > 100 {
+		v.reject("CAPTURE_CHANGE_THRESHOLD_PCT", c.ChangeThresholdPct, "0~100 범위", DEFAULT_CHANGE_THRESHOLD)
+		c.ChangeThresholdPct = DEFAULT_CHANGE_THRESHOLD
+	}
+	if c.BandwidthCapKbps < 0 {
+		v.reject("BANDWIDTH_CAP_KBPS", c.BandwidthCapKbps, "0 이상", DEFAULT_BANDWIDTH_CAP)
+		c.BandwidthCapKbps = DEFAULT_BANDWIDTH_CAP
+	}
+	if c.FrameSendLimitKBs < 0 {
+		v.reject("FRAME_SEND_LIMIT_KBS", c.FrameSendLimitKBs, "0 이상", DEFAULT_SEND_LIMIT_KBS)
+		c.FrameSendLimitKBs = DEFAULT_SEND_LIMIT_KBS
+	}
+	if c.FrameSendLimitPolicy != "delay" && c.FrameSendLimitPolicy != "drop" {
+		v.reject("FRAME_SEND_LIMIT_POLICY", c.FrameSendLimitPolicy, "delay | drop 중 하나", DEFAULT_SEND_LIMIT_MODE)
+		c.FrameSendLimitPolicy = DEFAULT_SEND_LIMIT_MODE
+	}
+	if c.MinQuality < 1 || c.MinQuality > 100 {
+		v.reject("ADAPTIVE_QUALITY_MIN", c.MinQuality, "1~100 범위", DEFAULT_MIN_QUALITY)
+		c.MinQuality = DEFAULT_MIN_QUALITY
+	}
+	if c.IdleFPS < MIN_TARGET_FPS || c.IdleFPS > MAX_TARGET_FPS {
+		v.reject("CAPTURE_IDLE_FPS", c.IdleFPS, fpsRange, DEFAULT_IDLE_FPS)
+		c.IdleFPS = DEFAULT_IDLE_FPS
+	}
+	if c.IdleAfterFrames < 1 {
+		v.reject("CAPTURE_IDLE_AFTER_FRAMES", c.IdleAfterFrames, "1 이상", DEFAULT_IDLE_AFTER)
+		c.IdleAfterFrames = DEFAULT_IDLE_AFTER
+	}
+	if c.CaptureScale <= 0 || c.CaptureScale > 1 {
+		v.reject("CAPTURE_SCALE", c.CaptureScale, "0 초과 1 이하", DEFAULT_CAPTURE_SCALE)
+		c.CaptureScale = DEFAULT_CAPTURE_SCALE
+	}
+	if c.CaptureMaxWidth < 0 {
+		v.reject("CAPTURE_MAX_WIDTH", c.CaptureMaxWidth, "0 이상", 0)
+		c.CaptureMaxWidth = 0
+	}
+	if c.CaptureMaxHeight < 0 {
+		v.reject("CAPTURE_MAX_HEIGHT", c.CaptureMaxHeight, "0 이상", 0)
+		c.CaptureMaxHeight = 0
+	}
+	if c.CaptureScaleFilter != "catmullrom" && c.CaptureScaleFilter != "bilinear" && c.CaptureScaleFilter != "approx" {
+		v.reject("CAPTURE_SCALE_FILTER", c.CaptureScaleFilter, "catmullrom | bilinear | approx 중 하나", DEFAULT_SCALE_FILTER)
+		c.CaptureScaleFilter = DEFAULT_SCALE_FILTER
+	}
+	if c.KeepaliveFrameMs < 1 {
+		v.reject("CAPTURE_KEEPALIVE_MS", c.KeepaliveFrameMs, "1 이상", DEFAULT_KEEPALIVE_MS)
+		c.KeepaliveFrameMs = DEFAULT_KEEPALIVE_MS
+	}
+	if c.CPUMaxProcs < 0 {
+		v.reject("CPU_MAX_PROCS", c.CPUMaxProcs, "0 이상", DEFAULT_CPU_MAX_PROCS)
+		c.CPUMaxProcs = DEFAULT_CPU_MAX_PROCS
+	}
+	if c.DeltaKeyframeInterval < 1 {
+		v.reject("DELTA_KEYFRAME_INTERVAL", c.DeltaKeyframeInterval, "1 이상", DEFAULT_DELTA_KEYFRAME)
+		c.DeltaKeyframeInterval = DEFAULT_DELTA_KEYFRAME
+	}
+	if c.EventBatchWindowMs < 0 {
+		v.reject("EVENT_BATCH_WINDOW_MS", c.EventBatchWindowMs, "0 이상", DEFAULT_EVENT_BATCH_MS)
+		c.EventBatchWindowMs = DEFAULT_EVENT_BATCH_MS
+	}
+	if c.EventMinSeverity != "debug" && c.EventMinSeverity != "info" && c.EventMinSeverity != "warning" && c.EventMinSeverity != "critical" {
+		v.reject("EVENT_MIN_SEVERITY", c.EventMinSeverity, "debug | info | warning | critical 중 하나", DEFAULT_EVENT_SEVERITY)
+		c.EventMinSeverity = DEFAULT_EVENT_SEVERITY
+	}
+	if c.RemoteConfigSec < 0 {
+		v.reject("REMOTE_CONFIG_INTERVAL_SEC", c.RemoteConfigSec, "0 이상", DEFAULT_REMOTE_CONFIG)
+		c.RemoteConfigSec = DEFAULT_REMOTE_CONFIG
+	}
+	if c.LogLevel != "debug" && c.LogLevel != "info" && c.LogLevel != "warn" && c.LogLevel != "error" {
+		v.reject("LOG_LEVEL", c.LogLevel, "debug | info | warn | error 중 하나", DEFAULT_LOG_LEVEL)
+		c.LogLevel = DEFAULT_LOG_LEVEL
+	}
+	if c.TraceSampleRatio <= 0 || c.TraceSampleRatio > 1 {
+		v.reject("TRACE_SAMPLE_RATIO", c.TraceSampleRatio, "0 초과 1 이하", DEFAULT_TRACE_SAMPLE)
+		c.TraceSampleRatio = DEFAULT_TRACE_SAMPLE
+	}
+	if c.TraceSlowMs < 0 {
+		v.reject("TRACE_SLOW_MS", c.TraceSlowMs, "0 이상", DEFAULT_TRACE_SLOW_MS)
+		c.TraceSlowMs = DEFAULT_TRACE_SLOW_MS
+	}
+	if !IsLoopbackAddr(c.PprofAddr) { // 프로파일은 메모리 내용이 드러나므로 외부 노출 금지
+		v.reject("AGENT_PPROF_ADDR", c.PprofAddr, "루프백 host:port (127.0.0.1, ::1, localhost)", DEFAULT_PPROF_ADDR)
+		c.PprofAddr = DEFAULT_PPROF_ADDR
+	}
+	if !IsLoopbackAddr(c.HealthAddr) {
+		v.reject("AGENT_HEALTH_ADDR", c.HealthAddr, "루프백 host:port (127.0.0.1, ::1, localhost)", DEFAULT_HEALTH_ADDR)
+		c.HealthAddr = DEFAULT_HEALTH_ADDR
+	}
+	if c.ShutdownTimeoutMs <= 0 {
+		v.reject("AGENT_SHUTDOWN_TIMEOUT_MS", c.ShutdownTimeoutMs, "1 이상", DEFAULT_SHUTDOWN_MS)
+		c.ShutdownTimeoutMs = DEFAULT_SHUTDOWN_MS
+	}
+	if c.EventAckMaxMB <= 0 {
+		v.reject("EVENT_ACK_MAX_MB", c.EventAckMaxMB, "0 보다 커야 함", DEFAULT_EVENT_ACK_MB)
+		c.EventAckMaxMB = DEFAULT_EVENT_ACK_MB
+	}
+	if c.EventAckTimeoutSec <= 0 {
+		v.reject("EVENT_ACK_TIMEOUT_SEC", c.EventAckTimeoutSec, "0 보다 커야 함", DEFAULT_ACK_TIMEOUT_SEC)
+		c.EventAckTimeoutSec = DEFAULT_ACK_TIMEOUT_SEC
+	}
+	if c.BackoffBaseMs < 1 {
+		v.reject("RECONNECT_BACKOFF_BASE_MS", c.BackoffBaseMs, "1 이상", DEFAULT_BACKOFF_BASE_MS)
+		c.BackoffBaseMs = DEFAULT_BACKOFF_BASE_MS
+	}
+	if c.BackoffMaxMs < c.BackoffBaseMs {
+		v.reject("RECONNECT_BACKOFF_MAX_MS", c.BackoffMaxMs, "RECONNECT_BACKOFF_BASE_MS 이상", c.BackoffBaseMs)
+		c.BackoffMaxMs = c.BackoffBaseMs
+	}
+	if c.BackoffJitterPct < 0 || c.BackoffJitterPct > 100 {
+		v.reject("RECONNECT_BACKOFF_JITTER_PCT", c.BackoffJitterPct, "0~100 범위", DEFAULT_BACKOFF_JITTER)
+		c.BackoffJitterPct = DEFAULT_BACKOFF_JITTER
+	}
+	if c.KeepaliveTimeMs != 0 && c.KeepaliveTimeMs < MIN_KEEPALIVE_PING { // gRPC 가 조용히 올려 쓰는 값은 미리 알림
+		v.reject("GRPC_KEEPALIVE_TIME_MS", c.KeepaliveTimeMs, fmt.Sprintf("0(끔) 또는 %d 이상", MIN_KEEPALIVE_PING), DEFAULT_KEEPALIVE_PING)
+		c.KeepaliveTimeMs = DEFAULT_KEEPALIVE_PING
+	}
+	if c.KeepaliveTimeoutMs < 1 {
+		v.reject("GRPC_KEEPALIVE_TIMEOUT_MS", c.KeepaliveTimeoutMs, "1 이상", DEFAULT_KEEPALIVE_WAIT)
+		c.KeepaliveTimeoutMs = DEFAULT_KEEPALIVE_WAIT
+	}
+	if !compression.Supported(c.Compression) {
+		v.reject("GRPC_COMPRESSION", c.Compression, strings.Join(compression.Names, " | ")+" 중 하나", DEFAULT_GRPC_COMPRESSION)
+		c.Compression = DEFAULT_GRPC_COMPRESSION
+	}
+	if c.MaxMessageBytes < MIN_MAX_MESSAGE {
+		v.reject("GRPC_MAX_MESSAGE_BYTES", c.MaxMessageBytes, fmt.Sprintf("%d 이상", MIN_MAX_MESSAGE), DEFAULT_MAX_MESSAGE)
+		c.MaxMessageBytes = DEFAULT_MAX_MESSAGE
+	}
+	if c.HeartbeatIntervalMs < 0 {
+		v.reject("HEARTBEAT_INTERVAL_MS", c.HeartbeatIntervalMs, "0 이상", DEFAULT_HEARTBEAT_MS)
+		c.HeartbeatIntervalMs = DEFAULT_HEARTBEAT_MS
+	}
+	if c.HeartbeatFailThreshold < 1 {
+		v.reject("HEARTBEAT_FAIL_THRESHOLD", c.HeartbeatFailThreshold, "1 이상", DEFAULT_HEARTBEAT_FAILS)
+		c.HeartbeatFailThreshold = DEFAULT_HEARTBEAT_FAILS
+	}
+	if c.SpoolFrameDrop != "oldest" && c.SpoolFrameDrop != "newest" {
+		v.reject("SPOOL_FRAME_DROP", c.SpoolFrameDrop, "oldest | newest 중 하나", DEFAULT_SPOOL_FRAME_DROP)
+		c.SpoolFrameDrop = DEFAULT_SPOOL_FRAME_DROP
+	}
+	if c.SpoolEventDrop != "oldest" && c.SpoolEventDrop != "newest" {
+		v.reject("SPOOL_EVENT_DROP", c.SpoolEventDrop, "oldest | newest 중 하나", DEFAULT_SPOOL_EVENT_DROP)
+		c.SpoolEventDrop = DEFAULT_SPOOL_EVENT_DROP
+	}
+	if c.SpoolMaxAgeSec < 0 {
+		v.reject("SPOOL_MAX_AGE_SEC", c.SpoolMaxAgeSec, "0 이상", DEFAULT_SPOOL_MAX_AGE)
+		c.SpoolMaxAgeSec = DEFAULT_SPOOL_MAX_AGE
+	}
+	if c.SpoolFrameIntervalMs < 0 {
+		v.reject("SPOOL_FRAME_INTERVAL_MS", c.SpoolFrameIntervalMs, "0 이상", DEFAULT_SPOOL_FRAME_GAP)
+		c.SpoolFrameIntervalMs = DEFAULT_SPOOL_FRAME_GAP
+	}
+	if c.FrameQueueSize < 1 {
+		v.reject("FRAME_QUEUE_SIZE", c.FrameQueueSize, "1 이상", DEFAULT_FRAME_QUEUE_SIZE)
+		c.FrameQueueSize = DEFAULT_FRAME_QUEUE_SIZE
+	}
+	if c.TestPatternWidth < 1 || c.TestPatternHeight < 1 {
+		size := fmt.Sprintf("%dx%d", c.TestPatternWidth, c.TestPatternHeight)
+		v.reject("CAPTURE_TEST_PATTERN_WIDTH", size, "폭과 높이 모두 1 이상", fmt.Sprintf("%dx%d", DEFAULT_PATTERN_WIDTH, DEFAULT_PATTERN_HEIGHT))
+		c.TestPatternWidth, c.TestPatternHeight = DEFAULT_PATTERN_WIDTH, DEFAULT_PATTERN_HEIGHT
+	}
+	if c.EncodeWorkers < 0 {
+		v.reject("CAPTURE_ENCODE_WORKERS", c.EncodeWorkers, "0 이상", DEFAULT_ENCODE_WORKERS)
+		c.EncodeWorkers = DEFAULT_ENCODE_WORKERS
+	}
+	if c.WatchdogFrames < 0 {
+		v.reject("CAPTURE_WATCHDOG_FRAMES", c.WatchdogFrames, "0 이상", DEFAULT_WATCHDOG_FRAMES)
+		c.WatchdogFrames = DEFAULT_WATCHDOG_FRAMES
+	}
+	return v.problems
+}
+
+// validateServerAddr 함수는 쉼표로 구분한 서버 주소 목록의 각 항목이 host:port 형식인지 검사합니다.
+// 잘못된 항목은 목록에서 빼고, 남는 주소가 없으면 기본 주소를 사용합니다. gRPC 대상 URI(dns:///, unix:)는 그대로 둡니다.
+func (c *Config) validateServerAddr(v *validator) { // 단일 책임: 서버 주소 검증
+	var valid []string
+	for _, addr := range strings.Split(c.ServerAddr, ",") {
+		if addr = strings.TrimSpace(addr); addr == "" {
+			continue
+		}
+		if strings.Contains(addr, "://") || strings.HasPrefix(addr, "unix:") {
+			valid = append(valid, addr)
+			continue
+		}
+		host, port, err := net.SplitHostPort(addr)
+		n, perr := strconv.Atoi(port)
+		if err != nil || host == "" || perr != nil || n < 1 || n > 65535 {
+			v.reject("AGENT_SERVER_ADDR", addr, "host:port 형식 (포트 1~65535, 여러 개는 쉼표로 구분)", "목록에서 제외")
+			continue
+		}
+		valid = append(valid, addr)
+	}
+	if len(valid) == 0 {
+		if strings.TrimSpace(c.ServerAddr) == "" {
+			v.reject("AGENT_SERVER_ADDR", c.ServerAddr, "서버 주소 필요", DEFAULT_SERVER_ADDR)
+		}
+		c.ServerAddr = DEFAULT_SERVER_ADDR
+		return
+	}
+	c.ServerAddr = strings.Join(valid, ",")
+}
+
+// IsLoopbackAddr 함수는 addr 이 루프백 호스트(127.0.0.0/8, ::1, localhost)의 host:port 인지 반환합니다 (포트 0 은 임의 포트).
+func IsLoopbackAddr(addr string) bool { // 단일 책임: 루프백 주소 판정
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 0 || n > 65535 {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
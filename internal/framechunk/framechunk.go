@@ -0,0 +1,85 @@
+package framechunk
+
+import (
+	"errors"
+	"fmt"
+
+	monitorProto "agent/proto"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	HEADER_RESERVE = 1024 // 조각 메시지에서 data 외 필드(agent_id, timestamp, FrameChunk 머리말)와 gRPC 인코딩 여유로 남길 바이트
+)
+
+// ErrOutOfOrder 변수는 이어지지 않는 조각을 받아 모으던 프레임을 버렸다는 오류입니다.
+var ErrOutOfOrder = errors.New("프레임 조각 순서 어긋남 - 모으던 프레임 폐기")
+
+// Split 함수는 직렬화 크기가 maxMessage 를 넘는 frame 을 FrameChunk 조각 메시지로 나눕니다.
+// 나눌 필요가 없으면 nil 을 반환합니다. 조각에는 수신 측 로그/정렬용으로 agent_id, timestamp, monitor_id 만 복사합니다.
+func Split(frame *monitorProto.FrameData, maxMessage int, frameID uint64) ([]*monitorProto.FrameData, error) { // 단일 책임: 큰 프레임 분할
+	if proto.Size(frame) <= maxMessage {
+		return nil, nil
+	}
+	step := maxMessage - HEADER_RESERVE
+	if step <= 0 {
+		return nil, fmt.Errorf("메시지 크기 상한이 너무 작음: %d", maxMessage)
+	}
+	raw, err := proto.Marshal(frame)
+	if err != nil {
+		return nil, fmt.Errorf("프레임 직렬화 실패: %w", err)
+	}
+	total := (len(raw) + step - 1) / step
+	chunks := make([]*monitorProto.FrameData, 0, total)
+	for i := 0; i < total; i++ {
+		end := min(len(raw), (i+1)*step)
+		chunks = append(chunks, &monitorProto.FrameData{
+			AgentId:   frame.GetAgentId(),
+			Timestamp: frame.GetTimestamp(),
+			MonitorId: frame.GetMonitorId(),
+			Chunk: &monitorProto.FrameChunk{
+				FrameId: frameID,
+				Index:   int32(i),
+				Total:   int32(total),
+				Data:    raw[i*step : end],
+			},
+		})
+	}
+	return chunks, nil
+}
+
+// Assembler 구조체는 한 스트림에서 받은 조각을 원본 FrameData 로 다시 모읍니다 (스트림마다 하나, 동시 사용 불가).
+type Assembler struct { // 단일 책임: 프레임 조각 재조립
+	id   uint64
+	next int32
+	buf  []byte
+}
+
+// Add 메서드는 msg 를 받아 완성된 프레임을 반환합니다. 조각이 아니면 msg 를 그대로, 아직 모으는 중이면 nil 을 반환합니다.
+// 이어지지 않는 조각(다른 frame_id 의 중간 조각, 빠진 index)을 받으면 모으던 프레임을 버리고 ErrOutOfOrder 를 반환합니다.
+func (a *Assembler) Add(msg *monitorProto.FrameData) (*monitorProto.FrameData, error) { // 단일 책임: 조각 누적
+	c := msg.GetChunk()
+	if c == nil {
+		return msg, nil
+	}
+	if c.GetIndex() == 0 { // 새 프레임 시작 (끝나지 않은 이전 프레임은 버림)
+		a.id, a.next, a.buf = c.GetFrameId(), 0, a.buf[:0]
+	}
+	if c.GetFrameId() != a.id || c.GetIndex() != a.next || c.GetTotal() <= c.GetIndex() {
+		a.next, a.buf = -1, a.buf[:0]
+		return nil, ErrOutOfOrder
+	}
+	a.buf = append(a.buf, c.GetData()...)
+	a.next++
+	if a.next < c.GetTotal() {
+		return nil, nil
+	}
+	frame := &monitorProto.FrameData{}
+	err := proto.Unmarshal(a.buf, frame)
+	a.next, a.buf = -1, a.buf[:0]
+	if err != nil {
+		return nil, fmt.Errorf("프레임 조각 역직렬화 실패: %w", err)
+	}
+	return frame, nil
+}
@@ -0,0 +1,153 @@
+package i18n
+
+// catalog 변수는 로캘별 사용자 표시 문구입니다 (key → 문구, fmt 형식 자리표시자 허용).
+// key 는 "영역.이름" 으로 짓고, 연결 상태는 "conn." 뒤에 agent.CONN_STATE_* 값을 붙입니다.
+// 프론트엔드도 GetMessages 로 같은 카탈로그를 받으므로 key 를 바꾸면 App.tsx 도 함께 고칩니다.
+var catalog = map[string]map[string]string{
+	LOCALE_KO: {
+		"conn.idle":         "대기",
+		"conn.connecting":   "연결 중",
+		"conn.ready":        "연결됨",
+		"conn.reconnecting": "재연결 중",
+		"conn.offline":      "오프라인",
+		"conn.failed":       "연결 실패",
+		"conn.unreachable":  "서버 응답 없음",
+		"conn.spooling":     "오프라인 보관 중",
+
+		"capture.active": "캡처 중",
+		"capture.idle":   "대기",
+		"capture.paused": "일시 중지 (%s 남음)",
+		"capture.start":  "캡처 시작",
+		"capture.stop":   "캡처 중지",
+		"capture.failed": "캡처 시작 실패: %s",
+
+		"status.connection": "서버 연결",
+		"status.capture":    "캡처 상태",
+		"status.fps":        "목표 FPS",
+		"status.mode":       "모드",
+		"status.monitors":   "모니터 수",
+		"status.bandwidth":  "송신량",
+		"bandwidth.summary": "1분 %s · 1시간 %s · 24시간 %s",
+
+		"consent.title":   "화면 캡처 동의",
+		"consent.body":    "이 에이전트는 화면을 캡처해 서버로 전송합니다. 모니터링 정책(버전 %s)에 동의해야 캡처를 시작합니다.",
+		"consent.changed": "모니터링 정책이 %s 에서 %s (으)로 바뀌어 다시 동의가 필요합니다.",
+		"consent.accept":  "동의하고 캡처 시작",
+		"consent.failed":  "동의 기록 실패: %s",
+
+		"tray.status.paused": "일시 중지 (%s 까지) · %s",
+		"tray.status.active": "캡처 중 · %s",
+		"tray.status.idle":   "대기 · %s",
+		"tray.start":         "캡처 시작",
+		"tray.stop":          "캡처 중지",
+		"tray.resume":        "캡처 재개",
+		"tray.toggle.tip":    "화면 캡처 시작/중지",
+		"tray.pause":         "%d분 일시 중지",
+		"tray.pause.tip":     "잠시 캡처를 멈추고 자동으로 다시 시작",
+		"tray.open":          "창 열기",
+		"tray.open.tip":      "에이전트 창 표시",
+		"tray.quit":          "종료",
+		"tray.quit.tip":      "에이전트 종료",
+
+		"notify.recovered":       "서버 연결 복구",
+		"notify.disconnected":    "서버 연결 끊김",
+		"notify.disconnect.body": "%s 넘게 연결되지 않았습니다. 화면/이벤트는 연결될 때까지 보관합니다.",
+		"notify.remote.start":    "원격 요청으로 화면 캡처 시작",
+		"notify.remote.stop":     "원격 요청으로 화면 캡처 중지",
+		"notify.remote.other":    "원격 명령 수신: %s",
+		"notify.remote.ok":       "처리 완료",
+		"notify.remote.failed":   "처리 실패: %s",
+
+		"overlay.capturing": "화면 전송 중",
+
+		"events.title": "서버로 보고한 이벤트",
+		"events.empty": "아직 보고한 이벤트 없음",
+
+		"error.consent_required":     "캡처 동의 필요 (UI 또는 'agent consent accept' 로 동의)",
+		"error.user_paused":          "캡처 일시 중지 중",
+		"error.outside_schedule":     "캡처 허용 시간대가 아님 (시간대가 시작되면 자동으로 캡처)",
+		"error.blocked_app":          "캡처 금지 앱이 전면에 있어 캡처가 일시 중지됨",
+		"error.window_not_found":     "캡처 대상 창을 찾을 수 없음",
+		"error.window_hidden":        "캡처 대상 창이 보이지 않음",
+		"error.window_unsupported":   "이 플랫폼에서는 창 캡처를 지원하지 않음",
+		"error.screen_recording":     "화면 기록 권한 없음 (시스템 설정 > 개인정보 보호 및 보안 > 화면 기록에서 허용 필요)",
+		"error.agent_id_fixed":       "AGENT_ID 설정으로 지정된 ID 는 재발급할 수 없음",
+		"error.device_name_too_long": "장치 이름이 너무 김",
+		"error.register_rejected":    "서버가 에이전트 등록을 거부",
+		"error.profile_busy":         "CPU 프로파일 수집 중",
+		"error.locale_unsupported":   "지원하지 않는 언어",
+	},
+	LOCALE_EN: {
+		"conn.idle":         "Idle",
+		"conn.connecting":   "Connecting",
+		"conn.ready":        "Connected",
+		"conn.reconnecting": "Reconnecting",
+		"conn.offline":      "Offline",
+		"conn.failed":       "Connection failed",
+		"conn.unreachable":  "Server not responding",
+		"conn.spooling":     "storing offline",
+
+		"capture.active": "Capturing",
+		"capture.idle":   "Idle",
+		"capture.paused": "Paused (%s left)",
+		"capture.start":  "Capture started",
+		"capture.stop":   "Capture stopped",
+		"capture.failed": "Failed to start capture: %s",
+
+		"status.connection": "Server",
+		"status.capture":    "Capture",
+		"status.fps":        "Target FPS",
+		"status.mode":       "Mode",
+		"status.monitors":   "Monitors",
+		"status.bandwidth":  "Data sent",
+		"bandwidth.summary": "1 min %s · 1 h %s · 24 h %s",
+
+		"consent.title":   "Screen capture consent",
+		"consent.body":    "This agent captures your screen and sends it to a server. Capture starts only after you accept the monitoring policy (version %s).",
+		"consent.changed": "The monitoring policy changed from %s to %s. Please accept it again.",
+		"consent.accept":  "Accept and start capture",
+		"consent.failed":  "Failed to record consent: %s",
+
+		"tray.status.paused": "Paused (until %s) · %s",
+		"tray.status.active": "Capturing · %s",
+		"tray.status.idle":   "Idle · %s",
+		"tray.start":         "Start capture",
+		"tray.stop":          "Stop capture",
+		"tray.resume":        "Resume capture",
+		"tray.toggle.tip":    "Start/stop screen capture",
+		"tray.pause":         "Pause for %d min",
+		"tray.pause.tip":     "Pause capture and resume automatically",
+		"tray.open":          "Open window",
+		"tray.open.tip":      "Show the agent window",
+		"tray.quit":          "Quit",
+		"tray.quit.tip":      "Quit the agent",
+
+		"notify.recovered":       "Server connection restored",
+		"notify.disconnected":    "Server connection lost",
+		"notify.disconnect.body": "Disconnected for more than %s. Frames and events are kept until the connection returns.",
+		"notify.remote.start":    "Screen capture started by remote request",
+		"notify.remote.stop":     "Screen capture stopped by remote request",
+		"notify.remote.other":    "Remote command received: %s",
+		"notify.remote.ok":       "Done",
+		"notify.remote.failed":   "Failed: %s",
+
+		"overlay.capturing": "Sharing screen",
+
+		"events.title": "Events reported to the server",
+		"events.empty": "No events reported yet",
+
+		"error.consent_required":     "Capture consent required (accept in the UI or run 'agent consent accept')",
+		"error.user_paused":          "Capture is paused",
+		"error.outside_schedule":     "Outside the allowed capture schedule (capture starts automatically when it begins)",
+		"error.blocked_app":          "Capture paused because a blocked app is in the foreground",
+		"error.window_not_found":     "Capture target window not found",
+		"error.window_hidden":        "Capture target window is not visible",
+		"error.window_unsupported":   "Window capture is not supported on this platform",
+		"error.screen_recording":     "Screen recording permission denied (allow it in System Settings > Privacy & Security > Screen Recording)",
+		"error.agent_id_fixed":       "An ID set by AGENT_ID cannot be regenerated",
+		"error.device_name_too_long": "Device name is too long",
+		"error.register_rejected":    "The server rejected agent registration",
+		"error.profile_busy":         "A CPU profile is already being collected",
+		"error.locale_unsupported":   "Unsupported language",
+	},
+}
@@ -0,0 +1,105 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	LOCALE_KO      = "ko"      // 한국어
+	LOCALE_EN      = "en"      // 영어 (한국어가 아닌 OS 로캘의 기본)
+	DEFAULT_LOCALE = LOCALE_KO // OS 로캘을 알 수 없을 때
+)
+
+// Locales 변수는 메시지 카탈로그가 있는 로캘 목록입니다.
+var Locales = []string{LOCALE_KO, LOCALE_EN}
+
+// current 변수는 T 와 Error 가 쓰는 현재 로캘입니다 (비었으면 DEFAULT_LOCALE).
+var current atomic.Value
+
+// Resolve 함수는 설정 값(빈 값이면 OS 로캘)을 카탈로그가 있는 로캘로 맞춥니다.
+// "ko_KR.UTF-8", "en-US" 처럼 지역/문자셋이 붙어 있어도 언어 부분으로 고르며, 지원하지 않는 언어는 영어입니다.
+func Resolve(tag string) string { // 단일 책임: 로캘 결정
+	if tag == "" {
+		tag = Detect()
+	}
+	lang, _, _ := strings.Cut(strings.ToLower(tag), ".")
+	lang, _, _ = strings.Cut(strings.ReplaceAll(lang, "_", "-"), "-")
+	switch lang {
+	case "":
+		return DEFAULT_LOCALE
+	case LOCALE_KO:
+		return LOCALE_KO
+	}
+	return LOCALE_EN
+}
+
+// Supported 함수는 tag 가 카탈로그가 있는 로캘 이름인지 반환합니다.
+func Supported(tag string) bool { // 단일 책임: 로캘 지원 확인
+	_, ok := catalog[tag]
+	return ok
+}
+
+// SetLocale 함수는 현재 로캘을 바꿉니다 (지원하지 않으면 DEFAULT_LOCALE).
+func SetLocale(tag string) { // 단일 책임: 현재 로캘 변경
+	if !Supported(tag) {
+		tag = DEFAULT_LOCALE
+	}
+	current.Store(tag)
+}
+
+// Locale 함수는 현재 로캘을 반환합니다.
+func Locale() string { // 단일 책임: 현재 로캘 조회
+	if tag, ok := current.Load().(string); ok {
+		return tag
+	}
+	return DEFAULT_LOCALE
+}
+
+// T 함수는 key 의 현재 로캘 문구를 반환합니다. args 가 있으면 fmt 형식으로 채우며,
+// 현재 로캘에 없으면 기본 로캘, 그래도 없으면 key 자체를 씁니다.
+func T(key string, args ...any) string { // 단일 책임: 문구 번역
+	msg, ok := catalog[Locale()][key]
+	if !ok {
+		if msg, ok = catalog[DEFAULT_LOCALE][key]; !ok {
+			msg = key
+		}
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Messages 함수는 현재 로캘의 전체 카탈로그 사본을 반환합니다 (프론트엔드 문구용, 빠진 항목은 기본 로캘로 채움).
+func Messages() map[string]string { // 단일 책임: 카탈로그 조회
+	res := make(map[string]string, len(catalog[DEFAULT_LOCALE]))
+	for k, v := range catalog[DEFAULT_LOCALE] {
+		res[k] = v
+	}
+	for k, v := range catalog[Locale()] {
+		res[k] = v
+	}
+	return res
+}
+
+// Error 구조체는 Error() 를 부를 때 현재 로캘로 번역되는 오류입니다. 포인터로 비교되므로 errors.Is 센티널로 씁니다.
+type Error struct {
+	key string
+}
+
+// NewError 함수는 카탈로그 key 로 번역되는 오류를 만듭니다.
+func NewError(key string) error { // 단일 책임: 번역 오류 생성
+	return &Error{key: key}
+}
+
+// Error 메서드는 현재 로캘의 오류 문구를 반환합니다.
+func (e *Error) Error() string { // 단일 책임: 오류 문구 번역
+	return T(e.key)
+}
+
+// Key 메서드는 오류의 카탈로그 key 를 반환합니다 (프론트엔드가 직접 번역할 때).
+func (e *Error) Key() string { // 단일 책임: 오류 key 조회
+	return e.key
+}
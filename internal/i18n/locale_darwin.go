@@ -0,0 +1,18 @@
+//go:build darwin
+
+package i18n
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// Detect 함수는 OS 로캘 태그를 반환합니다. 앱 번들로 실행하면 LANG 이 없으므로 시스템 설정의 AppleLocale 을 먼저 봅니다.
+func Detect() string { // 단일 책임: OS 로캘 감지
+	if out, err := exec.Command("defaults", "read", "-g", "AppleLocale").Output(); err == nil {
+		if tag := strings.TrimSpace(string(out)); tag != "" {
+			return tag
+		}
+	}
+	return envLocale()
+}
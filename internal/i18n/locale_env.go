@@ -0,0 +1,13 @@
+package i18n
+
+import "os"
+
+// envLocale 함수는 POSIX 로캘 환경 변수(LC_ALL → LC_MESSAGES → LANG)를 반환합니다. "C"/"POSIX" 는 없는 것으로 봅니다.
+func envLocale() string { // 단일 책임: 환경 변수 로캘 조회
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(name); v != "" && v != "C" && v != "POSIX" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,8 @@
+//go:build !windows && !darwin
+
+package i18n
+
+// Detect 함수는 OS 로캘 태그를 반환합니다 (Linux/BSD 는 로캘 환경 변수, 없으면 빈 값).
+func Detect() string { // 단일 책임: OS 로캘 감지
+	return envLocale()
+}
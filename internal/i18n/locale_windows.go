@@ -0,0 +1,23 @@
+//go:build windows
+
+package i18n
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const LOCALE_NAME_MAX_LENGTH = 85 // Windows 로캘 이름 최대 길이(문자, NUL 포함)
+
+// procGetUserDefaultLocaleName 변수는 사용자 기본 로캘 이름("ko-KR") 조회 API 입니다.
+var procGetUserDefaultLocaleName = windows.NewLazySystemDLL("kernel32.dll").NewProc("GetUserDefaultLocaleName")
+
+// Detect 함수는 OS 로캘 태그를 반환합니다 (사용자 기본 로캘, 실패하면 로캘 환경 변수).
+func Detect() string { // 단일 책임: OS 로캘 감지
+	buf := make([]uint16, LOCALE_NAME_MAX_LENGTH)
+	if n, _, _ := procGetUserDefaultLocaleName.Call(uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf))); n > 0 {
+		return windows.UTF16ToString(buf)
+	}
+	return envLocale()
+}
@@ -1,15 +1,39 @@
 package logging
 
 import (
+	"fmt"
+
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
+// level 변수는 NewLogger 로 만든 로거가 공유하는 로그 수준입니다 (실행 중 SetLevel 로 변경).
+var level = zap.NewAtomicLevelAt(zapcore.InfoLevel)
+
 // NewLogger 함수는 환경에 맞춘 SugaredLogger 를 생성합니다.
 func NewLogger() (*zap.SugaredLogger, error) { // 단일 책임: 로거 초기화
 	cfg := zap.NewProductionConfig()
-	l, err := cfg.Build()
+	cfg.Level = level
+	l, err := cfg.Build(zap.WrapCore(func(core zapcore.Core) zapcore.Core { // 최근 로그 버퍼 병행 기록
+		return zapcore.NewTee(core, newRecentCore(recent, cfg.Level))
+	}))
 	if err != nil {
 		return nil, err
 	}
 	return l.Sugar(), nil
 }
+
+// SetLevel 함수는 로그 수준을 바꿉니다 (debug | info | warn | error). 이미 만든 로거에도 즉시 적용됩니다.
+func SetLevel(name string) error { // 단일 책임: 로그 수준 변경
+	switch name {
+	case "debug", "info", "warn", "error":
+	default:
+		return fmt.Errorf("지원하지 않는 로그 수준: %q (debug | info | warn | error)", name)
+	}
+	return level.UnmarshalText([]byte(name))
+}
+
+// Level 함수는 현재 로그 수준 이름을 반환합니다.
+func Level() string { // 단일 책임: 로그 수준 조회
+	return level.Level().String()
+}
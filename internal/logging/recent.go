@@ -0,0 +1,180 @@
+package logging
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	RECENT_LOG_CAPACITY = 500 // 메모리에 보관할 최근 로그 라인 수
+)
+
+// Entry 구조체는 최근 로그 한 건입니다 (앱 로그 콘솔 표시용).
+type Entry struct {
+	Seq     uint64 `json:"seq"`     // 1부터 증가하는 순번 (이어 받기 기준)
+	Time    string `json:"time"`    // 기록 시각 (RFC3339)
+	Level   string `json:"level"`   // debug | info | warn | error
+	Message string `json:"message"` // 로그 메시지
+	Line    string `json:"line"`    // 구조화 필드를 포함한 JSON 라인
+}
+
+// listeners 변수는 Subscribe 로 등록한 새 로그 수신 함수입니다.
+var listeners struct {
+	mu   sync.Mutex
+	next int
+	fns  map[int]func(Entry)
+}
+
+// recent 변수는 프로세스 전역 최근 로그 버퍼입니다.
+var recent = newRecentBuffer(RECENT_LOG_CAPACITY)
+
+// recentBuffer 구조체는 최근 로그 라인을 고정 크기 링 버퍼로 보관합니다.
+type recentBuffer struct { // 단일 책임: 최근 로그 보관
+	mu      sync.Mutex
+	entries []Entry
+	next    int
+	full    bool
+	seq     uint64 // 마지막으로 기록한 순번
+}
+
+// newRecentBuffer 함수는 recentBuffer 생성자입니다.
+func newRecentBuffer(capacity int) *recentBuffer { // 단일 책임: 인스턴스 생성
+	return &recentBuffer{entries: make([]Entry, capacity)}
+}
+
+// add 함수는 로그 라인을 버퍼에 추가합니다 (가득 차면 가장 오래된 라인 덮어쓰기).
+func (r *recentBuffer) add(e Entry) Entry { // 단일 책임: 엔트리 추가
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	e.Seq = r.seq
+	r.entries[r.next] = e
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+	return e
+}
+
+// snapshot 함수는 오래된 순서로 순번이 after 보다 큰 최근 엔트리를 최대 n 개 복사해 반환합니다.
+func (r *recentBuffer) snapshot(n int, after uint64) []Entry { // 단일 책임: 버퍼 스냅샷
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	size := r.next
+	if r.full {
+		size = len(r.entries)
+	}
+	if newer := r.seq - after; after > 0 && newer < uint64(size) {
+		size = int(newer)
+	}
+	if n <= 0 || n > size {
+		n = size
+	}
+	out := make([]Entry, 0, n)
+	start := (r.next - n + len(r.entries)) % len(r.entries)
+	for i := 0; i < n; i++ {
+		out = append(out, r.entries[(start+i)%len(r.entries)])
+	}
+	return out
+}
+
+// RecentLines 함수는 최근 로그 라인을 최대 n 개 반환합니다 (n<=0 이면 전체).
+func RecentLines(n int) []string { // 단일 책임: 최근 로그 조회
+	entries := recent.snapshot(n, 0)
+	lines := make([]string, len(entries))
+	for i, e := range entries {
+		lines[i] = e.Line
+	}
+	return lines
+}
+
+// RecentEntries 함수는 순번이 after 보다 큰 최근 로그를 오래된 순으로 최대 n 개 반환합니다 (n<=0 이면 전체, after 0 이면 처음부터).
+func RecentEntries(n int, after uint64) []Entry { // 단일 책임: 최근 로그 엔트리 조회
+	return recent.snapshot(n, after)
+}
+
+// Subscribe 함수는 새 로그가 기록될 때마다 fn 을 호출하도록 등록하고 해제 함수를 반환합니다.
+// fn 은 로그를 남긴 고루틴에서 바로 호출되므로 막히지 않아야 하며 로그를 남기면 안 됩니다.
+func Subscribe(fn func(Entry)) (cancel func()) { // 단일 책임: 새 로그 수신 등록
+	listeners.mu.Lock()
+	defer listeners.mu.Unlock()
+	if listeners.fns == nil {
+		listeners.fns = map[int]func(Entry){}
+	}
+	id := listeners.next
+	listeners.next++
+	listeners.fns[id] = fn
+	return func() {
+		listeners.mu.Lock()
+		delete(listeners.fns, id)
+		listeners.mu.Unlock()
+	}
+}
+
+// notify 함수는 등록된 수신 함수에 새 로그를 전달합니다.
+func notify(e Entry) { // 단일 책임: 새 로그 전달
+	listeners.mu.Lock()
+	defer listeners.mu.Unlock()
+	for _, fn := range listeners.fns {
+		fn(e)
+	}
+}
+
+// recentCore 구조체는 로그 엔트리를 JSON 라인으로 직렬화해 recentBuffer 에 기록하는 zapcore.Core 입니다.
+type recentCore struct { // 단일 책임: 최근 로그 수집 코어
+	zapcore.LevelEnabler
+	enc zapcore.Encoder
+	buf *recentBuffer
+}
+
+// newRecentCore 함수는 recentCore 생성자입니다.
+func newRecentCore(buf *recentBuffer, level zapcore.LevelEnabler) *recentCore { // 단일 책임: 인스턴스 생성
+	encCfg := zapcore.EncoderConfig{
+		TimeKey:        "ts",
+		LevelKey:       "level",
+		MessageKey:     "msg",
+		EncodeTime:     zapcore.ISO8601TimeEncoder,
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeDuration: zapcore.StringDurationEncoder,
+	}
+	return &recentCore{LevelEnabler: level, enc: zapcore.NewJSONEncoder(encCfg), buf: buf}
+}
+
+// With 함수는 필드가 추가된 하위 코어를 반환합니다.
+func (c *recentCore) With(fields []zapcore.Field) zapcore.Core { // 단일 책임: 필드 누적
+	enc := c.enc.Clone()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	return &recentCore{LevelEnabler: c.LevelEnabler, enc: enc, buf: c.buf}
+}
+
+// Check 함수는 활성 레벨이면 엔트리에 코어를 등록합니다.
+func (c *recentCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry { // 단일 책임: 레벨 필터
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write 함수는 엔트리를 인코딩해 버퍼에 추가합니다.
+func (c *recentCore) Write(ent zapcore.Entry, fields []zapcore.Field) error { // 단일 책임: 엔트리 기록
+	b, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	line := string(b.Bytes())
+	b.Free()
+	if n := len(line); n > 0 && line[n-1] == '\n' {
+		line = line[:n-1]
+	}
+	notify(c.buf.add(Entry{Time: ent.Time.Format(time.RFC3339Nano), Level: ent.Level.String(), Message: ent.Message, Line: line}))
+	return nil
+}
+
+// Sync 함수는 메모리 버퍼이므로 수행할 작업이 없습니다.
+func (c *recentCore) Sync() error { // 단일 책임: 동기화 (무동작)
+	return nil
+}
@@ -0,0 +1,357 @@
+package loopback
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	_ "agent/internal/compression" // 에이전트 GRPC_COMPRESSION(gzip, snappy) 수신용 압축기 등록
+	"agent/internal/framechunk"
+	monitorProto "agent/proto"
+
+	"go.uber.org/zap"
+	grpcPkg "google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	LOOPBACK_LISTEN_ADDR   = "127.0.0.1:0"    // 임의 포트 로컬 수신 주소
+	LOOPBACK_PING_MIN_TIME = 10 * time.Second // 허용하는 에이전트 keepalive ping 최소 간격 (gRPC 클라이언트 최소값)
+	LOOPBACK_MAX_MESSAGE   = 64 << 20         // 수신 메시지 크기 상한 (에이전트 GRPC_MAX_MESSAGE_BYTES 를 기본값보다 올려도 받도록)
+	LOOPBACK_DIR_NAME      = "loopback"       // 데이터 디렉터리 하위 수신 결과 폴더
+	LOOPBACK_RECENT_EVENTS = 100              // 메모리에 보관할 최근 이벤트 수
+	LOOPBACK_EVENTS_FILE   = "events.log"     // 수신 이벤트 기록 파일
+	LOOPBACK_LATEST_PREFIX = "latest"         // 마지막 수신 프레임 파일명 접두사
+	LOOPBACK_ACK_MESSAGE   = "loopback"       // 스트림 종료 응답 메시지
+	LOOPBACK_VIDEO_PREFIX  = "video-"         // 영상 세션 파일명 접두사 (세션 ID 가 뒤에 붙음)
+)
+
+// Status 구조체는 루프백 서버가 수신한 내용의 요약입니다 (UI 노출용).
+type Status struct {
+	Addr            string   `json:"addr"`            // 수신 주소
+	AgentVersion    string   `json:"agentVersion"`    // 등록한 에이전트 버전 (미등록이면 빈 값)
+	Frames          int64    `json:"frames"`          // 수신 프레임 수
+	FrameBytes      int64    `json:"frameBytes"`      // 수신 프레임 누적 바이트
+	UnchangedFrames int64    `json:"unchangedFrames"` // 수신 프레임 중 동일 화면 표시 프레임 수
+	PreviewFrames   int64    `json:"previewFrames"`   // 수신 프레임 중 preview(IsPreview) 프레임 수 (이중 스트림 확인용)
+	ChunkedFrames   int64    `json:"chunkedFrames"`   // 수신 프레임 중 FrameChunk 조각을 모아 복원한 프레임 수
+	LostChunks      int64    `json:"lostChunks"`      // 순서가 어긋나 버린 조각 수 (전송 중 스트림 재연결)
+	Monitors        []int32  `json:"monitors"`        // 프레임을 받은 모니터 ID 목록 (all 모드 확인용, 수신 순)
+	Events          int64    `json:"events"`          // 수신 이벤트 수 (묶음 해제 기준)
+	DuplicateEvents int64    `json:"duplicateEvents"` // 확인 스트림에서 이미 받은 순번이라 버린 재전송 이벤트 수
+	Diagnostics     int64    `json:"diagnostics"`     // 수신 진단 번들 수
+	Recordings      int64    `json:"recordings"`      // 수신 완료 녹화 수
+	LastFrameAt     int64    `json:"lastFrameAt"`     // 마지막 프레임 타임스탬프(ms)
+	LastEncoding    string   `json:"lastEncoding"`    // 마지막 프레임 인코딩
+	LastFramePath   string   `json:"lastFramePath"`   // 마지막 프레임 저장 경로
+	VideoChunks     int64    `json:"videoChunks"`     // 수신 H.264 청크 수
+	VideoBytes      int64    `json:"videoBytes"`      // 수신 H.264 누적 바이트
+	LastVideoPath   string   `json:"lastVideoPath"`   // 마지막 영상 세션 저장 경로 (ffplay 로 재생 가능)
+	RecentEvents    []string `json:"recentEvents"`    // 최근 이벤트 요약 (오래된 순)
+	OutputDirectory string   `json:"outputDirectory"` // 수신 결과 저장 디렉터리
+}
+
+// Server 구조체는 외부 수집 서버 없이 에이전트를 끝까지 실행해 볼 수 있는 프로세스 내 AgentService 구현입니다.
+type Server struct { // 단일 책임: 로컬 수신 + 결과 보관
+	monitorProto.UnimplementedAgentServiceServer
+
+	grpcServer *grpcPkg.Server
+	listener   net.Listener
+	dir        string
+	logger     *zap.SugaredLogger
+
+	mu     sync.Mutex
+	status Status
+	acked  map[string]uint64 // 에이전트 ID → 확인 스트림 마지막 수신 순번 (중복 제거)
+}
+
+// Start 함수는 로컬 임의 포트에서 루프백 서버를 시작합니다. 수신 결과는 dataDir/loopback 에 저장됩니다.
+func Start(dataDir string, logger *zap.SugaredLogger) (*Server, error) { // 단일 책임: 서버 시작
+	dir := filepath.Join(dataDir, LOOPBACK_DIR_NAME)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("루프백 디렉터리 생성 실패: %w", err)
+	}
+	lis, err := net.Listen("tcp", LOOPBACK_LISTEN_ADDR)
+	if err != nil {
+		return nil, fmt.Errorf("루프백 수신 대기 실패: %w", err)
+	}
+	if logger == nil {
+		logger = zap.NewNop().Sugar()
+	}
+	s := &Server{
+		grpcServer: grpcPkg.NewServer(
+			grpcPkg.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{MinTime: LOOPBACK_PING_MIN_TIME, PermitWithoutStream: true}),
+			grpcPkg.MaxRecvMsgSize(LOOPBACK_MAX_MESSAGE),
+		),
+		listener: lis,
+		dir:      dir,
+		logger:   logger,
+		status:   Status{Addr: lis.Addr().String(), OutputDirectory: dir},
+		acked:    make(map[string]uint64),
+	}
+	monitorProto.RegisterAgentServiceServer(s.grpcServer, s)
+	go func() {
+		if err := s.grpcServer.Serve(lis); err != nil {
+			s.logger.Warnf("루프백 서버 종료: %v", err)
+		}
+	}()
+	s.logger.Infof("루프백 서버 시작 (%s) 저장 위치=%s", s.status.Addr, dir)
+	return s, nil
+}
+
+// Addr 함수는 에이전트가 접속할 서버 주소를 반환합니다.
+func (s *Server) Addr() string { // 단일 책임: 주소 조회
+	return s.status.Addr
+}
+
+// Status 함수는 현재까지 수신 요약의 복사본을 반환합니다.
+func (s *Server) Status() Status { // 단일 책임: 수신 요약 조회
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.status
+	st.RecentEvents = append([]string(nil), s.status.RecentEvents...)
+	st.Monitors = slices.Clone(s.status.Monitors)
+	return st
+}
+
+// Stop 함수는 진행 중 스트림을 정리하고 서버를 종료합니다.
+func (s *Server) Stop() { // 단일 책임: 서버 종료
+	s.grpcServer.Stop()
+}
+
+// StreamFrames 함수는 프레임을 수신해 집계하고 마지막 프레임을 파일로 저장합니다.
+func (s *Server) StreamFrames(stream grpcPkg.ClientStreamingServer[monitorProto.FrameData, monitorProto.StreamAck]) error { // 단일 책임: 프레임 수신
+	var chunks framechunk.Assembler
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&monitorProto.StreamAck{Success: true, Message: LOOPBACK_ACK_MESSAGE})
+		}
+		if err != nil {
+			return err
+		}
+		frame, err := chunks.Add(msg)
+		if err != nil { // 조각 하나 때문에 스트림을 끊지 않고 그 프레임만 버림
+			s.logger.Debugf("루프백 프레임 조각 폐기: %v", err)
+			s.mu.Lock()
+			s.status.LostChunks++
+			s.mu.Unlock()
+			continue
+		}
+		if frame == nil {
+			continue
+		}
+		if msg.GetChunk() != nil {
+			s.mu.Lock()
+			s.status.ChunkedFrames++
+			s.mu.Unlock()
+		}
+		s.recordFrame(frame)
+	}
+}
+
+// StreamVideo 함수는 H.264 청크를 세션별 .h264 파일에 이어 씁니다.
+func (s *Server) StreamVideo(stream grpcPkg.ClientStreamingServer[monitorProto.VideoChunk, monitorProto.StreamAck]) error { // 단일 책임: 영상 수신
+	var f *os.File
+	session := ""
+	defer func() {
+		if f != nil {
+			_ = f.Close()
+		}
+	}()
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&monitorProto.StreamAck{Success: true, Message: LOOPBACK_ACK_MESSAGE})
+		}
+		if err != nil {
+			return err
+		}
+		if chunk.GetSessionId() != session { // 인코더 세션 변경: 새 파일
+			if f != nil {
+				_ = f.Close()
+			}
+			session = chunk.GetSessionId()
+			path := filepath.Join(s.dir, LOOPBACK_VIDEO_PREFIX+filepath.Base(session)+"."+chunk.GetCodec())
+			if f, err = os.Create(path); err != nil {
+				return err
+			}
+			s.mu.Lock()
+			s.status.LastVideoPath = path
+			s.mu.Unlock()
+		}
+		if _, err := f.Write(chunk.GetData()); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		s.status.VideoChunks++
+		s.status.VideoBytes += int64(len(chunk.GetData()))
+		s.mu.Unlock()
+	}
+}
+
+// StreamEvents 함수는 이벤트를 수신해 묶음을 풀어 기록합니다.
+func (s *Server) StreamEvents(stream grpcPkg.ClientStreamingServer[monitorProto.EventData, monitorProto.StreamAck]) error { // 단일 책임: 이벤트 수신
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&monitorProto.StreamAck{Success: true, Message: LOOPBACK_ACK_MESSAGE})
+		}
+		if err != nil {
+			return err
+		}
+		if len(event.GetBatch()) > 0 {
+			for _, e := range event.GetBatch() {
+				s.recordEvent(e)
+			}
+			continue
+		}
+		s.recordEvent(event)
+	}
+}
+
+// StreamAckedEvents 함수는 확인 기반 이벤트를 받아 처음 보는 순번만 기록하고 받은 순번을 바로 확인합니다.
+func (s *Server) StreamAckedEvents(stream grpcPkg.BidiStreamingServer[monitorProto.EventData, monitorProto.EventAck]) error { // 단일 책임: 확인 이벤트 수신
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.mu.Lock()
+		last := s.acked[event.GetAgentId()]
+		fresh := event.GetSeq() > last
+		if fresh {
+			s.acked[event.GetAgentId()] = event.GetSeq()
+		} else {
+			s.status.DuplicateEvents++
+		}
+		s.mu.Unlock()
+		if fresh {
+			s.recordEvent(event)
+		}
+		if err := stream.Send(&monitorProto.EventAck{AgentId: event.GetAgentId(), Seq: max(last, event.GetSeq())}); err != nil {
+			return err
+		}
+	}
+}
+
+// Register 함수는 에이전트 등록을 수락하고 버전을 기록합니다. 설정은 지정하지 않아 에이전트 로컬 설정을 그대로 사용합니다.
+func (s *Server) Register(_ context.Context, req *monitorProto.RegisterRequest) (*monitorProto.RegisterResponse, error) { // 단일 책임: 등록 수신
+	s.mu.Lock()
+	s.status.AgentVersion = req.GetVersion()
+	s.mu.Unlock()
+	return &monitorProto.RegisterResponse{Accepted: true, Message: LOOPBACK_ACK_MESSAGE}, nil
+}
+
+// UploadDiagnostics 함수는 진단 번들을 출력 디렉터리에 저장합니다.
+func (s *Server) UploadDiagnostics(_ context.Context, bundle *monitorProto.DiagnosticsBundle) (*monitorProto.StreamAck, error) { // 단일 책임: 진단 번들 수신
+	path := filepath.Join(s.dir, filepath.Base(bundle.GetFileName()))
+	if err := os.WriteFile(path, bundle.GetArchive(), 0o644); err != nil {
+		return &monitorProto.StreamAck{Success: false, Message: err.Error()}, nil
+	}
+	s.mu.Lock()
+	s.status.Diagnostics++
+	s.mu.Unlock()
+	return &monitorProto.StreamAck{Success: true, Message: path}, nil
+}
+
+// UploadRecording 함수는 녹화 청크를 순서대로 받아 출력 디렉터리에 파일로 조립합니다.
+func (s *Server) UploadRecording(stream grpcPkg.ClientStreamingServer[monitorProto.RecordingChunk, monitorProto.StreamAck]) error { // 단일 책임: 녹화 수신
+	var f *os.File
+	defer func() {
+		if f != nil {
+			_ = f.Close()
+		}
+	}()
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&monitorProto.StreamAck{Success: false, Message: "마지막 청크 없이 종료"})
+		}
+		if err != nil {
+			return err
+		}
+		if f == nil {
+			name := filepath.Base(chunk.GetRecordingId()) + ".arec"
+			if f, err = os.Create(filepath.Join(s.dir, name)); err != nil {
+				return err
+			}
+		}
+		if _, err := f.WriteAt(chunk.GetData(), chunk.GetOffset()); err != nil {
+			return err
+		}
+		if chunk.GetLast() {
+			s.mu.Lock()
+			s.status.Recordings++
+			s.mu.Unlock()
+			return stream.SendAndClose(&monitorProto.StreamAck{Success: true, Message: f.Name()})
+		}
+	}
+}
+
+// recordFrame 함수는 프레임 통계를 갱신하고 이미지가 있으면 latest.<encoding> 으로 덮어씁니다.
+func (s *Server) recordFrame(frame *monitorProto.FrameData) { // 단일 책임: 프레임 집계
+	path := ""
+	if len(frame.GetImageData()) > 0 {
+		ext := frame.GetEncoding()
+		if ext == "" {
+			ext = "png"
+		}
+		path = filepath.Join(s.dir, LOOPBACK_LATEST_PREFIX+"."+ext)
+		if err := os.WriteFile(path, frame.GetImageData(), 0o644); err != nil {
+			s.logger.Warnf("루프백 프레임 저장 실패: %v", err)
+			path = ""
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.Frames++
+	if frame.GetUnchanged() {
+		s.status.UnchangedFrames++
+	}
+	if frame.GetIsPreview() {
+		s.status.PreviewFrames++
+	}
+	if id := frame.GetMonitorId(); !slices.Contains(s.status.Monitors, id) {
+		s.status.Monitors = append(s.status.Monitors, id)
+	}
+	s.status.FrameBytes += int64(len(frame.GetImageData()))
+	for _, region := range frame.GetRegions() { // tiles 인코딩: 변경 영역 이미지 합계
+		s.status.FrameBytes += int64(len(region.GetData()))
+	}
+	s.status.LastFrameAt = frame.GetTimestamp()
+	s.status.LastEncoding = frame.GetEncoding()
+	if path != "" {
+		s.status.LastFramePath = path
+	}
+}
+
+// recordEvent 함수는 이벤트를 최근 목록과 events.log 에 기록합니다.
+func (s *Server) recordEvent(event *monitorProto.EventData) { // 단일 책임: 이벤트 기록
+	line := fmt.Sprintf("%s %s %s", time.UnixMilli(event.GetTimestamp()).Format(time.RFC3339), event.GetEventType(), event.GetEventDetail())
+	if event.GetSeverity() != "" {
+		line = fmt.Sprintf("%s [%s/%s]", line, event.GetCategory(), event.GetSeverity())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.Events++
+	s.status.RecentEvents = append(s.status.RecentEvents, line)
+	if over := len(s.status.RecentEvents) - LOOPBACK_RECENT_EVENTS; over > 0 {
+		s.status.RecentEvents = s.status.RecentEvents[over:]
+	}
+	f, err := os.OpenFile(filepath.Join(s.dir, LOOPBACK_EVENTS_FILE), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	_, _ = fmt.Fprintln(f, line)
+	_ = f.Close()
+}
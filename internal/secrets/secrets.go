@@ -0,0 +1,95 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/zalando/go-keyring"
+)
+
+const (
+	SERVICE_NAME = "agent" // OS 비밀 저장소 서비스명 (Windows 자격 증명 관리자/macOS 키체인/libsecret 공통)
+
+	AUTH_TOKEN     = "auth_token"     // 서버 인증 API 키/JWT
+	TLS_CLIENT_KEY = "tls_client_key" // 상호 TLS 클라이언트 개인키 PEM
+	AGENT_ID       = "agent_id"       // 에이전트 고유 ID
+)
+
+// Names 변수는 이 패키지가 관리하는 비밀 이름 목록입니다 (CLI 조회/검증용).
+var Names = []string{AUTH_TOKEN, TLS_CLIENT_KEY, AGENT_ID}
+
+var (
+	ErrNotFound = errors.New("저장된 비밀 없음")
+	ErrDisabled = errors.New("비밀 저장소 사용 안 함")
+	ErrUnknown  = errors.New("알 수 없는 비밀 이름")
+)
+
+// disabled 변수는 AGENT_SECRET_STORE=false 등으로 저장소 사용을 끈 상태입니다 (키체인 잠금 해제 창/D-Bus 호출 방지).
+var disabled atomic.Bool
+
+// SetEnabled 함수는 비밀 저장소 사용 여부를 지정합니다. 끄면 모든 호출이 ErrDisabled 를 반환합니다.
+func SetEnabled(on bool) { // 단일 책임: 사용 여부 지정
+	disabled.Store(!on)
+}
+
+// Get 함수는 name 비밀을 OS 비밀 저장소에서 읽습니다. 없으면 ErrNotFound 입니다.
+func Get(name string) (string, error) { // 단일 책임: 비밀 조회
+	if err := check(name); err != nil {
+		return "", err
+	}
+	return Lookup(SERVICE_NAME, name)
+}
+
+// Set 함수는 name 비밀을 OS 비밀 저장소에 저장합니다 (있으면 덮어씀).
+func Set(name, value string) error { // 단일 책임: 비밀 저장
+	if err := check(name); err != nil {
+		return err
+	}
+	if err := keyring.Set(SERVICE_NAME, name, value); err != nil {
+		return fmt.Errorf("비밀 저장 실패 (%s): %w", name, err)
+	}
+	return nil
+}
+
+// Delete 함수는 name 비밀을 지웁니다. 원래 없었으면 ErrNotFound 입니다.
+func Delete(name string) error { // 단일 책임: 비밀 삭제
+	if err := check(name); err != nil {
+		return err
+	}
+	if err := keyring.Delete(SERVICE_NAME, name); err != nil {
+		if errors.Is(err, keyring.ErrNotFound) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("비밀 삭제 실패 (%s): %w", name, err)
+	}
+	return nil
+}
+
+// Lookup 함수는 임의 서비스/계정의 항목을 읽습니다 (배포 도구가 직접 넣은 토큰 등, 사용 안 함 설정은 그대로 적용).
+func Lookup(service, user string) (string, error) { // 단일 책임: 외부 항목 조회
+	if disabled.Load() {
+		return "", ErrDisabled
+	}
+	v, err := keyring.Get(service, user)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("비밀 저장소 조회 실패 (%s/%s): %w", service, user, err)
+	}
+	return v, nil
+}
+
+// check 함수는 저장소 사용 여부와 비밀 이름을 확인합니다.
+func check(name string) error { // 단일 책임: 호출 전제 확인
+	if disabled.Load() {
+		return ErrDisabled
+	}
+	for _, n := range Names {
+		if n == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrUnknown, name)
+}
@@ -0,0 +1,19 @@
+//go:build !linux && !darwin && !freebsd && !windows
+
+package spool
+
+import (
+	"fmt"
+	"os"
+)
+
+// mapFile 함수는 미지원 OS 임을 알립니다.
+func mapFile(f *os.File, size int) ([]byte, error) { // 단일 책임: 파일 매핑 (미지원)
+	return nil, fmt.Errorf("지원하지 않는 OS")
+}
+
+// flushMap 함수는 미지원 OS 에서 아무것도 하지 않습니다.
+func flushMap(b []byte) error { return nil }
+
+// unmapFile 함수는 미지원 OS 에서 아무것도 하지 않습니다.
+func unmapFile(b []byte) error { return nil }
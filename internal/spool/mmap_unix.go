@@ -0,0 +1,24 @@
+//go:build linux || darwin || freebsd
+
+package spool
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// mapFile 함수는 파일 앞부분 size 바이트를 읽기/쓰기 공유 매핑합니다.
+func mapFile(f *os.File, size int) ([]byte, error) { // 단일 책임: 파일 매핑
+	return unix.Mmap(int(f.Fd()), 0, size, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED)
+}
+
+// flushMap 함수는 매핑된 변경 내용을 비동기로 디스크에 기록하도록 요청합니다.
+func flushMap(b []byte) error { // 단일 책임: 매핑 플러시
+	return unix.Msync(b, unix.MS_ASYNC)
+}
+
+// unmapFile 함수는 매핑을 해제합니다.
+func unmapFile(b []byte) error { // 단일 책임: 매핑 해제
+	return unix.Munmap(b)
+}
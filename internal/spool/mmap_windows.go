@@ -0,0 +1,41 @@
+//go:build windows
+
+package spool
+
+import (
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// mapFile 함수는 파일 앞부분 size 바이트를 읽기/쓰기 뷰로 매핑합니다.
+func mapFile(f *os.File, size int) ([]byte, error) { // 단일 책임: 파일 매핑
+	h, err := windows.CreateFileMapping(windows.Handle(f.Fd()), nil, windows.PAGE_READWRITE, uint32(uint64(size)>>32), uint32(size), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer windows.CloseHandle(h) // 뷰가 매핑 객체 참조를 유지하므로 핸들은 바로 닫아도 됨
+	addr, err := windows.MapViewOfFile(h, windows.FILE_MAP_WRITE, 0, 0, uintptr(size))
+	if err != nil {
+		return nil, err
+	}
+	var b []byte // 매핑 주소로 슬라이스 헤더 구성 (uintptr → Pointer 직접 변환 회피)
+	hdr := (*struct {
+		data uintptr
+		len  int
+		cap  int
+	})(unsafe.Pointer(&b))
+	hdr.data, hdr.len, hdr.cap = addr, size, size
+	return b, nil
+}
+
+// flushMap 함수는 매핑된 변경 내용을 디스크에 기록하도록 요청합니다.
+func flushMap(b []byte) error { // 단일 책임: 매핑 플러시
+	return windows.FlushViewOfFile(uintptr(unsafe.Pointer(&b[0])), uintptr(len(b)))
+}
+
+// unmapFile 함수는 뷰 매핑을 해제합니다.
+func unmapFile(b []byte) error { // 단일 책임: 매핑 해제
+	return windows.UnmapViewOfFile(uintptr(unsafe.Pointer(&b[0])))
+}
@@ -0,0 +1,390 @@
+package spool
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	DEFAULT_SEGMENT_SIZE = 32 << 20    // 세그먼트 파일 크기 기본값 (32MiB, 생성 시 미리 할당)
+	DEFAULT_MAX_SEGMENTS = 32          // 보관할 최대 세그먼트 수 (초과 시 가장 오래된 세그먼트 폐기)
+	SEGMENT_EXT          = ".seg"      // 세그먼트 파일 확장자
+	INDEX_FILE_NAME      = "spool.idx" // 읽기 위치 인덱스 파일명
+	INDEX_MAGIC          = "ASPOOL1\n" // 인덱스 파일 식별자
+	RECORD_HEADER_SIZE   = 9           // 길이(4) + CRC32(4) + 종류(1)
+	INDEX_FILE_SIZE      = 8 + 8 + 8   // 식별자 + 세그먼트 ID + 오프셋
+	SEGMENT_NAME_FORMAT  = "%016d" + SEGMENT_EXT
+)
+
+var (
+	ErrRecordTooLarge = errors.New("레코드가 세그먼트 크기를 초과합니다")
+	ErrClosed         = errors.New("스풀이 닫혔습니다")
+	ErrFull           = errors.New("스풀 용량 초과")
+)
+
+// Options 구조체는 스풀 크기/폐기 정책입니다.
+type Options struct {
+	SegmentSize int  // 세그먼트 파일 크기 (0 이하이면 기본값)
+	MaxSegments int  // 최대 세그먼트 수 (0 이하이면 기본값)
+	DropNewest  bool // 가득 차면 새 레코드를 거부 (false 이면 가장 오래된 세그먼트 폐기)
+}
+
+// Record 구조체는 스풀에 기록되는 단일 레코드입니다.
+type Record struct {
+	Kind byte   // 호출자 정의 레코드 종류 (프레임/이벤트 등)
+	Data []byte // 레코드 본문
+}
+
+// Stats 구조체는 스풀 상태 요약입니다.
+type Stats struct {
+	Segments        int    `json:"segments"`         // 현재 세그먼트 파일 수
+	DiskBytes       int64  `json:"disk_bytes"`       // 세그먼트가 차지하는 디스크 용량
+	DroppedSegments uint64 `json:"dropped_segments"` // 용량 초과로 폐기된 세그먼트 수
+}
+
+// segment 구조체는 미리 할당된 고정 크기 세그먼트 파일입니다. 필요할 때만 매핑해 RSS 를 제한합니다.
+type segment struct {
+	id   uint64
+	path string
+	file *os.File
+	data []byte // 매핑 영역 (nil 이면 미매핑)
+	size int    // 기록 끝 위치 (꼬리 세그먼트에서만 유효)
+}
+
+// Spool 구조체는 메모리 매핑 세그먼트 파일 기반 오프라인 레코드 버퍼입니다.
+// 레코드는 [길이+1][CRC32][종류][본문] 형식으로 이어 쓰며, 길이 0 은 세그먼트 끝을 뜻합니다.
+// 읽기 위치는 Commit 시 인덱스 파일에 저장되어 재시작 후에도 이어서 재전송할 수 있습니다.
+type Spool struct { // 단일 책임: 세그먼트 스풀 관리
+	mu          sync.Mutex
+	dir         string
+	segSize     int
+	maxSegments int
+	dropNewest  bool
+	segs        []*segment // ID 오름차순, 마지막이 쓰기 세그먼트
+	closed      bool
+
+	readID, commitID   uint64 // 읽기/커밋 위치 세그먼트 ID
+	readOff, commitOff int    // 읽기/커밋 위치 오프셋
+
+	droppedSegments uint64
+}
+
+// Open 함수는 dir 의 스풀을 열거나 새로 만듭니다.
+func Open(dir string, opts Options) (*Spool, error) { // 단일 책임: 스풀 열기
+	segSize, maxSegments := opts.SegmentSize, opts.MaxSegments
+	if segSize <= RECORD_HEADER_SIZE {
+		segSize = DEFAULT_SEGMENT_SIZE
+	}
+	if maxSegments < 1 {
+		maxSegments = DEFAULT_MAX_SEGMENTS
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("스풀 디렉터리 생성 실패: %w", err)
+	}
+	s := &Spool{dir: dir, segSize: segSize, maxSegments: maxSegments, dropNewest: opts.DropNewest}
+	ids, err := listSegmentIDs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		s.segs = append(s.segs, &segment{id: id, path: s.segmentPath(id)})
+	}
+	if len(s.segs) == 0 {
+		s.segs = append(s.segs, &segment{id: 1, path: s.segmentPath(1)})
+	}
+	tail := s.segs[len(s.segs)-1]
+	if err := s.mapSegment(tail); err != nil {
+		return nil, err
+	}
+	tail.size = scanEnd(tail.data)
+	s.commitID, s.commitOff = s.loadIndex()
+	s.readID, s.readOff = s.commitID, s.commitOff
+	return s, nil
+}
+
+// Append 함수는 레코드를 쓰기 세그먼트 끝에 추가합니다. 공간이 부족하면 새 세그먼트로 넘어가며,
+// 세그먼트 수가 상한에 도달하면 정책에 따라 가장 오래된 세그먼트를 폐기하거나 ErrFull 을 반환합니다.
+func (s *Spool) Append(kind byte, data []byte) error { // 단일 책임: 레코드 추가
+	need := RECORD_HEADER_SIZE + len(data)
+	if need > s.segSize {
+		return ErrRecordTooLarge
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	tail := s.segs[len(s.segs)-1]
+	if tail.size+need > s.segSize {
+		var err error
+		if tail, err = s.rotate(); err != nil {
+			return err
+		}
+	}
+	if err := s.mapSegment(tail); err != nil {
+		return err
+	}
+	buf := tail.data[tail.size : tail.size+need]
+	binary.LittleEndian.PutUint32(buf[0:4], uint32(len(data)+1))
+	binary.LittleEndian.PutUint32(buf[4:8], crc32.ChecksumIEEE(data))
+	buf[8] = kind
+	copy(buf[RECORD_HEADER_SIZE:], data)
+	tail.size += need
+	return nil
+}
+
+// Next 함수는 읽기 위치의 다음 레코드를 반환하고 위치를 전진시킵니다. 더 읽을 레코드가 없으면 ok=false 입니다.
+// 손상된 레코드(비정상 종료로 잘린 쓰기 등)를 만나면 해당 세그먼트의 나머지를 건너뜁니다.
+func (s *Spool) Next() (rec Record, ok bool, err error) { // 단일 책임: 레코드 읽기
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return Record{}, false, ErrClosed
+	}
+	for {
+		idx := s.indexOf(s.readID)
+		if idx < 0 { // 읽기 세그먼트가 폐기됨: 남은 가장 오래된 세그먼트부터
+			s.readID, s.readOff = s.segs[0].id, 0
+			continue
+		}
+		seg := s.segs[idx]
+		if err := s.mapSegment(seg); err != nil {
+			return Record{}, false, err
+		}
+		if rec, n, valid := readRecord(seg.data, s.readOff); valid {
+			s.readOff += n
+			return rec, true, nil
+		}
+		if idx == len(s.segs)-1 { // 쓰기 세그먼트 끝 도달
+			return Record{}, false, nil
+		}
+		if seg.id != s.commitID { // 다 읽은 중간 세그먼트는 매핑 해제 (Rewind 시 다시 매핑)
+			s.releaseSegment(seg)
+		}
+		s.readID, s.readOff = s.segs[idx+1].id, 0
+	}
+}
+
+// Commit 함수는 현재 읽기 위치까지 처리 완료로 기록하고 완전히 소비된 세그먼트 파일을 삭제합니다.
+func (s *Spool) Commit() error { // 단일 책임: 읽기 위치 확정
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	s.commitID, s.commitOff = s.readID, s.readOff
+	for len(s.segs) > 1 && s.segs[0].id < s.commitID {
+		s.removeSegment(s.segs[0])
+		s.segs = s.segs[1:]
+	}
+	return s.saveIndex()
+}
+
+// Rewind 함수는 읽기 위치를 마지막 Commit 위치로 되돌립니다 (재전송 실패 시 사용).
+func (s *Spool) Rewind() { // 단일 책임: 읽기 위치 복원
+	s.mu.Lock()
+	s.readID, s.readOff = s.commitID, s.commitOff
+	s.mu.Unlock()
+}
+
+// Empty 함수는 커밋되지 않은 레코드가 없는지 반환합니다.
+func (s *Spool) Empty() bool { // 단일 책임: 대기 레코드 여부
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tail := s.segs[len(s.segs)-1]
+	return s.commitID == tail.id && s.commitOff >= tail.size
+}
+
+// Stats 함수는 스풀 상태 요약을 반환합니다.
+func (s *Spool) Stats() Stats { // 단일 책임: 상태 조회
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Stats{Segments: len(s.segs), DiskBytes: int64(len(s.segs)) * int64(s.segSize), DroppedSegments: s.droppedSegments}
+}
+
+// Close 함수는 매핑을 플러시/해제하고 커밋 위치를 저장합니다.
+func (s *Spool) Close() error { // 단일 책임: 스풀 닫기
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	for _, seg := range s.segs {
+		s.releaseSegment(seg)
+	}
+	return s.saveIndex()
+}
+
+// rotate 함수는 현재 쓰기 세그먼트를 플러시하고 새 세그먼트를 추가합니다.
+func (s *Spool) rotate() (*segment, error) { // 단일 책임: 세그먼트 교체
+	if s.dropNewest && len(s.segs) >= s.maxSegments {
+		return nil, ErrFull
+	}
+	old := s.segs[len(s.segs)-1]
+	if old.data != nil {
+		_ = flushMap(old.data)
+	}
+	if old.id != s.readID && old.id != s.commitID {
+		s.releaseSegment(old)
+	}
+	seg := &segment{id: old.id + 1, path: s.segmentPath(old.id + 1)}
+	if err := s.mapSegment(seg); err != nil {
+		return nil, err
+	}
+	s.segs = append(s.segs, seg)
+	for len(s.segs) > s.maxSegments { // 용량 상한: 가장 오래된 세그먼트 폐기
+		s.removeSegment(s.segs[0])
+		s.segs = s.segs[1:]
+		s.droppedSegments++
+	}
+	return seg, nil
+}
+
+// mapSegment 함수는 세그먼트 파일을 열고(없으면 미리 할당해 생성) 매핑합니다.
+func (s *Spool) mapSegment(seg *segment) error { // 단일 책임: 세그먼트 매핑
+	if seg.data != nil {
+		return nil
+	}
+	f, err := os.OpenFile(seg.path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	if st, err := f.Stat(); err != nil || st.Size() < int64(s.segSize) {
+		if err := f.Truncate(int64(s.segSize)); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	data, err := mapFile(f, s.segSize)
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("세그먼트 매핑 실패: %w", err)
+	}
+	seg.file, seg.data = f, data
+	return nil
+}
+
+// releaseSegment 함수는 세그먼트 매핑을 플러시/해제하고 파일을 닫습니다.
+func (s *Spool) releaseSegment(seg *segment) { // 단일 책임: 세그먼트 해제
+	if seg.data != nil {
+		_ = flushMap(seg.data)
+		_ = unmapFile(seg.data)
+		seg.data = nil
+	}
+	if seg.file != nil {
+		_ = seg.file.Close()
+		seg.file = nil
+	}
+}
+
+// removeSegment 함수는 세그먼트를 해제하고 파일을 삭제합니다.
+func (s *Spool) removeSegment(seg *segment) { // 단일 책임: 세그먼트 삭제
+	s.releaseSegment(seg)
+	_ = os.Remove(seg.path)
+}
+
+// indexOf 함수는 ID 에 해당하는 세그먼트 위치를 반환합니다 (없으면 -1).
+func (s *Spool) indexOf(id uint64) int { // 단일 책임: 세그먼트 검색
+	for i, seg := range s.segs {
+		if seg.id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// segmentPath 함수는 세그먼트 파일 경로를 반환합니다.
+func (s *Spool) segmentPath(id uint64) string { // 단일 책임: 경로 계산
+	return filepath.Join(s.dir, fmt.Sprintf(SEGMENT_NAME_FORMAT, id))
+}
+
+// loadIndex 함수는 인덱스 파일의 커밋 위치를 읽습니다. 없거나 유효하지 않으면 가장 오래된 세그먼트 처음입니다.
+func (s *Spool) loadIndex() (uint64, int) { // 단일 책임: 인덱스 읽기
+	first := s.segs[0].id
+	b, err := os.ReadFile(filepath.Join(s.dir, INDEX_FILE_NAME))
+	if err != nil || len(b) != INDEX_FILE_SIZE || string(b[:8]) != INDEX_MAGIC {
+		return first, 0
+	}
+	id := binary.LittleEndian.Uint64(b[8:16])
+	off := int(binary.LittleEndian.Uint64(b[16:24]))
+	if s.indexOf(id) < 0 || off < 0 || off > s.segSize {
+		return first, 0
+	}
+	return id, off
+}
+
+// saveIndex 함수는 커밋 위치를 임시 파일에 쓴 뒤 교체해 원자적으로 저장합니다.
+func (s *Spool) saveIndex() error { // 단일 책임: 인덱스 저장
+	b := make([]byte, INDEX_FILE_SIZE)
+	copy(b, INDEX_MAGIC)
+	binary.LittleEndian.PutUint64(b[8:16], s.commitID)
+	binary.LittleEndian.PutUint64(b[16:24], uint64(s.commitOff))
+	path := filepath.Join(s.dir, INDEX_FILE_NAME)
+	if err := os.WriteFile(path+".tmp", b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// listSegmentIDs 함수는 dir 의 세그먼트 파일 ID 를 오름차순으로 반환합니다.
+func listSegmentIDs(dir string) ([]uint64, error) { // 단일 책임: 세그먼트 목록
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var ids []uint64
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, SEGMENT_EXT) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(name, SEGMENT_EXT), 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+// readRecord 함수는 data 의 off 위치 레코드를 복사해 반환합니다. 끝이거나 손상되었으면 valid=false 입니다.
+func readRecord(data []byte, off int) (rec Record, n int, valid bool) { // 단일 책임: 레코드 해석
+	if off+RECORD_HEADER_SIZE > len(data) {
+		return Record{}, 0, false
+	}
+	length := binary.LittleEndian.Uint32(data[off : off+4])
+	if length == 0 {
+		return Record{}, 0, false
+	}
+	n = RECORD_HEADER_SIZE + int(length-1)
+	if off+n > len(data) {
+		return Record{}, 0, false
+	}
+	payload := data[off+RECORD_HEADER_SIZE : off+n]
+	if crc32.ChecksumIEEE(payload) != binary.LittleEndian.Uint32(data[off+4:off+8]) {
+		return Record{}, 0, false
+	}
+	return Record{Kind: data[off+8], Data: append([]byte(nil), payload...)}, n, true
+}
+
+// scanEnd 함수는 세그먼트의 마지막 유효 레코드 다음 위치를 반환합니다.
+func scanEnd(data []byte) int { // 단일 책임: 기록 끝 탐색
+	off := 0
+	for {
+		_, n, valid := readRecord(data, off)
+		if !valid {
+			return off
+		}
+		off += n
+	}
+}
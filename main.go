@@ -2,6 +2,11 @@ package main
 
 import (
 	"embed"
+	"os"
+
+	"agent/internal/cli"
+	"agent/internal/config"
+	"agent/internal/i18n"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
@@ -13,11 +18,26 @@ import (
 var assets embed.FS
 
 func main() {
+	// 서브커맨드(diag 등)는 Wails 실행 전에 처리
+	// 공통 플래그(--config, --server, --fps, --headless, --minimized)는 Wails 실행과 서브커맨드 모두에 적용
+	opts, args, err := cli.ParseGlobalFlags(os.Args[1:])
+	if err != nil {
+		os.Exit(2)
+	}
+	if len(args) > 0 && args[0] == OVERLAY_SUBCOMMAND { // 캡처 중 표시 창은 Wails 가 필요해 GUI 실행 파일에서만 처리
+		os.Exit(runOverlay(args[1:]))
+	}
+	if code, handled := cli.Run(args, opts.Headless); handled {
+		os.Exit(code)
+	}
+
 	// Create an instance of the app structure
-	app := NewApp()
+	cfg := config.Load()
+	i18n.SetLocale(i18n.Resolve(cfg.Locale)) // UI/트레이/알림 문구 언어 (UI_LOCALE, 없으면 OS 로캘)
+	app := NewApp(cfg)
 
 	// 애플리케이션 옵션을 설정하여 배경이 투명하게 보이도록 설정합니다.
-	err := wails.Run(&options.App{
+	err = wails.Run(&options.App{
 		Title:  "agent_ui",
 		Width:  1024,
 		Height: 768,
@@ -26,8 +46,10 @@ func main() {
 		},
 		// 완전 투명 배경 설정 (알파값 0)
 		BackgroundColour: &options.RGBA{R: 255, G: 255, B: 255, A: 0},
+		StartHidden:      cfg.StartMinimized, // 트레이 메뉴 "창 열기"로 표시
 		OnStartup:        app.startup,
 		OnShutdown:       app.shutdown,
+		OnBeforeClose:    app.beforeClose,
 		Bind: []interface{}{
 			app,
 		},
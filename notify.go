@@ -0,0 +1,120 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"agent/internal/agent"
+	"agent/internal/i18n"
+
+	"github.com/gen2brain/beeep"
+)
+
+const (
+	NOTIFY_APP_NAME = "agent" // 알림 발신 앱 이름 (macOS 알림 묶음, Linux 알림 데몬 표시)
+)
+
+// notifyCommandTitles 변수는 원격 캡처 시작/중지 명령의 알림 제목 카탈로그 key 입니다 (그 밖의 명령은 공통 제목).
+var notifyCommandTitles = map[string]string{
+	agent.CMD_START_CAPTURE: "notify.remote.start",
+	agent.CMD_STOP_CAPTURE:  "notify.remote.stop",
+}
+
+// desktopNotifier 구조체는 연결 끊김/원격 명령 데스크톱 알림 상태입니다.
+// 연결이 끊기면 타이머를 걸어 NotifyDisconnectSec 안에 복구되면 알리지 않고, 알린 뒤 복구되면 복구도 알립니다.
+type desktopNotifier struct { // 단일 책임: 데스크톱 알림
+	after    time.Duration // 연결 끊김 알림 기준 (0 이면 연결 끊김 알림 안 함)
+	mu       sync.Mutex
+	down     *time.Timer // 끊김 이후 기준 시간 대기 (연결 중이면 nil)
+	notified bool        // 이번 끊김을 알림
+	debugf   func(format string, args ...any)
+}
+
+// newDesktopNotifier 함수는 Notifications 설정 시 알림기를 만듭니다 (꺼져 있으면 nil, nil 의 메서드는 무동작).
+func newDesktopNotifier(a *App) *desktopNotifier { // 단일 책임: 인스턴스 생성
+	if !a.cfg.Notifications {
+		return nil
+	}
+	beeep.AppName = NOTIFY_APP_NAME
+	return &desktopNotifier{
+		after: time.Duration(a.cfg.NotifyDisconnectSec) * time.Second,
+		debugf: func(format string, args ...any) {
+			if a.logger != nil {
+				a.logger.Debugf(format, args...)
+			}
+		},
+	}
+}
+
+// connection 함수는 연결 상태 변경을 받아 끊김 타이머를 걸거나 풀고, 알린 끊김이 복구되면 복구를 알립니다.
+func (n *desktopNotifier) connection(st agent.ConnectionStatus) { // 단일 책임: 연결 끊김 알림 판단
+	if n == nil || n.after <= 0 {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	switch st.State {
+	case agent.CONN_STATE_READY:
+		if n.down != nil {
+			n.down.Stop()
+			n.down = nil
+		}
+		if n.notified {
+			n.notified = false
+			go n.show(i18n.T("notify.recovered"), st.Server)
+		}
+	case agent.CONN_STATE_IDLE, agent.CONN_STATE_CONNECTING: // 첫 연결 전
+	default:
+		if n.down == nil && !n.notified {
+			n.down = time.AfterFunc(n.after, n.disconnected)
+		}
+	}
+}
+
+// disconnected 함수는 끊김이 기준 시간을 넘기면 알립니다. 그 사이 복구되어 타이머가 풀렸으면 무시합니다.
+func (n *desktopNotifier) disconnected() { // 단일 책임: 연결 끊김 알림
+	n.mu.Lock()
+	if n.down == nil {
+		n.mu.Unlock()
+		return
+	}
+	n.down, n.notified = nil, true
+	n.mu.Unlock()
+	n.show(i18n.T("notify.disconnected"), i18n.T("notify.disconnect.body", n.after.String()))
+}
+
+// command 함수는 원격 명령 처리 결과를 알립니다.
+func (n *desktopNotifier) command(c agent.RemoteCommand) { // 단일 책임: 원격 명령 알림
+	if n == nil {
+		return
+	}
+	title := i18n.T("notify.remote.other", c.Type)
+	if key, ok := notifyCommandTitles[c.Type]; ok {
+		title = i18n.T(key)
+	}
+	body := i18n.T("notify.remote.ok")
+	if !c.Success {
+		body = i18n.T("notify.remote.failed", c.Message)
+	}
+	go n.show(title, body)
+}
+
+// show 함수는 데스크톱 알림을 띄웁니다. 알림 데몬이 없는 환경 등 실패는 디버그 로그만 남깁니다.
+func (n *desktopNotifier) show(title, body string) { // 단일 책임: 알림 표시
+	if err := beeep.Notify(title, body, trayIcon); err != nil {
+		n.debugf("데스크톱 알림 실패: %v", err)
+	}
+}
+
+// stop 함수는 대기 중인 끊김 타이머를 멈춥니다.
+func (n *desktopNotifier) stop() { // 단일 책임: 알림 정리
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.down != nil {
+		n.down.Stop()
+		n.down = nil
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"agent/internal/i18n"
+
+	"github.com/wailsapp/wails/v2"
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	OVERLAY_SUBCOMMAND = "overlay" // 캡처 중 표시 창 자식 프로세스 서브커맨드 (내부용)
+	OVERLAY_WIDTH      = 168       // 표시 창 폭(논리 px)
+	OVERLAY_HEIGHT     = 32        // 표시 창 높이(논리 px)
+	OVERLAY_MARGIN     = 12        // 화면 가장자리와의 간격(논리 px)
+	OVERLAY_LABEL_SLOT = "{label}" // overlayPage 에서 현재 로캘 문구로 바꿀 자리
+)
+
+// overlayPage 상수는 표시 창 HTML 입니다. 프론트엔드 번들과 무관하게 자식 프로세스가 직접 제공하며, 끌어서 옮길 수 있습니다.
+const overlayPage = `<!doctype html><html><head><meta charset="utf-8"><style>
+html,body{margin:0;height:100%;overflow:hidden;background:#b3261e;color:#fff;font:600 13px system-ui,sans-serif;user-select:none;cursor:default}
+body{display:flex;align-items:center;justify-content:center;gap:8px;--wails-draggable:drag}
+i{width:9px;height:9px;border-radius:50%;background:#fff;animation:b 1.2s ease-in-out infinite}
+@keyframes b{50%{opacity:.25}}
+</style></head><body><i></i>{label}</body></html>`
+
+// captureIndicator 구조체는 실행 중인 표시 창 자식 프로세스입니다. Wails v2 는 창을 하나만 만들 수 있어
+// 같은 실행 파일을 overlay 서브커맨드로 띄우고, 표준 입력을 닫아 내립니다 (부모가 죽어도 함께 종료).
+type captureIndicator struct { // 단일 책임: 표시 창 프로세스 관리
+	mu    sync.Mutex
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+}
+
+// setCaptureIndicator 함수는 캡처 시작/중지에 맞춰 표시 창을 띄우거나 내립니다 (CaptureIndicator 설정 시).
+func (a *App) setCaptureIndicator(capturing bool) { // 단일 책임: 표시 창 전환
+	if !a.cfg.CaptureIndicator {
+		return
+	}
+	if !capturing {
+		a.indicator.stop()
+		return
+	}
+	if err := a.indicator.start(a.cfg.IndicatorPosition); err != nil && a.logger != nil {
+		a.logger.Warnf("캡처 중 표시 창 시작 실패: %v", err)
+	}
+}
+
+// start 함수는 표시 창이 없으면 자식 프로세스를 띄웁니다.
+func (c *captureIndicator) start(position string) error { // 단일 책임: 표시 창 시작
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cmd != nil {
+		return nil
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(exe, OVERLAY_SUBCOMMAND, "-position", position, "-locale", i18n.Locale())
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	c.cmd, c.stdin = cmd, stdin
+	go cmd.Wait() // 종료 회수
+	return nil
+}
+
+// stop 함수는 표준 입력을 닫아 표시 창 프로세스를 끝냅니다 (없으면 무동작).
+func (c *captureIndicator) stop() { // 단일 책임: 표시 창 종료
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cmd == nil {
+		return
+	}
+	c.stdin.Close()
+	c.cmd, c.stdin = nil, nil
+}
+
+// runOverlay 함수는 overlay 서브커맨드로 테두리 없는 항상 위 표시 창을 띄우고, 표준 입력이 닫히면 종료합니다.
+func runOverlay(args []string) int { // 단일 책임: overlay 서브커맨드 실행
+	fs := flag.NewFlagSet(OVERLAY_SUBCOMMAND, flag.ContinueOnError)
+	position := fs.String("position", "top-right", "표시 위치 (top-left | top-right | bottom-left | bottom-right)")
+	locale := fs.String("locale", i18n.DEFAULT_LOCALE, "표시 문구 언어 (부모 프로세스의 현재 로캘)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	i18n.SetLocale(*locale)
+	page := strings.Replace(overlayPage, OVERLAY_LABEL_SLOT, html.EscapeString(i18n.T("overlay.capturing")), 1)
+	err := wails.Run(&options.App{
+		Title:         "agent_indicator",
+		Width:         OVERLAY_WIDTH,
+		Height:        OVERLAY_HEIGHT,
+		Frameless:     true,
+		AlwaysOnTop:   true,
+		DisableResize: true,
+		AssetServer: &assetserver.Options{
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				io.WriteString(w, page)
+			}),
+		},
+		BackgroundColour: &options.RGBA{R: 0xb3, G: 0x26, B: 0x1e, A: 255},
+		OnStartup: func(ctx context.Context) {
+			placeOverlay(ctx, *position)
+			go func() { // 부모가 표준 입력을 닫거나 종료하면 함께 종료
+				io.Copy(io.Discard, os.Stdin)
+				runtime.Quit(ctx)
+			}()
+		},
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "캡처 중 표시 창 실패: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// placeOverlay 함수는 표시 창을 주 모니터의 position 모서리로 옮깁니다 (모니터 정보가 없으면 기본 위치 유지).
+func placeOverlay(ctx context.Context, position string) { // 단일 책임: 표시 창 배치
+	screens, err := runtime.ScreenGetAll(ctx)
+	if err != nil || len(screens) == 0 {
+		return
+	}
+	s := screens[0]
+	for _, sc := range screens {
+		if sc.IsPrimary {
+			s = sc
+		}
+	}
+	x, y := OVERLAY_MARGIN, OVERLAY_MARGIN
+	if position == "top-right" || position == "bottom-right" {
+		x = s.Size.Width - OVERLAY_WIDTH - OVERLAY_MARGIN
+	}
+	if position == "bottom-left" || position == "bottom-right" {
+		y = s.Size.Height - OVERLAY_HEIGHT - OVERLAY_MARGIN
+	}
+	runtime.WindowSetPosition(ctx, x, y)
+}
@@ -143,30 +143,1407 @@ func (x *AdminInfo) GetIp() string {
 }
 
 type FrameData struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AgentId        string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ImageData      []byte                 `protobuf:"bytes,2,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"` // 인코딩된 이미지 (JPEG/PNG/WebP)
+	Timestamp      int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	IsPreview      bool                   `protobuf:"varint,4,opt,name=is_preview,json=isPreview,proto3" json:"is_preview,omitempty"`                // true면 저해상도 미리보기, false면 고해상도
+	ScalePct       int32                  `protobuf:"varint,5,opt,name=scale_pct,json=scalePct,proto3" json:"scale_pct,omitempty"`                   // 원본 대비 전송 해상도 비율(%) - 0 또는 100 이면 원본
+	Encoding       string                 `protobuf:"bytes,6,opt,name=encoding,proto3" json:"encoding,omitempty"`                                    // png | jpeg | webp | delta | tiles (delta: zstd 압축된 RGBA, 키프레임은 원본 픽셀, 그 외는 직전 프레임과 XOR)
+	Keyframe       bool                   `protobuf:"varint,7,opt,name=keyframe,proto3" json:"keyframe,omitempty"`                                   // delta/tiles 인코딩에서 독립 디코딩 가능한 키프레임 여부
+	Width          int32                  `protobuf:"varint,8,opt,name=width,proto3" json:"width,omitempty"`                                         // delta/tiles 인코딩 전체 프레임 픽셀 폭
+	Height         int32                  `protobuf:"varint,9,opt,name=height,proto3" json:"height,omitempty"`                                       // delta/tiles 인코딩 전체 프레임 픽셀 높이
+	Regions        []*FrameRegion         `protobuf:"bytes,10,rep,name=regions,proto3" json:"regions,omitempty"`                                     // tiles 인코딩: 직전 프레임 대비 변경된 영역 (키프레임은 전체 화면 1개)
+	RegionEncoding string                 `protobuf:"bytes,11,opt,name=region_encoding,json=regionEncoding,proto3" json:"region_encoding,omitempty"` // tiles 인코딩 영역 이미지 형식 (png | jpeg | webp)
+	Unchanged      bool                   `protobuf:"varint,12,opt,name=unchanged,proto3" json:"unchanged,omitempty"`                                // true 이면 직전 전송 프레임과 동일한 화면 (이미지 없음, 생존 표시용)
+	MonitorId      int32                  `protobuf:"varint,13,opt,name=monitor_id,json=monitorId,proto3" json:"monitor_id,omitempty"`               // 캡처한 모니터 인덱스 (single/all 모드) - 여러 모니터를 합친 화면이나 창 캡처는 -1
+	Chunk          *FrameChunk            `protobuf:"bytes,14,opt,name=chunk,proto3" json:"chunk,omitempty"`                                         // 설정하면 이 메시지는 큰 프레임의 조각 (나머지 필드는 agent_id/timestamp/monitor_id 만 채움)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FrameData) Reset() {
+	*x = FrameData{}
+	mi := &file_proto_monitor_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameData) ProtoMessage() {}
+
+func (x *FrameData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitor_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameData.ProtoReflect.Descriptor instead.
+func (*FrameData) Descriptor() ([]byte, []int) {
+	return file_proto_monitor_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FrameData) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *FrameData) GetImageData() []byte {
+	if x != nil {
+		return x.ImageData
+	}
+	return nil
+}
+
+func (x *FrameData) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *FrameData) GetIsPreview() bool {
+	if x != nil {
+		return x.IsPreview
+	}
+	return false
+}
+
+func (x *FrameData) GetScalePct() int32 {
+	if x != nil {
+		return x.ScalePct
+	}
+	return 0
+}
+
+func (x *FrameData) GetEncoding() string {
+	if x != nil {
+		return x.Encoding
+	}
+	return ""
+}
+
+func (x *FrameData) GetKeyframe() bool {
+	if x != nil {
+		return x.Keyframe
+	}
+	return false
+}
+
+func (x *FrameData) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *FrameData) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *FrameData) GetRegions() []*FrameRegion {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+func (x *FrameData) GetRegionEncoding() string {
+	if x != nil {
+		return x.RegionEncoding
+	}
+	return ""
+}
+
+func (x *FrameData) GetUnchanged() bool {
+	if x != nil {
+		return x.Unchanged
+	}
+	return false
+}
+
+func (x *FrameData) GetMonitorId() int32 {
+	if x != nil {
+		return x.MonitorId
+	}
+	return 0
+}
+
+func (x *FrameData) GetChunk() *FrameChunk {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+// GRPC_MAX_MESSAGE_BYTES 를 넘는 프레임은 원본 FrameData 를 직렬화한 바이트를 나눠 같은 스트림에 연속으로 보냅니다.
+// 서버는 같은 frame_id 의 index 0..total-1 을 차례로 모아 이어 붙인 뒤 FrameData 로 역직렬화합니다.
+// 조각이 빠지거나 순서가 어긋나면(중간에 스트림 재연결) 그 프레임은 버립니다.
+type FrameChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FrameId       uint64                 `protobuf:"varint,1,opt,name=frame_id,json=frameId,proto3" json:"frame_id,omitempty"` // 스트림 안에서 프레임을 구분하는 에이전트 순번
+	Index         int32                  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`                    // 0 부터 시작하는 조각 순서
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`                    // 전체 조각 수
+	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`                       // 직렬화한 원본 FrameData 의 index 번째 조각
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FrameChunk) Reset() {
+	*x = FrameChunk{}
+	mi := &file_proto_monitor_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameChunk) ProtoMessage() {}
+
+func (x *FrameChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitor_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameChunk.ProtoReflect.Descriptor instead.
+func (*FrameChunk) Descriptor() ([]byte, []int) {
+	return file_proto_monitor_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FrameChunk) GetFrameId() uint64 {
+	if x != nil {
+		return x.FrameId
+	}
+	return 0
+}
+
+func (x *FrameChunk) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *FrameChunk) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *FrameChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// tiles 인코딩에서 직전 프레임 위에 덮어 그릴 변경 영역
+type FrameRegion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             int32                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"` // 프레임 좌상단 기준 영역 x 오프셋
+	Y             int32                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"` // 프레임 좌상단 기준 영역 y 오프셋
+	Width         int32                  `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Data          []byte                 `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"` // region_encoding 으로 인코딩된 영역 이미지
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FrameRegion) Reset() {
+	*x = FrameRegion{}
+	mi := &file_proto_monitor_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FrameRegion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FrameRegion) ProtoMessage() {}
+
+func (x *FrameRegion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitor_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FrameRegion.ProtoReflect.Descriptor instead.
+func (*FrameRegion) Descriptor() ([]byte, []int) {
+	return file_proto_monitor_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FrameRegion) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *FrameRegion) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *FrameRegion) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *FrameRegion) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *FrameRegion) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type EventData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	EventType     string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"` // "keyboard", "mouse", "printer", "usb" 등
+	EventDetail   string                 `protobuf:"bytes,3,opt,name=event_detail,json=eventDetail,proto3" json:"event_detail,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Batch         []*EventData           `protobuf:"bytes,5,rep,name=batch,proto3" json:"batch,omitempty"`       // event_type 이 "batch" 이면 같은 시간 창에 발생한 이벤트 묶음
+	Severity      string                 `protobuf:"bytes,6,opt,name=severity,proto3" json:"severity,omitempty"` // "debug" | "info" | "warning" | "critical" (batch 는 비움)
+	Category      string                 `protobuf:"bytes,7,opt,name=category,proto3" json:"category,omitempty"` // "session" | "activity" | "system" | "network" | "agent" 등 event_type 묶음 (batch 는 비움)
+	Seq           uint64                 `protobuf:"varint,8,opt,name=seq,proto3" json:"seq,omitempty"`          // StreamAckedEvents 로 보낸 이벤트의 에이전트 순번 (1 부터 증가, 재전송해도 같은 값 - 그 외 0)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventData) Reset() {
+	*x = EventData{}
+	mi := &file_proto_monitor_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventData) ProtoMessage() {}
+
+func (x *EventData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitor_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventData.ProtoReflect.Descriptor instead.
+func (*EventData) Descriptor() ([]byte, []int) {
+	return file_proto_monitor_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EventData) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *EventData) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *EventData) GetEventDetail() string {
+	if x != nil {
+		return x.EventDetail
+	}
+	return ""
+}
+
+func (x *EventData) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *EventData) GetBatch() []*EventData {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+func (x *EventData) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *EventData) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *EventData) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+// EventAck 는 StreamAckedEvents 수신 확인입니다. 서버는 seq 까지 받은 이벤트를 저장한 뒤 보냅니다 (누적 확인).
+type EventAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Seq           uint64                 `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventAck) Reset() {
+	*x = EventAck{}
+	mi := &file_proto_monitor_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventAck) ProtoMessage() {}
+
+func (x *EventAck) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitor_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventAck.ProtoReflect.Descriptor instead.
+func (*EventAck) Descriptor() ([]byte, []int) {
+	return file_proto_monitor_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EventAck) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *EventAck) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+type StreamAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamAck) Reset() {
+	*x = StreamAck{}
+	mi := &file_proto_monitor_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamAck) ProtoMessage() {}
+
+func (x *StreamAck) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitor_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamAck.ProtoReflect.Descriptor instead.
+func (*StreamAck) Descriptor() ([]byte, []int) {
+	return file_proto_monitor_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StreamAck) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StreamAck) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type DiagnosticsBundle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	FileName      string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"` // 아카이브 파일명 (zip)
+	Archive       []byte                 `protobuf:"bytes,3,opt,name=archive,proto3" json:"archive,omitempty"`                   // zip 아카이브 바이트
+	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiagnosticsBundle) Reset() {
+	*x = DiagnosticsBundle{}
+	mi := &file_proto_monitor_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiagnosticsBundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiagnosticsBundle) ProtoMessage() {}
+
+func (x *DiagnosticsBundle) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitor_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiagnosticsBundle.ProtoReflect.Descriptor instead.
+func (*DiagnosticsBundle) Descriptor() ([]byte, []int) {
+	return file_proto_monitor_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DiagnosticsBundle) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *DiagnosticsBundle) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *DiagnosticsBundle) GetArchive() []byte {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
+func (x *DiagnosticsBundle) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type RecordingChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	RecordingId   string                 `protobuf:"bytes,2,opt,name=recording_id,json=recordingId,proto3" json:"recording_id,omitempty"`
+	Offset        int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"` // 파일 내 청크 시작 위치
+	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	Last          bool                   `protobuf:"varint,5,opt,name=last,proto3" json:"last,omitempty"` // 마지막 청크 여부
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordingChunk) Reset() {
+	*x = RecordingChunk{}
+	mi := &file_proto_monitor_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordingChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordingChunk) ProtoMessage() {}
+
+func (x *RecordingChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitor_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordingChunk.ProtoReflect.Descriptor instead.
+func (*RecordingChunk) Descriptor() ([]byte, []int) {
+	return file_proto_monitor_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RecordingChunk) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *RecordingChunk) GetRecordingId() string {
+	if x != nil {
+		return x.RecordingId
+	}
+	return ""
+}
+
+func (x *RecordingChunk) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *RecordingChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *RecordingChunk) GetLast() bool {
+	if x != nil {
+		return x.Last
+	}
+	return false
+}
+
+type VideoChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // 인코더 세션 ID (해상도/FPS 변경이나 오류로 인코더를 다시 시작하면 바뀜)
+	Seq           int64                  `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"`                             // 세션 내 청크 순번 (0 부터)
+	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`                            // H.264 Annex-B 바이트 스트림 조각 (NAL 경계와 무관)
+	Codec         string                 `protobuf:"bytes,5,opt,name=codec,proto3" json:"codec,omitempty"`                          // "h264"
+	Encoder       string                 `protobuf:"bytes,6,opt,name=encoder,proto3" json:"encoder,omitempty"`                      // 사용 중인 인코더 ("nvenc", "qsv", "videotoolbox", "x264")
+	Width         int32                  `protobuf:"varint,7,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,8,opt,name=height,proto3" json:"height,omitempty"`
+	Fps           int32                  `protobuf:"varint,9,opt,name=fps,proto3" json:"fps,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,10,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	MonitorId     int32                  `protobuf:"varint,11,opt,name=monitor_id,json=monitorId,proto3" json:"monitor_id,omitempty"` // 캡처한 모니터 인덱스 (FrameData.monitor_id 와 같은 규칙)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VideoChunk) Reset() {
+	*x = VideoChunk{}
+	mi := &file_proto_monitor_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VideoChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VideoChunk) ProtoMessage() {}
+
+func (x *VideoChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitor_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VideoChunk.ProtoReflect.Descriptor instead.
+func (*VideoChunk) Descriptor() ([]byte, []int) {
+	return file_proto_monitor_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VideoChunk) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *VideoChunk) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *VideoChunk) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *VideoChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *VideoChunk) GetCodec() string {
+	if x != nil {
+		return x.Codec
+	}
+	return ""
+}
+
+func (x *VideoChunk) GetEncoder() string {
+	if x != nil {
+		return x.Encoder
+	}
+	return ""
+}
+
+func (x *VideoChunk) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *VideoChunk) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *VideoChunk) GetFps() int32 {
+	if x != nil {
+		return x.Fps
+	}
+	return 0
+}
+
+func (x *VideoChunk) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *VideoChunk) GetMonitorId() int32 {
+	if x != nil {
+		return x.MonitorId
+	}
+	return 0
+}
+
+type MonitorInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	X             int32                  `protobuf:"varint,2,opt,name=x,proto3" json:"x,omitempty"` // 가상 데스크톱 기준 좌상단 X
+	Y             int32                  `protobuf:"varint,3,opt,name=y,proto3" json:"y,omitempty"` // 가상 데스크톱 기준 좌상단 Y
+	Width         int32                  `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MonitorInfo) Reset() {
+	*x = MonitorInfo{}
+	mi := &file_proto_monitor_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MonitorInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonitorInfo) ProtoMessage() {}
+
+func (x *MonitorInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitor_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonitorInfo.ProtoReflect.Descriptor instead.
+func (*MonitorInfo) Descriptor() ([]byte, []int) {
+	return file_proto_monitor_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MonitorInfo) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *MonitorInfo) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *MonitorInfo) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *MonitorInfo) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *MonitorInfo) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type RegisterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Hostname      string                 `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Os            string                 `protobuf:"bytes,3,opt,name=os,proto3" json:"os,omitempty"`
+	Arch          string                 `protobuf:"bytes,4,opt,name=arch,proto3" json:"arch,omitempty"`
+	Version       string                 `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"` // 에이전트 빌드 버전
+	Monitors      []*MonitorInfo         `protobuf:"bytes,6,rep,name=monitors,proto3" json:"monitors,omitempty"`
+	Encodings     []string               `protobuf:"bytes,7,rep,name=encodings,proto3" json:"encodings,omitempty"` // 지원 프레임 인코딩 ("png", "jpeg", "delta", "tiles", "webp", "h264")
+	Commands      []string               `protobuf:"bytes,8,rep,name=commands,proto3" json:"commands,omitempty"`   // 처리 가능한 원격 명령 타입
+	Timestamp     int64                  `protobuf:"varint,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ServerAddr    string                 `protobuf:"bytes,10,opt,name=server_addr,json=serverAddr,proto3" json:"server_addr,omitempty"` // 접속한 서버 주소 (AGENT_SERVER_ADDR 목록 중 현재 대상)
+	DeviceName    string                 `protobuf:"bytes,11,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"` // 사용자가 정한 장치 이름 (정하지 않았으면 hostname 과 같음)
+	Consent       *ConsentInfo           `protobuf:"bytes,12,opt,name=consent,proto3" json:"consent,omitempty"`                         // 사용자 캡처 동의 상태
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
+	mi := &file_proto_monitor_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRequest) ProtoMessage() {}
+
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitor_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
+	return file_proto_monitor_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RegisterRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetArch() string {
+	if x != nil {
+		return x.Arch
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetMonitors() []*MonitorInfo {
+	if x != nil {
+		return x.Monitors
+	}
+	return nil
+}
+
+func (x *RegisterRequest) GetEncodings() []string {
+	if x != nil {
+		return x.Encodings
+	}
+	return nil
+}
+
+func (x *RegisterRequest) GetCommands() []string {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+func (x *RegisterRequest) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *RegisterRequest) GetServerAddr() string {
+	if x != nil {
+		return x.ServerAddr
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetConsent() *ConsentInfo {
+	if x != nil {
+		return x.Consent
+	}
+	return nil
+}
+
+// ConsentInfo 는 에이전트에 기록된 사용자 캡처 동의입니다.
+type ConsentInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Required      bool                   `protobuf:"varint,1,opt,name=required,proto3" json:"required,omitempty"`                               // 동의가 있어야 캡처 시작
+	Granted       bool                   `protobuf:"varint,2,opt,name=granted,proto3" json:"granted,omitempty"`                                 // 현재 정책 버전에 대한 동의 있음
+	User          string                 `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`                                        // 동의한 OS 사용자
+	AcceptedAt    int64                  `protobuf:"varint,4,opt,name=accepted_at,json=acceptedAt,proto3" json:"accepted_at,omitempty"`         // 동의 시각 (Unix ms, 없으면 0)
+	PolicyVersion string                 `protobuf:"bytes,5,opt,name=policy_version,json=policyVersion,proto3" json:"policy_version,omitempty"` // 동의한 정책 버전
+	CurrentPolicy string                 `protobuf:"bytes,6,opt,name=current_policy,json=currentPolicy,proto3" json:"current_policy,omitempty"` // 에이전트가 요구하는 정책 버전
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsentInfo) Reset() {
+	*x = ConsentInfo{}
+	mi := &file_proto_monitor_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsentInfo) ProtoMessage() {}
+
+func (x *ConsentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitor_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsentInfo.ProtoReflect.Descriptor instead.
+func (*ConsentInfo) Descriptor() ([]byte, []int) {
+	return file_proto_monitor_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ConsentInfo) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *ConsentInfo) GetGranted() bool {
+	if x != nil {
+		return x.Granted
+	}
+	return false
+}
+
+func (x *ConsentInfo) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *ConsentInfo) GetAcceptedAt() int64 {
+	if x != nil {
+		return x.AcceptedAt
+	}
+	return 0
+}
+
+func (x *ConsentInfo) GetPolicyVersion() string {
+	if x != nil {
+		return x.PolicyVersion
+	}
+	return ""
+}
+
+func (x *ConsentInfo) GetCurrentPolicy() string {
+	if x != nil {
+		return x.CurrentPolicy
+	}
+	return ""
+}
+
+type RegisterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"` // false 면 서버가 에이전트를 거부 (스트림을 열지 않음)
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	TargetFps     int32                  `protobuf:"varint,3,opt,name=target_fps,json=targetFps,proto3" json:"target_fps,omitempty"`       // 0 이면 에이전트 설정 유지
+	JpegQuality   int32                  `protobuf:"varint,4,opt,name=jpeg_quality,json=jpegQuality,proto3" json:"jpeg_quality,omitempty"` // 0 이면 에이전트 설정 유지
+	Encoding      string                 `protobuf:"bytes,5,opt,name=encoding,proto3" json:"encoding,omitempty"`                           // 빈 값이면 에이전트 설정 유지
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterResponse) Reset() {
+	*x = RegisterResponse{}
+	mi := &file_proto_monitor_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterResponse) ProtoMessage() {}
+
+func (x *RegisterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitor_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterResponse.ProtoReflect.Descriptor instead.
+func (*RegisterResponse) Descriptor() ([]byte, []int) {
+	return file_proto_monitor_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RegisterResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *RegisterResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RegisterResponse) GetTargetFps() int32 {
+	if x != nil {
+		return x.TargetFps
+	}
+	return 0
+}
+
+func (x *RegisterResponse) GetJpegQuality() int32 {
+	if x != nil {
+		return x.JpegQuality
+	}
+	return 0
+}
+
+func (x *RegisterResponse) GetEncoding() string {
+	if x != nil {
+		return x.Encoding
+	}
+	return ""
+}
+
+type HeartbeatRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	AgentId            string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	CpuPercent         float64                `protobuf:"fixed64,2,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`   // 에이전트 프로세스 CPU 사용률 (전체 코어 대비 %)
+	MemoryBytes        uint64                 `protobuf:"varint,3,opt,name=memory_bytes,json=memoryBytes,proto3" json:"memory_bytes,omitempty"` // Go 런타임이 OS 에서 확보한 메모리
+	UptimeSec          int64                  `protobuf:"varint,4,opt,name=uptime_sec,json=uptimeSec,proto3" json:"uptime_sec,omitempty"`
+	CaptureFps         float64                `protobuf:"fixed64,5,opt,name=capture_fps,json=captureFps,proto3" json:"capture_fps,omitempty"`         // 직전 보고 이후 실제 캡처 FPS
+	FramesSent         uint64                 `protobuf:"varint,6,opt,name=frames_sent,json=framesSent,proto3" json:"frames_sent,omitempty"`          // 누적 송신 프레임 수
+	FramesDropped      uint64                 `protobuf:"varint,7,opt,name=frames_dropped,json=framesDropped,proto3" json:"frames_dropped,omitempty"` // 누적 드롭 프레임 수 (송신 큐 + 오프라인 스풀 + 송신 속도 제한)
+	Capturing          bool                   `protobuf:"varint,8,opt,name=capturing,proto3" json:"capturing,omitempty"`
+	Timestamp          int64                  `protobuf:"varint,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	FramesCaptured     uint64                 `protobuf:"varint,10,opt,name=frames_captured,json=framesCaptured,proto3" json:"frames_captured,omitempty"`               // 누적 캡처 프레임 수 (생략된 프레임 포함)
+	AvgEncodeMs        float64                `protobuf:"fixed64,11,opt,name=avg_encode_ms,json=avgEncodeMs,proto3" json:"avg_encode_ms,omitempty"`                     // 직전 집계 구간 프레임당 평균 인코딩 시간(ms)
+	AvgFrameBytes      float64                `protobuf:"fixed64,12,opt,name=avg_frame_bytes,json=avgFrameBytes,proto3" json:"avg_frame_bytes,omitempty"`               // 직전 집계 구간 프레임당 평균 인코딩 크기(바이트)
+	ServerAddr         string                 `protobuf:"bytes,13,opt,name=server_addr,json=serverAddr,proto3" json:"server_addr,omitempty"`                            // 접속한 서버 주소 (장애 조치로 바뀌면 새 주소)
+	Bandwidth          []*BandwidthUsage      `protobuf:"bytes,14,rep,name=bandwidth,proto3" json:"bandwidth,omitempty"`                                                // 스트림 묶음별 송신 바이트 (보낸 적 없는 묶음은 생략)
+	FramesDroppedQueue uint64                 `protobuf:"varint,15,opt,name=frames_dropped_queue,json=framesDroppedQueue,proto3" json:"frames_dropped_queue,omitempty"` // frames_dropped 중 송신이 밀려 큐에서 버린 가장 오래된 프레임 수 (늘면 네트워크가 캡처를 못 따라감)
+	FrameQueueDepth    int32                  `protobuf:"varint,16,opt,name=frame_queue_depth,json=frameQueueDepth,proto3" json:"frame_queue_depth,omitempty"`          // 보고 시점 송신 대기 프레임 수
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_proto_monitor_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRequest) ProtoMessage() {}
+
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitor_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_proto_monitor_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *HeartbeatRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *HeartbeatRequest) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetMemoryBytes() uint64 {
+	if x != nil {
+		return x.MemoryBytes
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetUptimeSec() int64 {
+	if x != nil {
+		return x.UptimeSec
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetCaptureFps() float64 {
+	if x != nil {
+		return x.CaptureFps
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetFramesSent() uint64 {
+	if x != nil {
+		return x.FramesSent
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetFramesDropped() uint64 {
+	if x != nil {
+		return x.FramesDropped
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetCapturing() bool {
+	if x != nil {
+		return x.Capturing
+	}
+	return false
+}
+
+func (x *HeartbeatRequest) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetFramesCaptured() uint64 {
+	if x != nil {
+		return x.FramesCaptured
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetAvgEncodeMs() float64 {
+	if x != nil {
+		return x.AvgEncodeMs
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetAvgFrameBytes() float64 {
+	if x != nil {
+		return x.AvgFrameBytes
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetServerAddr() string {
+	if x != nil {
+		return x.ServerAddr
+	}
+	return ""
+}
+
+func (x *HeartbeatRequest) GetBandwidth() []*BandwidthUsage {
+	if x != nil {
+		return x.Bandwidth
+	}
+	return nil
+}
+
+func (x *HeartbeatRequest) GetFramesDroppedQueue() uint64 {
+	if x != nil {
+		return x.FramesDroppedQueue
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetFrameQueueDepth() int32 {
+	if x != nil {
+		return x.FrameQueueDepth
+	}
+	return 0
+}
+
+// BandwidthUsage 는 스트림 묶음 하나의 송신 바이트입니다 (gRPC 압축/프레이밍 포함).
+type BandwidthUsage struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Stream          string                 `protobuf:"bytes,1,opt,name=stream,proto3" json:"stream,omitempty"`                                             // "frames" | "video" | "events" | "recording" | "control" | "other"
+	TotalBytes      uint64                 `protobuf:"varint,2,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`                  // 에이전트 시작 이후 누적
+	LastMinuteBytes uint64                 `protobuf:"varint,3,opt,name=last_minute_bytes,json=lastMinuteBytes,proto3" json:"last_minute_bytes,omitempty"` // 최근 1분
+	LastHourBytes   uint64                 `protobuf:"varint,4,opt,name=last_hour_bytes,json=lastHourBytes,proto3" json:"last_hour_bytes,omitempty"`       // 최근 60분
+	LastDayBytes    uint64                 `protobuf:"varint,5,opt,name=last_day_bytes,json=lastDayBytes,proto3" json:"last_day_bytes,omitempty"`          // 최근 24시간
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BandwidthUsage) Reset() {
+	*x = BandwidthUsage{}
+	mi := &file_proto_monitor_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BandwidthUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BandwidthUsage) ProtoMessage() {}
+
+func (x *BandwidthUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitor_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BandwidthUsage.ProtoReflect.Descriptor instead.
+func (*BandwidthUsage) Descriptor() ([]byte, []int) {
+	return file_proto_monitor_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BandwidthUsage) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
+func (x *BandwidthUsage) GetTotalBytes() uint64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *BandwidthUsage) GetLastMinuteBytes() uint64 {
+	if x != nil {
+		return x.LastMinuteBytes
+	}
+	return 0
+}
+
+func (x *BandwidthUsage) GetLastHourBytes() uint64 {
+	if x != nil {
+		return x.LastHourBytes
+	}
+	return 0
+}
+
+func (x *BandwidthUsage) GetLastDayBytes() uint64 {
+	if x != nil {
+		return x.LastDayBytes
+	}
+	return 0
+}
+
+type ConfigRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	ImageData     []byte                 `protobuf:"bytes,2,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"` // 인코딩된 이미지 (JPEG/PNG/WebP)
-	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	IsPreview     bool                   `protobuf:"varint,4,opt,name=is_preview,json=isPreview,proto3" json:"is_preview,omitempty"` // true면 저해상도 미리보기, false면 고해상도
+	Revision      uint64                 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"` // 에이전트가 마지막으로 적용한 서버 설정 개정 번호 (0 이면 없음)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FrameData) Reset() {
-	*x = FrameData{}
-	mi := &file_proto_monitor_proto_msgTypes[2]
+func (x *ConfigRequest) Reset() {
+	*x = ConfigRequest{}
+	mi := &file_proto_monitor_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FrameData) String() string {
+func (x *ConfigRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FrameData) ProtoMessage() {}
+func (*ConfigRequest) ProtoMessage() {}
 
-func (x *FrameData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_monitor_proto_msgTypes[2]
+func (x *ConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitor_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -177,64 +1554,182 @@ func (x *FrameData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FrameData.ProtoReflect.Descriptor instead.
-func (*FrameData) Descriptor() ([]byte, []int) {
-	return file_proto_monitor_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ConfigRequest.ProtoReflect.Descriptor instead.
+func (*ConfigRequest) Descriptor() ([]byte, []int) {
+	return file_proto_monitor_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *FrameData) GetAgentId() string {
+func (x *ConfigRequest) GetAgentId() string {
 	if x != nil {
 		return x.AgentId
 	}
 	return ""
 }
 
-func (x *FrameData) GetImageData() []byte {
+func (x *ConfigRequest) GetRevision() uint64 {
 	if x != nil {
-		return x.ImageData
+		return x.Revision
 	}
-	return nil
+	return 0
 }
 
-func (x *FrameData) GetTimestamp() int64 {
+type AgentConfig struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Revision         uint64                 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`                                           // 설정 개정 번호 (요청과 같으면 나머지 항목 무시)
+	TargetFps        int32                  `protobuf:"varint,2,opt,name=target_fps,json=targetFps,proto3" json:"target_fps,omitempty"`                        // 0 이면 에이전트 설정 유지
+	Encoding         string                 `protobuf:"bytes,3,opt,name=encoding,proto3" json:"encoding,omitempty"`                                            // 빈 값이면 에이전트 설정 유지
+	JpegQuality      int32                  `protobuf:"varint,4,opt,name=jpeg_quality,json=jpegQuality,proto3" json:"jpeg_quality,omitempty"`                  // 0 이면 에이전트 설정 유지
+	WebpQuality      int32                  `protobuf:"varint,5,opt,name=webp_quality,json=webpQuality,proto3" json:"webp_quality,omitempty"`                  // 0 이면 에이전트 설정 유지
+	PrivacyManaged   bool                   `protobuf:"varint,6,opt,name=privacy_managed,json=privacyManaged,proto3" json:"privacy_managed,omitempty"`         // true 면 아래 가림 영역/금지 앱 규칙을 서버 값으로 교체 (빈 목록이면 해제)
+	PrivacyMasks     string                 `protobuf:"bytes,7,opt,name=privacy_masks,json=privacyMasks,proto3" json:"privacy_masks,omitempty"`                // PRIVACY_MASKS 형식 "[모니터@]x,y,w,h;..."
+	PrivacyMaskMode  string                 `protobuf:"bytes,8,opt,name=privacy_mask_mode,json=privacyMaskMode,proto3" json:"privacy_mask_mode,omitempty"`     // black | blur (빈 값이면 유지)
+	BlockedApps      string                 `protobuf:"bytes,9,opt,name=blocked_apps,json=blockedApps,proto3" json:"blocked_apps,omitempty"`                   // CAPTURE_BLOCKED_APPS 형식 (쉼표 구분)
+	BlockedAppAction string                 `protobuf:"bytes,10,opt,name=blocked_app_action,json=blockedAppAction,proto3" json:"blocked_app_action,omitempty"` // blank | pause (빈 값이면 유지)
+	LocalOverrides   []string               `protobuf:"bytes,11,rep,name=local_overrides,json=localOverrides,proto3" json:"local_overrides,omitempty"`         // 에이전트에서 명시한 값이 서버 값보다 우선하는 항목 ("fps", "encoding", "quality", "privacy")
+	PollIntervalSec  int32                  `protobuf:"varint,12,opt,name=poll_interval_sec,json=pollIntervalSec,proto3" json:"poll_interval_sec,omitempty"`   // 다음 조회까지 간격(초, 0 이면 에이전트 설정)
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AgentConfig) Reset() {
+	*x = AgentConfig{}
+	mi := &file_proto_monitor_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentConfig) ProtoMessage() {}
+
+func (x *AgentConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitor_proto_msgTypes[18]
 	if x != nil {
-		return x.Timestamp
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentConfig.ProtoReflect.Descriptor instead.
+func (*AgentConfig) Descriptor() ([]byte, []int) {
+	return file_proto_monitor_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AgentConfig) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
 	}
 	return 0
 }
 
-func (x *FrameData) GetIsPreview() bool {
+func (x *AgentConfig) GetTargetFps() int32 {
 	if x != nil {
-		return x.IsPreview
+		return x.TargetFps
+	}
+	return 0
+}
+
+func (x *AgentConfig) GetEncoding() string {
+	if x != nil {
+		return x.Encoding
+	}
+	return ""
+}
+
+func (x *AgentConfig) GetJpegQuality() int32 {
+	if x != nil {
+		return x.JpegQuality
+	}
+	return 0
+}
+
+func (x *AgentConfig) GetWebpQuality() int32 {
+	if x != nil {
+		return x.WebpQuality
+	}
+	return 0
+}
+
+func (x *AgentConfig) GetPrivacyManaged() bool {
+	if x != nil {
+		return x.PrivacyManaged
 	}
 	return false
 }
 
-type EventData struct {
+func (x *AgentConfig) GetPrivacyMasks() string {
+	if x != nil {
+		return x.PrivacyMasks
+	}
+	return ""
+}
+
+func (x *AgentConfig) GetPrivacyMaskMode() string {
+	if x != nil {
+		return x.PrivacyMaskMode
+	}
+	return ""
+}
+
+func (x *AgentConfig) GetBlockedApps() string {
+	if x != nil {
+		return x.BlockedApps
+	}
+	return ""
+}
+
+func (x *AgentConfig) GetBlockedAppAction() string {
+	if x != nil {
+		return x.BlockedAppAction
+	}
+	return ""
+}
+
+func (x *AgentConfig) GetLocalOverrides() []string {
+	if x != nil {
+		return x.LocalOverrides
+	}
+	return nil
+}
+
+func (x *AgentConfig) GetPollIntervalSec() int32 {
+	if x != nil {
+		return x.PollIntervalSec
+	}
+	return 0
+}
+
+type AgentCommand struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	EventType     string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"` // "keyboard", "mouse", "printer", "usb" 등
-	EventDetail   string                 `protobuf:"bytes,3,opt,name=event_detail,json=eventDetail,proto3" json:"event_detail,omitempty"`
+	CommandId     string                 `protobuf:"bytes,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`                                                // 응답 매칭용 명령 ID
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                                                                           // "start_capture", "stop_capture", "set_fps", "select_monitor", "screenshot" 등
+	Args          map[string]string      `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 명령 인자 (set_fps: fps, select_monitor: index 또는 "combined")
 	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EventData) Reset() {
-	*x = EventData{}
-	mi := &file_proto_monitor_proto_msgTypes[3]
+func (x *AgentCommand) Reset() {
+	*x = AgentCommand{}
+	mi := &file_proto_monitor_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EventData) String() string {
+func (x *AgentCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EventData) ProtoMessage() {}
+func (*AgentCommand) ProtoMessage() {}
 
-func (x *EventData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_monitor_proto_msgTypes[3]
+func (x *AgentCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitor_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -245,62 +1740,67 @@ func (x *EventData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EventData.ProtoReflect.Descriptor instead.
-func (*EventData) Descriptor() ([]byte, []int) {
-	return file_proto_monitor_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use AgentCommand.ProtoReflect.Descriptor instead.
+func (*AgentCommand) Descriptor() ([]byte, []int) {
+	return file_proto_monitor_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *EventData) GetAgentId() string {
+func (x *AgentCommand) GetCommandId() string {
 	if x != nil {
-		return x.AgentId
+		return x.CommandId
 	}
 	return ""
 }
 
-func (x *EventData) GetEventType() string {
+func (x *AgentCommand) GetType() string {
 	if x != nil {
-		return x.EventType
+		return x.Type
 	}
 	return ""
 }
 
-func (x *EventData) GetEventDetail() string {
+func (x *AgentCommand) GetArgs() map[string]string {
 	if x != nil {
-		return x.EventDetail
+		return x.Args
 	}
-	return ""
+	return nil
 }
 
-func (x *EventData) GetTimestamp() int64 {
+func (x *AgentCommand) GetTimestamp() int64 {
 	if x != nil {
 		return x.Timestamp
 	}
 	return 0
 }
 
-type StreamAck struct {
+type CommandAck struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	CommandId     string                 `protobuf:"bytes,2,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"` // 빈 값이면 채널 개시 메시지
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`   // 실패 사유 또는 처리 결과 요약
+	Data          []byte                 `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`         // 명령 결과 데이터 (screenshot: 인코딩된 이미지)
+	Encoding      string                 `protobuf:"bytes,6,opt,name=encoding,proto3" json:"encoding,omitempty"` // data 인코딩 ("png", "jpeg", "webp", "json")
+	Timestamp     int64                  `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StreamAck) Reset() {
-	*x = StreamAck{}
-	mi := &file_proto_monitor_proto_msgTypes[4]
+func (x *CommandAck) Reset() {
+	*x = CommandAck{}
+	mi := &file_proto_monitor_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamAck) String() string {
+func (x *CommandAck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamAck) ProtoMessage() {}
+func (*CommandAck) ProtoMessage() {}
 
-func (x *StreamAck) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_monitor_proto_msgTypes[4]
+func (x *CommandAck) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_monitor_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -311,25 +1811,60 @@ func (x *StreamAck) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamAck.ProtoReflect.Descriptor instead.
-func (*StreamAck) Descriptor() ([]byte, []int) {
-	return file_proto_monitor_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use CommandAck.ProtoReflect.Descriptor instead.
+func (*CommandAck) Descriptor() ([]byte, []int) {
+	return file_proto_monitor_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *StreamAck) GetSuccess() bool {
+func (x *CommandAck) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *CommandAck) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+func (x *CommandAck) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *StreamAck) GetMessage() string {
+func (x *CommandAck) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
+func (x *CommandAck) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *CommandAck) GetEncoding() string {
+	if x != nil {
+		return x.Encoding
+	}
+	return ""
+}
+
+func (x *CommandAck) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
 type AdminSubscribeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AdminId       string                 `protobuf:"bytes,1,opt,name=admin_id,json=adminId,proto3" json:"admin_id,omitempty"`
@@ -339,7 +1874,7 @@ type AdminSubscribeRequest struct {
 
 func (x *AdminSubscribeRequest) Reset() {
 	*x = AdminSubscribeRequest{}
-	mi := &file_proto_monitor_proto_msgTypes[5]
+	mi := &file_proto_monitor_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -351,7 +1886,7 @@ func (x *AdminSubscribeRequest) String() string {
 func (*AdminSubscribeRequest) ProtoMessage() {}
 
 func (x *AdminSubscribeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_monitor_proto_msgTypes[5]
+	mi := &file_proto_monitor_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -364,7 +1899,7 @@ func (x *AdminSubscribeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AdminSubscribeRequest.ProtoReflect.Descriptor instead.
 func (*AdminSubscribeRequest) Descriptor() ([]byte, []int) {
-	return file_proto_monitor_proto_rawDescGZIP(), []int{5}
+	return file_proto_monitor_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *AdminSubscribeRequest) GetAdminId() string {
@@ -384,7 +1919,7 @@ type AgentDetailRequest struct {
 
 func (x *AgentDetailRequest) Reset() {
 	*x = AgentDetailRequest{}
-	mi := &file_proto_monitor_proto_msgTypes[6]
+	mi := &file_proto_monitor_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -396,7 +1931,7 @@ func (x *AgentDetailRequest) String() string {
 func (*AgentDetailRequest) ProtoMessage() {}
 
 func (x *AgentDetailRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_monitor_proto_msgTypes[6]
+	mi := &file_proto_monitor_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -409,7 +1944,7 @@ func (x *AgentDetailRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AgentDetailRequest.ProtoReflect.Descriptor instead.
 func (*AgentDetailRequest) Descriptor() ([]byte, []int) {
-	return file_proto_monitor_proto_rawDescGZIP(), []int{6}
+	return file_proto_monitor_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *AgentDetailRequest) GetAdminId() string {
@@ -438,31 +1973,201 @@ const file_proto_monitor_proto_rawDesc = "" +
 	"\tAdminInfo\x12\x19\n" +
 	"\badmin_id\x18\x01 \x01(\tR\aadminId\x12\x1a\n" +
 	"\bhostname\x18\x02 \x01(\tR\bhostname\x12\x0e\n" +
-	"\x02ip\x18\x03 \x01(\tR\x02ip\"\x82\x01\n" +
+	"\x02ip\x18\x03 \x01(\tR\x02ip\"\xc6\x03\n" +
 	"\tFrameData\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1d\n" +
 	"\n" +
 	"image_data\x18\x02 \x01(\fR\timageData\x12\x1c\n" +
 	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x12\x1d\n" +
 	"\n" +
-	"is_preview\x18\x04 \x01(\bR\tisPreview\"\x86\x01\n" +
+	"is_preview\x18\x04 \x01(\bR\tisPreview\x12\x1b\n" +
+	"\tscale_pct\x18\x05 \x01(\x05R\bscalePct\x12\x1a\n" +
+	"\bencoding\x18\x06 \x01(\tR\bencoding\x12\x1a\n" +
+	"\bkeyframe\x18\a \x01(\bR\bkeyframe\x12\x14\n" +
+	"\x05width\x18\b \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\t \x01(\x05R\x06height\x12.\n" +
+	"\aregions\x18\n" +
+	" \x03(\v2\x14.monitor.FrameRegionR\aregions\x12'\n" +
+	"\x0fregion_encoding\x18\v \x01(\tR\x0eregionEncoding\x12\x1c\n" +
+	"\tunchanged\x18\f \x01(\bR\tunchanged\x12\x1d\n" +
+	"\n" +
+	"monitor_id\x18\r \x01(\x05R\tmonitorId\x12)\n" +
+	"\x05chunk\x18\x0e \x01(\v2\x13.monitor.FrameChunkR\x05chunk\"g\n" +
+	"\n" +
+	"FrameChunk\x12\x19\n" +
+	"\bframe_id\x18\x01 \x01(\x04R\aframeId\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x05R\x05index\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\"k\n" +
+	"\vFrameRegion\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x05R\x01y\x12\x14\n" +
+	"\x05width\x18\x03 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x04 \x01(\x05R\x06height\x12\x12\n" +
+	"\x04data\x18\x05 \x01(\fR\x04data\"\xfa\x01\n" +
 	"\tEventData\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1d\n" +
 	"\n" +
 	"event_type\x18\x02 \x01(\tR\teventType\x12!\n" +
 	"\fevent_detail\x18\x03 \x01(\tR\veventDetail\x12\x1c\n" +
-	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\"?\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12(\n" +
+	"\x05batch\x18\x05 \x03(\v2\x12.monitor.EventDataR\x05batch\x12\x1a\n" +
+	"\bseverity\x18\x06 \x01(\tR\bseverity\x12\x1a\n" +
+	"\bcategory\x18\a \x01(\tR\bcategory\x12\x10\n" +
+	"\x03seq\x18\b \x01(\x04R\x03seq\"7\n" +
+	"\bEventAck\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x10\n" +
+	"\x03seq\x18\x02 \x01(\x04R\x03seq\"?\n" +
 	"\tStreamAck\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"2\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x83\x01\n" +
+	"\x11DiagnosticsBundle\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1b\n" +
+	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12\x18\n" +
+	"\aarchive\x18\x03 \x01(\fR\aarchive\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\"\x8e\x01\n" +
+	"\x0eRecordingChunk\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12!\n" +
+	"\frecording_id\x18\x02 \x01(\tR\vrecordingId\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x03R\x06offset\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\x12\x12\n" +
+	"\x04last\x18\x05 \x01(\bR\x04last\"\x99\x02\n" +
+	"\n" +
+	"VideoChunk\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x10\n" +
+	"\x03seq\x18\x03 \x01(\x03R\x03seq\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\x12\x14\n" +
+	"\x05codec\x18\x05 \x01(\tR\x05codec\x12\x18\n" +
+	"\aencoder\x18\x06 \x01(\tR\aencoder\x12\x14\n" +
+	"\x05width\x18\a \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\b \x01(\x05R\x06height\x12\x10\n" +
+	"\x03fps\x18\t \x01(\x05R\x03fps\x12\x1c\n" +
+	"\ttimestamp\x18\n" +
+	" \x01(\x03R\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"monitor_id\x18\v \x01(\x05R\tmonitorId\"m\n" +
+	"\vMonitorInfo\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\f\n" +
+	"\x01x\x18\x02 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x03 \x01(\x05R\x01y\x12\x14\n" +
+	"\x05width\x18\x04 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x05 \x01(\x05R\x06height\"\x82\x03\n" +
+	"\x0fRegisterRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1a\n" +
+	"\bhostname\x18\x02 \x01(\tR\bhostname\x12\x0e\n" +
+	"\x02os\x18\x03 \x01(\tR\x02os\x12\x12\n" +
+	"\x04arch\x18\x04 \x01(\tR\x04arch\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\tR\aversion\x120\n" +
+	"\bmonitors\x18\x06 \x03(\v2\x14.monitor.MonitorInfoR\bmonitors\x12\x1c\n" +
+	"\tencodings\x18\a \x03(\tR\tencodings\x12\x1a\n" +
+	"\bcommands\x18\b \x03(\tR\bcommands\x12\x1c\n" +
+	"\ttimestamp\x18\t \x01(\x03R\ttimestamp\x12\x1f\n" +
+	"\vserver_addr\x18\n" +
+	" \x01(\tR\n" +
+	"serverAddr\x12\x1f\n" +
+	"\vdevice_name\x18\v \x01(\tR\n" +
+	"deviceName\x12.\n" +
+	"\aconsent\x18\f \x01(\v2\x14.monitor.ConsentInfoR\aconsent\"\xc6\x01\n" +
+	"\vConsentInfo\x12\x1a\n" +
+	"\brequired\x18\x01 \x01(\bR\brequired\x12\x18\n" +
+	"\agranted\x18\x02 \x01(\bR\agranted\x12\x12\n" +
+	"\x04user\x18\x03 \x01(\tR\x04user\x12\x1f\n" +
+	"\vaccepted_at\x18\x04 \x01(\x03R\n" +
+	"acceptedAt\x12%\n" +
+	"\x0epolicy_version\x18\x05 \x01(\tR\rpolicyVersion\x12%\n" +
+	"\x0ecurrent_policy\x18\x06 \x01(\tR\rcurrentPolicy\"\xa6\x01\n" +
+	"\x10RegisterResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"target_fps\x18\x03 \x01(\x05R\ttargetFps\x12!\n" +
+	"\fjpeg_quality\x18\x04 \x01(\x05R\vjpegQuality\x12\x1a\n" +
+	"\bencoding\x18\x05 \x01(\tR\bencoding\"\xe0\x04\n" +
+	"\x10HeartbeatRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1f\n" +
+	"\vcpu_percent\x18\x02 \x01(\x01R\n" +
+	"cpuPercent\x12!\n" +
+	"\fmemory_bytes\x18\x03 \x01(\x04R\vmemoryBytes\x12\x1d\n" +
+	"\n" +
+	"uptime_sec\x18\x04 \x01(\x03R\tuptimeSec\x12\x1f\n" +
+	"\vcapture_fps\x18\x05 \x01(\x01R\n" +
+	"captureFps\x12\x1f\n" +
+	"\vframes_sent\x18\x06 \x01(\x04R\n" +
+	"framesSent\x12%\n" +
+	"\x0eframes_dropped\x18\a \x01(\x04R\rframesDropped\x12\x1c\n" +
+	"\tcapturing\x18\b \x01(\bR\tcapturing\x12\x1c\n" +
+	"\ttimestamp\x18\t \x01(\x03R\ttimestamp\x12'\n" +
+	"\x0fframes_captured\x18\n" +
+	" \x01(\x04R\x0eframesCaptured\x12\"\n" +
+	"\ravg_encode_ms\x18\v \x01(\x01R\vavgEncodeMs\x12&\n" +
+	"\x0favg_frame_bytes\x18\f \x01(\x01R\ravgFrameBytes\x12\x1f\n" +
+	"\vserver_addr\x18\r \x01(\tR\n" +
+	"serverAddr\x125\n" +
+	"\tbandwidth\x18\x0e \x03(\v2\x17.monitor.BandwidthUsageR\tbandwidth\x120\n" +
+	"\x14frames_dropped_queue\x18\x0f \x01(\x04R\x12framesDroppedQueue\x12*\n" +
+	"\x11frame_queue_depth\x18\x10 \x01(\x05R\x0fframeQueueDepth\"\xc3\x01\n" +
+	"\x0eBandwidthUsage\x12\x16\n" +
+	"\x06stream\x18\x01 \x01(\tR\x06stream\x12\x1f\n" +
+	"\vtotal_bytes\x18\x02 \x01(\x04R\n" +
+	"totalBytes\x12*\n" +
+	"\x11last_minute_bytes\x18\x03 \x01(\x04R\x0flastMinuteBytes\x12&\n" +
+	"\x0flast_hour_bytes\x18\x04 \x01(\x04R\rlastHourBytes\x12$\n" +
+	"\x0elast_day_bytes\x18\x05 \x01(\x04R\flastDayBytes\"F\n" +
+	"\rConfigRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x04R\brevision\"\xca\x03\n" +
+	"\vAgentConfig\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x04R\brevision\x12\x1d\n" +
+	"\n" +
+	"target_fps\x18\x02 \x01(\x05R\ttargetFps\x12\x1a\n" +
+	"\bencoding\x18\x03 \x01(\tR\bencoding\x12!\n" +
+	"\fjpeg_quality\x18\x04 \x01(\x05R\vjpegQuality\x12!\n" +
+	"\fwebp_quality\x18\x05 \x01(\x05R\vwebpQuality\x12'\n" +
+	"\x0fprivacy_managed\x18\x06 \x01(\bR\x0eprivacyManaged\x12#\n" +
+	"\rprivacy_masks\x18\a \x01(\tR\fprivacyMasks\x12*\n" +
+	"\x11privacy_mask_mode\x18\b \x01(\tR\x0fprivacyMaskMode\x12!\n" +
+	"\fblocked_apps\x18\t \x01(\tR\vblockedApps\x12,\n" +
+	"\x12blocked_app_action\x18\n" +
+	" \x01(\tR\x10blockedAppAction\x12'\n" +
+	"\x0flocal_overrides\x18\v \x03(\tR\x0elocalOverrides\x12*\n" +
+	"\x11poll_interval_sec\x18\f \x01(\x05R\x0fpollIntervalSec\"\xcd\x01\n" +
+	"\fAgentCommand\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x01 \x01(\tR\tcommandId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x123\n" +
+	"\x04args\x18\x03 \x03(\v2\x1f.monitor.AgentCommand.ArgsEntryR\x04args\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x1a7\n" +
+	"\tArgsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc8\x01\n" +
+	"\n" +
+	"CommandAck\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x02 \x01(\tR\tcommandId\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x12\n" +
+	"\x04data\x18\x05 \x01(\fR\x04data\x12\x1a\n" +
+	"\bencoding\x18\x06 \x01(\tR\bencoding\x12\x1c\n" +
+	"\ttimestamp\x18\a \x01(\x03R\ttimestamp\"2\n" +
 	"\x15AdminSubscribeRequest\x12\x19\n" +
 	"\badmin_id\x18\x01 \x01(\tR\aadminId\"J\n" +
 	"\x12AgentDetailRequest\x12\x19\n" +
 	"\badmin_id\x18\x01 \x01(\tR\aadminId\x12\x19\n" +
-	"\bagent_id\x18\x02 \x01(\tR\aagentId2\x82\x01\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId2\xf6\x04\n" +
 	"\fAgentService\x128\n" +
 	"\fStreamFrames\x12\x12.monitor.FrameData\x1a\x12.monitor.StreamAck(\x01\x128\n" +
-	"\fStreamEvents\x12\x12.monitor.EventData\x1a\x12.monitor.StreamAck(\x012\xe5\x01\n" +
+	"\vStreamVideo\x12\x13.monitor.VideoChunk\x1a\x12.monitor.StreamAck(\x01\x128\n" +
+	"\fStreamEvents\x12\x12.monitor.EventData\x1a\x12.monitor.StreamAck(\x01\x12>\n" +
+	"\x11StreamAckedEvents\x12\x12.monitor.EventData\x1a\x11.monitor.EventAck(\x010\x01\x12C\n" +
+	"\x11UploadDiagnostics\x12\x1a.monitor.DiagnosticsBundle\x1a\x12.monitor.StreamAck\x12@\n" +
+	"\x0fUploadRecording\x12\x17.monitor.RecordingChunk\x1a\x12.monitor.StreamAck(\x01\x12?\n" +
+	"\bRegister\x12\x18.monitor.RegisterRequest\x1a\x19.monitor.RegisterResponse\x12:\n" +
+	"\tHeartbeat\x12\x19.monitor.HeartbeatRequest\x1a\x12.monitor.StreamAck\x129\n" +
+	"\aControl\x12\x13.monitor.CommandAck\x1a\x15.monitor.AgentCommand(\x010\x01\x129\n" +
+	"\tGetConfig\x12\x16.monitor.ConfigRequest\x1a\x14.monitor.AgentConfig2\xe5\x01\n" +
 	"\fAdminService\x12I\n" +
 	"\x11SubscribeOverview\x12\x1e.monitor.AdminSubscribeRequest\x1a\x12.monitor.FrameData0\x01\x12D\n" +
 	"\x0fSubscribeDetail\x12\x1b.monitor.AgentDetailRequest\x1a\x12.monitor.FrameData0\x01\x12D\n" +
@@ -480,32 +2185,72 @@ func file_proto_monitor_proto_rawDescGZIP() []byte {
 	return file_proto_monitor_proto_rawDescData
 }
 
-var file_proto_monitor_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_monitor_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_proto_monitor_proto_goTypes = []any{
 	(*AgentInfo)(nil),             // 0: monitor.AgentInfo
 	(*AdminInfo)(nil),             // 1: monitor.AdminInfo
 	(*FrameData)(nil),             // 2: monitor.FrameData
-	(*EventData)(nil),             // 3: monitor.EventData
-	(*StreamAck)(nil),             // 4: monitor.StreamAck
-	(*AdminSubscribeRequest)(nil), // 5: monitor.AdminSubscribeRequest
-	(*AgentDetailRequest)(nil),    // 6: monitor.AgentDetailRequest
+	(*FrameChunk)(nil),            // 3: monitor.FrameChunk
+	(*FrameRegion)(nil),           // 4: monitor.FrameRegion
+	(*EventData)(nil),             // 5: monitor.EventData
+	(*EventAck)(nil),              // 6: monitor.EventAck
+	(*StreamAck)(nil),             // 7: monitor.StreamAck
+	(*DiagnosticsBundle)(nil),     // 8: monitor.DiagnosticsBundle
+	(*RecordingChunk)(nil),        // 9: monitor.RecordingChunk
+	(*VideoChunk)(nil),            // 10: monitor.VideoChunk
+	(*MonitorInfo)(nil),           // 11: monitor.MonitorInfo
+	(*RegisterRequest)(nil),       // 12: monitor.RegisterRequest
+	(*ConsentInfo)(nil),           // 13: monitor.ConsentInfo
+	(*RegisterResponse)(nil),      // 14: monitor.RegisterResponse
+	(*HeartbeatRequest)(nil),      // 15: monitor.HeartbeatRequest
+	(*BandwidthUsage)(nil),        // 16: monitor.BandwidthUsage
+	(*ConfigRequest)(nil),         // 17: monitor.ConfigRequest
+	(*AgentConfig)(nil),           // 18: monitor.AgentConfig
+	(*AgentCommand)(nil),          // 19: monitor.AgentCommand
+	(*CommandAck)(nil),            // 20: monitor.CommandAck
+	(*AdminSubscribeRequest)(nil), // 21: monitor.AdminSubscribeRequest
+	(*AgentDetailRequest)(nil),    // 22: monitor.AgentDetailRequest
+	nil,                           // 23: monitor.AgentCommand.ArgsEntry
 }
 var file_proto_monitor_proto_depIdxs = []int32{
-	2, // 0: monitor.AgentService.StreamFrames:input_type -> monitor.FrameData
-	3, // 1: monitor.AgentService.StreamEvents:input_type -> monitor.EventData
-	5, // 2: monitor.AdminService.SubscribeOverview:input_type -> monitor.AdminSubscribeRequest
-	6, // 3: monitor.AdminService.SubscribeDetail:input_type -> monitor.AgentDetailRequest
-	6, // 4: monitor.AdminService.SubscribeEvents:input_type -> monitor.AgentDetailRequest
-	4, // 5: monitor.AgentService.StreamFrames:output_type -> monitor.StreamAck
-	4, // 6: monitor.AgentService.StreamEvents:output_type -> monitor.StreamAck
-	2, // 7: monitor.AdminService.SubscribeOverview:output_type -> monitor.FrameData
-	2, // 8: monitor.AdminService.SubscribeDetail:output_type -> monitor.FrameData
-	3, // 9: monitor.AdminService.SubscribeEvents:output_type -> monitor.EventData
-	5, // [5:10] is the sub-list for method output_type
-	0, // [0:5] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	4,  // 0: monitor.FrameData.regions:type_name -> monitor.FrameRegion
+	3,  // 1: monitor.FrameData.chunk:type_name -> monitor.FrameChunk
+	5,  // 2: monitor.EventData.batch:type_name -> monitor.EventData
+	11, // 3: monitor.RegisterRequest.monitors:type_name -> monitor.MonitorInfo
+	13, // 4: monitor.RegisterRequest.consent:type_name -> monitor.ConsentInfo
+	16, // 5: monitor.HeartbeatRequest.bandwidth:type_name -> monitor.BandwidthUsage
+	23, // 6: monitor.AgentCommand.args:type_name -> monitor.AgentCommand.ArgsEntry
+	2,  // 7: monitor.AgentService.StreamFrames:input_type -> monitor.FrameData
+	10, // 8: monitor.AgentService.StreamVideo:input_type -> monitor.VideoChunk
+	5,  // 9: monitor.AgentService.StreamEvents:input_type -> monitor.EventData
+	5,  // 10: monitor.AgentService.StreamAckedEvents:input_type -> monitor.EventData
+	8,  // 11: monitor.AgentService.UploadDiagnostics:input_type -> monitor.DiagnosticsBundle
+	9,  // 12: monitor.AgentService.UploadRecording:input_type -> monitor.RecordingChunk
+	12, // 13: monitor.AgentService.Register:input_type -> monitor.RegisterRequest
+	15, // 14: monitor.AgentService.Heartbeat:input_type -> monitor.HeartbeatRequest
+	20, // 15: monitor.AgentService.Control:input_type -> monitor.CommandAck
+	17, // 16: monitor.AgentService.GetConfig:input_type -> monitor.ConfigRequest
+	21, // 17: monitor.AdminService.SubscribeOverview:input_type -> monitor.AdminSubscribeRequest
+	22, // 18: monitor.AdminService.SubscribeDetail:input_type -> monitor.AgentDetailRequest
+	22, // 19: monitor.AdminService.SubscribeEvents:input_type -> monitor.AgentDetailRequest
+	7,  // 20: monitor.AgentService.StreamFrames:output_type -> monitor.StreamAck
+	7,  // 21: monitor.AgentService.StreamVideo:output_type -> monitor.StreamAck
+	7,  // 22: monitor.AgentService.StreamEvents:output_type -> monitor.StreamAck
+	6,  // 23: monitor.AgentService.StreamAckedEvents:output_type -> monitor.EventAck
+	7,  // 24: monitor.AgentService.UploadDiagnostics:output_type -> monitor.StreamAck
+	7,  // 25: monitor.AgentService.UploadRecording:output_type -> monitor.StreamAck
+	14, // 26: monitor.AgentService.Register:output_type -> monitor.RegisterResponse
+	7,  // 27: monitor.AgentService.Heartbeat:output_type -> monitor.StreamAck
+	19, // 28: monitor.AgentService.Control:output_type -> monitor.AgentCommand
+	18, // 29: monitor.AgentService.GetConfig:output_type -> monitor.AgentConfig
+	2,  // 30: monitor.AdminService.SubscribeOverview:output_type -> monitor.FrameData
+	2,  // 31: monitor.AdminService.SubscribeDetail:output_type -> monitor.FrameData
+	5,  // 32: monitor.AdminService.SubscribeEvents:output_type -> monitor.EventData
+	20, // [20:33] is the sub-list for method output_type
+	7,  // [7:20] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_proto_monitor_proto_init() }
@@ -519,7 +2264,7 @@ func file_proto_monitor_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_monitor_proto_rawDesc), len(file_proto_monitor_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
@@ -19,8 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AgentService_StreamFrames_FullMethodName = "/monitor.AgentService/StreamFrames"
-	AgentService_StreamEvents_FullMethodName = "/monitor.AgentService/StreamEvents"
+	AgentService_StreamFrames_FullMethodName      = "/monitor.AgentService/StreamFrames"
+	AgentService_StreamVideo_FullMethodName       = "/monitor.AgentService/StreamVideo"
+	AgentService_StreamEvents_FullMethodName      = "/monitor.AgentService/StreamEvents"
+	AgentService_StreamAckedEvents_FullMethodName = "/monitor.AgentService/StreamAckedEvents"
+	AgentService_UploadDiagnostics_FullMethodName = "/monitor.AgentService/UploadDiagnostics"
+	AgentService_UploadRecording_FullMethodName   = "/monitor.AgentService/UploadRecording"
+	AgentService_Register_FullMethodName          = "/monitor.AgentService/Register"
+	AgentService_Heartbeat_FullMethodName         = "/monitor.AgentService/Heartbeat"
+	AgentService_Control_FullMethodName           = "/monitor.AgentService/Control"
+	AgentService_GetConfig_FullMethodName         = "/monitor.AgentService/GetConfig"
 )
 
 // AgentServiceClient is the client API for AgentService service.
@@ -31,8 +39,24 @@ const (
 type AgentServiceClient interface {
 	// 화면 프레임 스트리밍
 	StreamFrames(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FrameData, StreamAck], error)
+	// H.264 영상 스트리밍 (CAPTURE_ENCODING=h264, 프레임 스트림 대신 사용)
+	StreamVideo(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[VideoChunk, StreamAck], error)
 	// 이벤트 스트리밍
 	StreamEvents(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[EventData, StreamAck], error)
+	// 확인 기반 이벤트 스트리밍 (확인 전까지 에이전트 디스크에 보관, 재시작/재연결 후 같은 seq 로 재전송 - 서버는 seq 로 중복 제거)
+	StreamAckedEvents(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[EventData, EventAck], error)
+	// 진단 번들 업로드
+	UploadDiagnostics(ctx context.Context, in *DiagnosticsBundle, opts ...grpc.CallOption) (*StreamAck, error)
+	// 로컬 녹화 파일 업로드 (청크 스트리밍)
+	UploadRecording(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[RecordingChunk, StreamAck], error)
+	// 스트림 개시 전 에이전트 등록 (기능 보고 + 서버 지정 설정 수신)
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
+	// 주기적 상태 보고 (연속 실패 시 에이전트는 서버 응답 없음으로 표시)
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*StreamAck, error)
+	// 원격 제어 채널 (서버가 명령을 푸시하고 에이전트가 처리 결과로 응답)
+	Control(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[CommandAck, AgentCommand], error)
+	// 서버 관리 설정 조회 (등록 직후와 주기적으로 호출, 개정 번호가 같으면 에이전트는 적용 생략)
+	GetConfig(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*AgentConfig, error)
 }
 
 type agentServiceClient struct {
@@ -56,9 +80,22 @@ func (c *agentServiceClient) StreamFrames(ctx context.Context, opts ...grpc.Call
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type AgentService_StreamFramesClient = grpc.ClientStreamingClient[FrameData, StreamAck]
 
+func (c *agentServiceClient) StreamVideo(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[VideoChunk, StreamAck], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[1], AgentService_StreamVideo_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[VideoChunk, StreamAck]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_StreamVideoClient = grpc.ClientStreamingClient[VideoChunk, StreamAck]
+
 func (c *agentServiceClient) StreamEvents(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[EventData, StreamAck], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[1], AgentService_StreamEvents_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[2], AgentService_StreamEvents_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -69,6 +106,85 @@ func (c *agentServiceClient) StreamEvents(ctx context.Context, opts ...grpc.Call
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type AgentService_StreamEventsClient = grpc.ClientStreamingClient[EventData, StreamAck]
 
+func (c *agentServiceClient) StreamAckedEvents(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[EventData, EventAck], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[3], AgentService_StreamAckedEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[EventData, EventAck]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_StreamAckedEventsClient = grpc.BidiStreamingClient[EventData, EventAck]
+
+func (c *agentServiceClient) UploadDiagnostics(ctx context.Context, in *DiagnosticsBundle, opts ...grpc.CallOption) (*StreamAck, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StreamAck)
+	err := c.cc.Invoke(ctx, AgentService_UploadDiagnostics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) UploadRecording(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[RecordingChunk, StreamAck], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[4], AgentService_UploadRecording_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RecordingChunk, StreamAck]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_UploadRecordingClient = grpc.ClientStreamingClient[RecordingChunk, StreamAck]
+
+func (c *agentServiceClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterResponse)
+	err := c.cc.Invoke(ctx, AgentService_Register_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*StreamAck, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StreamAck)
+	err := c.cc.Invoke(ctx, AgentService_Heartbeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) Control(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[CommandAck, AgentCommand], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[5], AgentService_Control_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CommandAck, AgentCommand]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_ControlClient = grpc.BidiStreamingClient[CommandAck, AgentCommand]
+
+func (c *agentServiceClient) GetConfig(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*AgentConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AgentConfig)
+	err := c.cc.Invoke(ctx, AgentService_GetConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentServiceServer is the server API for AgentService service.
 // All implementations must embed UnimplementedAgentServiceServer
 // for forward compatibility.
@@ -77,8 +193,24 @@ type AgentService_StreamEventsClient = grpc.ClientStreamingClient[EventData, Str
 type AgentServiceServer interface {
 	// 화면 프레임 스트리밍
 	StreamFrames(grpc.ClientStreamingServer[FrameData, StreamAck]) error
+	// H.264 영상 스트리밍 (CAPTURE_ENCODING=h264, 프레임 스트림 대신 사용)
+	StreamVideo(grpc.ClientStreamingServer[VideoChunk, StreamAck]) error
 	// 이벤트 스트리밍
 	StreamEvents(grpc.ClientStreamingServer[EventData, StreamAck]) error
+	// 확인 기반 이벤트 스트리밍 (확인 전까지 에이전트 디스크에 보관, 재시작/재연결 후 같은 seq 로 재전송 - 서버는 seq 로 중복 제거)
+	StreamAckedEvents(grpc.BidiStreamingServer[EventData, EventAck]) error
+	// 진단 번들 업로드
+	UploadDiagnostics(context.Context, *DiagnosticsBundle) (*StreamAck, error)
+	// 로컬 녹화 파일 업로드 (청크 스트리밍)
+	UploadRecording(grpc.ClientStreamingServer[RecordingChunk, StreamAck]) error
+	// 스트림 개시 전 에이전트 등록 (기능 보고 + 서버 지정 설정 수신)
+	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
+	// 주기적 상태 보고 (연속 실패 시 에이전트는 서버 응답 없음으로 표시)
+	Heartbeat(context.Context, *HeartbeatRequest) (*StreamAck, error)
+	// 원격 제어 채널 (서버가 명령을 푸시하고 에이전트가 처리 결과로 응답)
+	Control(grpc.BidiStreamingServer[CommandAck, AgentCommand]) error
+	// 서버 관리 설정 조회 (등록 직후와 주기적으로 호출, 개정 번호가 같으면 에이전트는 적용 생략)
+	GetConfig(context.Context, *ConfigRequest) (*AgentConfig, error)
 	mustEmbedUnimplementedAgentServiceServer()
 }
 
@@ -92,9 +224,33 @@ type UnimplementedAgentServiceServer struct{}
 func (UnimplementedAgentServiceServer) StreamFrames(grpc.ClientStreamingServer[FrameData, StreamAck]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamFrames not implemented")
 }
+func (UnimplementedAgentServiceServer) StreamVideo(grpc.ClientStreamingServer[VideoChunk, StreamAck]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamVideo not implemented")
+}
 func (UnimplementedAgentServiceServer) StreamEvents(grpc.ClientStreamingServer[EventData, StreamAck]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
 }
+func (UnimplementedAgentServiceServer) StreamAckedEvents(grpc.BidiStreamingServer[EventData, EventAck]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamAckedEvents not implemented")
+}
+func (UnimplementedAgentServiceServer) UploadDiagnostics(context.Context, *DiagnosticsBundle) (*StreamAck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadDiagnostics not implemented")
+}
+func (UnimplementedAgentServiceServer) UploadRecording(grpc.ClientStreamingServer[RecordingChunk, StreamAck]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadRecording not implemented")
+}
+func (UnimplementedAgentServiceServer) Register(context.Context, *RegisterRequest) (*RegisterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedAgentServiceServer) Heartbeat(context.Context, *HeartbeatRequest) (*StreamAck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedAgentServiceServer) Control(grpc.BidiStreamingServer[CommandAck, AgentCommand]) error {
+	return status.Errorf(codes.Unimplemented, "method Control not implemented")
+}
+func (UnimplementedAgentServiceServer) GetConfig(context.Context, *ConfigRequest) (*AgentConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
+}
 func (UnimplementedAgentServiceServer) mustEmbedUnimplementedAgentServiceServer() {}
 func (UnimplementedAgentServiceServer) testEmbeddedByValue()                      {}
 
@@ -123,6 +279,13 @@ func _AgentService_StreamFrames_Handler(srv interface{}, stream grpc.ServerStrea
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type AgentService_StreamFramesServer = grpc.ClientStreamingServer[FrameData, StreamAck]
 
+func _AgentService_StreamVideo_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServiceServer).StreamVideo(&grpc.GenericServerStream[VideoChunk, StreamAck]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_StreamVideoServer = grpc.ClientStreamingServer[VideoChunk, StreamAck]
+
 func _AgentService_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(AgentServiceServer).StreamEvents(&grpc.GenericServerStream[EventData, StreamAck]{ServerStream: stream})
 }
@@ -130,24 +293,156 @@ func _AgentService_StreamEvents_Handler(srv interface{}, stream grpc.ServerStrea
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type AgentService_StreamEventsServer = grpc.ClientStreamingServer[EventData, StreamAck]
 
+func _AgentService_StreamAckedEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServiceServer).StreamAckedEvents(&grpc.GenericServerStream[EventData, EventAck]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_StreamAckedEventsServer = grpc.BidiStreamingServer[EventData, EventAck]
+
+func _AgentService_UploadDiagnostics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiagnosticsBundle)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).UploadDiagnostics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_UploadDiagnostics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).UploadDiagnostics(ctx, req.(*DiagnosticsBundle))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_UploadRecording_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServiceServer).UploadRecording(&grpc.GenericServerStream[RecordingChunk, StreamAck]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_UploadRecordingServer = grpc.ClientStreamingServer[RecordingChunk, StreamAck]
+
+func _AgentService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_Control_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServiceServer).Control(&grpc.GenericServerStream[CommandAck, AgentCommand]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_ControlServer = grpc.BidiStreamingServer[CommandAck, AgentCommand]
+
+func _AgentService_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_GetConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).GetConfig(ctx, req.(*ConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AgentService_ServiceDesc is the grpc.ServiceDesc for AgentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var AgentService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "monitor.AgentService",
 	HandlerType: (*AgentServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UploadDiagnostics",
+			Handler:    _AgentService_UploadDiagnostics_Handler,
+		},
+		{
+			MethodName: "Register",
+			Handler:    _AgentService_Register_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _AgentService_Heartbeat_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _AgentService_GetConfig_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "StreamFrames",
 			Handler:       _AgentService_StreamFrames_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "StreamVideo",
+			Handler:       _AgentService_StreamVideo_Handler,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "StreamEvents",
 			Handler:       _AgentService_StreamEvents_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "StreamAckedEvents",
+			Handler:       _AgentService_StreamAckedEvents_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "UploadRecording",
+			Handler:       _AgentService_UploadRecording_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Control",
+			Handler:       _AgentService_Control_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "proto/monitor.proto",
 }
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"agent/internal/i18n"
+
+	"fyne.io/systray"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	TRAY_TOOLTIP       = "agent" // 트레이 아이콘 툴팁
+	TRAY_PAUSE_MINUTES = 15      // 트레이 일시 중지 메뉴 시간(분)
+	TRAY_REFRESH_SEC   = 2       // 트레이 메뉴 상태 갱신 주기(초, 일정/원격 명령 변경 반영)
+)
+
+// trayMenu 구조체는 트레이 메뉴 항목과 종료 함수입니다.
+type trayMenu struct {
+	end     func()
+	capture *systray.MenuItem // 캡처 시작/중지 (일시 중지 중이면 재개)
+	pause   *systray.MenuItem
+	open    *systray.MenuItem
+	quit    *systray.MenuItem
+	status  *systray.MenuItem // 현재 상태 표시 (선택 불가)
+}
+
+// startTray 함수는 TrayEnabled 설정 시 트레이 아이콘을 띄웁니다. Wails 가 이벤트 루프를 소유하므로 외부 루프 방식으로 시작합니다.
+func (a *App) startTray() { // 단일 책임: 트레이 시작
+	if !a.cfg.TrayEnabled {
+		return
+	}
+	if !trayHostAvailable() {
+		a.logTray("호스트 없음 - 트레이 없이 실행")
+		if a.cfg.StartMinimized { // 숨긴 창을 되살릴 메뉴가 없으므로 최소화 상태로 표시
+			runtime.WindowShow(a.ctx)
+			runtime.WindowMinimise(a.ctx)
+		}
+		return
+	}
+	t := &trayMenu{}
+	start, end := systray.RunWithExternalLoop(func() { a.onTrayReady(t) }, nil)
+	t.end = end
+	a.tray = t
+	start()
+}
+
+// stopTray 함수는 트레이 아이콘을 내립니다.
+func (a *App) stopTray() { // 단일 책임: 트레이 종료
+	if a.tray != nil && a.tray.end != nil {
+		a.tray.end()
+	}
+}
+
+// onTrayReady 함수는 트레이 준비 후 메뉴를 구성하고 항목 선택을 처리합니다.
+func (a *App) onTrayReady(t *trayMenu) { // 단일 책임: 트레이 메뉴 구성/처리
+	systray.SetIcon(trayIcon)
+	systray.SetTooltip(TRAY_TOOLTIP)
+	t.status = systray.AddMenuItem("", "")
+	t.status.Disable()
+	systray.AddSeparator()
+	t.capture = systray.AddMenuItem(i18n.T("tray.stop"), i18n.T("tray.toggle.tip"))
+	t.pause = systray.AddMenuItem(i18n.T("tray.pause", TRAY_PAUSE_MINUTES), i18n.T("tray.pause.tip"))
+	t.open = systray.AddMenuItem(i18n.T("tray.open"), i18n.T("tray.open.tip"))
+	systray.AddSeparator()
+	t.quit = systray.AddMenuItem(i18n.T("tray.quit"), i18n.T("tray.quit.tip"))
+	a.trayActive.Store(true)
+	a.refreshTray()
+	tick := time.NewTicker(TRAY_REFRESH_SEC * time.Second)
+	defer tick.Stop()
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-tick.C:
+		case <-t.capture.ClickedCh:
+			a.toggleCaptureFromTray()
+		case <-t.pause.ClickedCh:
+			if err := a.agent.PauseCapture(TRAY_PAUSE_MINUTES * time.Minute); err != nil {
+				a.logTray("일시 중지 실패: %v", err)
+			}
+		case <-t.open.ClickedCh:
+			a.showWindow()
+		case <-t.quit.ClickedCh:
+			a.quitting.Store(true)
+			runtime.Quit(a.ctx)
+			return
+		}
+		a.refreshTray()
+	}
+}
+
+// toggleCaptureFromTray 함수는 캡처 상태에 따라 재개/중지/시작합니다.
+func (a *App) toggleCaptureFromTray() { // 단일 책임: 트레이 캡처 전환
+	var err error
+	switch {
+	case !a.agent.PausedUntil().IsZero():
+		err = a.agent.ResumeCapture()
+	case a.agent.Capturing():
+		a.agent.StopCapture()
+	default:
+		err = a.agent.StartCapture()
+	}
+	if err != nil {
+		a.logTray("캡처 전환 실패: %v", err)
+	}
+}
+
+// refreshTray 함수는 캡처/일시 중지/연결 상태를 메뉴 문구에 반영합니다. 고정 항목도 다시 써서 언어 변경을 반영합니다.
+func (a *App) refreshTray() { // 단일 책임: 트레이 메뉴 갱신
+	t := a.tray
+	if t == nil || t.status == nil {
+		return
+	}
+	conn := i18n.T("conn." + a.agent.ConnectionStatus().State)
+	until := a.agent.PausedUntil()
+	switch {
+	case !until.IsZero():
+		t.status.SetTitle(i18n.T("tray.status.paused", until.Format("15:04"), conn))
+		t.capture.SetTitle(i18n.T("tray.resume"))
+	case a.agent.Capturing():
+		t.status.SetTitle(i18n.T("tray.status.active", conn))
+		t.capture.SetTitle(i18n.T("tray.stop"))
+	default:
+		t.status.SetTitle(i18n.T("tray.status.idle", conn))
+		t.capture.SetTitle(i18n.T("tray.start"))
+	}
+	t.pause.SetTitle(i18n.T("tray.pause", TRAY_PAUSE_MINUTES))
+	t.open.SetTitle(i18n.T("tray.open"))
+	t.quit.SetTitle(i18n.T("tray.quit"))
+}
+
+// showWindow 함수는 숨긴(트레이로 닫은) 창을 다시 보이고 앞으로 가져옵니다.
+func (a *App) showWindow() { // 단일 책임: 창 표시
+	runtime.WindowShow(a.ctx)
+	runtime.WindowUnminimise(a.ctx)
+}
+
+// beforeClose 함수는 창 닫기 시 호출됩니다. 트레이가 떠 있고 CloseToTray 이면 종료 대신 창을 숨깁니다 (트레이 종료 메뉴는 그대로 종료).
+func (a *App) beforeClose(ctx context.Context) bool { // 단일 책임: 창 닫기 처리
+	if a.quitting.Load() || !a.trayActive.Load() || !a.cfg.CloseToTray {
+		return false
+	}
+	runtime.WindowHide(ctx)
+	return true
+}
+
+// logTray 함수는 트레이 동작 실패를 에이전트 로그에 남깁니다.
+func (a *App) logTray(format string, args ...any) { // 단일 책임: 트레이 오류 기록
+	if a.logger != nil {
+		a.logger.Warnf("트레이 "+format, args...)
+	}
+}
@@ -0,0 +1,22 @@
+//go:build linux
+
+package main
+
+import "github.com/godbus/dbus/v5"
+
+// TRAY_WATCHER_NAME 상수는 StatusNotifierItem 트레이 호스트가 등록하는 D-Bus 이름입니다.
+const TRAY_WATCHER_NAME = "org.kde.StatusNotifierWatcher"
+
+// trayHostAvailable 함수는 데스크톱에 트레이 호스트가 있는지 반환합니다. 없는 환경(확장 없는 GNOME 등)에서
+// 트레이로 닫으면 창을 되살릴 방법이 없으므로, 이때는 트레이 없이 실행합니다.
+func trayHostAvailable() bool { // 단일 책임: 트레이 호스트 확인
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return false
+	}
+	var has bool
+	if err := conn.BusObject().Call("org.freedesktop.DBus.NameHasOwner", 0, TRAY_WATCHER_NAME).Store(&has); err != nil {
+		return false
+	}
+	return has
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package main
+
+// trayHostAvailable 함수는 Windows 알림 영역/macOS 메뉴 막대는 항상 있으므로 true 를 반환합니다.
+func trayHostAvailable() bool { // 단일 책임: 트레이 호스트 확인
+	return true
+}
@@ -0,0 +1,10 @@
+//go:build !windows
+
+package main
+
+import _ "embed"
+
+// trayIcon 변수는 트레이(메뉴 막대) 아이콘 이미지입니다 (macOS/Linux 는 PNG 사용).
+//
+//go:embed build/appicon.png
+var trayIcon []byte
@@ -0,0 +1,10 @@
+//go:build windows
+
+package main
+
+import _ "embed"
+
+// trayIcon 변수는 트레이 아이콘 이미지입니다 (Windows 알림 영역은 ICO 형식 필요).
+//
+//go:embed build/windows/icon.ico
+var trayIcon []byte